@@ -0,0 +1,143 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// maxSettledAcks is the number of acknowledgments kept for records whose delivery is awaited after it was acknowledged.
+const maxSettledAcks = 1024
+
+// ackWaiter is a caller of [Ack] waiting for the acknowledgment of a record.
+type ackWaiter struct {
+	ch   chan error
+	stop func() bool
+}
+
+// ackTracker tracks the acknowledgments of records by the sinks.
+type ackTracker struct {
+	mu      sync.Mutex
+	waiters map[string][]*ackWaiter
+	// settled are the results of the recent acknowledgments, so records acknowledged before [Ack] is called are not awaited forever.
+	settled map[string]error
+	// order are the IDs of the settled acknowledgments from oldest to newest.
+	order []string
+}
+
+// newAckTracker returns a new ackTracker.
+func newAckTracker() *ackTracker {
+	return &ackTracker{waiters: map[string][]*ackWaiter{}, settled: map[string]error{}}
+}
+
+// defaultAckTracker is the tracker used by [Ack] and [Acknowledge].
+var defaultAckTracker = newAckTracker()
+
+// Ack returns a channel receiving the result of the delivery of the record with the given ID, see [RecordIDKey]:
+// nil once a sink acknowledged its durable delivery, the sink's error if the delivery failed
+// or the error of the context if it is done first. The channel is closed after the result is sent.
+//
+// This allows applications with audit requirements to confirm that critical records were delivered before proceeding.
+// It requires a sink supporting acknowledgments, which reports the delivery of every record by calling [Acknowledge] with its ID:
+// a [FluentHandler] with [FluentOptions.RequireAck], a [LokiHandler] once Loki accepted the batch
+// and a [NATSHandler] with [NATSOptions.JetStream] once the stream stored the record.
+// Custom sinks call [Acknowledge] themselves.
+// Records logged with a [RecordIDKey] attribute keep their ID, so the ID to wait for can be chosen upfront:
+//
+//	id := logger.NewULID()
+//	log.Info("Payment captured", logger.RecordIDKey, id)
+//	if err := <-logger.Ack(ctx, id); err != nil {
+//		return err
+//	}
+func Ack(ctx context.Context, recordID string) <-chan error {
+	return defaultAckTracker.wait(ctx, recordID)
+}
+
+// Acknowledge reports the delivery of the record with the given ID to the callers of [Ack] waiting for it.
+// A nil error reports a durable delivery, any other error a failed one.
+// It is called by sinks supporting acknowledgments, e.g. once the broker confirmed the record.
+func Acknowledge(recordID string, err error) {
+	defaultAckTracker.acknowledge(recordID, err)
+}
+
+// acknowledgeRecord reports the delivery of the record to the callers of [Ack] if the record has a [RecordIDKey] attribute.
+func acknowledgeRecord(r slog.Record, err error) { //nolint:gocritic // records are passed by value
+	if id := recordID(r); id != "" {
+		Acknowledge(id, err)
+	}
+}
+
+// recordID returns the value of the record's [RecordIDKey] attribute or an empty string if it has none.
+func recordID(r slog.Record) string { //nolint:gocritic // records are passed by value
+	var id string
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == RecordIDKey {
+			id = a.Value.String()
+			return false
+		}
+		return true
+	})
+	return id
+}
+
+// wait returns a channel receiving the result of the delivery of the record with the given ID.
+func (t *ackTracker) wait(ctx context.Context, id string) <-chan error {
+	ch := make(chan error, 1)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err, ok := t.settled[id]; ok {
+		ch <- err
+		close(ch)
+		return ch
+	}
+
+	w := &ackWaiter{ch: ch}
+	w.stop = context.AfterFunc(ctx, func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if t.remove(id, w) {
+			ch <- ctx.Err()
+			close(ch)
+		}
+	})
+	t.waiters[id] = append(t.waiters[id], w)
+	return ch
+}
+
+// acknowledge sends the result of the delivery of the record with the given ID to its waiters.
+func (t *ackTracker) acknowledge(id string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, w := range t.waiters[id] {
+		w.stop()
+		w.ch <- err
+		close(w.ch)
+	}
+	delete(t.waiters, id)
+
+	if _, ok := t.settled[id]; !ok {
+		t.order = append(t.order, id)
+	}
+	t.settled[id] = err
+	if len(t.order) > maxSettledAcks {
+		delete(t.settled, t.order[0])
+		t.order = t.order[1:]
+	}
+}
+
+// remove removes the waiter of the record with the given ID and reports whether it was still waiting.
+func (t *ackTracker) remove(id string, w *ackWaiter) bool {
+	waiters := t.waiters[id]
+	for i, other := range waiters {
+		if other == w {
+			waiters = append(waiters[:i], waiters[i+1:]...)
+			if len(waiters) == 0 {
+				delete(t.waiters, id)
+			} else {
+				t.waiters[id] = waiters
+			}
+			return true
+		}
+	}
+	return false
+}
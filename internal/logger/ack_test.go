@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestAckTracker(t *testing.T) {
+	errDelivery := errors.New("delivery failed")
+	tests := []struct {
+		name string
+		run  func(tr *ackTracker) <-chan error
+		want error
+	}{
+		{
+			name: "acknowledged after wait",
+			run: func(tr *ackTracker) <-chan error {
+				ch := tr.wait(context.Background(), "id")
+				tr.acknowledge("id", nil)
+				return ch
+			},
+			want: nil,
+		},
+		{
+			name: "acknowledged before wait",
+			run: func(tr *ackTracker) <-chan error {
+				tr.acknowledge("id", nil)
+				return tr.wait(context.Background(), "id")
+			},
+			want: nil,
+		},
+		{
+			name: "failed delivery",
+			run: func(tr *ackTracker) <-chan error {
+				ch := tr.wait(context.Background(), "id")
+				tr.acknowledge("id", errDelivery)
+				return ch
+			},
+			want: errDelivery,
+		},
+		{
+			name: "context done",
+			run: func(tr *ackTracker) <-chan error {
+				ctx, cancel := context.WithCancel(context.Background())
+				ch := tr.wait(ctx, "id")
+				cancel()
+				return ch
+			},
+			want: context.Canceled,
+		},
+		{
+			name: "other record acknowledged",
+			run: func(tr *ackTracker) <-chan error {
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+				t.Cleanup(cancel)
+				ch := tr.wait(ctx, "id")
+				tr.acknowledge("other", nil)
+				return ch
+			},
+			want: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := newAckTracker()
+			select {
+			case err := <-tt.run(tr):
+				if !errors.Is(err, tt.want) {
+					t.Errorf("Ack() = %v, want %v", err, tt.want)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("Ack() did not return a result")
+			}
+			if len(tr.waiters) != 0 {
+				t.Errorf("waiters = %d, want 0", len(tr.waiters))
+			}
+		})
+	}
+}
+
+func TestAckTracker_Evicts(t *testing.T) {
+	tr := newAckTracker()
+	for i := range maxSettledAcks + 1 {
+		tr.acknowledge(fmt.Sprintf("id-%d", i), nil)
+	}
+	if len(tr.settled) != maxSettledAcks {
+		t.Errorf("settled = %d, want %d", len(tr.settled), maxSettledAcks)
+	}
+	if _, ok := tr.settled["id-0"]; ok {
+		t.Error("Expected the oldest acknowledgment to be evicted")
+	}
+}
+
+func TestAck(t *testing.T) {
+	id := NewULID()
+	ch := Ack(context.Background(), id)
+	Acknowledge(id, nil)
+	if err := <-ch; err != nil {
+		t.Errorf("Ack() = %v, want nil", err)
+	}
+}
@@ -0,0 +1,22 @@
+package logger
+
+import (
+	"os"
+	"slices"
+	"strings"
+)
+
+// isAllowed reports whether attributes with the key may be emitted.
+// All keys are allowed unless an allow-list is configured.
+func (r *redactionRules) isAllowed(key string) bool {
+	return len(r.allowed) == 0 || slices.Contains(r.allowed, strings.ToLower(key))
+}
+
+// allowKeys returns the allowed keys of the options.
+// The LOG_ALLOW_KEYS environment variable takes precedence as comma-separated list.
+func allowKeys(o Options) []string {
+	if env, ok := os.LookupEnv("LOG_ALLOW_KEYS"); ok {
+		return splitList(env)
+	}
+	return o.AllowKeys
+}
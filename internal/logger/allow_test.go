@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestNewRedactionHandler_AllowKeys(t *testing.T) {
+	tests := []struct {
+		name        string
+		placeholder bool
+		policy      RedactionPolicy
+		log         func(l Provider)
+		want        string
+	}{
+		{
+			name: "dropped",
+			log: func(l Provider) {
+				l.With("user", "alice").Info("test", "status", 200, "body", "secret")
+			},
+			want: "status=200",
+		},
+		{
+			name:        "placeholder",
+			placeholder: true,
+			log: func(l Provider) {
+				l.Info("test", "status", 200, "Body", "secret")
+			},
+			want: "status=200 Body=[REDACTED]",
+		},
+		{
+			name: "groups",
+			log: func(l Provider) {
+				l.Info("test", slog.Group("HTTP", "method", "GET", "cookie", "abc"), slog.Group("db", "method", "query"))
+			},
+			want: "HTTP.method=GET",
+		},
+		{
+			name:   "combined with policy",
+			policy: RedactStandard,
+			log: func(l Provider) {
+				l.Info("test", slog.Group("http", "method", "Bearer abc"))
+			},
+			want: "http.method=[REDACTED]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewRedactionHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey) {
+						return slog.Attr{}
+					}
+					return a
+				},
+			}), tt.policy, RedactionOptions{AllowKeys: []string{"status", "http", "method"}, AllowPlaceholder: tt.placeholder})
+			tt.log(NewLogger(Options{Handler: h}))
+
+			if got := strings.TrimSpace(buf.String()); got != tt.want {
+				t.Errorf("Expected\n%s\ngot\n%s", tt.want, got)
+			}
+		})
+	}
+}
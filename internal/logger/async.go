@@ -0,0 +1,208 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+)
+
+// ErrHandlerClosed is returned by the handlers returned by [NewAsyncHandler], [NewSyslogHandler],
+// [NewJournaldHandler], [NewGELFHandler], [NewFluentHandler], [NewNATSHandler], [NewLokiHandler],
+// [NewWebhookHandler] and [NewSocketHandler] for records handled after they were closed.
+var ErrHandlerClosed = errors.New("handler closed")
+
+// AsyncOptions is the optional configuration for [NewAsyncHandler].
+type AsyncOptions struct {
+	// BufferSize is the number of records buffered before Handle blocks. Defaults to 1024.
+	BufferSize int
+	// OnError is called with the errors returned by the wrapped handler, which cannot be returned to the caller.
+	OnError func(err error)
+	// Metrics counts the records dropped after the handler was closed with [DropReasonAsyncQueue].
+	// Nil disables the counting.
+	Metrics *PipelineMetrics
+}
+
+// defaultAsyncBufferSize is the default [AsyncOptions.BufferSize].
+const defaultAsyncBufferSize = 1024
+
+// newAsyncOptions returns the provided AsyncOptions merged with the default AsyncOptions.
+func newAsyncOptions(o ...AsyncOptions) AsyncOptions {
+	opts := AsyncOptions{BufferSize: defaultAsyncBufferSize}
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided AsyncOptions with the receiver AsyncOptions.
+func (o *AsyncOptions) merge(d AsyncOptions) AsyncOptions {
+	if o.BufferSize > 0 {
+		d.BufferSize = o.BufferSize
+	}
+	if o.OnError != nil {
+		d.OnError = o.OnError
+	}
+	if o.Metrics != nil {
+		d.Metrics = o.Metrics
+	}
+	return d
+}
+
+// asyncRecord is a record queued by an [AsyncHandler].
+// A record without a handler is a flush marker, whose flushed channel is closed once the worker reaches it.
+type asyncRecord struct {
+	handler slog.Handler
+	ctx     context.Context
+	record  slog.Record
+	flushed chan struct{}
+}
+
+// asyncQueue is the queue and worker shared by an [AsyncHandler] and the handlers derived from it.
+type asyncQueue struct {
+	records chan asyncRecord
+	onError func(error)
+	metrics *PipelineMetrics
+	mu      sync.RWMutex
+	closed  bool
+	done    chan struct{}
+}
+
+var _ slog.Handler = (*AsyncHandler)(nil)
+
+// AsyncHandler is a [slog.Handler] passing records to the wrapped handler in a background worker,
+// so slow sinks do not block the caller. It must be closed to flush the buffered records.
+type AsyncHandler struct {
+	slog.Handler
+	queue *asyncQueue
+}
+
+// NewAsyncHandler returns a new [AsyncHandler] passing records to the given handler in a background worker.
+//
+// Since the context of a record may be cancelled by the time the worker handles it, the worker receives
+// a context carrying all values of the record's context that is never cancelled, see [context.WithoutCancel].
+// The attributes stored by [AppendCtx] and the values of the record's attributes are resolved eagerly.
+//
+// Records at or above [LevelPanic] are handled synchronously after the buffered records were flushed,
+// so the last record before a panic or exit is not lost and its error is returned to the caller.
+func NewAsyncHandler(h slog.Handler, o ...AsyncOptions) *AsyncHandler {
+	opts := newAsyncOptions(o...)
+	q := &asyncQueue{
+		records: make(chan asyncRecord, opts.BufferSize),
+		onError: opts.OnError,
+		metrics: opts.Metrics,
+		done:    make(chan struct{}),
+	}
+	go q.run()
+	return &AsyncHandler{Handler: h, queue: q}
+}
+
+// Handle snapshots the record and its context and queues it for the worker.
+// It blocks if the buffer is full and returns [ErrHandlerClosed] once the handler is closed.
+// Records at or above [LevelPanic] are passed to the wrapped handler directly after flushing the buffered records.
+func (h *AsyncHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	if Level(r.Level) >= LevelPanic {
+		_ = h.Flush(ctx)
+		return h.Handler.Handle(ctx, r)
+	}
+
+	h.queue.mu.RLock()
+	defer h.queue.mu.RUnlock()
+	if h.queue.closed {
+		h.queue.metrics.drop(DropReasonAsyncQueue)
+		return ErrHandlerClosed
+	}
+	h.queue.records <- asyncRecord{handler: h.Handler, ctx: snapshotContext(ctx), record: snapshotRecord(r)}
+	return nil
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *AsyncHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &AsyncHandler{Handler: h.Handler.WithAttrs(attrs), queue: h.queue}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *AsyncHandler) WithGroup(name string) slog.Handler {
+	return &AsyncHandler{Handler: h.Handler.WithGroup(name), queue: h.queue}
+}
+
+// Flush waits until the records buffered so far are handled or the context is done.
+// It returns [ErrHandlerClosed] if the handler is closed, in which case Close already waits for the buffered records.
+func (h *AsyncHandler) Flush(ctx context.Context) error {
+	h.queue.mu.RLock()
+	if h.queue.closed {
+		h.queue.mu.RUnlock()
+		return ErrHandlerClosed
+	}
+	flushed := make(chan struct{})
+	h.queue.records <- asyncRecord{flushed: flushed}
+	h.queue.mu.RUnlock()
+
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting records and waits until the buffered records are handled.
+// It closes the handlers derived by WithAttrs and WithGroup as well.
+func (h *AsyncHandler) Close() error {
+	h.queue.mu.Lock()
+	if !h.queue.closed {
+		h.queue.closed = true
+		close(h.queue.records)
+	}
+	h.queue.mu.Unlock()
+
+	<-h.queue.done
+	return nil
+}
+
+// run handles the queued records until the queue is closed.
+func (q *asyncQueue) run() {
+	defer close(q.done)
+	for rec := range q.records {
+		if rec.handler == nil {
+			close(rec.flushed)
+			continue
+		}
+		if err := rec.handler.Handle(rec.ctx, rec.record); err != nil && q.onError != nil {
+			q.onError(err)
+		}
+	}
+}
+
+// snapshotContext returns a new context carrying all values of the given context, e.g. the OpenTelemetry span
+// and baggage and the request ID, with the attributes stored by [AppendCtx] resolved.
+// The returned context is never cancelled, even if the given context is.
+func snapshotContext(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+
+	snapshot := context.WithoutCancel(ctx)
+	if attrs := AttrsFromContext(ctx); len(attrs) > 0 {
+		resolved := make([]slog.Attr, len(attrs))
+		for i, a := range attrs {
+			resolved[i] = resolveAttr(a)
+		}
+		snapshot = context.WithValue(snapshot, ctxAttrsKey{}, resolved)
+	}
+	return snapshot
+}
+
+// snapshotRecord returns a copy of the record with all attribute values resolved,
+// so later changes of the values are not reflected in the handled record.
+func snapshotRecord(r slog.Record) slog.Record { //nolint:gocritic // records are passed by value
+	out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, resolveAttr(a))
+		return true
+	})
+	out.AddAttrs(slices.Clip(attrs)...)
+	return out
+}
@@ -0,0 +1,199 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// counter is a [slog.LogValuer] whose value changes after it is logged.
+type counter struct {
+	n *int
+}
+
+func (c counter) LogValue() slog.Value {
+	return slog.IntValue(*c.n)
+}
+
+// tenantKey is a context key of the application, unknown to the handlers.
+type tenantKey struct{}
+
+func TestAsyncHandler_Snapshot(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{1},
+	})
+
+	var (
+		mu      sync.Mutex
+		got     = map[string]string{}
+		ctxErr  error
+		release = make(chan struct{})
+	)
+	mock := test.MockHandler{
+		HandleFunc: func(ctx context.Context, r slog.Record) error {
+			<-release
+			mu.Lock()
+			defer mu.Unlock()
+			ctxErr = ctx.Err()
+			r.Attrs(func(a slog.Attr) bool {
+				got[a.Key] = a.Value.Resolve().String()
+				return true
+			})
+			got[RequestIDKey] = RequestIDFromContext(ctx)
+			got["tenant"], _ = ctx.Value(tenantKey{}).(string)
+			return nil
+		},
+	}
+	h := NewAsyncHandler(NewContextHandler(NewTraceHandler(mock)))
+	log := NewLogger(Options{Handler: h})
+
+	// The record is logged within a request whose context is cancelled once the handler returns.
+	n := 1
+	handler := RequestID("")(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(trace.ContextWithSpanContext(r.Context(), sc), tenantKey{}, "acme")
+		ctx, cancel := context.WithCancel(AppendCtx(ctx, slog.String("user", "alice")))
+		log.InfoContext(ctx, "test", "count", counter{n: &n})
+		cancel()
+		n = 2
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	req.Header.Set(RequestIDHeader, "abc")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	close(release)
+	if err := h.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if ctxErr != nil {
+		t.Errorf("Expected the worker context not to be cancelled, got %v", ctxErr)
+	}
+	want := map[string]string{
+		"user":       "alice",
+		"count":      "1",
+		TraceIDKey:   sc.TraceID().String(),
+		SpanIDKey:    sc.SpanID().String(),
+		RequestIDKey: "abc",
+		"tenant":     "acme",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("Expected %s=%q, got %q", k, v, got[k])
+		}
+	}
+}
+
+func TestAsyncHandler_Close(t *testing.T) {
+	errSink := errors.New("sink failed")
+	var (
+		mu      sync.Mutex
+		handled []string
+		errs    []error
+	)
+	h := NewAsyncHandler(test.MockHandler{
+		HandleFunc: func(_ context.Context, r slog.Record) error {
+			mu.Lock()
+			defer mu.Unlock()
+			handled = append(handled, r.Message)
+			if r.Message == "fail" {
+				return errSink
+			}
+			return nil
+		},
+	}, AsyncOptions{BufferSize: 1, OnError: func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}})
+
+	log := NewLogger(Options{Handler: h})
+	for _, msg := range []string{"first", "second", "fail"} {
+		log.With("key", "value").Info(msg)
+	}
+	if err := h.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := h.Close(); err != nil {
+		t.Fatalf("Expected closing twice to succeed, got %v", err)
+	}
+
+	if len(handled) != 3 {
+		t.Errorf("Expected all buffered records to be flushed, got %v", handled)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], errSink) {
+		t.Errorf("Expected the sink error to be reported, got %v", errs)
+	}
+	if err := h.WithGroup("group").Handle(context.Background(), slog.Record{}); !errors.Is(err, ErrHandlerClosed) {
+		t.Errorf("Expected %v after close, got %v", ErrHandlerClosed, err)
+	}
+}
+
+func TestAsyncHandler_Synchronous(t *testing.T) {
+	release := make(chan struct{})
+	var (
+		mu       sync.Mutex
+		messages []string
+	)
+	h := NewAsyncHandler(test.MockHandler{
+		HandleFunc: func(_ context.Context, r slog.Record) error {
+			if r.Message == "slow" {
+				<-release
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			messages = append(messages, r.Message)
+			if Level(r.Level) == LevelFatal {
+				return errors.New("sink failed")
+			}
+			return nil
+		},
+	})
+	defer func() { _ = h.Close() }()
+	log := slog.New(h)
+
+	log.Info("slow")
+	log.Info("queued")
+	done := make(chan error, 1)
+	go func() {
+		done <- h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.Level(LevelFatal), "fatal", 0))
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Expected fatal record to wait for the buffered records")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(release)
+
+	if err := <-done; err == nil {
+		t.Error("Expected error of the wrapped handler to be returned")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []string{"slow", "queued", "fatal"}; !slices.Equal(messages, want) {
+		t.Errorf("Expected messages %v, got %v", want, messages)
+	}
+}
+
+func TestAsyncHandler_Flush(t *testing.T) {
+	h := NewAsyncHandler(test.MockHandler{})
+	if err := h.Flush(context.Background()); err != nil {
+		t.Errorf("Flush() error = %v", err)
+	}
+	_ = h.Close()
+	if err := h.Flush(context.Background()); !errors.Is(err, ErrHandlerClosed) {
+		t.Errorf("Expected ErrHandlerClosed, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package logger
+
+import (
+	"log/slog"
+	"slices"
+)
+
+// scope is a group of attributes accumulated by a [Provider].
+// The root scope has no name, every other scope was opened by [Provider.WithGroup].
+type scope struct {
+	name  string
+	attrs []slog.Attr
+}
+
+// Attrs returns the attributes accumulated by [Provider.With] and [Provider.WithGroup].
+// Groups opened by [Provider.WithGroup] are returned as group attributes, empty groups are omitted.
+// All values are resolved.
+//
+// Note: The attributes of a [slog.Logger] passed to [FromSlog] are not known and therefore not returned.
+func (l *logger) Attrs() []slog.Attr {
+	return nestScopes(l.scopes, nil)
+}
+
+// withAttrs returns the scopes with the given attributes added to the innermost scope.
+func (l *logger) withAttrs(attrs []slog.Attr) []scope {
+	return withScopeAttrs(l.scopes, attrs)
+}
+
+// withGroup returns the scopes with a new scope of the given name opened.
+func (l *logger) withGroup(name string) []scope {
+	return withScope(l.scopes, name)
+}
+
+// nestScopes returns the attributes of the scopes followed by the given attributes in the innermost scope.
+// Every scope except the root scope is returned as group attribute, empty groups are omitted.
+// All values are resolved.
+func nestScopes(scopes []scope, attrs []slog.Attr) []slog.Attr {
+	if len(scopes) == 0 {
+		scopes = []scope{{}}
+	}
+
+	var inner []slog.Attr
+	for i := len(scopes) - 1; i >= 0; i-- {
+		own := scopes[i].attrs
+		if i == len(scopes)-1 {
+			own = append(slices.Clip(own), attrs...)
+		}
+		resolved := make([]slog.Attr, 0, len(own)+1)
+		for _, a := range own {
+			resolved = append(resolved, resolveAttr(a))
+		}
+		if len(inner) > 0 {
+			resolved = append(resolved, slog.Attr{Key: scopes[i+1].name, Value: slog.GroupValue(inner...)})
+		}
+		inner = resolved
+	}
+	return inner
+}
+
+// withScopeAttrs returns a copy of the scopes with the given attributes added to the innermost scope.
+func withScopeAttrs(scopes []scope, attrs []slog.Attr) []scope {
+	scopes = slices.Clone(scopes)
+	if len(scopes) == 0 {
+		scopes = append(scopes, scope{})
+	}
+	last := &scopes[len(scopes)-1]
+	last.attrs = append(slices.Clip(last.attrs), attrs...)
+	return scopes
+}
+
+// withScope returns a copy of the scopes with a new scope of the given name opened.
+func withScope(scopes []scope, name string) []scope {
+	scopes = slices.Clone(scopes)
+	if len(scopes) == 0 {
+		scopes = append(scopes, scope{})
+	}
+	return append(scopes, scope{name: name})
+}
+
+// argsToAttrs converts the given arguments to attributes in the manner of [slog.Logger.With].
+func argsToAttrs(args []any) []slog.Attr {
+	return slog.Group("", args...).Value.Group()
+}
+
+// resolveAttr returns the attribute with its value and all nested group values resolved.
+func resolveAttr(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() != slog.KindGroup {
+		return a
+	}
+
+	group := a.Value.Group()
+	attrs := make([]slog.Attr, 0, len(group))
+	for _, ga := range group {
+		attrs = append(attrs, resolveAttr(ga))
+	}
+	a.Value = slog.GroupValue(attrs...)
+	return a
+}
@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+)
+
+type lazyValue string
+
+func (v lazyValue) LogValue() slog.Value {
+	return slog.StringValue(string(v))
+}
+
+func TestLogger_Attrs(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(l Provider) Provider
+		want  []slog.Attr
+	}{
+		{
+			name:  "no attributes",
+			build: func(l Provider) Provider { return l },
+			want:  nil,
+		},
+		{
+			name: "flat attributes",
+			build: func(l Provider) Provider {
+				return l.With("key", "value").With(slog.Int("count", 1))
+			},
+			want: []slog.Attr{slog.String("key", "value"), slog.Int("count", 1)},
+		},
+		{
+			name: "typed attributes",
+			build: func(l Provider) Provider {
+				return l.WithAttrs(slog.String("key", "value")).WithGroup("group").WithAttrs().WithAttrs(slog.Int("count", 1))
+			},
+			want: []slog.Attr{slog.String("key", "value"), slog.Group("group", slog.Int("count", 1))},
+		},
+		{
+			name: "resolved values",
+			build: func(l Provider) Provider {
+				return l.With("lazy", lazyValue("resolved"))
+			},
+			want: []slog.Attr{slog.String("lazy", "resolved")},
+		},
+		{
+			name: "nested groups",
+			build: func(l Provider) Provider {
+				return l.With("key", "value").WithGroup("outer").With("a", 1).WithGroup("inner").With("b", 2)
+			},
+			want: []slog.Attr{
+				slog.String("key", "value"),
+				slog.Group("outer", slog.Int("a", 1), slog.Group("inner", slog.Int("b", 2))),
+			},
+		},
+		{
+			name: "empty groups are omitted",
+			build: func(l Provider) Provider {
+				return l.With("key", "value").WithGroup("empty")
+			},
+			want: []slog.Attr{slog.String("key", "value")},
+		},
+		{
+			name: "siblings do not share attributes",
+			build: func(l Provider) Provider {
+				parent := l.With("parent", true)
+				_ = parent.With("sibling", 1)
+				return parent.With("child", 2)
+			},
+			want: []slog.Attr{slog.Bool("parent", true), slog.Int("child", 2)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := tt.build(NewLogger(Options{Handler: test.MockHandler{}}))
+			got := l.Attrs()
+			if len(got) != len(tt.want) {
+				t.Fatalf("Attrs() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("Attrs()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// AuditActorKey is the key of the mandatory attribute identifying who performed the audited action.
+	AuditActorKey = "actor"
+	// AuditActionKey is the key of the mandatory attribute naming the audited action, e.g. "delete".
+	AuditActionKey = "action"
+	// AuditResourceKey is the key of the mandatory attribute identifying the resource the action was performed on.
+	AuditResourceKey = "resource"
+	// AuditOutcomeKey is the key of the mandatory attribute describing the outcome of the action, e.g. "success".
+	AuditOutcomeKey = "outcome"
+	// AuditHashKey is the key used for the hash of a chained audit record, see [AuditOptions.Chain].
+	AuditHashKey = "hash"
+	// AuditPrevHashKey is the key used for the hash of the previous chained audit record, see [AuditOptions.Chain].
+	AuditPrevHashKey = "prev_hash"
+)
+
+// auditFields are the keys of the attributes every audit record must have.
+var auditFields = []string{AuditActorKey, AuditActionKey, AuditResourceKey, AuditOutcomeKey}
+
+var (
+	// ErrMissingAuditField is returned by [AuditLogger.Audit] for events without a mandatory attribute.
+	ErrMissingAuditField = errors.New("missing audit field")
+	// ErrAuditChainBroken is returned by [VerifyAuditChain] for records whose hashes do not match.
+	ErrAuditChainBroken = errors.New("audit chain broken")
+)
+
+// AuditOptions is the optional configuration for [NewAuditLogger].
+type AuditOptions struct {
+	// Handlers are the dedicated handlers the audit records are written to. All handlers receive every record.
+	// Defaults to a JSON handler writing to stderr.
+	Handlers []slog.Handler
+	// Chain is a flag to chain the audit records with hashes for tamper evidence.
+	// Every record carries the hash of the previous record as [AuditPrevHashKey] and its own hash as [AuditHashKey],
+	// so modified, inserted or removed records are detected by [VerifyAuditChain].
+	Chain bool
+	// PrevHash is the hash of the last record of an existing chain, so a restarted application continues it.
+	// Empty starts a new chain.
+	PrevHash string
+}
+
+// newAuditOptions returns the provided AuditOptions merged with the default AuditOptions.
+func newAuditOptions(o ...AuditOptions) AuditOptions {
+	opts := AuditOptions{Handlers: []slog.Handler{slog.NewJSONHandler(os.Stderr, nil)}}
+	if len(o) == 0 {
+		return opts
+	}
+	return o[0].merge(opts)
+}
+
+// merge merges the provided AuditOptions with the receiver AuditOptions.
+func (o *AuditOptions) merge(d AuditOptions) AuditOptions {
+	if len(o.Handlers) > 0 {
+		d.Handlers = o.Handlers
+	}
+	d.Chain = o.Chain
+	d.PrevHash = o.PrevHash
+	return d
+}
+
+// AuditLogger writes audit events to its own handlers, separate from the application logs.
+// Unlike a [Provider], it never samples, filters or drops events: every event with all mandatory attributes
+// is passed to every handler regardless of its level, and the errors of the handlers are returned to the caller.
+// Handlers dropping records themselves, e.g. a [NewAsyncHandler] with a full queue, should not be used.
+type AuditLogger struct {
+	handlers []slog.Handler
+	chain    bool
+	// mu serializes the events, so the order of the chain matches the order of the outputs.
+	mu sync.Mutex
+	// prev is the hash of the last chained record.
+	prev string
+}
+
+// NewAuditLogger returns a new [AuditLogger] writing to the handlers of the given options.
+//
+// Example:
+//
+//	audit := logger.NewAuditLogger(logger.AuditOptions{Handlers: []slog.Handler{h}, Chain: true})
+func NewAuditLogger(o ...AuditOptions) *AuditLogger {
+	opts := newAuditOptions(o...)
+	return &AuditLogger{handlers: opts.Handlers, chain: opts.Chain, prev: opts.PrevHash}
+}
+
+// Audit writes the event with the given attributes to the handlers of the audit logger at [LevelInfo].
+// The attributes must include non-empty [AuditActorKey], [AuditActionKey], [AuditResourceKey]
+// and [AuditOutcomeKey] attributes, otherwise nothing is written and an error wrapping [ErrMissingAuditField] is returned.
+// The errors of the handlers are joined and returned.
+//
+// Example:
+//
+//	err := audit.Audit(ctx, "User deleted",
+//		slog.String(logger.AuditActorKey, "admin"),
+//		slog.String(logger.AuditActionKey, "delete"),
+//		slog.String(logger.AuditResourceKey, "user/jane.doe"),
+//		slog.String(logger.AuditOutcomeKey, "success"),
+//	)
+func (a *AuditLogger) Audit(ctx context.Context, event string, attrs ...slog.Attr) error {
+	if err := checkAuditFields(attrs); err != nil {
+		return err
+	}
+
+	var pcs [1]uintptr
+	// Skip runtime.Callers and this method.
+	runtime.Callers(2, pcs[:])
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, event, pcs[0])
+	r.AddAttrs(attrs...)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.chain {
+		r.AddAttrs(slog.String(AuditPrevHashKey, a.prev))
+		hash, err := auditHash(r)
+		if err != nil {
+			return fmt.Errorf("failed to hash audit record: %w", err)
+		}
+		r.AddAttrs(slog.String(AuditHashKey, hash))
+		// The chain continues even if a handler fails, so the failed record shows as gap in the outputs.
+		a.prev = hash
+	}
+
+	errs := make([]error, 0, len(a.handlers))
+	for _, h := range a.handlers {
+		errs = append(errs, h.Handle(ctx, r.Clone()))
+	}
+	return errors.Join(errs...)
+}
+
+// checkAuditFields returns an error wrapping [ErrMissingAuditField] if a mandatory attribute is missing or empty.
+func checkAuditFields(attrs []slog.Attr) error {
+	present := make(map[string]bool, len(auditFields))
+	for _, a := range attrs {
+		if a.Value.Resolve().String() != "" {
+			present[a.Key] = true
+		}
+	}
+	var missing []string
+	for _, key := range auditFields {
+		if !present[key] {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrMissingAuditField, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// auditHash returns the hash of the audit record, computed from its JSON encoding like [VerifyAuditChain] does.
+func auditHash(r slog.Record) (string, error) { //nolint:gocritic // records are passed by value
+	buf := &bytes.Buffer{}
+	if err := slog.NewJSONHandler(buf, nil).Handle(context.Background(), r); err != nil {
+		return "", err
+	}
+	fields, err := decodeAuditRecord(buf.Bytes())
+	if err != nil {
+		return "", err
+	}
+	return hashAuditFields(fields)
+}
+
+// decodeAuditRecord decodes the JSON encoded audit record keeping numbers as written.
+func decodeAuditRecord(data []byte) (map[string]any, error) {
+	var fields map[string]any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// hashAuditFields returns the hex encoded SHA-256 hash of the canonical JSON encoding of the fields
+// with sorted keys and the time in UTC. The level, the source and the hash itself are not covered,
+// so handlers may format them differently.
+func hashAuditFields(fields map[string]any) (string, error) {
+	delete(fields, AuditHashKey)
+	delete(fields, slog.LevelKey)
+	delete(fields, slog.SourceKey)
+	if s, ok := fields[slog.TimeKey].(string); ok {
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			fields[slog.TimeKey] = t.UTC().Format(time.RFC3339Nano)
+		}
+	}
+	canonical, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// VerifyAuditChain verifies the chained audit records read as JSON lines from r, e.g. from the file of a
+// JSON handler of an [AuditLogger] with [AuditOptions.Chain]. The times must be written in RFC 3339 with
+// nanoseconds, like [slog.JSONHandler] does. prevHash is the hash preceding the first record,
+// empty for a new chain. It returns the hash of the last record, to verify the next part of a rotated chain,
+// or an error wrapping [ErrAuditChainBroken] for the first record that was modified, inserted or removed.
+func VerifyAuditChain(r io.Reader, prevHash string) (string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		fields, err := decodeAuditRecord(scanner.Bytes())
+		if err != nil {
+			return "", fmt.Errorf("line %d: %w", line, err)
+		}
+		if prev, _ := fields[AuditPrevHashKey].(string); prev != prevHash {
+			return "", fmt.Errorf("%w: line %d: previous hash %q, expected %q", ErrAuditChainBroken, line, prev, prevHash)
+		}
+		hash, _ := fields[AuditHashKey].(string)
+		want, err := hashAuditFields(fields)
+		if err != nil {
+			return "", fmt.Errorf("line %d: %w", line, err)
+		}
+		if hash != want {
+			return "", fmt.Errorf("%w: line %d: hash %q, expected %q", ErrAuditChainBroken, line, hash, want)
+		}
+		prevHash = hash
+	}
+	return prevHash, scanner.Err()
+}
@@ -0,0 +1,130 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// auditAttrs returns the mandatory audit attributes followed by the given attributes.
+func auditAttrs(attrs ...slog.Attr) []slog.Attr {
+	return append([]slog.Attr{
+		slog.String(AuditActorKey, "admin"),
+		slog.String(AuditActionKey, "delete"),
+		slog.String(AuditResourceKey, "user/jane.doe"),
+		slog.String(AuditOutcomeKey, "success"),
+	}, attrs...)
+}
+
+func TestAuditLogger_Audit(t *testing.T) {
+	tests := []struct {
+		name    string
+		attrs   []slog.Attr
+		wantErr string
+	}{
+		{
+			name:  "all fields",
+			attrs: auditAttrs(slog.Int("count", 1)),
+		},
+		{
+			name:    "missing fields",
+			attrs:   []slog.Attr{slog.String(AuditActorKey, "admin"), slog.String(AuditOutcomeKey, "")},
+			wantErr: "missing audit field: action, resource, outcome",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			// The level of the handler is ignored, so no audit record is dropped.
+			h := slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelError})
+			err := NewAuditLogger(AuditOptions{Handlers: []slog.Handler{h}}).Audit(context.Background(), "User deleted", tt.attrs...)
+
+			if tt.wantErr != "" {
+				if !errors.Is(err, ErrMissingAuditField) || err.Error() != tt.wantErr {
+					t.Errorf("Expected error %q, got %v", tt.wantErr, err)
+				}
+				if buf.Len() != 0 {
+					t.Errorf("Expected no record, got %s", buf.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got := buf.String(); !strings.Contains(got, `"msg":"User deleted","actor":"admin"`) || strings.Contains(got, AuditHashKey) {
+				t.Errorf("Expected an unchained audit record, got %s", got)
+			}
+		})
+	}
+}
+
+func TestAuditLogger_Audit_HandlerErrors(t *testing.T) {
+	var failedOut strings.Builder
+	failed := newFlakyHandler(&failedOut)
+	failed.fail.Store(true)
+	buf := &bytes.Buffer{}
+	audit := NewAuditLogger(AuditOptions{Handlers: []slog.Handler{failed, slog.NewJSONHandler(buf, nil)}})
+
+	if err := audit.Audit(context.Background(), "User deleted", auditAttrs()...); err == nil || err.Error() != "sink down" {
+		t.Errorf("Expected the handler error, got %v", err)
+	}
+	if buf.Len() == 0 {
+		t.Error("Expected the record to be written to the other handler")
+	}
+}
+
+func TestVerifyAuditChain(t *testing.T) {
+	buf := &bytes.Buffer{}
+	audit := NewAuditLogger(AuditOptions{Handlers: []slog.Handler{slog.NewJSONHandler(buf, nil)}, Chain: true})
+	for _, user := range []string{"user/jane.doe", "user/john.doe", "user/max"} {
+		attrs := auditAttrs(slog.Float64("ratio", 0.1), slog.Group("req", "id", 42))
+		attrs[2] = slog.String(AuditResourceKey, user)
+		if err := audit.Audit(context.Background(), "User <deleted>", attrs...); err != nil {
+			t.Fatalf("Failed to audit: %v", err)
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+
+	tests := []struct {
+		name    string
+		lines   []string
+		prev    string
+		wantErr bool
+	}{
+		{name: "intact", lines: lines},
+		{name: "modified", lines: []string{lines[0], strings.Replace(lines[1], "john.doe", "max", 1), lines[2]}, wantErr: true},
+		{name: "removed", lines: []string{lines[0], lines[2]}, wantErr: true},
+		{name: "reordered", lines: []string{lines[1], lines[0], lines[2]}, wantErr: true},
+		{name: "unknown start", lines: lines, prev: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			last, err := VerifyAuditChain(strings.NewReader(strings.Join(tt.lines, "\n")), tt.prev)
+			if tt.wantErr {
+				if !errors.Is(err, ErrAuditChainBroken) {
+					t.Errorf("Expected %v, got %v", ErrAuditChainBroken, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			// The chain continues from the last hash.
+			next := &bytes.Buffer{}
+			err = NewAuditLogger(AuditOptions{Handlers: []slog.Handler{slog.NewJSONHandler(next, nil)}, Chain: true, PrevHash: last}).
+				Audit(context.Background(), "User created", auditAttrs()...)
+			if err != nil {
+				t.Fatalf("Failed to audit: %v", err)
+			}
+			if _, err = VerifyAuditChain(next, last); err != nil {
+				t.Errorf("Expected the continued chain to be intact, got %v", err)
+			}
+		})
+	}
+}
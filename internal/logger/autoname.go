@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// NameKey is the key used for the name of a logger, see [NewNamedLogger] and [Options.AutoName].
+const NameKey = "name"
+
+// packageNames caches the package path of the function of a program counter.
+var packageNames sync.Map // map[uintptr]string
+
+// packageName returns the path of the package of the function containing the given program counter.
+// It returns an empty string if the program counter is unknown.
+func packageName(pc uintptr) string {
+	if pc == 0 {
+		return ""
+	}
+	if name, ok := packageNames.Load(pc); ok {
+		return name.(string)
+	}
+
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	name := functionPackage(frame.Function)
+	packageNames.Store(pc, name)
+	return name
+}
+
+// groupOrAttrs is either a group or attributes added to a handler.
+type groupOrAttrs struct {
+	group string
+	attrs []slog.Attr
+}
+
+var _ slog.Handler = (*autoNameHandler)(nil)
+
+// autoNameHandler is a [slog.Handler] that adds the package of the caller as name to records of unnamed loggers.
+type autoNameHandler struct {
+	slog.Handler
+	// root is the handler without the groups and attributes added to the handler.
+	root  slog.Handler
+	goas  []groupOrAttrs
+	named bool
+	// handlers caches the handlers with the name of a package, groups and attributes added.
+	handlers *sync.Map // map[string]slog.Handler
+}
+
+// newAutoNameHandler returns a new [slog.Handler] that adds the package of the caller under [NameKey]
+// to every record, unless the logger was given a name.
+func newAutoNameHandler(h slog.Handler) slog.Handler {
+	return &autoNameHandler{Handler: h, root: h, handlers: &sync.Map{}}
+}
+
+// Handle adds the package of the caller as name to the record and passes it to the wrapped handler.
+// The name is added before the groups of the handler, so it is a top-level attribute like the name of a named logger.
+func (h *autoNameHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	name := packageName(r.PC)
+	if h.named || name == "" {
+		return h.Handler.Handle(ctx, r)
+	}
+
+	if handler, ok := h.handlers.Load(name); ok {
+		return handler.(slog.Handler).Handle(ctx, r)
+	}
+	handler := h.root.WithAttrs([]slog.Attr{slog.String(NameKey, name)})
+	for _, goa := range h.goas {
+		if goa.group != "" {
+			handler = handler.WithGroup(goa.group)
+			continue
+		}
+		handler = handler.WithAttrs(goa.attrs)
+	}
+	h.handlers.Store(name, handler)
+	return handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+// The handler stops naming records if a top-level name is added.
+func (h *autoNameHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	named := h.named
+	if !named && !slices.ContainsFunc(h.goas, func(goa groupOrAttrs) bool { return goa.group != "" }) {
+		for _, a := range attrs {
+			named = named || a.Key == NameKey
+		}
+	}
+	return h.with(h.Handler.WithAttrs(attrs), groupOrAttrs{attrs: attrs}, named)
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *autoNameHandler) WithGroup(name string) slog.Handler {
+	return h.with(h.Handler.WithGroup(name), groupOrAttrs{group: name}, h.named)
+}
+
+// with returns a new handler derived from the receiver.
+func (h *autoNameHandler) with(handler slog.Handler, goa groupOrAttrs, named bool) *autoNameHandler {
+	goas := make([]groupOrAttrs, len(h.goas), len(h.goas)+1)
+	copy(goas, h.goas)
+	return &autoNameHandler{
+		Handler:  handler,
+		root:     h.root,
+		goas:     append(goas, goa),
+		named:    named,
+		handlers: &sync.Map{},
+	}
+}
@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"runtime"
+	"testing"
+)
+
+// thisPackage is the path of the package of the tests.
+const thisPackage = "github.com/lvlcn-t/loggerhead/internal/logger"
+
+func TestPackageName(t *testing.T) {
+	pc := func() uintptr {
+		var pcs [1]uintptr
+		runtime.Callers(1, pcs[:])
+		return pcs[0]
+	}
+
+	tests := []struct {
+		name string
+		pc   uintptr
+		want string
+	}{
+		{name: "unknown", pc: 0, want: ""},
+		{name: "closure", pc: pc(), want: thisPackage},
+		{name: "nested closure", pc: func() uintptr { return pc() }(), want: thisPackage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := packageName(tt.pc); got != tt.want {
+				t.Errorf("packageName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOptions_AutoName(t *testing.T) {
+	tests := []struct {
+		name string
+		log  func(l Provider)
+		want map[string]any
+	}{
+		{
+			name: "unnamed logger",
+			log:  func(l Provider) { l.Info("test") },
+			want: map[string]any{NameKey: thisPackage},
+		},
+		{
+			name: "name precedes groups",
+			log:  func(l Provider) { l.With("key", "value").WithGroup("group").Info("test", "n", 1) },
+			want: map[string]any{NameKey: thisPackage, "key": "value", "group": map[string]any{"n": float64(1)}},
+		},
+		{
+			name: "named logger",
+			log:  func(l Provider) { l.With(NameKey, "checkout").Info("test") },
+			want: map[string]any{NameKey: "checkout"},
+		},
+		{
+			name: "name in group",
+			log:  func(l Provider) { l.WithGroup("group").With(NameKey, "value").Info("test") },
+			want: map[string]any{NameKey: thisPackage, "group": map[string]any{NameKey: "value"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewPipeline(func(Level) slog.Handler {
+				return slog.NewJSONHandler(buf, &slog.HandlerOptions{ReplaceAttr: replaceAttr})
+			}, Options{AutoName: true, Deterministic: true})
+			l := FromSlog(slog.New(h))
+			tt.log(l)
+			// A second record is handled by the cached handler.
+			tt.log(l)
+
+			dec := json.NewDecoder(buf)
+			for range 2 {
+				var got map[string]any
+				if err := dec.Decode(&got); err != nil {
+					t.Fatalf("Failed to parse record: %v", err)
+				}
+				delete(got, slog.LevelKey)
+				delete(got, slog.MessageKey)
+				if b, w := mustJSON(t, got), mustJSON(t, tt.want); b != w {
+					t.Errorf("Expected record %s, got %s", w, b)
+				}
+			}
+		})
+	}
+}
+
+// mustJSON returns the JSON encoding of v.
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
@@ -0,0 +1,292 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by the handler returned by [NewCircuitBreakerHandler] for records dropped
+// because the circuit is open.
+var ErrCircuitOpen = errors.New("circuit open, record dropped")
+
+const (
+	// defaultBreakerThreshold is the default [CircuitBreakerOptions.Threshold].
+	defaultBreakerThreshold = 5
+	// defaultBreakerRetryInterval is the default [CircuitBreakerOptions.RetryInterval].
+	defaultBreakerRetryInterval = 30 * time.Second
+)
+
+// The states of a [CircuitBreakerHandler].
+const (
+	// CircuitClosed is the state in which the records are passed to the wrapped handler.
+	CircuitClosed = "CLOSED"
+	// CircuitOpen is the state in which the records are dropped or buffered.
+	CircuitOpen = "OPEN"
+	// CircuitHalfOpen is the state in which a single record is passed to the wrapped handler to probe it.
+	CircuitHalfOpen = "HALF_OPEN"
+)
+
+// CircuitBreakerOptions is the optional configuration for [NewCircuitBreakerHandler].
+type CircuitBreakerOptions struct {
+	// Threshold is the number of consecutive errors of the wrapped handler after which the circuit opens. Defaults to 5.
+	Threshold int
+	// RetryInterval is the time after which an open circuit passes a record to the wrapped handler again
+	// to probe whether it recovered. Defaults to 30 seconds.
+	RetryInterval time.Duration
+	// BufferSize is the number of records buffered while the circuit is open, which are passed to the wrapped handler
+	// once it recovered. If the buffer is full, the oldest records are dropped.
+	// If zero, the records are dropped while the circuit is open.
+	BufferSize int
+	// OnStateChange is called with the states of the circuit if it changed, see [CircuitClosed].
+	OnStateChange func(from, to string)
+}
+
+// newCircuitBreakerOptions returns the provided CircuitBreakerOptions merged with the default CircuitBreakerOptions.
+func newCircuitBreakerOptions(o ...CircuitBreakerOptions) CircuitBreakerOptions {
+	opts := CircuitBreakerOptions{
+		Threshold:     defaultBreakerThreshold,
+		RetryInterval: defaultBreakerRetryInterval,
+	}
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided CircuitBreakerOptions with the receiver CircuitBreakerOptions.
+func (o *CircuitBreakerOptions) merge(d CircuitBreakerOptions) CircuitBreakerOptions {
+	if o.Threshold > 0 {
+		d.Threshold = o.Threshold
+	}
+	if o.RetryInterval > 0 {
+		d.RetryInterval = o.RetryInterval
+	}
+	if o.BufferSize > 0 {
+		d.BufferSize = o.BufferSize
+	}
+	if o.OnStateChange != nil {
+		d.OnStateChange = o.OnStateChange
+	}
+	return d
+}
+
+// circuitBreaker is the circuit shared by a [CircuitBreakerHandler] and the handlers derived from it.
+type circuitBreaker struct {
+	opts  CircuitBreakerOptions
+	mu    sync.Mutex
+	state string
+	// failures is the number of consecutive errors of the wrapped handler.
+	failures int
+	// retry is the time an open circuit is probed again.
+	retry time.Time
+	// buffer are the records buffered while the circuit is open.
+	buffer []bufferedRecord
+	// trips is the number of times the circuit opened, dropped the number of dropped records.
+	trips   uint64
+	dropped uint64
+	// changes are the state changes to report once the lock is released.
+	changes [][2]string
+}
+
+var _ slog.Handler = (*CircuitBreakerHandler)(nil)
+var _ MetricsProvider = (*CircuitBreakerHandler)(nil)
+
+// CircuitBreakerHandler is a [slog.Handler] that stops passing records to a failing handler.
+type CircuitBreakerHandler struct {
+	slog.Handler
+	breaker *circuitBreaker
+}
+
+// NewCircuitBreakerHandler returns a new [CircuitBreakerHandler] that stops passing records to the given handler,
+// e.g. one sending them to a remote sink, after [CircuitBreakerOptions.Threshold] consecutive errors.
+//
+// While the circuit is open, the records are buffered up to [CircuitBreakerOptions.BufferSize]
+// or dropped with [ErrCircuitOpen], so they can fall back to another handler, e.g. with [NewFailoverHandler].
+// After [CircuitBreakerOptions.RetryInterval], a single record probes the wrapped handler: if it succeeds,
+// the buffered records are passed to it before the probing record and the circuit closes, otherwise it opens again.
+//
+// The state and counters of the circuit are exposed with Metrics, e.g. to log them with [SnapshotEvery].
+func NewCircuitBreakerHandler(h slog.Handler, o ...CircuitBreakerOptions) *CircuitBreakerHandler {
+	return &CircuitBreakerHandler{
+		Handler: h,
+		breaker: &circuitBreaker{opts: newCircuitBreakerOptions(o...), state: CircuitClosed},
+	}
+}
+
+// State returns the current state of the circuit, see [CircuitClosed].
+func (h *CircuitBreakerHandler) State() string {
+	h.breaker.mu.Lock()
+	defer h.breaker.mu.Unlock()
+	return h.breaker.state
+}
+
+// Metrics returns the state of the circuit, the number of consecutive errors, times the circuit opened,
+// buffered and dropped records.
+func (h *CircuitBreakerHandler) Metrics(_ context.Context) []slog.Attr {
+	b := h.breaker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return []slog.Attr{
+		slog.String("state", b.state),
+		slog.Int("failures", b.failures),
+		slog.Uint64("trips", b.trips),
+		slog.Int("buffered", len(b.buffer)),
+		slog.Uint64("dropped", b.dropped),
+	}
+}
+
+// Handle passes the record to the wrapped handler if the circuit is closed or due to be probed,
+// otherwise it buffers or drops it.
+func (h *CircuitBreakerHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	br := bufferedRecord{ctx: ctx, handler: h.Handler, record: r}
+	state, pending := h.breaker.begin()
+	switch state {
+	case CircuitClosed:
+		err := h.Handler.Handle(ctx, r)
+		h.breaker.done(err)
+		return err
+	case CircuitHalfOpen:
+		return h.breaker.probe(append(pending, br))
+	default:
+		if h.breaker.opts.BufferSize == 0 {
+			h.breaker.drop()
+			return ErrCircuitOpen
+		}
+		br.ctx, br.record = context.WithoutCancel(ctx), r.Clone()
+		h.breaker.add(br)
+		return nil
+	}
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *CircuitBreakerHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &CircuitBreakerHandler{Handler: h.Handler.WithAttrs(attrs), breaker: h.breaker}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *CircuitBreakerHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &CircuitBreakerHandler{Handler: h.Handler.WithGroup(name), breaker: h.breaker}
+}
+
+// begin returns the state a record is handled in. If the open circuit is due to be probed,
+// it becomes half-open and the buffered records are returned to be passed to the wrapped handler first.
+func (b *circuitBreaker) begin() (state string, pending []bufferedRecord) {
+	b.mu.Lock()
+	defer b.unlock()
+	if b.state != CircuitOpen || time.Now().Before(b.retry) {
+		if b.state == CircuitHalfOpen {
+			// Another record is probing the wrapped handler.
+			return CircuitOpen, nil
+		}
+		return b.state, nil
+	}
+	pending, b.buffer = b.buffer, nil
+	b.transition(CircuitHalfOpen)
+	return CircuitHalfOpen, pending
+}
+
+// done records the result of passing a record to the wrapped handler while the circuit is closed.
+func (b *circuitBreaker) done(err error) {
+	b.mu.Lock()
+	defer b.unlock()
+	if err == nil {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == CircuitClosed && b.failures >= b.opts.Threshold {
+		b.open()
+	}
+}
+
+// probe passes the records to the wrapped handler in order and closes the circuit if all succeeded.
+// If one fails, the circuit opens again and it and the following records are buffered again.
+func (b *circuitBreaker) probe(records []bufferedRecord) error {
+	for i, br := range records {
+		if err := br.handler.Handle(br.ctx, br.record); err != nil {
+			return b.reopen(records[i:], err)
+		}
+	}
+
+	b.mu.Lock()
+	defer b.unlock()
+	b.failures = 0
+	b.transition(CircuitClosed)
+	return nil
+}
+
+// reopen opens the circuit again after a failed probe and buffers the remaining records,
+// the last of which is the probing record.
+func (b *circuitBreaker) reopen(records []bufferedRecord, err error) error {
+	b.mu.Lock()
+	defer b.unlock()
+	b.failures++
+	b.open()
+	if b.opts.BufferSize == 0 {
+		b.dropped++
+		return err
+	}
+
+	last := &records[len(records)-1]
+	last.ctx, last.record = context.WithoutCancel(last.ctx), last.record.Clone()
+	b.buffer = append(records, b.buffer...)
+	b.trim()
+	return nil
+}
+
+// add buffers the record while the circuit is open.
+func (b *circuitBreaker) add(br bufferedRecord) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buffer = append(b.buffer, br)
+	b.trim()
+}
+
+// drop counts a record dropped while the circuit is open.
+func (b *circuitBreaker) drop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.dropped++
+}
+
+// trim drops the oldest buffered records exceeding the buffer size. Must be called with the lock held.
+func (b *circuitBreaker) trim() {
+	if n := len(b.buffer) - b.opts.BufferSize; n > 0 {
+		clear(b.buffer[:n])
+		b.buffer = b.buffer[n:]
+		b.dropped += uint64(n)
+	}
+}
+
+// open opens the circuit until the next probe. Must be called with the lock held.
+func (b *circuitBreaker) open() {
+	b.retry = time.Now().Add(b.opts.RetryInterval)
+	b.trips++
+	b.transition(CircuitOpen)
+}
+
+// transition changes the state of the circuit. Must be called with the lock held.
+func (b *circuitBreaker) transition(to string) {
+	if b.state != to {
+		b.changes = append(b.changes, [2]string{b.state, to})
+		b.state = to
+	}
+}
+
+// unlock releases the lock and reports the state changes made while it was held.
+func (b *circuitBreaker) unlock() {
+	changes := b.changes
+	b.changes = nil
+	b.mu.Unlock()
+	if b.opts.OnStateChange != nil {
+		for _, c := range changes {
+			b.opts.OnStateChange(c[0], c[1])
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerHandler(t *testing.T) {
+	var out strings.Builder
+	sink := newFlakyHandler(&out)
+	var changes []string
+	h := NewCircuitBreakerHandler(sink, CircuitBreakerOptions{
+		Threshold:     2,
+		RetryInterval: 20 * time.Millisecond,
+		OnStateChange: func(from, to string) { changes = append(changes, from+"->"+to) },
+	})
+	log := slog.New(h)
+
+	sink.fail.Store(true)
+	log.Info("first")
+	if h.State() != CircuitClosed {
+		t.Fatalf("Expected the circuit to stay closed below the threshold, got %s", h.State())
+	}
+	log.Info("second")
+	if h.State() != CircuitOpen {
+		t.Fatalf("Expected the circuit to open, got %s", h.State())
+	}
+
+	sink.fail.Store(false)
+	if err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "dropped", 0)); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Handle() error = %v, want %v", err, ErrCircuitOpen)
+	}
+	if out.Len() > 0 {
+		t.Errorf("Expected no records to be passed to the wrapped handler, got %s", out.String())
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	log.Info("probe")
+	if h.State() != CircuitClosed || !strings.Contains(out.String(), "msg=probe") {
+		t.Errorf("Expected the probe to close the circuit, got %s with %q", h.State(), out.String())
+	}
+
+	want := []string{"CLOSED->OPEN", "OPEN->HALF_OPEN", "HALF_OPEN->CLOSED"}
+	if strings.Join(changes, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected the state changes %v, got %v", want, changes)
+	}
+	metrics := attrsToMap(h.Metrics(context.Background()))
+	if metrics["trips"] != uint64(1) || metrics["dropped"] != uint64(1) || metrics["failures"] != int64(0) {
+		t.Errorf("Unexpected metrics %v", metrics)
+	}
+}
+
+func TestCircuitBreakerHandler_Buffer(t *testing.T) {
+	var out strings.Builder
+	sink := newFlakyHandler(&out)
+	h := NewCircuitBreakerHandler(sink, CircuitBreakerOptions{Threshold: 1, RetryInterval: 20 * time.Millisecond, BufferSize: 2})
+	log := slog.New(h).With("service", "api")
+
+	sink.fail.Store(true)
+	log.Info("lost")
+	for _, msg := range []string{"first", "second", "third"} {
+		log.Info(msg)
+	}
+
+	// The failed probe is buffered again.
+	time.Sleep(30 * time.Millisecond)
+	log.Info("fourth")
+	if h.State() != CircuitOpen || out.Len() > 0 {
+		t.Fatalf("Expected the failed probe to open the circuit again, got %s with %q", h.State(), out.String())
+	}
+
+	sink.fail.Store(false)
+	time.Sleep(30 * time.Millisecond)
+	log.Info("fifth")
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	want := []string{"msg=third service=api", "msg=fourth", "msg=fifth"}
+	if len(lines) != len(want) {
+		t.Fatalf("Expected %d records, got %v", len(want), lines)
+	}
+	for i, w := range want {
+		if !strings.Contains(lines[i], w) {
+			t.Errorf("Expected record %d to contain %s, got %s", i, w, lines[i])
+		}
+	}
+	if metrics := attrsToMap(h.Metrics(context.Background())); metrics["dropped"] != uint64(2) || metrics["buffered"] != int64(0) {
+		t.Errorf("Unexpected metrics %v", metrics)
+	}
+}
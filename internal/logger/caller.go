@@ -0,0 +1,159 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// maxHelperDepth is the maximum number of stack frames searched for the caller if helpers are marked.
+const maxHelperDepth = 32
+
+var (
+	// helpersMu guards helpers.
+	helpersMu sync.RWMutex
+	// helpers is the set of the names of the functions marked by [Helper].
+	helpers = map[string]struct{}{}
+	// helpersCount is the number of marked functions, used to skip the lookup if there are none.
+	helpersCount atomic.Int64
+)
+
+// Helper marks the calling function as a logging helper, like [testing.T.Helper].
+// The records logged by a helper, or by functions called by a helper, report the caller of the helper
+// as source instead of the helper itself, so wrapper functions report the real call site.
+// It may be called from multiple goroutines and its cost is small after the first call of a function.
+//
+// Example:
+//
+//	func logFailure(log logger.Provider, err error) {
+//		logger.Helper()
+//		log.Error("Request failed", "error", err)
+//	}
+func Helper() {
+	var pcs [1]uintptr
+	// Skip calling runtime.Callers and this function.
+	_ = runtime.Callers(2, pcs[:]) //nolint:mnd // see above
+	frame, _ := runtime.CallersFrames(pcs[:]).Next()
+
+	helpersMu.RLock()
+	_, ok := helpers[frame.Function]
+	helpersMu.RUnlock()
+	if ok {
+		return
+	}
+
+	helpersMu.Lock()
+	defer helpersMu.Unlock()
+	if _, ok = helpers[frame.Function]; !ok {
+		helpers[frame.Function] = struct{}{}
+		helpersCount.Add(1)
+	}
+}
+
+// isHelper reports whether the function was marked by [Helper].
+func isHelper(function string) bool {
+	helpersMu.RLock()
+	defer helpersMu.RUnlock()
+	_, ok := helpers[function]
+	return ok
+}
+
+// callerConfig is the configuration of the source reported by a logger.
+type callerConfig struct {
+	// omit reports whether the source is omitted, see [Provider.WithSource].
+	omit bool
+	// skip is the number of additional stack frames skipped, see [Provider.WithCallerSkip].
+	skip int
+}
+
+// WithSource returns a logger omitting the source of its records if enabled is false.
+// WithSource(true) restores the source omitted by WithSource(false), but cannot add it
+// to records of handlers configured without the source, e.g. by [Options.OmitSource].
+func (l *logger) WithSource(enabled bool) Provider {
+	c := *l
+	c.caller.omit = !enabled
+	return &c
+}
+
+// WithCallerSkip returns a logger skipping n additional stack frames to find the source of its records.
+// The skips of nested calls add up. Negative values are treated as zero.
+func (l *logger) WithCallerSkip(n int) Provider {
+	c := *l
+	c.caller.skip = max(l.caller.skip+n, 0)
+	return &c
+}
+
+// pc returns the program counter of the logging call for the source of a record, or zero if it is omitted.
+// The argument skip is the number of stack frames to skip, with 0 identifying the caller of pc.
+// The additional frames skipped by [Provider.WithCallerSkip] and the helpers marked by [Helper] are skipped, too.
+func (l *logger) pc(skip int) uintptr {
+	if l.caller.omit {
+		return 0
+	}
+	// Skip calling runtime.Callers and this function.
+	skip += 2 + l.caller.skip
+	if helpersCount.Load() == 0 {
+		var pcs [1]uintptr
+		_ = runtime.Callers(skip, pcs[:])
+		return pcs[0]
+	}
+
+	var pcs [maxHelperDepth]uintptr
+	n := runtime.Callers(skip, pcs[:])
+	for _, pc := range pcs[:n] {
+		frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+		if !isHelper(frame.Function) {
+			return pc
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return pcs[n-1]
+}
+
+// omitSourceEnv reports whether the LOG_SOURCE environment variable disables the source.
+// The second result reports whether it is set to a valid boolean.
+func omitSourceEnv() (omit, ok bool) {
+	enabled, err := strconv.ParseBool(os.Getenv("LOG_SOURCE"))
+	if err != nil {
+		return false, false
+	}
+	return !enabled, true
+}
+
+var _ slog.Handler = (*omitSourceHandler)(nil)
+
+// omitSourceHandler is a [slog.Handler] that removes the caller from records,
+// so the handlers writing the records omit the source field.
+type omitSourceHandler struct {
+	slog.Handler
+}
+
+// newOmitSourceHandler returns a new [slog.Handler] that removes the caller from records.
+func newOmitSourceHandler(h slog.Handler) slog.Handler {
+	return &omitSourceHandler{Handler: h}
+}
+
+// Handle removes the caller from the record and passes it to the wrapped handler.
+func (h *omitSourceHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	r.PC = 0
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *omitSourceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &omitSourceHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *omitSourceHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &omitSourceHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// logViaWrapper logs through a wrapper function, like a logging helper of an application.
+func logViaWrapper(log Provider) {
+	log.Info("wrapped")
+}
+
+// logViaHelper logs through a wrapper function marked as helper.
+func logViaHelper(log Provider) {
+	Helper()
+	log.Info("helped")
+}
+
+func TestLogger_Source(t *testing.T) {
+	tests := []struct {
+		name string
+		log  func(l Provider)
+		// want is the suffix of the function reported as source, empty if the source is omitted.
+		want string
+	}{
+		{name: "direct call", log: func(l Provider) { l.Info("direct") }, want: "TestLogger_Source.func1"},
+		{name: "log method", log: func(l Provider) { l.Log(context.Background(), LevelInfo, "log") }, want: "TestLogger_Source.func2"},
+		{name: "log attrs method", log: func(l Provider) { l.LogAttrs(context.Background(), LevelInfo, "attrs") }, want: "TestLogger_Source.func3"},
+		{name: "wrapper", log: func(l Provider) { logViaWrapper(l) }, want: "logViaWrapper"},
+		{name: "wrapper with caller skip", log: func(l Provider) { logViaWrapper(l.WithCallerSkip(1)) }, want: "TestLogger_Source.func5"},
+		{name: "helper", log: func(l Provider) { logViaHelper(l) }, want: "TestLogger_Source.func6"},
+		{name: "without source", log: func(l Provider) { l.WithSource(false).Info("no source") }},
+		{name: "source restored", log: func(l Provider) { l.WithSource(false).WithSource(true).Info("source") }, want: "TestLogger_Source.func8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			log := NewLogger(Options{Handler: slog.NewJSONHandler(buf, &slog.HandlerOptions{AddSource: true})})
+			tt.log(log)
+
+			var rec map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+				t.Fatalf("Failed to unmarshal record %q: %v", buf.String(), err)
+			}
+			src, ok := rec[slog.SourceKey].(map[string]any)
+			if tt.want == "" {
+				if ok {
+					t.Errorf("Expected no source, got %v", src)
+				}
+				return
+			}
+			if fn, _ := src["function"].(string); !strings.HasSuffix(fn, tt.want) {
+				t.Errorf("Expected source function %q, got %q", tt.want, fn)
+			}
+		})
+	}
+}
+
+func TestOptions_OmitSource(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		opts Options
+		want bool
+	}{
+		{name: "default", want: true},
+		{name: "omitted by options", opts: Options{OmitSource: true}, want: false},
+		{name: "omitted by env", env: "false", want: false},
+		{name: "env overrides options", env: "true", opts: Options{OmitSource: true}, want: true},
+		{name: "invalid env is ignored", env: "maybe", opts: Options{OmitSource: true}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LOG_SOURCE", tt.env)
+			buf := &bytes.Buffer{}
+			sink := func(Level) slog.Handler {
+				return slog.NewJSONHandler(buf, &slog.HandlerOptions{AddSource: true})
+			}
+			slog.New(NewPipeline(sink, tt.opts)).Info("test")
+
+			if got := strings.Contains(buf.String(), `"source"`); got != tt.want {
+				t.Errorf("Expected source %v, got %s", tt.want, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package logger
+
+import (
+	"context"
+	"hash/fnv"
+	"log/slog"
+	"math"
+	"math/bits"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// CardinalityOptions is the configuration for [NewCardinalityHandler].
+type CardinalityOptions struct {
+	// Threshold is the approximate number of distinct values of an attribute key
+	// above which the key is considered to explode. Defaults to 1000.
+	Threshold int
+	// Hash is a flag to replace the values of exploding keys by a hash bucket in [0, Threshold),
+	// which bounds the cardinality of the key while keeping equal values equal.
+	// By default the values are kept and only the warning is logged.
+	Hash bool
+	// MaxKeys is the maximum number of attribute keys tracked, since every key takes about 1 KiB.
+	// The values of further keys are passed through without being tracked and counted as untracked. Defaults to 1000.
+	MaxKeys int
+}
+
+const (
+	// defaultCardinalityThreshold is the default [CardinalityOptions.Threshold].
+	defaultCardinalityThreshold = 1000
+	// defaultCardinalityMaxKeys is the default [CardinalityOptions.MaxKeys].
+	defaultCardinalityMaxKeys = 1000
+)
+
+// newCardinalityOptions returns the provided CardinalityOptions merged with the default CardinalityOptions.
+func newCardinalityOptions(o ...CardinalityOptions) CardinalityOptions {
+	opts := CardinalityOptions{Threshold: defaultCardinalityThreshold, MaxKeys: defaultCardinalityMaxKeys}
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided CardinalityOptions with the receiver CardinalityOptions.
+func (o *CardinalityOptions) merge(d CardinalityOptions) CardinalityOptions {
+	if o.Threshold > 0 {
+		d.Threshold = o.Threshold
+	}
+	if o.Hash {
+		d.Hash = o.Hash
+	}
+	if o.MaxKeys > 0 {
+		d.MaxKeys = o.MaxKeys
+	}
+	return d
+}
+
+var (
+	_ slog.Handler    = (*CardinalityHandler)(nil)
+	_ MetricsProvider = (*CardinalityHandler)(nil)
+)
+
+// CardinalityHandler is a [slog.Handler] tracking the approximate number of distinct values per attribute key.
+type CardinalityHandler struct {
+	slog.Handler
+	opts  CardinalityOptions
+	state *cardinalityState
+}
+
+// cardinalityState is the state shared by a [CardinalityHandler] and the handlers derived from it.
+type cardinalityState struct {
+	// mu guards sketches, exceeded and full.
+	mu       sync.Mutex
+	sketches map[string]*hyperLogLog
+	exceeded map[string]bool
+	// full reports whether the maximum number of keys is tracked.
+	full bool
+	// untracked is the number of values of keys not tracked because of the maximum number of keys.
+	untracked atomic.Uint64
+}
+
+// NewCardinalityHandler returns a new [CardinalityHandler] that tracks the approximate number of distinct values
+// of the top-level attribute keys of every record with a HyperLogLog sketch.
+// Once a key exceeds [CardinalityOptions.Threshold], a warning is logged at [LevelWarn] once
+// and, if [CardinalityOptions.Hash] is set, its values are replaced by hash buckets.
+// This protects index-based log stores from cardinality explosions, e.g. caused by logging user IDs as keys.
+//
+// At most [CardinalityOptions.MaxKeys] keys are tracked, so the memory stays bounded if the keys themselves explode.
+// Once the maximum is reached, a warning is logged at [LevelWarn] once and the values of further keys
+// are passed through untracked. The number of untracked values is reported by [CardinalityHandler.Metrics].
+func NewCardinalityHandler(h slog.Handler, o ...CardinalityOptions) *CardinalityHandler {
+	return &CardinalityHandler{
+		Handler: h,
+		opts:    newCardinalityOptions(o...),
+		state:   &cardinalityState{sketches: map[string]*hyperLogLog{}, exceeded: map[string]bool{}},
+	}
+}
+
+// Metrics returns the number of tracked keys, of keys exceeding the threshold
+// and of values not tracked because of the maximum number of keys.
+func (h *CardinalityHandler) Metrics(_ context.Context) []slog.Attr {
+	h.state.mu.Lock()
+	tracked, exceeded := len(h.state.sketches), len(h.state.exceeded)
+	h.state.mu.Unlock()
+	return []slog.Attr{
+		slog.Int("tracked", tracked),
+		slog.Int("exceeded", exceeded),
+		slog.Uint64("untracked", h.state.untracked.Load()),
+	}
+}
+
+// Handle tracks the values of the record's attributes and passes the record to the wrapped handler.
+func (h *CardinalityHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	var warnings []slog.Record
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	hashed := false
+	r.Attrs(func(a slog.Attr) bool {
+		a.Value = a.Value.Resolve()
+		if a.Key == "" || a.Value.Kind() == slog.KindGroup {
+			attrs = append(attrs, a)
+			return true
+		}
+
+		value := a.Value.String()
+		estimate, exceeded, first, full := h.state.track(a.Key, value, h.opts)
+		if full {
+			w := slog.NewRecord(r.Time, slog.Level(LevelWarn), "Attribute keys limit reached, further keys are not tracked", r.PC)
+			w.AddAttrs(slog.String("key", a.Key), slog.Int("max_keys", h.opts.MaxKeys))
+			warnings = append(warnings, w)
+		}
+		if first {
+			w := slog.NewRecord(r.Time, slog.Level(LevelWarn), "Attribute cardinality exceeded", r.PC)
+			w.AddAttrs(slog.String("key", a.Key), slog.Uint64("estimate", estimate), slog.Int("threshold", h.opts.Threshold))
+			warnings = append(warnings, w)
+		}
+		if exceeded && h.opts.Hash {
+			a.Value = slog.StringValue(hashBucket(value, h.opts.Threshold))
+			hashed = true
+		}
+		attrs = append(attrs, a)
+		return true
+	})
+
+	for _, w := range warnings {
+		if h.Enabled(ctx, w.Level) {
+			_ = h.Handler.Handle(ctx, w)
+		}
+	}
+	if !hashed {
+		return h.Handler.Handle(ctx, r)
+	}
+	out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	out.AddAttrs(attrs...)
+	return h.Handler.Handle(ctx, out)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *CardinalityHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &CardinalityHandler{Handler: h.Handler.WithAttrs(attrs), opts: h.opts, state: h.state}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *CardinalityHandler) WithGroup(name string) slog.Handler {
+	return &CardinalityHandler{Handler: h.Handler.WithGroup(name), opts: h.opts, state: h.state}
+}
+
+// track adds the value to the sketch of the key and returns the estimated cardinality,
+// whether it exceeds the threshold and whether it is the first time it does.
+// Values of new keys are counted as untracked if the maximum number of keys is tracked,
+// full reports whether this is the first time.
+func (s *cardinalityState) track(key, value string, opts CardinalityOptions) (estimate uint64, exceeded, first, full bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.exceeded[key] {
+		return 0, true, false, false
+	}
+	sketch, ok := s.sketches[key]
+	if !ok {
+		if len(s.sketches)+len(s.exceeded) >= opts.MaxKeys {
+			s.untracked.Add(1)
+			full, s.full = !s.full, true
+			return 0, false, false, full
+		}
+		sketch = &hyperLogLog{}
+		s.sketches[key] = sketch
+	}
+	sketch.add(value)
+	estimate = sketch.estimate()
+	if estimate <= uint64(opts.Threshold) { //nolint:gosec // threshold is positive
+		return estimate, false, false, false
+	}
+	s.exceeded[key] = true
+	delete(s.sketches, key)
+	return estimate, true, true, false
+}
+
+// hashBucket returns the hash bucket of the value in [0, buckets).
+func hashBucket(value string, buckets int) string {
+	return "#" + strconv.FormatUint(hashString(value)%uint64(buckets), 16) //nolint:gosec // buckets is positive
+}
+
+// hyperLogLogPrecision is the number of bits of the hash used to select the register of a [hyperLogLog].
+// 2^10 registers result in a standard error of about 3%.
+const hyperLogLogPrecision = 10
+
+// hyperLogLog is a HyperLogLog sketch estimating the number of distinct values added to it.
+type hyperLogLog struct {
+	registers [1 << hyperLogLogPrecision]uint8
+}
+
+// add adds the value to the sketch.
+func (s *hyperLogLog) add(value string) {
+	h := hashString(value)
+	idx := h >> (64 - hyperLogLogPrecision)
+	rank := uint8(bits.LeadingZeros64(h<<hyperLogLogPrecision|1<<(hyperLogLogPrecision-1))) + 1 //nolint:gosec // at most 64
+	if rank > s.registers[idx] {
+		s.registers[idx] = rank
+	}
+}
+
+// estimate returns the estimated number of distinct values added to the sketch.
+func (s *hyperLogLog) estimate() uint64 {
+	const m = float64(len(s.registers))
+	sum, zeros := 0.0, 0
+	for _, r := range s.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	alpha := 0.7213 / (1 + 1.079/m) //nolint:mnd // bias correction of HyperLogLog
+	estimate := alpha * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 { //nolint:mnd // small range correction of HyperLogLog
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5) //nolint:mnd // round to the nearest integer
+}
+
+// hashString returns the 64-bit FNV-1a hash of the string with a finalizer mixing the bits,
+// since the HyperLogLog relies on uniformly distributed high bits.
+func hashString(s string) uint64 {
+	f := fnv.New64a()
+	_, _ = f.Write([]byte(s))
+	h := f.Sum64()
+	h ^= h >> 33            //nolint:mnd // murmur3 finalizer
+	h *= 0xff51afd7ed558ccd //nolint:mnd // murmur3 finalizer
+	h ^= h >> 33            //nolint:mnd // murmur3 finalizer
+	h *= 0xc4ceb9fe1a85ec53 //nolint:mnd // murmur3 finalizer
+	h ^= h >> 33            //nolint:mnd // murmur3 finalizer
+	return h
+}
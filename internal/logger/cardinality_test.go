@@ -0,0 +1,121 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+)
+
+func TestHyperLogLog(t *testing.T) {
+	for _, n := range []int{10, 1000, 100000} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			s := &hyperLogLog{}
+			for i := range n {
+				s.add("value-" + strconv.Itoa(i))
+				s.add("value-" + strconv.Itoa(i))
+			}
+			got := float64(s.estimate())
+			if math.Abs(got-float64(n))/float64(n) > 0.1 {
+				t.Errorf("Expected an estimate of about %d, got %v", n, got)
+			}
+		})
+	}
+}
+
+func TestNewCardinalityHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         CardinalityOptions
+		wantWarnings int
+		wantHashed   bool
+	}{
+		{
+			name:         "warns once",
+			opts:         CardinalityOptions{Threshold: 50},
+			wantWarnings: 1,
+		},
+		{
+			name:         "hashes exploding values",
+			opts:         CardinalityOptions{Threshold: 50, Hash: true},
+			wantWarnings: 1,
+			wantHashed:   true,
+		},
+		{
+			name: "below default threshold",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := 0
+			values := map[string]bool{}
+			log := NewLogger(Options{Handler: NewCardinalityHandler(test.MockHandler{
+				HandleFunc: func(_ context.Context, r slog.Record) error {
+					if r.Message == "Attribute cardinality exceeded" {
+						warnings++
+						return nil
+					}
+					r.Attrs(func(a slog.Attr) bool {
+						if a.Key == "user" {
+							values[a.Value.String()] = true
+						}
+						return true
+					})
+					return nil
+				},
+			}, tt.opts)})
+
+			for i := range 500 {
+				log.Info("test", "user", "user-"+strconv.Itoa(i), "status", "ok")
+			}
+
+			if warnings != tt.wantWarnings {
+				t.Errorf("Expected %d warnings, got %d", tt.wantWarnings, warnings)
+			}
+			hashed := 0
+			for v := range values {
+				if strings.HasPrefix(v, "#") {
+					hashed++
+				}
+			}
+			if (hashed > 0) != tt.wantHashed {
+				t.Errorf("Expected hashed values: %v, got %d", tt.wantHashed, hashed)
+			}
+			if tt.wantHashed && hashed > tt.opts.Threshold {
+				t.Errorf("Expected at most %d hash buckets, got %d", tt.opts.Threshold, hashed)
+			}
+		})
+	}
+}
+
+func TestNewCardinalityHandler_MaxKeys(t *testing.T) {
+	warnings := 0
+	h := NewCardinalityHandler(test.MockHandler{
+		EnabledFunc: func(context.Context, slog.Level) bool { return true },
+		HandleFunc: func(_ context.Context, r slog.Record) error {
+			if r.Message == "Attribute keys limit reached, further keys are not tracked" {
+				warnings++
+			}
+			return nil
+		},
+	}, CardinalityOptions{MaxKeys: 2})
+
+	log := slog.New(h)
+	for i := range 10 {
+		log.Info("test", "key-"+strconv.Itoa(i), "value")
+	}
+
+	if warnings != 1 {
+		t.Errorf("Expected 1 warning, got %d", warnings)
+	}
+	want := []slog.Attr{slog.Int("tracked", 2), slog.Int("exceeded", 0), slog.Uint64("untracked", 8)}
+	if got := h.Metrics(context.Background()); !slices.EqualFunc(got, want, slog.Attr.Equal) {
+		t.Errorf("Metrics() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+)
+
+// RecordTransformer transforms a record, e.g. rewrites its message, adds or removes attributes or remaps its level.
+// A transformer removing attributes must return a new record created with [slog.NewRecord].
+type RecordTransformer func(r slog.Record) slog.Record
+
+var _ slog.Handler = (*chainHandler)(nil)
+
+// chainHandler is a [slog.Handler] transforming records by [RecordTransformer]s before passing them on.
+type chainHandler struct {
+	slog.Handler
+	transformers []RecordTransformer
+}
+
+// Chain returns a new [slog.Handler] that passes every record through the given transformers in order
+// before passing it to the given handler. In contrast to [slog.HandlerOptions.ReplaceAttr], the transformers see the whole record,
+// so they can rewrite its message and level or add attributes depending on the others.
+// Chains can be nested, the transformers of the outer chain run first.
+// Declarative rules, e.g. loaded from a configuration file, are compiled to a transformer by [NewTransformer].
+//
+// The transformers only see the attributes of the record, not those added by [Provider.With].
+// Records remapped to a level the given handler is not enabled for are dropped,
+// while records at a level it is not enabled for never reach the transformers.
+func Chain(h slog.Handler, transformers ...RecordTransformer) slog.Handler {
+	return &chainHandler{Handler: h, transformers: transformers}
+}
+
+// Handle transforms the record and passes it to the wrapped handler if it is enabled for its level.
+func (h *chainHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	// The transformers may add attributes, which must not be shared with the caller's record.
+	r = r.Clone()
+	for _, t := range h.transformers {
+		r = t(r)
+	}
+	if !h.Handler.Enabled(ctx, r.Level) {
+		return nil
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *chainHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &chainHandler{Handler: h.Handler.WithAttrs(attrs), transformers: h.transformers}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *chainHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &chainHandler{Handler: h.Handler.WithGroup(name), transformers: h.transformers}
+}
@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChain(t *testing.T) {
+	redactUser := func(r slog.Record) slog.Record {
+		out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+		r.Attrs(func(a slog.Attr) bool {
+			if a.Key != "user" {
+				out.AddAttrs(a)
+			}
+			return true
+		})
+		return out
+	}
+	prefix := func(r slog.Record) slog.Record {
+		r.Message = "[api] " + r.Message
+		return r
+	}
+	demote := func(r slog.Record) slog.Record {
+		if strings.Contains(r.Message, "retry") {
+			r.Level = slog.LevelDebug
+		}
+		return r
+	}
+	tag := func(r slog.Record) slog.Record {
+		r.AddAttrs(slog.Int("attrs", r.NumAttrs()))
+		return r
+	}
+
+	var buf strings.Builder
+	h := Chain(Chain(slog.NewTextHandler(&buf, nil), tag), redactUser, prefix, demote)
+	log := slog.New(h).With("service", "api")
+
+	log.Info("login", "user", "alice", "ok", true)
+	log.Warn("retry")
+	got := buf.String()
+	if strings.Count(got, "\n") != 1 {
+		t.Fatalf("Expected the demoted record to be dropped, got %s", got)
+	}
+	if !strings.Contains(got, `msg="[api] login" service=api ok=true attrs=1`) || strings.Contains(got, "alice") {
+		t.Errorf("Expected the transformed record, got %s", got)
+	}
+}
+
+func TestChain_Clone(t *testing.T) {
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "hello", 0)
+	r.AddAttrs(slog.Int("a", 1), slog.Int("b", 2), slog.Int("c", 3), slog.Int("d", 4), slog.Int("e", 5), slog.Int("f", 6))
+	h := Chain(slog.NewTextHandler(&strings.Builder{}, nil), func(r slog.Record) slog.Record {
+		r.AddAttrs(slog.Int("g", 7))
+		return r
+	})
+
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if r.NumAttrs() != 6 {
+		t.Errorf("Expected the caller's record to stay unchanged, got %d attributes", r.NumAttrs())
+	}
+}
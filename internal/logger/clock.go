@@ -0,0 +1,244 @@
+package logger
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// ElapsedKey is the key used by [Elapsed].
+	ElapsedKey = "elapsed"
+	// ClockOffsetKey is the key used for the clock offset sampled by a [ClockOffsetSampler].
+	ClockOffsetKey = "clock_offset"
+)
+
+// Elapsed returns a group attribute with the duration since start measured with the monotonic clock
+// and with the wall clock.
+// A difference between both durations indicates that the wall clock was adjusted (e.g. by NTP) during the measured period,
+// which helps to correlate logs across machines with skewed clocks.
+func Elapsed(start time.Time) slog.Attr {
+	now := time.Now()
+	return slog.Group(ElapsedKey,
+		slog.Duration("monotonic", now.Sub(start)),
+		slog.Duration("wall", now.Round(0).Sub(start.Round(0))),
+	)
+}
+
+// DefaultNTPServer is the NTP server queried by a [ClockOffsetSampler] if no server is provided.
+const DefaultNTPServer = "pool.ntp.org:123"
+
+const (
+	// ntpPacketSize is the size of an NTP packet without extensions.
+	ntpPacketSize = 48
+	// ntpClientRequest is the first byte of an SNTP client request (LI = 0, VN = 4, Mode = 3).
+	ntpClientRequest = 0x23
+	// ntpModeMask is the mask of the mode in the first byte of an NTP packet.
+	ntpModeMask = 0x07
+	// ntpModeServer is the mode of a server reply.
+	ntpModeServer = 4
+	// ntpEpochOffset is the number of seconds between the NTP epoch (1900) and the unix epoch (1970).
+	ntpEpochOffset = 2208988800
+	// ntpTimeout is the timeout of a single NTP query.
+	ntpTimeout = 5 * time.Second
+)
+
+var _ slog.LogValuer = (*ClockOffsetSampler)(nil)
+
+// ClockOffsetSampler periodically samples the offset of the local clock to an NTP server.
+// The latest sampled offset is added to every record by the handler returned by [NewClockOffsetHandler]:
+//
+//	sampler := NewClockOffsetSampler("", time.Minute)
+//	go sampler.Start(ctx)
+//	log := NewLogger(Options{Handler: NewClockOffsetHandler(h, sampler)})
+type ClockOffsetSampler struct {
+	server   string
+	interval time.Duration
+	// offset is the latest sampled offset in nanoseconds.
+	offset atomic.Int64
+	// sampled reports whether an offset was sampled successfully.
+	sampled atomic.Bool
+}
+
+// NewClockOffsetSampler returns a new [ClockOffsetSampler] querying the given NTP server ("host:port") every interval.
+// If the server is empty, [DefaultNTPServer] is used.
+func NewClockOffsetSampler(server string, interval time.Duration) *ClockOffsetSampler {
+	if server == "" {
+		server = DefaultNTPServer
+	}
+	return &ClockOffsetSampler{server: server, interval: interval}
+}
+
+// Start samples the clock offset immediately and then every interval until the context is done.
+// Failed samples are logged at [LevelDebug] with the logger found in the context.
+// Returns [ErrInvalidInterval] if the interval is not positive.
+func (s *ClockOffsetSampler) Start(ctx context.Context) error {
+	if s.interval <= 0 {
+		return ErrInvalidInterval
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Sample(ctx); err != nil {
+			FromContext(ctx).DebugContext(ctx, "Failed to sample clock offset", "server", s.server, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sample queries the NTP server once and returns the offset of the local clock.
+// A positive offset means the local clock is behind the server's clock.
+func (s *ClockOffsetSampler) Sample(ctx context.Context) (time.Duration, error) {
+	offset, err := queryNTP(ctx, s.server)
+	if err != nil {
+		return 0, err
+	}
+	s.offset.Store(int64(offset))
+	s.sampled.Store(true)
+	return offset, nil
+}
+
+// Offset returns the latest sampled clock offset and whether an offset was sampled yet.
+func (s *ClockOffsetSampler) Offset() (time.Duration, bool) {
+	return time.Duration(s.offset.Load()), s.sampled.Load()
+}
+
+// Attr returns an attribute resolving to the latest sampled clock offset when it is logged.
+// Handlers resolve the attributes added with [slog.Logger.With] only once,
+// so the attribute must be passed to the logging call or added by [NewClockOffsetHandler].
+func (s *ClockOffsetSampler) Attr() slog.Attr {
+	return slog.Any(ClockOffsetKey, s)
+}
+
+// LogValue returns the latest sampled clock offset or an empty group if no offset was sampled yet,
+// which omits the attribute.
+func (s *ClockOffsetSampler) LogValue() slog.Value {
+	offset, ok := s.Offset()
+	if !ok {
+		return slog.GroupValue()
+	}
+	return slog.DurationValue(offset)
+}
+
+var _ slog.Handler = (*clockOffsetHandler)(nil)
+
+// clockOffsetHandler is a [slog.Handler] adding the latest sampled clock offset to every record.
+type clockOffsetHandler struct {
+	slog.Handler
+	sampler *ClockOffsetSampler
+}
+
+// NewClockOffsetHandler returns a new [slog.Handler] that adds the latest clock offset sampled by s
+// under [ClockOffsetKey] to every record before passing it to the given handler.
+// The attribute is omitted until the first offset was sampled.
+func NewClockOffsetHandler(h slog.Handler, s *ClockOffsetSampler) slog.Handler {
+	return &clockOffsetHandler{Handler: h, sampler: s}
+}
+
+// Handle adds the latest sampled clock offset to the record and passes it to the wrapped handler.
+func (h *clockOffsetHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	if offset, ok := h.sampler.Offset(); ok {
+		r = r.Clone()
+		r.AddAttrs(slog.Duration(ClockOffsetKey, offset))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *clockOffsetHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &clockOffsetHandler{Handler: h.Handler.WithAttrs(attrs), sampler: h.sampler}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *clockOffsetHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &clockOffsetHandler{Handler: h.Handler.WithGroup(name), sampler: h.sampler}
+}
+
+// queryNTP sends an SNTP request to the server and returns the offset of the local clock.
+func queryNTP(ctx context.Context, server string) (time.Duration, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", server)
+	if err != nil {
+		return 0, fmt.Errorf("failed to dial ntp server: %w", err)
+	}
+	defer conn.Close() //nolint:errcheck // best effort
+
+	deadline := time.Now().Add(ntpTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err = conn.SetDeadline(deadline); err != nil {
+		return 0, fmt.Errorf("failed to set deadline: %w", err)
+	}
+
+	req := make([]byte, ntpPacketSize)
+	req[0] = ntpClientRequest
+	sent := time.Now()
+	transmit := toNTPTime(sent)
+	binary.BigEndian.PutUint64(req[40:], transmit)
+	if _, err = conn.Write(req); err != nil {
+		return 0, fmt.Errorf("failed to send ntp request: %w", err)
+	}
+
+	resp := make([]byte, ntpPacketSize)
+	n, err := conn.Read(resp)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read ntp response: %w", err)
+	}
+	received := sent.Add(time.Since(sent))
+	if n < ntpPacketSize {
+		return 0, errors.New("invalid ntp response: packet too short")
+	}
+	// A reply must come from a server, not be a kiss-o'-death packet (stratum 0)
+	// and echo the transmit time of the request, so stray or spoofed packets are rejected.
+	if mode := resp[0] & ntpModeMask; mode != ntpModeServer {
+		return 0, fmt.Errorf("invalid ntp response: mode %d", mode)
+	}
+	if resp[1] == 0 {
+		return 0, fmt.Errorf("invalid ntp response: kiss-o'-death %q", resp[12:16])
+	}
+	if binary.BigEndian.Uint64(resp[24:]) != transmit {
+		return 0, errors.New("invalid ntp response: originate time does not match the request")
+	}
+
+	serverReceived := fromNTPTime(binary.BigEndian.Uint64(resp[32:]))
+	serverSent := fromNTPTime(binary.BigEndian.Uint64(resp[40:]))
+	if serverSent.IsZero() || serverReceived.IsZero() {
+		return 0, errors.New("invalid ntp response: missing timestamps")
+	}
+
+	return (serverReceived.Sub(sent) + serverSent.Sub(received)) / 2, nil //nolint:mnd // average of both directions
+}
+
+// toNTPTime converts the time to an NTP timestamp.
+func toNTPTime(t time.Time) uint64 {
+	secs := uint64(t.Unix() + ntpEpochOffset) //nolint:gosec // the NTP era wraps around by design
+	frac := (uint64(t.Nanosecond()) << 32) / uint64(time.Second)
+	return secs<<32 | frac
+}
+
+// fromNTPTime converts an NTP timestamp to a time.
+// Returns the zero time if the timestamp is zero.
+func fromNTPTime(ts uint64) time.Time {
+	if ts == 0 {
+		return time.Time{}
+	}
+	secs := int64(ts>>32) - ntpEpochOffset
+	nsec := (ts & 0xffffffff) * uint64(time.Second) >> 32
+	return time.Unix(secs, int64(nsec)) //nolint:gosec // nsec is always less than a second
+}
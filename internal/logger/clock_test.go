@@ -0,0 +1,185 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestElapsed(t *testing.T) {
+	start := time.Now().Add(-time.Second)
+	attr := Elapsed(start)
+	if attr.Key != ElapsedKey {
+		t.Fatalf("Expected key %q, got %q", ElapsedKey, attr.Key)
+	}
+
+	got := map[string]time.Duration{}
+	for _, a := range attr.Value.Group() {
+		got[a.Key] = a.Value.Duration()
+	}
+	for _, key := range []string{"monotonic", "wall"} {
+		if got[key] < time.Second || got[key] > 2*time.Second {
+			t.Errorf("Expected %s duration of about 1s, got %v", key, got[key])
+		}
+	}
+}
+
+func TestClockOffsetSampler(t *testing.T) {
+	tests := []struct {
+		name       string
+		skew       time.Duration
+		respond    bool
+		tamper     func(resp []byte)
+		wantErr    bool
+		wantSample bool
+	}{
+		{
+			name:       "server ahead",
+			skew:       5 * time.Second,
+			respond:    true,
+			wantSample: true,
+		},
+		{
+			name:       "server behind",
+			skew:       -3 * time.Second,
+			respond:    true,
+			wantSample: true,
+		},
+		{
+			name:    "server not responding",
+			respond: false,
+			wantErr: true,
+		},
+		{
+			name:    "reply not from a server",
+			respond: true,
+			tamper:  func(resp []byte) { resp[0] = ntpClientRequest },
+			wantErr: true,
+		},
+		{
+			name:    "kiss-o'-death",
+			respond: true,
+			tamper:  func(resp []byte) { resp[1] = 0; copy(resp[12:], "RATE") },
+			wantErr: true,
+		},
+		{
+			name:    "originate time mismatch",
+			respond: true,
+			tamper:  func(resp []byte) { resp[31]++ },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := startNTPServer(t, tt.skew, tt.respond, tt.tamper)
+			s := NewClockOffsetSampler(addr, time.Minute)
+
+			if v := s.Attr().Value.Resolve(); v.Kind() != slog.KindGroup || len(v.Group()) != 0 {
+				t.Errorf("Expected empty value before the first sample, got %v", v)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+			offset, err := s.Sample(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Sample() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got, ok := s.Offset()
+			if ok != tt.wantSample {
+				t.Fatalf("Expected sampled offset: %v, got %v", tt.wantSample, ok)
+			}
+			if !tt.wantSample {
+				return
+			}
+			if got != offset || (got-tt.skew).Abs() > 100*time.Millisecond {
+				t.Errorf("Expected offset of about %v, got %v", tt.skew, got)
+			}
+			if v := s.Attr().Value.Resolve(); v.Duration() != got {
+				t.Errorf("Expected attribute to resolve to %v, got %v", got, v)
+			}
+		})
+	}
+}
+
+func TestClockOffsetSampler_Start_InvalidInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if err := NewClockOffsetSampler("127.0.0.1:0", interval).Start(context.Background()); !errors.Is(err, ErrInvalidInterval) {
+			t.Errorf("Expected %v for interval %v, got %v", ErrInvalidInterval, interval, err)
+		}
+	}
+}
+
+func TestNewClockOffsetHandler(t *testing.T) {
+	s := NewClockOffsetSampler("", time.Minute)
+	buf := &bytes.Buffer{}
+	log := slog.New(NewClockOffsetHandler(slog.NewJSONHandler(buf, nil), s)).With("name", "test")
+
+	offsets := []time.Duration{0, time.Second, -2 * time.Second}
+	for i, want := range offsets {
+		if i > 0 {
+			s.offset.Store(int64(want))
+			s.sampled.Store(true)
+		}
+		buf.Reset()
+		log.Info("test")
+
+		var rec map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+			t.Fatalf("Failed to unmarshal record: %v", err)
+		}
+		got, ok := rec[ClockOffsetKey]
+		if i == 0 {
+			if ok {
+				t.Errorf("Expected no offset before the first sample, got %v", got)
+			}
+			continue
+		}
+		if got != float64(want) {
+			t.Errorf("Expected the latest offset %v, got %v", want, got)
+		}
+	}
+}
+
+// startNTPServer starts a fake NTP server whose clock is skewed by the given duration.
+// If tamper is not nil, it modifies every reply before it is sent.
+func startNTPServer(t *testing.T, skew time.Duration, respond bool, tamper func(resp []byte)) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, ntpPacketSize)
+		for {
+			_, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if !respond {
+				continue
+			}
+			now := toNTPTime(time.Now().Add(skew))
+			resp := make([]byte, ntpPacketSize)
+			resp[0] = 0x24 // LI = 0, VN = 4, Mode = 4 (server)
+			resp[1] = 2    // stratum
+			copy(resp[24:], buf[40:48])
+			binary.BigEndian.PutUint64(resp[32:], now)
+			binary.BigEndian.PutUint64(resp[40:], now)
+			if tamper != nil {
+				tamper(resp)
+			}
+			_, _ = conn.WriteTo(resp, addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
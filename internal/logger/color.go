@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"os"
+)
+
+// colorMode is whether the human-readable formats write colors.
+type colorMode int
+
+const (
+	// colorAuto writes colors if the output is a terminal supporting them.
+	colorAuto colorMode = iota
+	// colorNever never writes colors.
+	colorNever
+	// colorAlways writes colors even if the output is not a terminal.
+	colorAlways
+)
+
+// colorModeFromEnv returns the color mode selected by the environment following the common conventions:
+// a non-empty NO_COLOR (https://no-color.org) disables colors and takes precedence,
+// a FORCE_COLOR or CLICOLOR_FORCE other than "0" forces colors (https://bixense.com/clicolors)
+// and a CLICOLOR of "0" or a TERM of "dumb" disable colors.
+func colorModeFromEnv() colorMode {
+	if os.Getenv("NO_COLOR") != "" {
+		return colorNever
+	}
+	for _, key := range []string{"FORCE_COLOR", "CLICOLOR_FORCE"} {
+		if v, ok := os.LookupEnv(key); ok && v != "0" && v != "false" {
+			return colorAlways
+		}
+	}
+	if os.Getenv("CLICOLOR") == "0" || os.Getenv("TERM") == "dumb" {
+		return colorNever
+	}
+	return colorAuto
+}
+
+// prefersHumanFormat reports whether the records written to the file should use a human-readable format,
+// i.e. whether the file is a terminal or colors are forced by the environment.
+func prefersHumanFormat(f *os.File) bool {
+	return terminalWidth(f) > 0 || colorModeFromEnv() == colorAlways
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package logger
+
+import "io"
+
+// enableVirtualTerminal reports whether ANSI escape sequences can be written to the writer,
+// which is always the case outside of Windows.
+func enableVirtualTerminal(_ io.Writer) bool {
+	return true
+}
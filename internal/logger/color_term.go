@@ -0,0 +1,27 @@
+//go:build !js && !wasip1
+
+package logger
+
+import (
+	"io"
+
+	"github.com/muesli/termenv"
+)
+
+// colorProfile returns the color profile of the writer selected by the environment, see [colorModeFromEnv],
+// and false if the profile is detected from the writer.
+// On Windows, the processing of escape sequences is enabled for consoles; consoles not supporting it are not colored.
+func colorProfile(w io.Writer) (termenv.Profile, bool) {
+	switch colorModeFromEnv() {
+	case colorNever:
+		return termenv.Ascii, true
+	case colorAlways:
+		enableVirtualTerminal(w)
+		return termenv.ANSI256, true
+	default:
+		if !enableVirtualTerminal(w) {
+			return termenv.Ascii, true
+		}
+		return termenv.Ascii, false
+	}
+}
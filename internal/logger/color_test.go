@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"os"
+	"testing"
+)
+
+// unsetColorEnv unsets the environment variables selecting the color mode for the duration of the test.
+func unsetColorEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{"NO_COLOR", "FORCE_COLOR", "CLICOLOR_FORCE", "CLICOLOR", "TERM"} {
+		t.Setenv(key, "")
+		if err := os.Unsetenv(key); err != nil {
+			t.Fatalf("Unsetenv() error = %v", err)
+		}
+	}
+}
+
+func TestColorModeFromEnv(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want colorMode
+	}{
+		{name: "unset", want: colorAuto},
+		{name: "no color", env: map[string]string{"NO_COLOR": "1"}, want: colorNever},
+		{name: "no color takes precedence", env: map[string]string{"NO_COLOR": "1", "FORCE_COLOR": "1"}, want: colorNever},
+		{name: "empty no color is ignored", env: map[string]string{"NO_COLOR": ""}, want: colorAuto},
+		{name: "force color", env: map[string]string{"FORCE_COLOR": "3"}, want: colorAlways},
+		{name: "force color disabled", env: map[string]string{"FORCE_COLOR": "0"}, want: colorAuto},
+		{name: "clicolor force", env: map[string]string{"CLICOLOR_FORCE": "1", "CLICOLOR": "0"}, want: colorAlways},
+		{name: "clicolor disabled", env: map[string]string{"CLICOLOR": "0"}, want: colorNever},
+		{name: "dumb terminal", env: map[string]string{"TERM": "dumb"}, want: colorNever},
+		{name: "forced on dumb terminal", env: map[string]string{"TERM": "dumb", "FORCE_COLOR": "1"}, want: colorAlways},
+		{name: "color terminal", env: map[string]string{"TERM": "xterm-256color"}, want: colorAuto},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unsetColorEnv(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if got := colorModeFromEnv(); got != tt.want {
+				t.Errorf("colorModeFromEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewOptions_Format(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		opts Options
+		want string
+	}{
+		{name: "json without terminal", want: ""},
+		{name: "dev with forced colors", env: map[string]string{"FORCE_COLOR": "1"}, want: devFormat},
+		{name: "explicit format wins", env: map[string]string{"FORCE_COLOR": "1"}, opts: Options{Format: "JSON"}, want: "JSON"},
+		{name: "env format wins", env: map[string]string{"FORCE_COLOR": "1", "LOG_FORMAT": "TEXT"}, opts: Options{Format: "JSON"}, want: "TEXT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unsetColorEnv(t)
+			t.Setenv("LOG_FORMAT", "")
+			if err := os.Unsetenv("LOG_FORMAT"); err != nil {
+				t.Fatalf("Unsetenv() error = %v", err)
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if terminalWidth(os.Stderr) > 0 {
+				t.Skip("stderr is a terminal")
+			}
+			if got := newOptions(tt.opts).Format; got != tt.want {
+				t.Errorf("Format = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+//go:build windows
+
+package logger
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// enableVirtualTerminal enables the processing of ANSI escape sequences if the writer is a Windows console
+// and reports whether escape sequences can be written to it. Writers other than consoles, e.g. files, pipes
+// or terminal emulators like mintty, are reported as capable, so their color support is detected as usual.
+// Legacy consoles not supporting virtual terminal processing are reported as not capable.
+func enableVirtualTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return true
+	}
+	handle := windows.Handle(f.Fd())
+	var mode uint32
+	if err := windows.GetConsoleMode(handle, &mode); err != nil {
+		return true
+	}
+	if mode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING != 0 {
+		return true
+	}
+	return windows.SetConsoleMode(handle, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING) == nil
+}
@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"sync"
+)
+
+// DictionaryKey is the key of the dictionary record mapping the short keys of the compact mode
+// back to the standard keys, see [Options.CompactKeys].
+const DictionaryKey = "dictionary"
+
+// shortKeys maps the standard keys to their abbreviations used in the compact mode.
+var shortKeys = map[string]string{
+	slog.TimeKey:    "t",
+	slog.LevelKey:   "l",
+	slog.MessageKey: "m",
+	slog.SourceKey:  "s",
+}
+
+// compactReplaceAttr returns the replacement function for slog.HandlerOptions
+// that abbreviates the standard keys of a record.
+func compactReplaceAttr(next func([]string, slog.Attr) slog.Attr) func([]string, slog.Attr) slog.Attr {
+	return func(groups []string, a slog.Attr) slog.Attr {
+		a = next(groups, a)
+		if len(groups) > 0 {
+			return a
+		}
+		if short, ok := shortKeys[a.Key]; ok {
+			a.Key = short
+		}
+		return a
+	}
+}
+
+// dictionaryWriter is an [io.Writer] that writes the dictionary of the short keys
+// as first record before any other output.
+type dictionaryWriter struct {
+	w    io.Writer
+	once sync.Once
+}
+
+// newDictionaryWriter returns a new [io.Writer] that prefixes the output of w with the dictionary record.
+func newDictionaryWriter(w io.Writer) io.Writer {
+	return &dictionaryWriter{w: w}
+}
+
+// Write writes the dictionary record on the first call and then p.
+func (d *dictionaryWriter) Write(p []byte) (int, error) {
+	var err error
+	d.once.Do(func() {
+		dict := make(map[string]string, len(shortKeys))
+		for key, short := range shortKeys {
+			dict[short] = key
+		}
+		var b []byte
+		b, err = json.Marshal(map[string]any{DictionaryKey: dict})
+		if err == nil {
+			_, err = d.w.Write(append(b, '\n'))
+		}
+	})
+	if err != nil {
+		return 0, err
+	}
+	return d.w.Write(p)
+}
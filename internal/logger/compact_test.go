@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestCompactKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		dictionary bool
+	}{
+		{name: "short keys"},
+		{name: "short keys with dictionary", dictionary: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			w := newDictionaryWriter(buf)
+			if !tt.dictionary {
+				w = buf
+			}
+			log := NewLogger(Options{Handler: slog.NewJSONHandler(w, &slog.HandlerOptions{
+				AddSource:   true,
+				ReplaceAttr: compactReplaceAttr(replaceAttr),
+			})})
+			log.WithGroup("group").Info("first", "msg", "nested")
+			log.Info("second")
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if tt.dictionary {
+				var dict map[string]map[string]string
+				if err := json.Unmarshal([]byte(lines[0]), &dict); err != nil {
+					t.Fatalf("Failed to unmarshal dictionary: %v", err)
+				}
+				if dict[DictionaryKey]["m"] != slog.MessageKey || dict[DictionaryKey]["l"] != slog.LevelKey {
+					t.Errorf("Expected dictionary of short keys, got %v", dict)
+				}
+				lines = lines[1:]
+			}
+			if len(lines) != 2 {
+				t.Fatalf("Expected 2 records, got %d: %s", len(lines), buf.String())
+			}
+
+			var rec map[string]any
+			if err := json.Unmarshal([]byte(lines[0]), &rec); err != nil {
+				t.Fatalf("Failed to unmarshal record: %v", err)
+			}
+			for _, key := range []string{"t", "l", "m", "s"} {
+				if _, ok := rec[key]; !ok {
+					t.Errorf("Expected short key %q, got %v", key, rec)
+				}
+			}
+			if rec["l"] != LevelInfo.String() || rec["m"] != "first" {
+				t.Errorf("Expected level and message values to be kept, got %v", rec)
+			}
+			if group, _ := rec["group"].(map[string]any); group["msg"] != "nested" {
+				t.Errorf("Expected nested keys to be kept, got %v", rec["group"])
+			}
+		})
+	}
+}
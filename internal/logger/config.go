@@ -0,0 +1,332 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrInvalidConfig is returned by [NewFromConfig] if the [Config] is invalid.
+var ErrInvalidConfig = errors.New("invalid logger config")
+
+// Config is the configuration of a logger built by [NewFromConfig], e.g. decoded from the configuration
+// of the application. In contrast to the [Options], it is not overridden by environment variables like LOG_LEVEL.
+type Config struct {
+	// Level is the name of the minimum log level. Defaults to "INFO".
+	Level string `json:"level,omitempty" yaml:"level,omitempty"`
+	// Format is the log format: "JSON", "TEXT" or "DEV". Defaults to "JSON".
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+	// Output is the destination of the records: "stderr", "stdout", "discard", the path of a file
+	// or a URI like "file:///var/log/app.log" or "tcp://collector:514", see [Options.Output]. Defaults to "stderr".
+	Output string `json:"output,omitempty" yaml:"output,omitempty"`
+	// AddSource is a flag to add the source of the logging call to the JSON and text records.
+	AddSource bool `json:"addSource,omitempty" yaml:"addSource,omitempty"`
+	// TimeFormat is the layout of the times of the records, see [Options.TimeFormat].
+	TimeFormat string `json:"timeFormat,omitempty" yaml:"timeFormat,omitempty"`
+	// TimeZone is the name of the location the times of the records are converted to, e.g. "UTC" or "Europe/Berlin".
+	// Defaults to the local time zone.
+	TimeZone string `json:"timeZone,omitempty" yaml:"timeZone,omitempty"`
+	// Environment is the environment the program runs in, see [Options.Environment].
+	Environment string `json:"environment,omitempty" yaml:"environment,omitempty"`
+	// Redaction is the [RedactionPolicy] applied to all records, see [Options.Redaction].
+	Redaction string `json:"redaction,omitempty" yaml:"redaction,omitempty"`
+	// RedactPaths are the dotted paths of attributes redacted in addition to the policy, see [Options.RedactPaths].
+	RedactPaths []string `json:"redactPaths,omitempty" yaml:"redactPaths,omitempty"`
+	// RedactKeys are the keys of attributes redacted in addition to the policy, see [Options.RedactKeys].
+	RedactKeys []string `json:"redactKeys,omitempty" yaml:"redactKeys,omitempty"`
+	// RedactPatterns are the names of the patterns redacted in addition to the policy, see [Options.RedactPatterns].
+	RedactPatterns []string `json:"redactPatterns,omitempty" yaml:"redactPatterns,omitempty"`
+	// Transforms are the rules transforming every record, see [Options.Transforms].
+	Transforms []TransformRule `json:"transforms,omitempty" yaml:"transforms,omitempty"`
+	// HashKeys are the keys of attributes whose values are replaced by their keyed hash, see [Options.HashKeys].
+	HashKeys []string `json:"hashKeys,omitempty" yaml:"hashKeys,omitempty"`
+	// HashSecret is the secret key of the hashes of HashKeys, see [Options.HashSecret].
+	// Prefer LOG_HASH_SECRET over storing it in a file.
+	HashSecret string `json:"hashSecret,omitempty" yaml:"hashSecret,omitempty"`
+	// AllowKeys are the only keys of attributes emitted if set, see [Options.AllowKeys].
+	AllowKeys []string `json:"allowKeys,omitempty" yaml:"allowKeys,omitempty"`
+	// AllowPlaceholder is a flag to redact the attributes not allowed instead of dropping them,
+	// see [Options.AllowPlaceholder].
+	AllowPlaceholder bool `json:"allowPlaceholder,omitempty" yaml:"allowPlaceholder,omitempty"`
+	// RecordIDs is a flag to add a unique ID to every record, see [Options.RecordIDs].
+	RecordIDs bool `json:"recordIDs,omitempty" yaml:"recordIDs,omitempty"`
+	// Sampling limits the repeated records passed to the outputs, see [NewSamplingHandler]. Nil disables sampling.
+	Sampling *SamplingOptions `json:"sampling,omitempty" yaml:"sampling,omitempty"`
+	// Limits truncates the records exceeding them, see [NewLimitHandler]. Nil disables the limits.
+	Limits *LimitOptions `json:"limits,omitempty" yaml:"limits,omitempty"`
+	// Metrics counts the records written per output and level, the errors of the outputs and the records
+	// dropped by the sampling, see [PipelineMetrics]. Nil disables the counting.
+	Metrics *PipelineMetrics `json:"-" yaml:"-"`
+	// Outputs are the outputs the records are written to. If empty, they are written to the single output
+	// described by Level, Format and Output. Otherwise, those are the defaults of the outputs.
+	Outputs []SinkConfig `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+}
+
+// SinkConfig is the configuration of an output of a [Config].
+type SinkConfig struct {
+	// Level is the name of the minimum log level of the output. Defaults to [Config.Level].
+	Level string `json:"level,omitempty" yaml:"level,omitempty"`
+	// Format is the log format of the output. Defaults to [Config.Format].
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+	// Output is the destination of the records of the output. Defaults to [Config.Output].
+	Output string `json:"output,omitempty" yaml:"output,omitempty"`
+}
+
+// Validate reports all invalid settings of the configuration.
+func (c *Config) Validate() error {
+	var errs []error
+	for i, sink := range c.sinks() {
+		prefix := "output"
+		if len(c.Outputs) > 0 {
+			prefix = fmt.Sprintf("outputs[%d]", i)
+		}
+		if _, err := parseLevel(sink.Level); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", prefix, err))
+		}
+		if !isConfigFormat(sink.Format) {
+			errs = append(errs, fmt.Errorf("%s: unknown format %q", prefix, sink.Format))
+		}
+	}
+	if c.TimeZone != "" {
+		if _, err := time.LoadLocation(c.TimeZone); err != nil {
+			errs = append(errs, fmt.Errorf("time zone: %w", err))
+		}
+	}
+	switch RedactionPolicy(strings.ToLower(c.Redaction)) {
+	case "", RedactOff, RedactStandard, RedactStrict:
+	default:
+		errs = append(errs, fmt.Errorf("unknown redaction policy %q", c.Redaction))
+	}
+	for _, name := range c.RedactPatterns {
+		if _, ok := redactionPattern(name); !ok {
+			errs = append(errs, fmt.Errorf("unknown redaction pattern %q", name))
+		}
+	}
+	if _, err := compileTransforms(c.Transforms); err != nil {
+		errs = append(errs, fmt.Errorf("transforms: %w", err))
+	}
+	if s := c.Sampling; s != nil && (s.Initial < 0 || s.Thereafter < 0 || s.Tick < 0) {
+		errs = append(errs, errors.New("sampling: negative values"))
+	}
+	if l := c.Limits; l != nil && (l.MaxStringLength < 0 || l.MaxAttrs < 0 || l.MaxRecordSize < 0) {
+		errs = append(errs, errors.New("limits: negative values"))
+	}
+	return errors.Join(errs...)
+}
+
+// sinks returns the outputs of the configuration with the defaults applied.
+func (c *Config) sinks() []SinkConfig {
+	top := SinkConfig{Level: c.Level, Format: c.Format, Output: c.Output}
+	d := top.merge(SinkConfig{Level: "INFO", Format: "JSON", Output: "stderr"})
+	if len(c.Outputs) == 0 {
+		return []SinkConfig{d}
+	}
+
+	sinks := make([]SinkConfig, len(c.Outputs))
+	for i, s := range c.Outputs {
+		sinks[i] = s.merge(d)
+	}
+	return sinks
+}
+
+// merge merges the provided SinkConfig with the receiver SinkConfig.
+func (s *SinkConfig) merge(d SinkConfig) SinkConfig {
+	for _, f := range []struct{ src, dst *string }{
+		{&s.Level, &d.Level},
+		{&s.Format, &d.Format},
+		{&s.Output, &d.Output},
+	} {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+	return d
+}
+
+// NewFromConfig returns a new logger built from the configuration,
+// so applications can drive the logger from their own configuration systems instead of environment variables.
+// Returns an error wrapping [ErrInvalidConfig] if the configuration is invalid or an output cannot be opened.
+//
+// The files and sockets of the outputs stay open for the lifetime of the program.
+// The outputs can be replaced at runtime with [Provider.SetHandler].
+func NewFromConfig(cfg Config) (Provider, error) {
+	l, _, err := newFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// newFromConfig returns a new logger built from the configuration and the files opened for its outputs.
+func newFromConfig(cfg Config) (*logger, []io.Closer, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	control := &levelControl{}
+	base, files, err := cfg.newHandler(control)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	swap := NewSwapHandler(base)
+	return &logger{Logger: slog.New(withControl(newPipeline(swap, cfg.options()), swap, control)), swap: swap, level: control}, files, nil
+}
+
+// NewConfigPipeline returns the handler pipeline built for the configuration with the outputs replaced
+// by the handler returned by sink for the lowest level of the outputs. The sampling of the configuration is applied.
+// Returns an error wrapping [ErrInvalidConfig] if the configuration is invalid.
+//
+// It allows tests to assert the behavior of the wrappers, e.g. redaction or limits, for a configuration file.
+func NewConfigPipeline(cfg Config, sink func(level Level) slog.Handler) (slog.Handler, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	level := LevelFatal
+	for _, s := range cfg.sinks() {
+		l, _ := parseLevel(s.Level)
+		level = min(level, Level(l))
+	}
+	return newPipeline(cfg.withSampling(sink(level)), cfg.options()), nil
+}
+
+// options returns the [Options] of the pipeline wrapping the outputs of the configuration.
+func (c *Config) options() Options {
+	return Options{
+		Environment:      c.Environment,
+		Redaction:        strings.ToLower(c.Redaction),
+		RedactPaths:      c.RedactPaths,
+		RedactKeys:       c.RedactKeys,
+		RedactPatterns:   c.RedactPatterns,
+		Transforms:       c.Transforms,
+		HashKeys:         c.HashKeys,
+		HashSecret:       []byte(c.HashSecret),
+		AllowKeys:        c.AllowKeys,
+		AllowPlaceholder: c.AllowPlaceholder,
+		RecordIDs:        c.RecordIDs,
+		Limits:           c.Limits,
+	}
+}
+
+// withSampling returns the handler wrapped with the sampling of the configuration, if any.
+func (c *Config) withSampling(h slog.Handler) slog.Handler {
+	if c.Sampling == nil {
+		return h
+	}
+	sampling := *c.Sampling
+	if sampling.Metrics == nil {
+		sampling.Metrics = c.Metrics
+	}
+	return NewSamplingHandler(h, sampling)
+}
+
+// newHandler returns the handler writing the records to the outputs of the validated configuration
+// and the files it opened. The levels of the outputs are controlled by the levelControl.
+func (c *Config) newHandler(control *levelControl) (slog.Handler, []io.Closer, error) {
+	var loc *time.Location
+	if c.TimeZone != "" {
+		loc, _ = time.LoadLocation(c.TimeZone)
+	}
+
+	sinks := c.sinks()
+	handlers := make([]slog.Handler, 0, len(sinks))
+	var files []io.Closer
+	for i, sink := range sinks {
+		w, err := newOutput(sink.Output)
+		if err != nil {
+			for _, f := range files {
+				_ = f.Close()
+			}
+			return nil, nil, fmt.Errorf("outputs[%d]: %w", i, err)
+		}
+		if f, ok := w.(io.Closer); ok && w != os.Stderr && w != os.Stdout {
+			files = append(files, f)
+		}
+		l, _ := parseLevel(sink.Level)
+		level := Level(l)
+		h := newFormatHandler(sink.Format, w, lowestLevel(level), c.TimeFormat, loc, c.AddSource)
+		h = NewMetricsHandler(h, c.Metrics, metricsHandlerName(sink.Output))
+		handlers = append(handlers, newLevelHandler(h, level, control))
+	}
+
+	var h slog.Handler = &fanoutHandler{handlers: handlers}
+	if len(handlers) == 1 {
+		h = handlers[0]
+	}
+	return c.withSampling(h), files, nil
+}
+
+// isConfigFormat reports whether the format is supported by [Config].
+func isConfigFormat(format string) bool {
+	return strings.EqualFold(format, "JSON") || isTextFormat(format) || isDevFormat(format)
+}
+
+// newFormatHandler returns the handler of the format writing to w.
+func newFormatHandler(format string, w io.Writer, level Level, layout string, loc *time.Location, source bool) slog.Handler {
+	switch {
+	case isTextFormat(format):
+		return newInlineHandler(newTextWriterHandler(w, level, layout, loc, source))
+	case isDevFormat(format):
+		return NewDevHandler(DevOptions{Level: level, Writer: w, TimeFormat: layout, TimeZone: loc})
+	default:
+		return slog.NewJSONHandler(w, &slog.HandlerOptions{
+			AddSource:   source,
+			Level:       slog.Level(level),
+			ReplaceAttr: timeReplaceAttr(layout, loc, replaceAttr),
+		})
+	}
+}
+
+var _ slog.Handler = (*fanoutHandler)(nil)
+
+// fanoutHandler is a [slog.Handler] passing the records to multiple handlers.
+type fanoutHandler struct {
+	handlers []slog.Handler
+}
+
+// Enabled reports whether any of the handlers handles records at the given level.
+func (h *fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle passes the record to all handlers enabled for its level.
+func (h *fanoutHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	var errs []error
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, r.Level) {
+			errs = append(errs, handler.Handle(ctx, r.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+	return &fanoutHandler{handlers: handlers}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *fanoutHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithGroup(name)
+	}
+	return &fanoutHandler{handlers: handlers}
+}
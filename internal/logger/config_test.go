@@ -0,0 +1,117 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr []string
+	}{
+		{name: "empty", cfg: Config{}},
+		{name: "valid", cfg: Config{Level: "debug", Format: "text", TimeZone: "UTC", Redaction: "Strict", Outputs: []SinkConfig{{Format: "DEV"}, {Level: "ERROR"}}}},
+		{name: "invalid level", cfg: Config{Level: "LOUD"}, wantErr: []string{`output: unknown level "LOUD"`}},
+		{
+			name:    "invalid outputs",
+			cfg:     Config{Format: "XML", Outputs: []SinkConfig{{Format: "JSON"}, {Level: "LOUD"}}},
+			wantErr: []string{`outputs[1]: unknown level "LOUD"`, `outputs[1]: unknown format "XML"`},
+		},
+		{
+			name:    "invalid settings",
+			cfg:     Config{TimeZone: "Mars/Olympus", Redaction: "some", Sampling: &SamplingOptions{Initial: -1}},
+			wantErr: []string{"time zone", `unknown redaction policy "some"`, "sampling"},
+		},
+		{
+			name:    "invalid transforms",
+			cfg:     Config{Transforms: []TransformRule{{Action: TransformDrop, Key: "a"}, {Action: "explode"}}},
+			wantErr: []string{`transforms: rule 1: unknown action "explode"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if (err != nil) != (len(tt.wantErr) > 0) {
+				t.Fatalf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() error = %v, want %s", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewFromConfig(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "ERROR")
+	t.Setenv("LOG_FORMAT", "DEV")
+	dir := t.TempDir()
+	all, errs := filepath.Join(dir, "all.log"), filepath.Join(dir, "errors.log")
+
+	log, err := NewFromConfig(Config{
+		Level:     "DEBUG",
+		AddSource: true,
+		Redaction: "standard",
+		Outputs:   []SinkConfig{{Output: all}, {Output: errs, Level: "ERROR", Format: "TEXT"}},
+	})
+	if err != nil {
+		t.Fatalf("NewFromConfig() error = %v", err)
+	}
+	log.With("service", "api").Debug("starting", "password", "hunter2")
+	log.Error("failed")
+
+	got, _ := os.ReadFile(all)
+	lines := strings.Split(strings.TrimSpace(string(got)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"source":{`) || !strings.Contains(lines[0], `"msg":"starting","service":"api","password":"[REDACTED]"`) {
+		t.Errorf("Expected both records as JSON, got %v", lines)
+	}
+	got, _ = os.ReadFile(errs)
+	if s := string(got); strings.Count(s, "\n") != 1 || !strings.Contains(s, "ERRO") || !strings.Contains(s, "failed") {
+		t.Errorf("Expected the error record as text, got %q", s)
+	}
+}
+
+func TestNewFromConfig_Invalid(t *testing.T) {
+	for _, cfg := range []Config{
+		{Format: "XML"},
+		{Output: filepath.Join(t.TempDir(), "missing", "app.log")},
+	} {
+		if _, err := NewFromConfig(cfg); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("NewFromConfig(%+v) error = %v, want %v", cfg, err, ErrInvalidConfig)
+		}
+	}
+}
+
+func TestNewConfigPipeline(t *testing.T) {
+	var got Level
+	sink := func(level Level) slog.Handler {
+		got = level
+		return test.MockHandler{
+			EnabledFunc: func(context.Context, slog.Level) bool { return true },
+			HandleFunc:  func(context.Context, slog.Record) error { return nil },
+		}
+	}
+
+	cfg := Config{Level: "WARN", Outputs: []SinkConfig{{}, {Level: "DEBUG"}}}
+	if _, err := NewConfigPipeline(cfg, sink); err != nil {
+		t.Fatalf("NewConfigPipeline() error = %v", err)
+	}
+	if got != LevelDebug {
+		t.Errorf("Expected the sink for the lowest level %s, got %s", LevelDebug, got)
+	}
+
+	if _, err := NewConfigPipeline(Config{Level: "LOUD"}, sink); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Expected ErrInvalidConfig, got %v", err)
+	}
+}
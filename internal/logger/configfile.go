@@ -0,0 +1,176 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultConfigWatchInterval is the default interval of [WatchConfig].
+const defaultConfigWatchInterval = 5 * time.Second
+
+// LoadConfig reads the [Config] from the file at the path and validates it.
+// The format of the file is selected by its extension: ".yaml" or ".yml" for YAML, ".json" for JSON
+// and ".toml" for TOML.
+// Unknown keys are rejected, so typos do not go unnoticed.
+//
+// Durations like the sampling tick are written as strings like "1s" or as integer nanoseconds.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // the path is provided by the application
+	if err != nil {
+		return Config{}, err
+	}
+	return parseConfig(path, data)
+}
+
+// parseConfig decodes and validates the [Config] read from the file at the path.
+func parseConfig(path string, data []byte) (Config, error) {
+	cfg, err := decodeConfig(filepath.Ext(path), data)
+	if err != nil {
+		return Config{}, fmt.Errorf("%w: %s: %w", ErrInvalidConfig, path, err)
+	}
+	if err = cfg.Validate(); err != nil {
+		return Config{}, fmt.Errorf("%w: %s: %w", ErrInvalidConfig, path, err)
+	}
+	return cfg, nil
+}
+
+// decodeConfig decodes the [Config] in the format of the file extension.
+func decodeConfig(ext string, data []byte) (Config, error) {
+	var cfg Config
+	switch strings.ToLower(ext) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+			return Config{}, err
+		}
+		return cfg, nil
+	case ".json":
+		return cfg, decodeStrictJSON(data, &cfg)
+	case ".toml":
+		var m map[string]any
+		if err := toml.Unmarshal(data, &m); err != nil {
+			return Config{}, err
+		}
+		// The decoded TOML is converted to JSON to decode it with the same rules as JSON files.
+		data, err := json.Marshal(m)
+		if err != nil {
+			return Config{}, err
+		}
+		return cfg, decodeStrictJSON(data, &cfg)
+	default:
+		return Config{}, fmt.Errorf("unsupported file extension %q", ext)
+	}
+}
+
+// decodeStrictJSON decodes the JSON into v, rejecting unknown fields.
+func decodeStrictJSON(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// UnmarshalJSON decodes the SamplingOptions with the tick as duration string like "1s" or as integer nanoseconds.
+func (o *SamplingOptions) UnmarshalJSON(data []byte) error {
+	type options SamplingOptions
+	var v struct {
+		options
+		Tick any `json:"tick,omitempty"`
+	}
+	if err := decodeStrictJSON(data, &v); err != nil {
+		return err
+	}
+
+	*o = SamplingOptions(v.options)
+	switch tick := v.Tick.(type) {
+	case nil:
+	case string:
+		d, err := time.ParseDuration(tick)
+		if err != nil {
+			return fmt.Errorf("sampling tick: %w", err)
+		}
+		o.Tick = d
+	case float64:
+		o.Tick = time.Duration(tick)
+	default:
+		return fmt.Errorf("sampling tick: invalid duration %v", tick)
+	}
+	return nil
+}
+
+// WatchConfig returns a new logger built from the [Config] in the file at the path, see [LoadConfig],
+// and reloads it every interval (5 seconds if zero) until the context is done.
+//
+// If the file changed, the outputs with their levels and formats, the time settings and the sampling
+// are replaced atomically, so the loggers already derived from the returned one follow the change.
+// The other settings, e.g. the redaction, only take effect on restart. If the changed file is invalid,
+// the error is logged and the previous configuration is kept. The files of replaced outputs are closed.
+func WatchConfig(ctx context.Context, path string, interval time.Duration) (Provider, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // the path is provided by the application
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := parseConfig(path, data)
+	if err != nil {
+		return nil, err
+	}
+	l, files, err := newFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if interval <= 0 {
+		interval = defaultConfigWatchInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			current, err := os.ReadFile(path) //nolint:gosec // the path is provided by the application
+			if err != nil || bytes.Equal(current, data) {
+				continue
+			}
+			data = current
+			if files, err = reloadConfig(l, path, current, files); err != nil {
+				l.ErrorContext(ctx, "Failed to reload the logger config", "path", path, "error", err)
+			}
+		}
+	}()
+	return l, nil
+}
+
+// reloadConfig replaces the handler of the logger by the one of the config file and closes the previous files.
+// Returns the files of the handler in use.
+func reloadConfig(l *logger, path string, data []byte, files []io.Closer) ([]io.Closer, error) {
+	cfg, err := parseConfig(path, data)
+	if err != nil {
+		return files, err
+	}
+	base, opened, err := cfg.newHandler(l.level)
+	if err != nil {
+		return files, err
+	}
+
+	l.swap.Swap(base)
+	for _, f := range files {
+		_ = f.Close()
+	}
+	return opened, nil
+}
@@ -0,0 +1,142 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadConfig(t *testing.T) {
+	want := Config{
+		Level:     "DEBUG",
+		AddSource: true,
+		Sampling:  &SamplingOptions{Initial: 10, Tick: 2 * time.Second},
+		Outputs:   []SinkConfig{{Format: "TEXT"}, {Output: "/var/log/app.log", Level: "ERROR"}},
+	}
+	files := map[string]string{
+		"app.yaml": `level: DEBUG
+addSource: true
+sampling:
+  initial: 10
+  tick: 2s
+outputs:
+  - format: TEXT
+  - output: /var/log/app.log
+    level: ERROR
+`,
+		"app.json": `{"level": "DEBUG", "addSource": true, "sampling": {"initial": 10, "tick": "2s"},
+			"outputs": [{"format": "TEXT"}, {"output": "/var/log/app.log", "level": "ERROR"}]}`,
+		"app.toml": `level = "DEBUG"
+addSource = true
+
+[sampling]
+initial = 10
+tick = "2s"
+
+[[outputs]]
+format = "TEXT"
+
+[[outputs]]
+output = "/var/log/app.log"
+level = "ERROR"
+`,
+	}
+
+	dir := t.TempDir()
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			got, err := LoadConfig(path)
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("LoadConfig() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	files := map[string]string{
+		"unknown.yaml": "levle: DEBUG",
+		"unknown.json": `{"sampling": {"initail": 1}}`,
+		"invalid.toml": "level = DEBUG",
+		"unknown.toml": "levle = \"DEBUG\"",
+		"level.json":   `{"level": "LOUD"}`,
+		"tick.json":    `{"sampling": {"tick": "soon"}}`,
+		"app.ini":      "level=DEBUG",
+	}
+
+	dir := t.TempDir()
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadConfig(path); !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("LoadConfig() error = %v, want %v", err, ErrInvalidConfig)
+			}
+		})
+	}
+}
+
+func TestWatchConfig(t *testing.T) {
+	dir := t.TempDir()
+	path, first, second := filepath.Join(dir, "log.yaml"), filepath.Join(dir, "first.log"), filepath.Join(dir, "second.log")
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitFor := func(file, want string) {
+		t.Helper()
+		for range 100 {
+			if got, _ := os.ReadFile(file); strings.Contains(string(got), want) {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		got, _ := os.ReadFile(file)
+		t.Fatalf("Expected %s in %s, got %s", want, file, got)
+	}
+
+	write("output: " + first)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	log, err := WatchConfig(ctx, path, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("WatchConfig() error = %v", err)
+	}
+	captured := log.With("service", "api")
+	captured.Debug("hidden")
+	captured.Info("first")
+
+	write("level: DEBUG\noutput: " + second)
+	for range 100 {
+		captured.Debug("second")
+		if got, _ := os.ReadFile(second); len(got) > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	waitFor(second, `"msg":"second","service":"api"`)
+
+	write("level: LOUD")
+	waitFor(second, "Failed to reload the logger config")
+
+	got, _ := os.ReadFile(first)
+	if strings.Contains(string(got), "hidden") || strings.Contains(string(got), `"msg":"second"`) {
+		t.Errorf("Expected only the info record in the first file, got %s", got)
+	}
+}
@@ -0,0 +1,24 @@
+package logger
+
+import (
+	"io"
+	"log/slog"
+)
+
+// NewConsoleHandler returns a new [slog.Handler] writing text records to the console,
+// records at or above [LevelError] to the error stream and all others to the standard stream.
+// On js/wasm the streams are the browser's console.error and console.log, so logs show up in the developer tools
+// with the matching severity. On all other platforms they are stderr and stdout.
+//
+// The handler is used for the "TEXT" format on WASM platforms, where no terminal is available.
+func NewConsoleHandler(level Level) slog.Handler {
+	stdout, stderr := consoleWriters()
+	newTextHandler := func(w io.Writer) slog.Handler {
+		return slog.NewTextHandler(w, &slog.HandlerOptions{
+			AddSource:   true,
+			Level:       slog.Level(level),
+			ReplaceAttr: replaceAttr,
+		})
+	}
+	return &streamHandler{stdout: newTextHandler(stdout), stderr: newTextHandler(stderr)}
+}
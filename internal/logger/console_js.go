@@ -0,0 +1,29 @@
+//go:build js && wasm
+
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"syscall/js"
+)
+
+// exit panics instead of calling [os.Exit], which would terminate the Go instance shared with the host page or plugin.
+var exit = func(code int) {
+	panic(fmt.Sprintf("logger: exit with code %d", code))
+}
+
+// consoleWriter is an [io.Writer] passing every write to the given method of the browser's console.
+type consoleWriter string
+
+// Write calls the console method with p stripped of the trailing newline.
+func (w consoleWriter) Write(p []byte) (int, error) {
+	js.Global().Get("console").Call(string(w), string(bytes.TrimSuffix(p, []byte("\n"))))
+	return len(p), nil
+}
+
+// consoleWriters returns the writers for console.log and console.error.
+func consoleWriters() (stdout, stderr io.Writer) {
+	return consoleWriter("log"), consoleWriter("error")
+}
@@ -0,0 +1,16 @@
+//go:build !js
+
+package logger
+
+import (
+	"io"
+	"os"
+)
+
+// exit is a variable for [os.Exit].
+var exit = os.Exit
+
+// consoleWriters returns the standard and the error stream of the console.
+func consoleWriters() (stdout, stderr io.Writer) {
+	return os.Stdout, os.Stderr
+}
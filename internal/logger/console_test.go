@@ -0,0 +1,42 @@
+//go:build !js
+
+package logger
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewConsoleHandler(t *testing.T) {
+	stdout, stderr := os.Stdout, os.Stderr
+	t.Cleanup(func() { os.Stdout, os.Stderr = stdout, stderr })
+
+	dir := t.TempDir()
+	files := map[string]*os.File{}
+	for _, name := range []string{"stdout", "stderr"} {
+		f, err := os.Create(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("Failed to create file: %v", err)
+		}
+		defer func() { _ = f.Close() }()
+		files[name] = f
+	}
+	os.Stdout, os.Stderr = files["stdout"], files["stderr"]
+
+	log := NewLogger(Options{Handler: NewConsoleHandler(LevelDebug)})
+	log.DebugContext(context.Background(), "debug")
+	log.ErrorContext(context.Background(), "error")
+
+	for name, want := range map[string]string{"stdout": "msg=debug", "stderr": "msg=error"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 1 || !strings.Contains(lines[0], want) {
+			t.Errorf("Expected %s to contain a single record with %q, got %q", name, want, data)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// ctxAttrsKey is the key used to store attributes in the context.
+type ctxAttrsKey struct{}
+
+// AppendCtx returns a copy of the context with the given attributes appended to the attributes already stored in it.
+// The attributes are added to every record logged with the returned context by a handler wrapped with [NewContextHandler].
+func AppendCtx(ctx context.Context, attrs ...slog.Attr) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if len(attrs) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxAttrsKey{}, append(slices.Clip(AttrsFromContext(ctx)), attrs...))
+}
+
+// AttrsFromContext returns the attributes stored in the context by [AppendCtx].
+func AttrsFromContext(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+	attrs, _ := ctx.Value(ctxAttrsKey{}).([]slog.Attr)
+	return attrs
+}
+
+var _ slog.Handler = (*contextHandler)(nil)
+
+// contextHandler is a [slog.Handler] that adds the attributes stored in the context to every record.
+type contextHandler struct {
+	slog.Handler
+}
+
+// NewContextHandler returns a new [slog.Handler] that adds the attributes stored in the context by [AppendCtx]
+// to every record before passing it to the given handler.
+func NewContextHandler(h slog.Handler) slog.Handler {
+	return &contextHandler{Handler: h}
+}
+
+// Handle adds the attributes stored in the context to the record and passes it to the wrapped handler.
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	if attrs := AttrsFromContext(ctx); len(attrs) > 0 {
+		r.AddAttrs(attrs...)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
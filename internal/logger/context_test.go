@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+)
+
+func TestAppendCtx(t *testing.T) {
+	tests := []struct {
+		name  string
+		ctx   context.Context
+		attrs [][]slog.Attr
+		want  []slog.Attr
+	}{
+		{
+			name: "nil context",
+			ctx:  nil,
+			want: nil,
+		},
+		{
+			name:  "single append",
+			ctx:   context.Background(),
+			attrs: [][]slog.Attr{{slog.String("request_id", "1")}},
+			want:  []slog.Attr{slog.String("request_id", "1")},
+		},
+		{
+			name:  "multiple appends",
+			ctx:   context.Background(),
+			attrs: [][]slog.Attr{{slog.String("request_id", "1")}, {slog.Int("user", 2), slog.Bool("admin", true)}},
+			want:  []slog.Attr{slog.String("request_id", "1"), slog.Int("user", 2), slog.Bool("admin", true)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			for _, attrs := range tt.attrs {
+				ctx = AppendCtx(ctx, attrs...)
+			}
+
+			var got []slog.Attr
+			h := NewContextHandler(test.MockHandler{
+				HandleFunc: func(_ context.Context, r slog.Record) error {
+					r.Attrs(func(a slog.Attr) bool {
+						got = append(got, a)
+						return true
+					})
+					return nil
+				},
+			})
+			NewLogger(Options{Handler: h}).InfoContext(ctx, "test")
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("Got attributes %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("Attribute %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAppendCtx_DoesNotModifyParent(t *testing.T) {
+	parent := AppendCtx(context.Background(), slog.String("a", "1"), slog.String("b", "2"))
+	_ = AppendCtx(parent, slog.String("c", "3"))
+	_ = AppendCtx(parent, slog.String("d", "4"))
+
+	if got := AttrsFromContext(parent); len(got) != 2 {
+		t.Errorf("Expected parent context to keep 2 attributes, got %v", got)
+	}
+}
@@ -3,13 +3,14 @@ package logger
 import (
 	"context"
 	"log/slog"
-	"runtime"
 	"time"
 )
 
 var _ Provider = (*logger)(nil)
 
 // Provider is a interface that provides logging methods.
+// It satisfies leveled logger interfaces like the LeveledLogger of go-retryablehttp,
+// so it can be passed to such libraries without an adapter.
 type Provider interface {
 	// Trace logs at [LevelTrace].
 	Trace(msg string, args ...any)
@@ -53,23 +54,43 @@ type Provider interface {
 	Errorf(msg string, args ...any)
 	// ErrorContext logs at [LevelError] with the given context.
 	ErrorContext(ctx context.Context, msg string, args ...any)
-	// Panic logs at [LevelPanic] and then panics with the given message.
+	// Panic logs at [LevelPanic] and then panics with the given message or a [*PanicError] if [Options.PanicErrors] is set.
 	Panic(msg string, args ...any)
-	// Panicf logs at [LevelPanic] and then panics.
+	// Panicf logs at [LevelPanic] and then panics with the formatted message or a [*PanicError] if [Options.PanicErrors] is set.
 	// Arguments are handled in the manner of [fmt.Printf].
 	Panicf(msg string, args ...any)
-	// PanicContext logs at [LevelPanic] with the given context and then panics with the given message.
+	// PanicContext logs at [LevelPanic] with the given context and then panics with the given message
+	// or a [*PanicError] if [Options.PanicErrors] is set.
 	PanicContext(ctx context.Context, msg string, args ...any)
-	// Fatal logs at [LevelFatal] and then calls [os.Exit](1).
+	// Fatal logs at [LevelFatal], calls the [Options.OnFatal] hooks and then exits with [Options.ExitCode] (default 1).
 	Fatal(msg string, args ...any)
-	// Fatalf logs at [LevelFatal] and then calls [os.Exit](1).
+	// Fatalf logs at [LevelFatal], calls the [Options.OnFatal] hooks and then exits with [Options.ExitCode] (default 1).
 	// Arguments are handled in the manner of [fmt.Printf].
 	Fatalf(msg string, args ...any)
-	// FatalContext logs at [LevelFatal] with the given context and then calls [os.Exit](1).
+	// FatalContext logs at [LevelFatal] with the given context, calls the [Options.OnFatal] hooks
+	// and then exits with [Options.ExitCode] (default 1).
 	FatalContext(ctx context.Context, msg string, args ...any)
 
+	// TraceT logs at [LevelTrace] with a message template, see [Provider.InfoT].
+	TraceT(ctx context.Context, template string, args ...any)
+	// DebugT logs at [LevelDebug] with a message template, see [Provider.InfoT].
+	DebugT(ctx context.Context, template string, args ...any)
+	// InfoT logs at [LevelInfo] with a message template.
+	// The placeholders of the template, e.g. {user_id}, are replaced by the values of the attributes with the same key.
+	// The template itself is added under [MessageTemplateKey].
+	InfoT(ctx context.Context, template string, args ...any)
+	// NoticeT logs at [LevelNotice] with a message template, see [Provider.InfoT].
+	NoticeT(ctx context.Context, template string, args ...any)
+	// WarnT logs at [LevelWarn] with a message template, see [Provider.InfoT].
+	WarnT(ctx context.Context, template string, args ...any)
+	// ErrorT logs at [LevelError] with a message template, see [Provider.InfoT].
+	ErrorT(ctx context.Context, template string, args ...any)
+
 	// With returns a Logger that has the given attributes.
 	With(args ...any) Provider
+	// WithAttrs returns a Logger that has the given attributes.
+	// It is a more efficient version of [Provider.With] that accepts only Attrs.
+	WithAttrs(attrs ...slog.Attr) Provider
 	// WithGroup returns a Logger that starts a group, if name is non-empty.
 	// The keys of all attributes added to the Logger will be qualified by the given
 	// name. (How that qualification happens depends on the [Handler.WithGroup]
@@ -77,6 +98,25 @@ type Provider interface {
 	//
 	// If name is empty, WithGroup returns the receiver.
 	WithGroup(name string) Provider
+	// WithSource returns a Logger omitting the source of its records if enabled is false,
+	// e.g. for high volume loggers where resolving the caller is too expensive.
+	// WithSource(true) restores the source omitted by WithSource(false), but cannot add it
+	// to the records of handlers configured without the source, e.g. by [Options.OmitSource].
+	WithSource(enabled bool) Provider
+	// WithCallerSkip returns a Logger skipping n additional stack frames to find the source of its records,
+	// so wrapper functions report the call site of the wrapper instead of the wrapper itself.
+	// The skips of nested calls add up. See [Helper] to mark the wrappers instead.
+	WithCallerSkip(n int) Provider
+
+	// Begin starts a new [Operation] with the given name and logs its start at [LevelInfo].
+	// All records of the operation share an operation ID, the end record carries the duration.
+	Begin(ctx context.Context, name string, args ...any) *Operation
+
+	// Prepare returns a function logging records at the given level with the given message and static attributes
+	// followed by the dynamic attributes passed to the function. The static attributes are passed to the handler once,
+	// which makes it cheap to log records of the same shape in hot paths.
+	// The level is checked on every call, so the returned function does nothing while the level is disabled.
+	Prepare(level Level, msg string, attrs ...slog.Attr) func(attrs ...slog.Attr)
 
 	// Log emits a log record with the current time and the given level and message.
 	// The Record's Attrs consist of the Logger's attributes followed by
@@ -93,17 +133,58 @@ type Provider interface {
 	LogAttrs(ctx context.Context, level Level, msg string, attrs ...slog.Attr)
 
 	// Handler returns the [slog.Handler] that the Logger emits log records to.
+	// It is the fully composed pipeline including all wrappers enabled by the [Options],
+	// e.g. redaction, record IDs or trace context, and the attributes and groups of the logger,
+	// so loggers rebuilt from it with [slog.New] or [FromSlog] write the same records.
+	// It also carries the handler replaced by [Provider.SetHandler] and the level set by [Provider.SetLevel],
+	// so loggers rebuilt with [FromSlog] can still change them and be used with [EnableLevelFor].
+	// The behavior configured on the logger itself, i.e. the [Options.ExitCode], [Options.OnFatal]
+	// and [Options.PanicErrors], is not part of the handler and has to be configured again.
 	Handler() slog.Handler
+	// SetHandler replaces the handler writing the records of the logger, all loggers derived from it
+	// and the logger it was derived from, e.g. to switch the destination or format on a configuration reload.
+	// The wrappers enabled by the [Options] and the attributes and groups of the loggers are kept.
+	// If [Options.Handler] is set, it only has an effect if the handler is a [SwapHandler].
+	SetHandler(h slog.Handler)
+	// SetLevel overrides the minimum level of the logger, all loggers derived from it and the logger
+	// it was derived from until [Provider.ResetLevel] is called, e.g. to enable the debug records of a running program.
+	// With multiple outputs of a [Config], it applies to all of them.
+	// It has no effect on handlers passed to [Options.Handler] or [Provider.SetHandler].
+	SetLevel(level Level)
+	// ResetLevel restores the configured minimum levels overridden by [Provider.SetLevel].
+	ResetLevel()
 	// Enabled reports whether the [Provider] emits log records at the given context and level.
 	Enabled(ctx context.Context, level Level) bool
 
+	// Attrs returns the attributes accumulated by [Provider.With] and [Provider.WithGroup].
+	// Groups are returned as group attributes and all values are resolved.
+	Attrs() []slog.Attr
+
 	// ToSlog returns the underlying [slog.Logger].
+	// It uses the same handler and carries the attributes and groups added by [Provider.With],
+	// [Provider.WithAttrs] and [Provider.WithGroup], so it can be passed to libraries accepting a [*slog.Logger].
 	ToSlog() *slog.Logger
 }
 
 // logger implements the Logger interface.
 // It is a wrapper around slog.Logger.
-type logger struct{ *slog.Logger }
+type logger struct {
+	*slog.Logger
+	// name is the name of the logger if created by NewNamedLogger.
+	name string
+	// swap is the handler replaced by SetHandler, shared by all loggers derived from the same logger.
+	swap *SwapHandler
+	// level is the dynamic minimum level set by SetLevel, shared by all loggers derived from the same logger.
+	level *levelControl
+	// scopes are the attributes and groups accumulated by With and WithGroup.
+	scopes []scope
+	// fatal is the behavior of Fatal. Nil uses the default behavior.
+	fatal *fatalConfig
+	// panicErrors reports whether Panic panics with a [*PanicError] instead of the message.
+	panicErrors bool
+	// caller is the configuration of the source of the records.
+	caller callerConfig
+}
 
 // Debug logs at LevelDebug.
 func (l *logger) Debug(msg string, a ...any) {
@@ -147,26 +228,62 @@ func (l *logger) ErrorContext(ctx context.Context, msg string, a ...any) {
 
 // With calls Logger.With on the default logger.
 func (l *logger) With(a ...any) Provider {
-	return &logger{Logger: l.Logger.With(a...)}
+	attrs := argsToAttrs(a)
+	if len(attrs) == 0 {
+		return l
+	}
+	return &logger{Logger: l.Logger.With(a...), name: l.name, swap: l.swap, level: l.level, scopes: l.withAttrs(attrs), fatal: l.fatal, panicErrors: l.panicErrors, caller: l.caller}
+}
+
+// WithAttrs returns a Logger that has the given attributes.
+func (l *logger) WithAttrs(attrs ...slog.Attr) Provider {
+	if len(attrs) == 0 {
+		return l
+	}
+	return &logger{
+		Logger:      slog.New(l.Handler().WithAttrs(attrs)),
+		name:        l.name,
+		swap:        l.swap,
+		level:       l.level,
+		scopes:      l.withAttrs(attrs),
+		fatal:       l.fatal,
+		panicErrors: l.panicErrors,
+		caller:      l.caller,
+	}
 }
 
 // WithGroup returns a Logger that starts a group, if name is non-empty.
 func (l *logger) WithGroup(name string) Provider {
-	return &logger{Logger: l.Logger.WithGroup(name)}
+	if name == "" {
+		return l
+	}
+	return &logger{Logger: l.Logger.WithGroup(name), name: l.name, swap: l.swap, level: l.level, scopes: l.withGroup(name), fatal: l.fatal, panicErrors: l.panicErrors, caller: l.caller}
 }
 
 // Log emits a log record with the current time and the given level and message.
 func (l *logger) Log(ctx context.Context, level Level, msg string, a ...any) {
-	l.Logger.Log(ctx, slog.Level(level), msg, a...)
+	l.logAttrs(ctx, level, msg, a...)
 }
 
 // LogAttrs is a more efficient version of [Provider.Log] that accepts only Attrs.
 func (l *logger) LogAttrs(ctx context.Context, level Level, msg string, attrs ...slog.Attr) {
-	l.Logger.LogAttrs(ctx, slog.Level(level), msg, attrs...)
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !l.Enabled(ctx, level) {
+		return
+	}
+
+	r := slog.NewRecord(time.Now(), slog.Level(level), msg, l.pc(1))
+	r.AddAttrs(attrs...)
+	l.handle(ctx, r)
 }
 
 // Enabled reports whether the [Provider] emits log records at the given context and level.
 func (l *logger) Enabled(ctx context.Context, level Level) bool {
+	if l.isSilenced() {
+		return false
+	}
 	return l.Logger.Enabled(ctx, slog.Level(level))
 }
 
@@ -177,16 +294,12 @@ func (l *logger) logAttrs(ctx context.Context, level Level, msg string, a ...any
 		return
 	}
 
-	// skip is the number of stack frames to skip to find the caller.
-	// We need to skip calling runtime.Callers, this function and the public log function.
-	const skip = 3
-	var pcs [1]uintptr
-	_ = runtime.Callers(skip, pcs[:])
-	r := slog.NewRecord(time.Now(), slog.Level(level), msg, pcs[0])
+	// Skip this function and the public log function to find the caller.
+	r := slog.NewRecord(time.Now(), slog.Level(level), msg, l.pc(2)) //nolint:mnd // see above
 	r.Add(a...)
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
-	_ = l.Handler().Handle(ctx, r)
+	l.handle(ctx, r)
 }
@@ -0,0 +1,205 @@
+package logger
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DatadogStatusKey is the key used for the level of a record in the Datadog format.
+	DatadogStatusKey = "status"
+	// DatadogMessageKey is the key used for the message of a record in the Datadog format.
+	DatadogMessageKey = "message"
+	// DatadogTraceKey is the key of the group carrying the trace and span ID of a record in the Datadog format.
+	DatadogTraceKey = "dd"
+	// DatadogErrorKindKey is the key used for the type name of the error within the error group in the Datadog format.
+	DatadogErrorKindKey = "kind"
+	// DatadogErrorStackKey is the key used for the stack trace within the error group in the Datadog format.
+	DatadogErrorStackKey = "stack"
+)
+
+// datadogStatuses are the Datadog statuses of the levels without a status of the same name.
+var datadogStatuses = map[Level]string{
+	LevelTrace: "debug",
+	LevelPanic: "critical",
+	LevelFatal: "emergency",
+}
+
+// isDatadogFormat reports whether the given format is the Datadog format.
+func isDatadogFormat(format string) bool {
+	return strings.EqualFold(format, "DATADOG")
+}
+
+// DatadogOptions is the optional configuration for [NewDatadogHandler].
+type DatadogOptions struct {
+	// Level is the minimum log level.
+	Level Level
+	// Writer is the writer of the records. Defaults to [os.Stderr].
+	Writer io.Writer
+}
+
+// newDatadogOptions returns the provided DatadogOptions merged with the default DatadogOptions.
+func newDatadogOptions(o ...DatadogOptions) DatadogOptions {
+	opts := DatadogOptions{Level: LevelInfo, Writer: os.Stderr}
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided DatadogOptions with the receiver DatadogOptions.
+func (o *DatadogOptions) merge(d DatadogOptions) DatadogOptions {
+	if o.Level != 0 {
+		d.Level = o.Level
+	}
+	if o.Writer != nil {
+		d.Writer = o.Writer
+	}
+	return d
+}
+
+var _ slog.Handler = (*datadogHandler)(nil)
+
+// datadogHandler is a [slog.Handler] that maps the fields of records to Datadog's standard attributes.
+type datadogHandler struct {
+	slog.Handler
+}
+
+// NewDatadogHandler returns a new [slog.Handler] writing JSON records with the fields mapped to Datadog's standard attributes:
+//   - The level is written as status under [DatadogStatusKey], e.g. "info" or "critical" for [LevelPanic].
+//   - The message is written under [DatadogMessageKey].
+//   - The trace and span ID added by [NewTraceHandler] are written as decimal IDs to the group under [DatadogTraceKey],
+//     i.e. dd.trace_id and dd.span_id, so Datadog correlates the logs with the traces.
+//   - The error group of [Err] is written as error.message, error.kind and error.stack.
+//     A stack trace added by [NewStacktraceHandler] is written as error.stack as well.
+//
+// The handler is used for the "DATADOG" format.
+func NewDatadogHandler(o ...DatadogOptions) slog.Handler {
+	opts := newDatadogOptions(o...)
+	return &datadogHandler{Handler: slog.NewJSONHandler(opts.Writer, &slog.HandlerOptions{
+		AddSource:   true,
+		Level:       slog.Level(opts.Level),
+		ReplaceAttr: datadogReplaceAttr,
+	})}
+}
+
+// Handle moves the trace and error attributes of the record to the groups of the Datadog attributes
+// and passes the record to the wrapped handler.
+func (h *datadogHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	var (
+		attrs     = make([]slog.Attr, 0, r.NumAttrs())
+		trace     []slog.Attr
+		errAttrs  []slog.Attr
+		stack     slog.Attr
+		hasErrors bool
+	)
+	var visit func(a slog.Attr)
+	visit = func(a slog.Attr) {
+		switch {
+		case a.Key == "" && a.Value.Kind() == slog.KindGroup:
+			// Groups without a key are inlined, e.g. the attribute of [Err].
+			for _, ga := range a.Value.Group() {
+				visit(ga)
+			}
+		case a.Key == TraceIDKey || a.Key == SpanIDKey:
+			trace = append(trace, slog.String(a.Key, datadogID(a.Value.String())))
+		case a.Key == ErrorKey:
+			hasErrors = true
+			errAttrs = append(errAttrs, datadogError(a.Value.Resolve())...)
+		case a.Key == StacktraceKey:
+			stack = a
+		default:
+			attrs = append(attrs, a)
+		}
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		visit(a)
+		return true
+	})
+	if len(trace) == 0 && !hasErrors && stack.Key == "" {
+		return h.Handler.Handle(ctx, r)
+	}
+
+	if stack.Key != "" {
+		errAttrs = append(errAttrs, stack)
+	}
+	if len(errAttrs) > 0 {
+		attrs = append(attrs, slog.Attr{Key: ErrorKey, Value: slog.GroupValue(errAttrs...)})
+	} else if hasErrors {
+		attrs = append(attrs, slog.Any(ErrorKey, nil))
+	}
+	if len(trace) > 0 {
+		attrs = append(attrs, slog.Attr{Key: DatadogTraceKey, Value: slog.GroupValue(trace...)})
+	}
+
+	out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	out.AddAttrs(attrs...)
+	return h.Handler.Handle(ctx, out)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *datadogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &datadogHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *datadogHandler) WithGroup(name string) slog.Handler {
+	return &datadogHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// datadogError returns the attributes of the error group for the given error value.
+// Errors logged without [Err] are described by their message and type name.
+func datadogError(v slog.Value) []slog.Attr {
+	switch v.Kind() {
+	case slog.KindGroup:
+		return v.Group()
+	case slog.KindAny:
+		if v.Any() == nil {
+			return nil
+		}
+		if err, ok := v.Any().(error); ok {
+			return []slog.Attr{slog.String(ErrorMessageKey, err.Error()), slog.String(ErrorTypeKey, fmt.Sprintf("%T", err))}
+		}
+	}
+	return []slog.Attr{slog.String(ErrorMessageKey, v.String())}
+}
+
+// datadogID returns the decimal representation of the lower 64 bits of the given hexadecimal trace or span ID,
+// which is the format Datadog expects. Other IDs are returned unchanged.
+func datadogID(id string) string {
+	b, err := hex.DecodeString(id)
+	if err != nil || len(b) < 8 {
+		return id
+	}
+	var n uint64
+	for _, c := range b[len(b)-8:] {
+		n = n<<8 | uint64(c)
+	}
+	return strconv.FormatUint(n, 10)
+}
+
+// datadogReplaceAttr renames the standard keys and the keys of the error group to Datadog's standard attributes.
+func datadogReplaceAttr(groups []string, a slog.Attr) slog.Attr {
+	switch {
+	case len(groups) == 0 && a.Key == slog.LevelKey:
+		lev := Level(a.Value.Any().(slog.Level))
+		status, ok := datadogStatuses[lev]
+		if !ok {
+			status = strings.ToLower(lev.String())
+		}
+		return slog.String(DatadogStatusKey, status)
+	case len(groups) == 0 && a.Key == slog.MessageKey:
+		a.Key = DatadogMessageKey
+	case len(groups) == 1 && groups[0] == ErrorKey && a.Key == ErrorTypeKey:
+		a.Key = DatadogErrorKindKey
+	case len(groups) == 1 && groups[0] == ErrorKey && a.Key == StacktraceKey:
+		a.Key = DatadogErrorStackKey
+	}
+	return a
+}
@@ -0,0 +1,121 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewDatadogHandler(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("0af7651916cd43dd8448eb211c80319c")
+	spanID, _ := trace.SpanIDFromHex("b7ad6b7169203331")
+	spanCtx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	tests := []struct {
+		name string
+		log  func(l Provider)
+		want map[string]any
+	}{
+		{
+			name: "status and message",
+			log:  func(l Provider) { l.Info("test", "key", "value") },
+			want: map[string]any{DatadogStatusKey: "info", DatadogMessageKey: "test", "key": "value"},
+		},
+		{
+			name: "custom levels",
+			log:  func(l Provider) { l.Log(context.Background(), LevelFatal, "test") },
+			want: map[string]any{DatadogStatusKey: "emergency", DatadogMessageKey: "test"},
+		},
+		{
+			name: "trace ids",
+			log:  func(l Provider) { l.InfoContext(spanCtx, "test") },
+			want: map[string]any{
+				DatadogStatusKey:  "info",
+				DatadogMessageKey: "test",
+				DatadogTraceKey:   map[string]any{TraceIDKey: "9532127138774266268", SpanIDKey: "13235353014750950193"},
+			},
+		},
+		{
+			name: "error group",
+			log:  func(l Provider) { l.Error("test", Err(errors.New("connection refused"))) },
+			want: map[string]any{
+				DatadogStatusKey:  "error",
+				DatadogMessageKey: "test",
+				ErrorKey:          map[string]any{ErrorMessageKey: "connection refused", DatadogErrorKindKey: "*errors.errorString"},
+			},
+		},
+		{
+			name: "plain error and stack trace",
+			log: func(l Provider) {
+				l.Error("test", ErrorKey, errors.New("connection refused"), StacktraceKey, "main.go:1")
+			},
+			want: map[string]any{
+				DatadogStatusKey:  "error",
+				DatadogMessageKey: "test",
+				ErrorKey: map[string]any{
+					ErrorMessageKey:      "connection refused",
+					DatadogErrorKindKey:  "*errors.errorString",
+					DatadogErrorStackKey: "main.go:1",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			l := NewLogger(Options{Handler: NewTraceHandler(NewDatadogHandler(DatadogOptions{Writer: buf}))})
+			tt.log(l)
+
+			var got map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("Failed to parse record: %v", err)
+			}
+			if _, ok := got["source"]; !ok {
+				t.Error("Expected source to be added")
+			}
+			delete(got, "time")
+			delete(got, "source")
+			if g, w := mustJSON(t, got), mustJSON(t, tt.want); g != w {
+				t.Errorf("Expected record %s, got %s", w, g)
+			}
+		})
+	}
+}
+
+func TestDatadogID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{id: "00000000000000000000000000000001", want: "1"},
+		{id: "00000000000000ff", want: "255"},
+		{id: "not-hex", want: "not-hex"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			if got := datadogID(tt.id); got != tt.want {
+				t.Errorf("datadogID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsDatadogFormat(t *testing.T) {
+	for _, f := range []string{"DATADOG", "datadog", "Datadog"} {
+		if !isDatadogFormat(f) {
+			t.Errorf("Expected %q to be the Datadog format", f)
+		}
+	}
+	if isDatadogFormat(strings.ToUpper("json")) {
+		t.Error("Expected JSON not to be the Datadog format")
+	}
+}
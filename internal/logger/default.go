@@ -0,0 +1,119 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+var (
+	// defaultLogger is the logger used by the package-level logging functions.
+	defaultLogger Provider
+	// defaultMu protects defaultLogger.
+	defaultMu sync.RWMutex
+)
+
+// DefaultOptions is the optional configuration for [SetDefault].
+type DefaultOptions struct {
+	// Slog is a flag to also make the logger the default of the [log/slog] package via [slog.SetDefault],
+	// so libraries logging with the top-level slog functions write to the same handler.
+	Slog bool
+}
+
+// newDefaultLoggerOptions returns the DefaultOptions with the provided options merged.
+func newDefaultLoggerOptions(o ...DefaultOptions) DefaultOptions {
+	var opts DefaultOptions
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided DefaultOptions with the receiver DefaultOptions.
+func (o *DefaultOptions) merge(d DefaultOptions) DefaultOptions {
+	if o.Slog {
+		d.Slog = o.Slog
+	}
+	return d
+}
+
+// SetDefault makes the given logger the default logger used by the package-level logging functions like [Info].
+// A nil logger resets the default logger.
+func SetDefault(l Provider, o ...DefaultOptions) {
+	defaultMu.Lock()
+	defaultLogger = l
+	defaultMu.Unlock()
+
+	if l != nil && newDefaultLoggerOptions(o...).Slog {
+		slog.SetDefault(l.ToSlog())
+	}
+}
+
+// Default returns the default logger used by the package-level logging functions.
+// If no logger was set by [SetDefault], a logger with the default configuration is created on first use.
+func Default() Provider {
+	defaultMu.RLock()
+	l := defaultLogger
+	defaultMu.RUnlock()
+	if l != nil {
+		return l
+	}
+
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	if defaultLogger == nil {
+		defaultLogger = NewLogger()
+	}
+	return defaultLogger
+}
+
+// Debug logs at [LevelDebug] with the default logger.
+func Debug(msg string, args ...any) {
+	logDefault(LevelDebug, msg, args...)
+}
+
+// Info logs at [LevelInfo] with the default logger.
+func Info(msg string, args ...any) {
+	logDefault(LevelInfo, msg, args...)
+}
+
+// Warn logs at [LevelWarn] with the default logger.
+func Warn(msg string, args ...any) {
+	logDefault(LevelWarn, msg, args...)
+}
+
+// Error logs at [LevelError] with the default logger.
+func Error(msg string, args ...any) {
+	logDefault(LevelError, msg, args...)
+}
+
+// Fatal logs at [LevelFatal] with the default logger, calls its [Options.OnFatal] hooks
+// and then exits with its [Options.ExitCode] (default 1).
+func Fatal(msg string, args ...any) {
+	l, ok := Default().(*logger)
+	if !ok {
+		Default().Fatal(msg, args...)
+		return
+	}
+	logDefault(LevelFatal, msg, args...)
+	l.exit(context.Background())
+}
+
+// logDefault logs with the default logger, reporting the caller of the package-level function as source.
+func logDefault(level Level, msg string, args ...any) {
+	ctx := context.Background()
+	l, ok := Default().(*logger)
+	if !ok {
+		Default().Log(ctx, level, msg, args...)
+		return
+	}
+	if !l.Enabled(ctx, level) {
+		return
+	}
+
+	// Skip this function and the package-level log function to find the caller.
+	r := slog.NewRecord(time.Now(), slog.Level(level), msg, l.pc(2)) //nolint:mnd // see above
+	r.Add(args...)
+	l.handle(ctx, r)
+}
@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestDefault(t *testing.T) {
+	tests := []struct {
+		name      string
+		log       func()
+		opts      []DefaultOptions
+		wantLevel Level
+		wantCode  int
+	}{
+		{name: "debug", log: func() { Debug("test", "key", "value") }, wantLevel: LevelDebug},
+		{name: "info", log: func() { Info("test", "key", "value") }, wantLevel: LevelInfo},
+		{name: "warn", log: func() { Warn("test", "key", "value") }, wantLevel: LevelWarn},
+		{name: "error", log: func() { Error("test", "key", "value") }, wantLevel: LevelError},
+		{name: "fatal", log: func() { Fatal("test", "key", "value") }, wantLevel: LevelFatal, wantCode: 4},
+		{
+			name:      "slog default",
+			log:       func() { slog.Info("test", "key", "value") },
+			opts:      []DefaultOptions{{Slog: true}},
+			wantLevel: LevelInfo,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev := slog.Default()
+			t.Cleanup(func() {
+				SetDefault(nil)
+				slog.SetDefault(prev)
+			})
+
+			buf := &bytes.Buffer{}
+			code := 0
+			SetDefault(NewLogger(Options{
+				Handler:  slog.NewJSONHandler(buf, &slog.HandlerOptions{AddSource: true, Level: slog.Level(LevelTrace), ReplaceAttr: replaceAttr}),
+				ExitCode: 4,
+				Exit:     func(c int) { code = c },
+			}), tt.opts...)
+			tt.log()
+
+			var rec map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+				t.Fatalf("Failed to unmarshal record %q: %v", buf.String(), err)
+			}
+			if rec[slog.LevelKey] != tt.wantLevel.String() || rec["key"] != "value" {
+				t.Errorf("Expected record at %v with attributes, got %v", tt.wantLevel, rec)
+			}
+			if src, _ := rec[slog.SourceKey].(map[string]any); !strings.HasSuffix(src["file"].(string), "default_test.go") {
+				t.Errorf("Expected source to be the caller, got %v", src)
+			}
+			if code != tt.wantCode {
+				t.Errorf("Expected exit code %d, got %d", tt.wantCode, code)
+			}
+		})
+	}
+}
+
+func TestDefault_Lazy(t *testing.T) {
+	SetDefault(nil)
+	l := Default()
+	if l == nil {
+		t.Fatal("Expected a default logger")
+	}
+	if Default() != l {
+		t.Error("Expected the default logger to be created once")
+	}
+	SetDefault(nil)
+}
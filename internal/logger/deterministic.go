@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// PIDKey is the key used for the process ID of a record.
+const PIDKey = "pid"
+
+var _ slog.Handler = (*deterministicHandler)(nil)
+
+// deterministicHandler is a [slog.Handler] that removes the time and the caller from records,
+// so the handlers writing the records omit the time and source fields.
+type deterministicHandler struct {
+	slog.Handler
+}
+
+// newDeterministicHandler returns a new [slog.Handler] that removes the time and the caller from records.
+func newDeterministicHandler(h slog.Handler) slog.Handler {
+	return &deterministicHandler{Handler: h}
+}
+
+// Handle removes the time and the caller from the record and passes it to the wrapped handler.
+func (h *deterministicHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	r.Time = time.Time{}
+	r.PC = 0
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *deterministicHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &deterministicHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *deterministicHandler) WithGroup(name string) slog.Handler {
+	return &deterministicHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+var _ IDGenerator = (*sequenceGenerator)(nil)
+
+// sequenceGenerator generates sequential IDs starting at 1.
+type sequenceGenerator struct {
+	n atomic.Uint64
+}
+
+// NewID returns the next ID of the sequence.
+func (g *sequenceGenerator) NewID() string {
+	return strconv.FormatUint(g.n.Add(1), 10)
+}
+
+// NormalizeJSON normalizes JSON output of the logger, one record per line, for comparison with golden files.
+// It removes the fields that differ between runs: the time, the source, the process ID, the record ID and the split ID,
+// as well as the fields with the given keys. The remaining fields of every record are written with sorted keys.
+// Lines that are not JSON objects are kept as they are.
+//
+// Example:
+//
+//	got, err := logger.NormalizeJSON(buf.Bytes(), "request_id")
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	want, _ := os.ReadFile("testdata/output.golden")
+//	if !bytes.Equal(got, want) {
+//		t.Errorf("unexpected output:\n%s", got)
+//	}
+func NormalizeJSON(data []byte, keys ...string) ([]byte, error) {
+	remove := append([]string{slog.TimeKey, slog.SourceKey, PIDKey, RecordIDKey, SplitIDKey}, keys...)
+
+	var out bytes.Buffer
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(nil, len(data)+1)
+	for sc.Scan() {
+		line := sc.Bytes()
+		record, ok := decodeJSONObject(line)
+		if !ok {
+			out.Write(line)
+			out.WriteByte('\n')
+			continue
+		}
+
+		for _, key := range remove {
+			delete(record, key)
+		}
+		b, err := json.Marshal(record)
+		if err != nil {
+			return nil, err
+		}
+		out.Write(b)
+		out.WriteByte('\n')
+	}
+	return out.Bytes(), sc.Err()
+}
+
+// decodeJSONObject decodes the line as a single JSON object and reports whether it is one.
+// Numbers are decoded as [json.Number], so they are encoded again without losing precision.
+func decodeJSONObject(line []byte) (map[string]any, bool) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	var record map[string]any
+	if dec.Decode(&record) != nil || record == nil || dec.More() {
+		return nil, false
+	}
+	return record, true
+}
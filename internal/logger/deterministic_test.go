@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func TestOptions_Deterministic(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := NewPipeline(func(level Level) slog.Handler {
+		return slog.NewJSONHandler(buf, &slog.HandlerOptions{AddSource: true, Level: slog.Level(level), ReplaceAttr: replaceAttr})
+	}, Options{Deterministic: true, RecordIDs: true})
+	log := FromSlog(slog.New(h))
+
+	log.Info("test", "key", "value")
+	log.Warn("test")
+
+	want := `{"level":"INFO","msg":"test","key":"value","record_id":"1"}` + "\n" +
+		`{"level":"WARN","msg":"test","record_id":"2"}` + "\n"
+	if buf.String() != want {
+		t.Errorf("Expected output\n%s\ngot\n%s", want, buf.String())
+	}
+}
+
+func TestNormalizeJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		keys []string
+		want string
+	}{
+		{
+			name: "removes nondeterministic fields and sorts keys",
+			data: `{"time":"2024-01-01T00:00:00Z","level":"INFO","source":{"file":"main.go","line":1},"msg":"test","pid":42,"record_id":"01J","b":1,"a":"x"}` + "\n",
+			want: `{"a":"x","b":1,"level":"INFO","msg":"test"}` + "\n",
+		},
+		{
+			name: "removes given keys",
+			data: `{"msg":"test","request_id":"abc","split_id":"def","part":1}`,
+			keys: []string{"request_id"},
+			want: `{"msg":"test","part":1}` + "\n",
+		},
+		{
+			name: "keeps other lines",
+			data: "starting\n" + `{"msg":"test","time":"now"}` + "\n[1,2]\n",
+			want: "starting\n" + `{"msg":"test"}` + "\n[1,2]\n",
+		},
+		{
+			name: "keeps the precision of numbers",
+			data: `{"msg":"test","id":12345678901234567890,"big":9007199254740993,"f":1.50}`,
+			want: `{"big":9007199254740993,"f":1.50,"id":12345678901234567890,"msg":"test"}` + "\n",
+		},
+		{
+			name: "empty",
+			data: "",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeJSON([]byte(tt.data), tt.keys...)
+			if err != nil {
+				t.Fatalf("NormalizeJSON() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Expected\n%s\ngot\n%s", tt.want, got)
+			}
+		})
+	}
+}
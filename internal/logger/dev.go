@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// devFormat is the name of the development format, which is selected by default if stderr is a terminal.
+const devFormat = "DEV"
+
+// isDevFormat reports whether the given format is the development format.
+func isDevFormat(format string) bool {
+	return strings.EqualFold(format, devFormat)
+}
+
+// defaultDevTimeFormat is the default [DevOptions.TimeFormat].
+const defaultDevTimeFormat = "15:04:05.000"
+
+// devIndent is the indentation of one level of attributes.
+const devIndent = "  "
+
+// DevOptions is the optional configuration for [NewDevHandler].
+type DevOptions struct {
+	// Level is the minimum log level.
+	Level Level
+	// Writer is the writer of the records. Defaults to [os.Stderr].
+	// The output is colored if the writer is a terminal supporting colors,
+	// unless NO_COLOR, FORCE_COLOR, CLICOLOR_FORCE or CLICOLOR say otherwise.
+	Writer io.Writer
+	// TimeFormat is the layout of the times of the records. Defaults to "15:04:05.000".
+	TimeFormat string
+	// TimeZone is the location the times of the records are converted to. Defaults to the local time zone.
+	TimeZone *time.Location
+}
+
+// newDevOptions returns the provided DevOptions merged with the default DevOptions.
+func newDevOptions(o ...DevOptions) DevOptions {
+	opts := DevOptions{Level: LevelInfo, Writer: os.Stderr, TimeFormat: defaultDevTimeFormat}
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided DevOptions with the receiver DevOptions.
+func (o *DevOptions) merge(d DevOptions) DevOptions {
+	if o.Level != 0 {
+		d.Level = o.Level
+	}
+	if o.Writer != nil {
+		d.Writer = o.Writer
+	}
+	if o.TimeFormat != "" {
+		d.TimeFormat = o.TimeFormat
+	}
+	if o.TimeZone != nil {
+		d.TimeZone = o.TimeZone
+	}
+	return d
+}
@@ -0,0 +1,230 @@
+//go:build !js && !wasip1
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// devState is the state shared by a [devHandler] and the handlers derived from it.
+type devState struct {
+	mu       sync.Mutex
+	opts     DevOptions
+	renderer *lipgloss.Renderer
+}
+
+// devScope is a group of a [devHandler] with the attributes added to it by WithAttrs.
+// The first scope of a handler is the root without a name.
+type devScope struct {
+	name  string
+	attrs []slog.Attr
+}
+
+var _ slog.Handler = (*devHandler)(nil)
+
+// devHandler is a [slog.Handler] writing human-readable multi-line records for local development.
+type devHandler struct {
+	state  *devState
+	scopes []devScope
+}
+
+// NewDevHandler returns a new [slog.Handler] writing colorized multi-line records for local development,
+// similar to zap's development configuration: a header line with the time, the aligned level, the message
+// and the caller, followed by the attributes as an indented tree with aligned keys.
+// Errors are highlighted and multi-line values like stack traces are written as indented blocks.
+//
+// The handler is used for the "DEV" format, which is the default format if stderr is a terminal.
+// The output is not meant to be parsed, use the JSON format for that.
+func NewDevHandler(o ...DevOptions) slog.Handler {
+	opts := newDevOptions(o...)
+	renderer := lipgloss.NewRenderer(opts.Writer)
+	if profile, ok := colorProfile(opts.Writer); ok {
+		renderer.SetColorProfile(profile)
+	}
+	return &devHandler{
+		state:  &devState{opts: opts, renderer: renderer},
+		scopes: []devScope{{}},
+	}
+}
+
+// Enabled reports whether the handler handles records at the given level.
+func (h *devHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= slog.Level(h.state.opts.Level)
+}
+
+// Handle writes the record.
+func (h *devHandler) Handle(_ context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+
+	var b bytes.Buffer
+	h.writeHeader(&b, r)
+	h.writeAttrs(&b, h.nest(attrs), 1, false)
+
+	h.state.mu.Lock()
+	defer h.state.mu.Unlock()
+	_, err := h.state.opts.Writer.Write(b.Bytes())
+	return err
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *devHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	scopes := slices.Clone(h.scopes)
+	last := &scopes[len(scopes)-1]
+	last.attrs = append(slices.Clip(last.attrs), attrs...)
+	return &devHandler{state: h.state, scopes: scopes}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *devHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &devHandler{state: h.state, scopes: append(slices.Clip(h.scopes), devScope{name: name})}
+}
+
+// nest returns the attributes of the handler followed by the attributes of the record nested in the handler's groups.
+// Groups without attributes are omitted.
+func (h *devHandler) nest(attrs []slog.Attr) []slog.Attr {
+	for i := len(h.scopes) - 1; i > 0; i-- {
+		scope := h.scopes[i]
+		all := append(slices.Clip(scope.attrs), attrs...)
+		attrs = nil
+		if len(all) > 0 {
+			attrs = []slog.Attr{{Key: scope.name, Value: slog.GroupValue(all...)}}
+		}
+	}
+	return append(slices.Clip(h.scopes[0].attrs), attrs...)
+}
+
+// writeHeader writes the time, the level, the message and the caller of the record as first line.
+// The time and the caller are omitted if they are zero.
+func (h *devHandler) writeHeader(b *bytes.Buffer, r slog.Record) { //nolint:gocritic // records are passed by value
+	style := h.state.renderer.NewStyle()
+	if !r.Time.IsZero() {
+		t := r.Time
+		if h.state.opts.TimeZone != nil {
+			t = t.In(h.state.opts.TimeZone)
+		}
+		b.WriteString(style.Faint(true).Render(t.Format(h.state.opts.TimeFormat)))
+		b.WriteByte(' ')
+	}
+
+	level := Level(r.Level)
+	b.WriteString(style.Bold(true).Foreground(levelColor(level)).Render(fmt.Sprintf("%-6s", level.String())))
+	b.WriteByte(' ')
+	b.WriteString(style.Bold(true).Render(r.Message))
+
+	if r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		caller := fmt.Sprintf("%s:%d", filepath.Join(filepath.Base(filepath.Dir(frame.File)), filepath.Base(frame.File)), frame.Line)
+		b.WriteByte(' ')
+		b.WriteString(style.Faint(true).Render(caller))
+	}
+	b.WriteByte('\n')
+}
+
+// writeAttrs writes the attributes as indented tree at the given depth with the keys aligned.
+// The values of attributes within an error group are highlighted as errors.
+func (h *devHandler) writeAttrs(b *bytes.Buffer, attrs []slog.Attr, depth int, inError bool) {
+	attrs = devAttrs(attrs)
+	width := 0
+	for _, a := range attrs {
+		if a.Value.Kind() != slog.KindGroup {
+			width = max(width, len(a.Key))
+		}
+	}
+
+	indent := strings.Repeat(devIndent, depth)
+	keyStyle := h.state.renderer.NewStyle().Foreground(lipgloss.Color("245"))
+	for _, a := range attrs {
+		b.WriteString(indent)
+		if a.Value.Kind() == slog.KindGroup {
+			b.WriteString(keyStyle.Render(a.Key + ":"))
+			b.WriteByte('\n')
+			h.writeAttrs(b, a.Value.Group(), depth+1, inError || a.Key == ErrorKey)
+			continue
+		}
+		h.writeValue(b, a, keyStyle, width, indent+devIndent, inError)
+	}
+}
+
+// writeValue writes the key of the attribute padded to the given width and its value followed by a newline.
+// Multi-line values are written as block below the key with the given indentation.
+func (h *devHandler) writeValue(b *bytes.Buffer, a slog.Attr, keyStyle lipgloss.Style, width int, indent string, inError bool) {
+	style := h.state.renderer.NewStyle()
+	value := a.Value.String()
+	err, isErr := a.Value.Any().(error)
+	switch {
+	case isErr:
+		value = err.Error()
+		style = style.Foreground(levelColor(LevelError)).Bold(true)
+	case a.Key == StacktraceKey:
+		style = style.Faint(true)
+	case inError && a.Key == ErrorMessageKey:
+		style = style.Foreground(levelColor(LevelError)).Bold(true)
+	case inError:
+		style = style.Foreground(levelColor(LevelPanic))
+	}
+
+	value = strings.TrimRight(value, "\n")
+	if !strings.Contains(value, "\n") {
+		b.WriteString(keyStyle.Render(fmt.Sprintf("%-*s", width+1, a.Key+":")))
+		b.WriteByte(' ')
+		b.WriteString(style.Render(value))
+		b.WriteByte('\n')
+		return
+	}
+	b.WriteString(keyStyle.Render(a.Key + ":"))
+	b.WriteByte('\n')
+	for _, line := range strings.Split(value, "\n") {
+		b.WriteString(indent)
+		b.WriteString(style.Render(strings.ReplaceAll(line, "\t", devIndent)))
+		b.WriteByte('\n')
+	}
+}
+
+// devAttrs returns the resolved attributes with empty attributes and empty groups removed
+// and the attributes of groups with an empty key inlined.
+func devAttrs(attrs []slog.Attr) []slog.Attr {
+	out := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		a.Value = a.Value.Resolve()
+		switch {
+		case a.Equal(slog.Attr{}):
+		case a.Value.Kind() != slog.KindGroup:
+			out = append(out, a)
+		case a.Key == "":
+			out = append(out, devAttrs(a.Value.Group())...)
+		case len(a.Value.Group()) > 0:
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// levelColor returns the color of the level in [LevelColors] or no color if the level has none.
+func levelColor(level Level) lipgloss.TerminalColor {
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+	if color, ok := LevelColors[level]; ok {
+		return lipgloss.Color(color)
+	}
+	return lipgloss.NoColor{}
+}
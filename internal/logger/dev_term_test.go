@@ -0,0 +1,117 @@
+//go:build !js && !wasip1
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestDevHandler(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(h slog.Handler) slog.Handler
+		attrs []slog.Attr
+		want  string
+	}{
+		{
+			name:  "message only",
+			setup: func(h slog.Handler) slog.Handler { return h },
+			want:  "INFO   hello\n",
+		},
+		{
+			name:  "aligned keys",
+			setup: func(h slog.Handler) slog.Handler { return h },
+			attrs: []slog.Attr{slog.Int("id", 1), slog.String("method", "GET")},
+			want:  "INFO   hello\n  id:     1\n  method: GET\n",
+		},
+		{
+			name: "groups are indented",
+			setup: func(h slog.Handler) slog.Handler {
+				return h.WithAttrs([]slog.Attr{slog.String("service", "api")}).WithGroup("req").WithGroup("empty")
+			},
+			attrs: []slog.Attr{slog.Int("id", 1)},
+			want:  "INFO   hello\n  service: api\n  req:\n    empty:\n      id: 1\n",
+		},
+		{
+			name:  "empty groups are omitted",
+			setup: func(h slog.Handler) slog.Handler { return h.WithGroup("req") },
+			want:  "INFO   hello\n",
+		},
+		{
+			name:  "errors and multi-line values",
+			setup: func(h slog.Handler) slog.Handler { return h },
+			attrs: []slog.Attr{
+				slog.Any("err", errors.New("boom")),
+				slog.String(StacktraceKey, "main.main()\n\tmain.go:1\n"),
+			},
+			want: "INFO   hello\n  err:         boom\n  stack_trace:\n    main.main()\n      main.go:1\n",
+		},
+		{
+			name:  "inlined groups",
+			setup: func(h slog.Handler) slog.Handler { return h },
+			attrs: []slog.Attr{slog.Group("", slog.String("a", "1"))},
+			want:  "INFO   hello\n  a: 1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			h := tt.setup(NewDevHandler(DevOptions{Writer: &buf}))
+
+			r := slog.NewRecord(time.Time{}, slog.LevelInfo, "hello", 0)
+			r.AddAttrs(tt.attrs...)
+			if err := h.Handle(context.Background(), r); err != nil {
+				t.Fatalf("Handle() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("output = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestDevHandler_Header(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewLogger(Options{Handler: NewDevHandler(DevOptions{Writer: &buf, TimeFormat: time.DateOnly, TimeZone: time.UTC})})
+	log.Warn("careful")
+
+	want := time.Now().UTC().Format(time.DateOnly) + " WARN   careful logger/dev_term_test.go:"
+	if got := buf.String(); !bytes.HasPrefix([]byte(got), []byte(want)) {
+		t.Errorf("output = %q, want prefix %q", got, want)
+	}
+}
+
+func TestDevHandler_Colors(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{name: "no terminal", want: false},
+		{name: "forced", env: map[string]string{"FORCE_COLOR": "1"}, want: true},
+		{name: "no color wins", env: map[string]string{"FORCE_COLOR": "1", "NO_COLOR": "1"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unsetColorEnv(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			var buf bytes.Buffer
+			h := NewDevHandler(DevOptions{Writer: &buf})
+			if err := h.Handle(context.Background(), slog.NewRecord(time.Time{}, slog.LevelError, "hello", 0)); err != nil {
+				t.Fatalf("Handle() error = %v", err)
+			}
+			if got := bytes.Contains(buf.Bytes(), []byte("\x1b[")); got != tt.want {
+				t.Errorf("colored = %v, want %v: %q", got, tt.want, buf.String())
+			}
+		})
+	}
+}
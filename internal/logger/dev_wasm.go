@@ -0,0 +1,12 @@
+//go:build js || wasip1
+
+package logger
+
+import "log/slog"
+
+// NewDevHandler returns the [slog.Handler] of the "DEV" format.
+// There is no terminal to write multi-line colored records to on WASM platforms,
+// so the records are written by the handler returned by [NewConsoleHandler] instead.
+func NewDevHandler(o ...DevOptions) slog.Handler {
+	return NewConsoleHandler(newDevOptions(o...).Level)
+}
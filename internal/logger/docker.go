@@ -0,0 +1,132 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// DockerTagKey is the key used for the tag of a record in the Docker format.
+	DockerTagKey = "tag"
+	// DockerStreamKey is the key used for the stream a record was written to in the Docker format.
+	// It differs from the "source" key of the logging call, which the Docker format includes as well.
+	DockerStreamKey = "docker_stream"
+)
+
+// isDockerFormat reports whether the given format is the Docker format.
+func isDockerFormat(format string) bool {
+	return strings.EqualFold(format, "DOCKER")
+}
+
+// DockerOptions is the optional configuration for [NewDockerHandler].
+type DockerOptions struct {
+	// Tag is the tag added to every record. Defaults to the LOG_TAG environment variable or the name of the executable.
+	Tag string
+	// Level is the minimum log level.
+	Level Level
+	// Stdout is the writer for records below [LevelError]. Defaults to [os.Stdout].
+	Stdout io.Writer
+	// Stderr is the writer for records at or above [LevelError]. Defaults to [os.Stderr].
+	Stderr io.Writer
+	// KeyNames maps the standard keys (time, level, msg and source) to other names, see [Options.KeyNames].
+	KeyNames map[string]string
+}
+
+// newDockerOptions returns the provided DockerOptions merged with the default DockerOptions.
+func newDockerOptions(o ...DockerOptions) DockerOptions {
+	opts := DockerOptions{
+		Tag:    os.Getenv("LOG_TAG"),
+		Level:  LevelInfo,
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}
+	if opts.Tag == "" {
+		opts.Tag = filepath.Base(os.Args[0])
+	}
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided DockerOptions with the receiver DockerOptions.
+func (o *DockerOptions) merge(d DockerOptions) DockerOptions {
+	if o.Tag != "" {
+		d.Tag = o.Tag
+	}
+	if o.Level != 0 {
+		d.Level = o.Level
+	}
+	if o.Stdout != nil {
+		d.Stdout = o.Stdout
+	}
+	if o.Stderr != nil {
+		d.Stderr = o.Stderr
+	}
+	if len(o.KeyNames) > 0 {
+		d.KeyNames = o.KeyNames
+	}
+	return d
+}
+
+var _ slog.Handler = (*streamHandler)(nil)
+
+// streamHandler is a [slog.Handler] passing records to the handler of the stdout or stderr stream depending on their level.
+type streamHandler struct {
+	stdout slog.Handler
+	stderr slog.Handler
+}
+
+// NewDockerHandler returns a new [slog.Handler] for containers using Docker's fluentd, gelf or awslogs logging drivers.
+// Records are written as JSON, records at or above [LevelError] to stderr and all others to stdout,
+// so the stream reported by the logging driver reflects the severity.
+// Every record carries the tag under [DockerTagKey] and the stream under [DockerStreamKey],
+// which preserves the metadata when the driver forwards the JSON payload without a sidecar.
+//
+// The handler is used for the "DOCKER" format.
+func NewDockerHandler(o ...DockerOptions) slog.Handler {
+	opts := newDockerOptions(o...)
+	newJSONHandler := func(w io.Writer, source string) slog.Handler {
+		return slog.NewJSONHandler(w, &slog.HandlerOptions{
+			AddSource:   true,
+			Level:       slog.Level(opts.Level),
+			ReplaceAttr: renameReplaceAttr(opts.KeyNames, replaceAttr),
+		}).WithAttrs([]slog.Attr{slog.String(DockerTagKey, opts.Tag), slog.String(DockerStreamKey, source)})
+	}
+	return &streamHandler{
+		stdout: newJSONHandler(opts.Stdout, "stdout"),
+		stderr: newJSONHandler(opts.Stderr, "stderr"),
+	}
+}
+
+// Enabled reports whether the handler handles records at the given level.
+func (h *streamHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler(level).Enabled(ctx, level)
+}
+
+// Handle writes the record to stderr if its level is at or above [LevelError], otherwise to stdout.
+func (h *streamHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	return h.handler(r.Level).Handle(ctx, r)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *streamHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &streamHandler{stdout: h.stdout.WithAttrs(attrs), stderr: h.stderr.WithAttrs(attrs)}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *streamHandler) WithGroup(name string) slog.Handler {
+	return &streamHandler{stdout: h.stdout.WithGroup(name), stderr: h.stderr.WithGroup(name)}
+}
+
+// handler returns the handler for records of the given level.
+func (h *streamHandler) handler(level slog.Level) slog.Handler {
+	if Level(level) >= LevelError {
+		return h.stderr
+	}
+	return h.stdout
+}
@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestDockerHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		log        func(l Provider)
+		wantSource string
+		wantLevel  string
+	}{
+		{
+			name:       "info to stdout",
+			log:        func(l Provider) { l.Info("test", "key", "value") },
+			wantSource: "stdout",
+			wantLevel:  "INFO",
+		},
+		{
+			name:       "warn to stdout",
+			log:        func(l Provider) { l.WithGroup("group").Warn("test", "key", "value") },
+			wantSource: "stdout",
+			wantLevel:  "WARN",
+		},
+		{
+			name:       "error to stderr",
+			log:        func(l Provider) { l.With("key", "value").Error("test") },
+			wantSource: "stderr",
+			wantLevel:  "ERROR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			l := NewLogger(Options{Handler: NewDockerHandler(DockerOptions{Tag: "app", Stdout: &stdout, Stderr: &stderr})})
+			tt.log(l)
+
+			out, other := &stdout, &stderr
+			if tt.wantSource == "stderr" {
+				out, other = &stderr, &stdout
+			}
+			if other.Len() != 0 {
+				t.Errorf("Expected no output on the other stream, got %s", other.String())
+			}
+
+			var record map[string]any
+			if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+				t.Fatalf("Failed to decode record %q: %v", out.String(), err)
+			}
+			if record[DockerTagKey] != "app" {
+				t.Errorf("Expected tag %q, got %v", "app", record[DockerTagKey])
+			}
+			if record[DockerStreamKey] != tt.wantSource {
+				t.Errorf("Expected source %q, got %v", tt.wantSource, record[DockerStreamKey])
+			}
+			if _, ok := record[slog.SourceKey].(map[string]any); !ok || bytes.Count(out.Bytes(), []byte(`"source":`)) != 1 {
+				t.Errorf("Expected a single source of the logging call, got %s", out.String())
+			}
+			if record["level"] != tt.wantLevel {
+				t.Errorf("Expected level %q, got %v", tt.wantLevel, record["level"])
+			}
+		})
+	}
+}
+
+func TestNewDockerOptions(t *testing.T) {
+	t.Setenv("LOG_TAG", "env-tag")
+
+	if got := newDockerOptions().Tag; got != "env-tag" {
+		t.Errorf("Expected tag from environment, got %q", got)
+	}
+	if got := newDockerOptions(DockerOptions{Tag: "custom"}).Tag; got != "custom" {
+		t.Errorf("Expected custom tag, got %q", got)
+	}
+	if got := newDockerOptions(DockerOptions{Level: LevelDebug}).Level; got != LevelDebug {
+		t.Errorf("Expected level %v, got %v", LevelDebug, got)
+	}
+}
@@ -0,0 +1,252 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrNoContextLogger is returned by [EnableLevelFor] if the context does not have a logger.
+var ErrNoContextLogger = errors.New("no logger in context")
+
+var (
+	// nameLevelsMu guards nameLevels.
+	nameLevelsMu sync.RWMutex
+	// nameLevels is a map of logger names to their minimum levels set by [SetLevelFor].
+	nameLevels = map[string]Level{}
+	// nameLevelsCount is the number of names with a level, used to skip the lookup if there are none.
+	nameLevelsCount atomic.Int64
+)
+
+// SetLevelFor sets the minimum level of the named loggers with the given name and the names below it,
+// e.g. "db" applies to "db" and "db.migrations", so a noisy subsystem can be silenced or boosted
+// without affecting the rest. The level of the most specific name wins and takes precedence
+// over the level of the logger, including the level set by [Provider.SetLevel].
+// The windows of [EnableLevelFor] still lower it while they are active.
+//
+// It applies to the loggers created by [NewNamedLogger] or given a name with the [NameKey] attribute,
+// if their handlers are built from the [Options] or a [Config].
+func SetLevelFor(name string, level Level) {
+	nameLevelsMu.Lock()
+	defer nameLevelsMu.Unlock()
+	if _, ok := nameLevels[name]; !ok {
+		nameLevelsCount.Add(1)
+	}
+	nameLevels[name] = level
+}
+
+// ResetLevelFor removes the level of the name set by [SetLevelFor].
+// The named loggers fall back to the level of the closest name above it or their own level.
+func ResetLevelFor(name string) {
+	nameLevelsMu.Lock()
+	defer nameLevelsMu.Unlock()
+	if _, ok := nameLevels[name]; ok {
+		delete(nameLevels, name)
+		nameLevelsCount.Add(-1)
+	}
+}
+
+// levelFor returns the level set by [SetLevelFor] for the name or the closest name above it.
+func levelFor(name string) (Level, bool) {
+	if name == "" || nameLevelsCount.Load() == 0 {
+		return 0, false
+	}
+
+	nameLevelsMu.RLock()
+	defer nameLevelsMu.RUnlock()
+	for {
+		if level, ok := nameLevels[name]; ok {
+			return level, true
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			return 0, false
+		}
+		name = name[:i]
+	}
+}
+
+// levelControl is the dynamic minimum level of the handlers built by a logger,
+// shared by all loggers derived from the same logger.
+type levelControl struct {
+	// mu guards windows and nextWindow.
+	mu sync.Mutex
+	// windows are the levels of the active windows of [EnableLevelFor] by their IDs.
+	windows map[uint64]Level
+	// nextWindow is the ID of the next window.
+	nextWindow uint64
+	// level is the level set by SetLevel overriding the configured levels, nil if they apply.
+	level atomic.Pointer[Level]
+	// window is the lowest level of the active windows, nil if there are none.
+	window atomic.Pointer[Level]
+}
+
+// minimum returns the minimum level of a handler of the named logger configured with the given level.
+// The level of the name set by [SetLevelFor] takes precedence over the level set by SetLevel,
+// which takes precedence over the configured level. Active windows only lower the resulting level.
+func (c *levelControl) minimum(name string, configured Level) Level {
+	level := configured
+	if named, ok := levelFor(name); ok {
+		level = named
+	} else if set := c.level.Load(); set != nil {
+		level = *set
+	}
+	if window := c.window.Load(); window != nil {
+		return min(level, *window)
+	}
+	return level
+}
+
+// set sets the level overriding the configured levels, nil to restore them.
+func (c *levelControl) set(level *Level) {
+	c.level.Store(level)
+}
+
+// open opens a window lowering the minimum level to the given level until the returned function is called.
+func (c *levelControl) open(level Level) (closeWindow func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.windows == nil {
+		c.windows = map[uint64]Level{}
+	}
+	id := c.nextWindow
+	c.nextWindow++
+	c.windows[id] = level
+	c.update()
+
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.windows, id)
+		c.update()
+	}
+}
+
+// update stores the lowest level of the windows. Must be called with the mutex held.
+func (c *levelControl) update() {
+	var window *Level
+	for _, level := range c.windows {
+		if window == nil || level < *window {
+			window = &level
+		}
+	}
+	c.window.Store(window)
+}
+
+// SetLevel overrides the minimum level of the handlers built by the logger and the loggers sharing them.
+func (l *logger) SetLevel(level Level) {
+	if l.level != nil {
+		l.level.set(&level)
+	}
+}
+
+// ResetLevel restores the configured minimum levels of the handlers built by the logger.
+func (l *logger) ResetLevel() {
+	if l.level != nil {
+		l.level.set(nil)
+	}
+}
+
+// EnableLevelFor lowers the minimum level of the logger found in the context to the given level
+// for the duration, e.g. to debug a production issue for 10 minutes without a restart.
+// The level is restored once the duration has elapsed, the returned restore function is called
+// or the context is done, whichever happens first.
+// Returns [ErrNoContextLogger] if the context does not have a logger, see [IntoContext],
+// so the level of the [Default] logger is not changed by accident.
+//
+// The start and the end of the window are logged with the logger at [LevelNotice].
+// A window only lowers the minimum level: while it is active, the minimum level is the lowest
+// of the window levels and the level that applies otherwise, i.e. the level set by [SetLevelFor]
+// for the logger's name, the level set by [Provider.SetLevel] or the configured level, so windows may overlap.
+// The restore function is safe to call multiple times.
+// Like [Provider.SetLevel], it has no effect on handlers passed to [Options.Handler] or [Provider.SetHandler].
+func EnableLevelFor(ctx context.Context, level Level, d time.Duration) (restore func(), err error) {
+	if ctx == nil {
+		return nil, ErrNoContextLogger
+	}
+	log, ok := ctx.Value(ctxKey{}).(Provider)
+	if !ok {
+		return nil, ErrNoContextLogger
+	}
+
+	closeWindow := func() {}
+	if l, ok := log.(*logger); ok && l.level != nil {
+		closeWindow = l.level.open(level)
+	}
+	log.NoticeContext(ctx, "Enabled log level", "min_level", level.String(), "duration", d)
+
+	start := time.Now()
+	var once sync.Once
+	disable := func() {
+		once.Do(func() {
+			log.NoticeContext(context.WithoutCancel(ctx), "Restored log level", "min_level", level.String(), "duration", time.Since(start))
+			closeWindow()
+		})
+	}
+
+	timer := time.AfterFunc(d, disable)
+	stop := context.AfterFunc(ctx, disable)
+	return func() {
+		timer.Stop()
+		stop()
+		disable()
+	}, nil
+}
+
+// lowestLevel returns the level the handlers controlled by a levelControl are built with,
+// so the level can be lowered down to [LevelTrace] or the configured level if it is lower.
+func lowestLevel(configured Level) Level {
+	return min(configured, LevelTrace)
+}
+
+var _ slog.Handler = (*levelHandler)(nil)
+
+// levelHandler is a [slog.Handler] filtering the records by the dynamic minimum level of a [levelControl]
+// and the levels of the logger names set by [SetLevelFor]. The wrapped handler is built with the [lowestLevel].
+type levelHandler struct {
+	slog.Handler
+	level   Level
+	control *levelControl
+	// name is the top-level [NameKey] attribute of the logger.
+	name string
+	// grouped reports whether a group was added, after which names are not top-level anymore.
+	grouped bool
+}
+
+// newLevelHandler returns a new [levelHandler] with the given configured level.
+func newLevelHandler(h slog.Handler, level Level, control *levelControl) slog.Handler {
+	return &levelHandler{Handler: h, level: level, control: control}
+}
+
+// Enabled reports whether the level is at or above the current minimum level and enabled by the wrapped handler.
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return Level(level) >= h.control.minimum(h.name, h.level) && h.Handler.Enabled(ctx, level)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handler := *h
+	handler.Handler = h.Handler.WithAttrs(attrs)
+	if !h.grouped {
+		for _, a := range attrs {
+			if a.Key == NameKey {
+				handler.name = a.Value.String()
+			}
+		}
+	}
+	return &handler
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	handler := *h
+	handler.Handler, handler.grouped = h.Handler.WithGroup(name), true
+	return &handler
+}
@@ -0,0 +1,236 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogger_SetLevel(t *testing.T) {
+	ctx := context.Background()
+	log := NewLogger(Options{Level: "WARN", Format: "TEXT"})
+	derived := log.With("service", "api").WithGroup("req")
+
+	tests := []struct {
+		name   string
+		change func()
+		want   map[Level]bool
+	}{
+		{name: "configured", change: func() {}, want: map[Level]bool{LevelTrace: false, LevelInfo: false, LevelWarn: true}},
+		{name: "lowered", change: func() { derived.SetLevel(LevelTrace) }, want: map[Level]bool{LevelTrace: true, LevelInfo: true, LevelWarn: true}},
+		{name: "raised", change: func() { log.SetLevel(LevelError) }, want: map[Level]bool{LevelTrace: false, LevelWarn: false, LevelError: true}},
+		{name: "reset", change: func() { log.ResetLevel() }, want: map[Level]bool{LevelTrace: false, LevelInfo: false, LevelWarn: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.change()
+			for level, want := range tt.want {
+				for _, l := range []Provider{log, derived} {
+					if got := l.Enabled(ctx, level); got != want {
+						t.Errorf("Enabled(%s) = %v, want %v", level, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestLogger_SetLevel_Config(t *testing.T) {
+	dir := t.TempDir()
+	info, errs := filepath.Join(dir, "info.log"), filepath.Join(dir, "error.log")
+	log, err := NewFromConfig(Config{Outputs: []SinkConfig{{Output: info}, {Output: errs, Level: "ERROR"}}})
+	if err != nil {
+		t.Fatalf("NewFromConfig() error = %v", err)
+	}
+
+	log.Debug("hidden")
+	log.SetLevel(LevelDebug)
+	log.Debug("debug")
+	log.ResetLevel()
+	log.Debug("hidden")
+
+	for _, path := range []string{info, errs} {
+		got, _ := os.ReadFile(path)
+		if strings.Count(string(got), "\n") != 1 || !strings.Contains(string(got), `"msg":"debug"`) {
+			t.Errorf("Expected only the debug record in %s, got %s", path, got)
+		}
+	}
+}
+
+func TestLogger_SetLevel_Handler(t *testing.T) {
+	var buf strings.Builder
+	log := NewLogger(Options{Handler: slog.NewTextHandler(&buf, nil)})
+	log.SetLevel(LevelDebug)
+	log.Debug("hidden")
+	if buf.Len() != 0 {
+		t.Errorf("Expected the level of a custom handler to be kept, got %s", buf.String())
+	}
+}
+
+func TestSetLevelFor(t *testing.T) {
+	ctx := context.Background()
+	opts := Options{Level: "INFO", Format: "TEXT"}
+	loggers := map[string]Provider{
+		"db":            NewNamedLogger("db", opts),
+		"db.migrations": NewNamedLogger("db.migrations", opts).WithGroup("req"),
+		"db.pool":       NewNamedLogger("db.pool", opts),
+		"dbx":           NewNamedLogger("dbx", opts),
+		"unnamed":       NewLogger(opts),
+	}
+	t.Cleanup(func() {
+		ResetLevelFor("db")
+		ResetLevelFor("db.migrations")
+	})
+
+	tests := []struct {
+		name   string
+		change func()
+		level  Level
+		want   map[string]bool
+	}{
+		{
+			name:   "configured",
+			change: func() {},
+			level:  LevelInfo,
+			want:   map[string]bool{"db": true, "db.migrations": true, "db.pool": true, "dbx": true, "unnamed": true},
+		},
+		{
+			name:   "parent silenced",
+			change: func() { SetLevelFor("db", LevelError) },
+			level:  LevelWarn,
+			want:   map[string]bool{"db": false, "db.migrations": false, "db.pool": false, "dbx": true, "unnamed": true},
+		},
+		{
+			name:   "child boosted",
+			change: func() { SetLevelFor("db.migrations", LevelDebug) },
+			level:  LevelDebug,
+			want:   map[string]bool{"db": false, "db.migrations": true, "db.pool": false, "dbx": false, "unnamed": false},
+		},
+		{
+			name:   "logger level overridden",
+			change: func() { loggers["db.pool"].SetLevel(LevelTrace) },
+			level:  LevelTrace,
+			want:   map[string]bool{"db": false, "db.migrations": false, "db.pool": false, "dbx": false, "unnamed": false},
+		},
+		{
+			name:   "parent reset",
+			change: func() { ResetLevelFor("db") },
+			level:  LevelTrace,
+			want:   map[string]bool{"db": false, "db.migrations": false, "db.pool": true, "dbx": false, "unnamed": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.change()
+			for name, want := range tt.want {
+				if got := loggers[name].Enabled(ctx, tt.level); got != want {
+					t.Errorf("%s: Enabled(%s) = %v, want %v", name, tt.level, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEnableLevelFor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	log, err := NewFromConfig(Config{Level: "WARN", Output: path})
+	if err != nil {
+		t.Fatalf("NewFromConfig() error = %v", err)
+	}
+	ctx := IntoContext(context.Background(), log)
+
+	if _, err = EnableLevelFor(ctx, LevelDebug, 50*time.Millisecond); err != nil {
+		t.Fatalf("EnableLevelFor() error = %v", err)
+	}
+	log.Debug("inside")
+	for range 100 {
+		if !log.Enabled(ctx, LevelDebug) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	log.Debug("outside")
+
+	got, _ := os.ReadFile(path)
+	for _, want := range []string{`"msg":"Enabled log level","min_level":"DEBUG","duration":50000000`, `"msg":"inside"`, `"msg":"Restored log level"`} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("Expected %s in the records, got %s", want, got)
+		}
+	}
+	if strings.Contains(string(got), "outside") {
+		t.Errorf("Expected the level to be restored after the duration, got %s", got)
+	}
+}
+
+func TestEnableLevelFor_Overlapping(t *testing.T) {
+	log, err := NewFromConfig(Config{Level: "WARN", Output: filepath.Join(t.TempDir(), "app.log")})
+	if err != nil {
+		t.Fatalf("NewFromConfig() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(IntoContext(context.Background(), log))
+	defer cancel()
+	enabled := func(level Level) bool { return log.Enabled(context.Background(), level) }
+
+	restoreDebug, _ := EnableLevelFor(ctx, LevelDebug, time.Hour)
+	restoreTrace, _ := EnableLevelFor(ctx, LevelTrace, time.Hour)
+	if !enabled(LevelTrace) {
+		t.Errorf("Expected the lowest window level to apply")
+	}
+	restoreTrace()
+	restoreTrace()
+	if enabled(LevelTrace) || !enabled(LevelDebug) {
+		t.Errorf("Expected the remaining window level to apply")
+	}
+
+	log.SetLevel(LevelError)
+	if !enabled(LevelDebug) {
+		t.Errorf("Expected the window level to apply while the window is active")
+	}
+	cancel()
+	for range 100 {
+		if !enabled(LevelDebug) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if enabled(LevelWarn) || !enabled(LevelError) {
+		t.Errorf("Expected the level set during the window to apply after the context is done")
+	}
+	restoreDebug()
+}
+
+func TestEnableLevelFor_OnlyLowers(t *testing.T) {
+	log, err := NewFromConfig(Config{Level: "TRACE", Output: filepath.Join(t.TempDir(), "app.log")})
+	if err != nil {
+		t.Fatalf("NewFromConfig() error = %v", err)
+	}
+	ctx := IntoContext(context.Background(), log)
+
+	restore, err := EnableLevelFor(ctx, LevelInfo, time.Hour)
+	if err != nil {
+		t.Fatalf("EnableLevelFor() error = %v", err)
+	}
+	defer restore()
+	if !log.Enabled(ctx, LevelTrace) {
+		t.Error("Expected the window not to raise the configured level")
+	}
+
+	SetLevelFor("db", LevelError)
+	defer ResetLevelFor("db")
+	if db := log.With(NameKey, "db"); !db.Enabled(ctx, LevelInfo) || db.Enabled(ctx, LevelDebug) {
+		t.Error("Expected the window to lower the level of the name")
+	}
+}
+
+func TestEnableLevelFor_NoLogger(t *testing.T) {
+	if _, err := EnableLevelFor(context.Background(), LevelDebug, time.Hour); !errors.Is(err, ErrNoContextLogger) {
+		t.Errorf("Expected ErrNoContextLogger, got %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package logger
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+const (
+	// ErrorKey is the key used for the error group by [Err].
+	ErrorKey = "error"
+	// ErrorMessageKey is the key used for the error message within the error group.
+	ErrorMessageKey = "message"
+	// ErrorTypeKey is the key used for the type name of the error within the error group.
+	ErrorTypeKey = "type"
+	// ErrorChainKey is the key used for the chain of wrapped errors within the error group.
+	ErrorChainKey = "chain"
+)
+
+// attrError is an error carrying structured attributes.
+type attrError struct {
+	err   error
+	attrs []slog.Attr
+}
+
+// Wrap returns an error wrapping err that carries the given attributes.
+// The arguments are handled in the manner of [Provider.With].
+// When the error is logged with [Err], the attributes of all wrapped errors are added to the record,
+// which preserves context gathered deep in the call stack.
+//
+// Returns nil if err is nil.
+func Wrap(err error, args ...any) error {
+	if err == nil {
+		return nil
+	}
+	return &attrError{err: err, attrs: argsToAttrs(args)}
+}
+
+// Error returns the message of the wrapped error.
+func (e *attrError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *attrError) Unwrap() error {
+	return e.err
+}
+
+// stackError is an error carrying the stack trace of its creation.
+type stackError struct {
+	err error
+	pcs []uintptr
+}
+
+// WithStack returns an error wrapping err that carries the stack trace of the caller.
+// When the error is logged with [Err], the stack trace is added to the error group under [StacktraceKey].
+//
+// Returns nil if err is nil.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &stackError{err: err, pcs: captureStack(1)}
+}
+
+// Error returns the message of the wrapped error.
+func (e *stackError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *stackError) Unwrap() error {
+	return e.err
+}
+
+// errorLink is an error of the unwrap chain logged by [Err].
+type errorLink struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
+
+// Err returns an attribute for the given error.
+// The attribute consists of a group under [ErrorKey] followed by the attributes
+// attached to the error and its wrapped errors by [Wrap], outermost first.
+// The group contains the error message, the type name of the error, the message and type name of
+// every wrapped error in the unwrap chain (if any) and the stack trace captured by [WithStack] (if any).
+// The attribute has an empty key, so its attributes are inlined into the record.
+//
+// Example output of a wrapped error:
+//
+//	{"error": {"message": "query failed: connection refused", "type": "*fmt.wrapError", "chain": [{"message": "connection refused", "type": "*errors.errorString"}]}}
+func Err(err error) slog.Attr {
+	if err == nil {
+		return slog.Any(ErrorKey, nil)
+	}
+
+	attrs := []slog.Attr{slog.Attr{Key: ErrorKey, Value: errorValue(err)}}
+	return slog.Attr{Key: "", Value: slog.GroupValue(append(attrs, errorAttrs(err)...)...)}
+}
+
+// errorValue returns the group value describing the error, its unwrap chain and its stack trace.
+// The wrappers created by [Wrap] and [WithStack] are skipped, since they only carry additional information.
+func errorValue(err error) slog.Value {
+	var links []errorLink
+	var pcs []uintptr
+	for _, e := range unwrapAll(err) {
+		switch se := e.(type) { //nolint:errorlint // the wrapped errors are visited by unwrapAll
+		case *attrError:
+		case *stackError:
+			// The innermost stack trace is the closest to the origin of the error.
+			pcs = se.pcs
+		default:
+			links = append(links, errorLink{Message: e.Error(), Type: fmt.Sprintf("%T", e)})
+		}
+	}
+
+	attrs := []slog.Attr{slog.String(ErrorMessageKey, err.Error())}
+	if len(links) > 0 {
+		attrs = append(attrs, slog.String(ErrorTypeKey, links[0].Type))
+	}
+	if len(links) > 1 {
+		attrs = append(attrs, slog.Any(ErrorChainKey, links[1:]))
+	}
+	if len(pcs) > 0 {
+		attrs = append(attrs, slog.String(StacktraceKey, formatStack(pcs)))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// errorAttrs returns the attributes attached by [Wrap] to the error and its wrapped errors.
+func errorAttrs(err error) []slog.Attr {
+	var attrs []slog.Attr
+	for _, e := range unwrapAll(err) {
+		if ae, ok := e.(*attrError); ok { //nolint:errorlint // the wrapped errors are visited by unwrapAll
+			attrs = append(attrs, ae.attrs...)
+		}
+	}
+	return attrs
+}
+
+// unwrapAll returns the error and all errors wrapped by it in depth-first order.
+func unwrapAll(err error) []error {
+	var errs []error
+	for err != nil {
+		errs = append(errs, err)
+		switch u := err.(type) { //nolint:errorlint // we need to unwrap manually to visit every error
+		case interface{ Unwrap() []error }:
+			for _, e := range u.Unwrap() {
+				errs = append(errs, unwrapAll(e)...)
+			}
+			return errs
+		case interface{ Unwrap() error }:
+			err = u.Unwrap()
+		default:
+			return errs
+		}
+	}
+	return errs
+}
@@ -0,0 +1,54 @@
+//go:build !js && !wasip1
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	clog "github.com/charmbracelet/log"
+)
+
+func TestErr_Output(t *testing.T) {
+	err := Wrap(errors.New("boom"), "key", "value")
+
+	tests := []struct {
+		name    string
+		handler func(buf *bytes.Buffer) slog.Handler
+		want    []string
+	}{
+		{
+			name: "json",
+			handler: func(buf *bytes.Buffer) slog.Handler {
+				return slog.NewJSONHandler(buf, nil)
+			},
+			want: []string{`"error":{"message":"boom","type":"*errors.errorString"}`, `"key":"value"`},
+		},
+		{
+			name: "text",
+			handler: func(buf *bytes.Buffer) slog.Handler {
+				return newInlineHandler(clog.New(buf))
+			},
+			want: []string{"message=boom", "key=value"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := NewLogger(Options{Handler: tt.handler(&buf)})
+			l.Error("Failed", Err(err))
+			l.With(Err(err)).ErrorContext(context.Background(), "Failed")
+
+			for _, want := range tt.want {
+				if strings.Count(buf.String(), want) != 2 {
+					t.Errorf("Expected output to contain %q twice, got %s", want, buf.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	base := errors.New("connection refused")
+	errGroup := func(msg, typ string, chain ...errorLink) slog.Attr {
+		attrs := []slog.Attr{slog.String(ErrorMessageKey, msg), slog.String(ErrorTypeKey, typ)}
+		if len(chain) > 0 {
+			attrs = append(attrs, slog.Any(ErrorChainKey, chain))
+		}
+		return slog.Attr{Key: ErrorKey, Value: slog.GroupValue(attrs...)}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want []slog.Attr
+	}{
+		{
+			name: "nil error",
+			err:  nil,
+			want: []slog.Attr{slog.Any(ErrorKey, nil)},
+		},
+		{
+			name: "plain error",
+			err:  base,
+			want: []slog.Attr{errGroup("connection refused", "*errors.errorString")},
+		},
+		{
+			name: "wrapped error",
+			err:  Wrap(base, "host", "db", "port", 5432),
+			want: []slog.Attr{errGroup("connection refused", "*errors.errorString"), slog.String("host", "db"), slog.Int("port", 5432)},
+		},
+		{
+			name: "wrapped multiple times",
+			err:  Wrap(fmt.Errorf("query failed: %w", Wrap(base, "host", "db")), "query", "SELECT 1"),
+			want: []slog.Attr{
+				errGroup("query failed: connection refused", "*fmt.wrapError", errorLink{Message: "connection refused", Type: "*errors.errorString"}),
+				slog.String("query", "SELECT 1"),
+				slog.String("host", "db"),
+			},
+		},
+		{
+			name: "joined errors",
+			err:  errors.Join(Wrap(base, "a", 1), Wrap(errors.New("timeout"), "b", 2)),
+			want: []slog.Attr{
+				errGroup("connection refused\ntimeout", "*errors.joinError",
+					errorLink{Message: "connection refused", Type: "*errors.errorString"},
+					errorLink{Message: "timeout", Type: "*errors.errorString"},
+				),
+				slog.Int("a", 1),
+				slog.Int("b", 2),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := Err(tt.err)
+			got := []slog.Attr{a}
+			if a.Key == "" {
+				got = a.Value.Group()
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Err() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].String() != tt.want[i].String() {
+					t.Errorf("Err()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWithStack(t *testing.T) {
+	err := Wrap(fmt.Errorf("outer: %w", WithStack(errors.New("boom"))), "key", "value")
+
+	var group []slog.Attr
+	for _, a := range Err(err).Value.Group() {
+		if a.Key == ErrorKey {
+			group = a.Value.Group()
+		}
+	}
+
+	var stack string
+	for _, a := range group {
+		if a.Key == StacktraceKey {
+			stack = a.Value.String()
+		}
+	}
+	if !strings.Contains(stack, "TestWithStack") {
+		t.Errorf("Expected the stack trace to contain the caller, got %q", stack)
+	}
+	if strings.Contains(stack, "captureStack") {
+		t.Errorf("Expected the stack trace not to contain logger frames, got %q", stack)
+	}
+	if WithStack(nil) != nil {
+		t.Error("WithStack(nil) != nil")
+	}
+}
+
+func TestWrap_Nil(t *testing.T) {
+	if err := Wrap(nil, "key", "value"); err != nil {
+		t.Errorf("Wrap(nil) = %v, want nil", err)
+	}
+}
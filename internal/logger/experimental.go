@@ -0,0 +1,106 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+)
+
+// experimentalEnv is the environment variable listing the enabled experimental features.
+const experimentalEnv = "LOG_EXPERIMENTAL"
+
+var (
+	// experimentalMu guards experimental.
+	experimentalMu sync.RWMutex
+	// experimental is the set of experimental features enabled by [EnableExperimental].
+	experimental = map[string]bool{}
+)
+
+// Experimental reports whether the experimental feature with the given name is enabled.
+// Experimental handlers and sinks check it at runtime, so they can be opted into per environment without separate builds,
+// e.g. by [NewExperimentalHandler].
+//
+// The features are enabled by [EnableExperimental] or by the LOG_EXPERIMENTAL environment variable,
+// a comma-separated list of feature names. Like LOG_LEVEL and LOG_FORMAT, the environment variable takes precedence if set.
+func Experimental(name string) bool {
+	if env, ok := os.LookupEnv(experimentalEnv); ok {
+		for _, f := range strings.Split(env, ",") {
+			if strings.TrimSpace(f) == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	experimentalMu.RLock()
+	defer experimentalMu.RUnlock()
+	return experimental[name]
+}
+
+// EnableExperimental enables the experimental features with the given names.
+func EnableExperimental(names ...string) {
+	experimentalMu.Lock()
+	defer experimentalMu.Unlock()
+	for _, name := range names {
+		experimental[name] = true
+	}
+}
+
+// DisableExperimental disables the experimental features with the given names.
+func DisableExperimental(names ...string) {
+	experimentalMu.Lock()
+	defer experimentalMu.Unlock()
+	for _, name := range names {
+		delete(experimental, name)
+	}
+}
+
+var _ slog.Handler = (*experimentalHandler)(nil)
+
+// experimentalHandler is a [slog.Handler] passing the records to an experimental handler
+// while its feature is enabled and to a stable handler otherwise.
+type experimentalHandler struct {
+	name         string
+	experimental slog.Handler
+	stable       slog.Handler
+}
+
+// NewExperimentalHandler returns a new [slog.Handler] that passes every record to the experimental handler
+// while the feature with the given name is enabled, see [Experimental], and to the stable handler otherwise.
+// The feature is checked for every record, so enabling or disabling it takes effect immediately.
+func NewExperimentalHandler(name string, experimental, stable slog.Handler) slog.Handler {
+	return &experimentalHandler{name: name, experimental: experimental, stable: stable}
+}
+
+// active returns the handler the records are currently passed to.
+func (h *experimentalHandler) active() slog.Handler {
+	if Experimental(h.name) {
+		return h.experimental
+	}
+	return h.stable
+}
+
+// Enabled reports whether the active handler is enabled for the level.
+func (h *experimentalHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.active().Enabled(ctx, level)
+}
+
+// Handle passes the record to the active handler.
+func (h *experimentalHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	return h.active().Handle(ctx, r)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *experimentalHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &experimentalHandler{name: h.name, experimental: h.experimental.WithAttrs(attrs), stable: h.stable.WithAttrs(attrs)}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *experimentalHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &experimentalHandler{name: h.name, experimental: h.experimental.WithGroup(name), stable: h.stable.WithGroup(name)}
+}
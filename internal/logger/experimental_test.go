@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+)
+
+func TestExperimental(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     *string
+		enabled []string
+		feature string
+		want    bool
+	}{
+		{name: "disabled by default", feature: "zstd-sink", want: false},
+		{name: "enabled programmatically", enabled: []string{"zstd-sink"}, feature: "zstd-sink", want: true},
+		{name: "other feature enabled", enabled: []string{"other"}, feature: "zstd-sink", want: false},
+		{name: "enabled by env", env: ptr("other, zstd-sink"), feature: "zstd-sink", want: true},
+		{name: "env takes precedence", env: ptr("other"), enabled: []string{"zstd-sink"}, feature: "zstd-sink", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.env != nil {
+				t.Setenv(experimentalEnv, *tt.env)
+			}
+			EnableExperimental(tt.enabled...)
+			defer DisableExperimental(tt.enabled...)
+
+			if got := Experimental(tt.feature); got != tt.want {
+				t.Errorf("Experimental(%q) = %v, want %v", tt.feature, got, tt.want)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestNewExperimentalHandler(t *testing.T) {
+	const feature = "test-sink"
+	var got []string
+	sink := func(name string) slog.Handler {
+		return &test.MockHandler{
+			HandleFunc: func(context.Context, slog.Record) error {
+				got = append(got, name)
+				return nil
+			},
+		}
+	}
+	log := NewLogger(Options{Handler: NewExperimentalHandler(feature, sink("experimental"), sink("stable"))})
+
+	log.Info("before")
+	EnableExperimental(feature)
+	log.Info("enabled")
+	DisableExperimental(feature)
+	log.Info("disabled")
+	t.Setenv(experimentalEnv, feature)
+	log.Info("env")
+
+	want := []string{"stable", "experimental", "stable", "experimental"}
+	if len(got) != len(want) {
+		t.Fatalf("Expected the records to be passed to %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected record %d to be passed to the %s handler, got %s", i, want[i], got[i])
+		}
+	}
+}
@@ -3,6 +3,8 @@ package logger
 import (
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
 )
 
@@ -65,7 +67,7 @@ func (l *logger) Errorf(msg string, args ...any) {
 // Panic logs at [LevelPanic] and then panics.
 func (l *logger) Panic(msg string, args ...any) {
 	l.logAttrs(context.Background(), LevelPanic, msg, args...)
-	panic(msg)
+	panic(l.panicValue(msg, args))
 }
 
 // Panicf logs at LevelPanic and then panics.
@@ -73,33 +75,99 @@ func (l *logger) Panic(msg string, args ...any) {
 func (l *logger) Panicf(msg string, args ...any) {
 	fmsg := fmt.Sprintf(msg, args...)
 	l.logAttrs(context.Background(), LevelPanic, fmsg)
-	panic(fmsg)
+	panic(l.panicValue(fmsg, nil))
 }
 
 // PanicContext logs at [LevelPanic] and then panics.
 func (l *logger) PanicContext(ctx context.Context, msg string, args ...any) {
 	l.logAttrs(ctx, LevelPanic, msg, args...)
-	panic(msg)
+	panic(l.panicValue(msg, args))
 }
 
-// exit is a variable for [os.Exit].
-var exit = os.Exit
+// panicValue returns the value to panic with, the message or a [*PanicError] if [Options.PanicErrors] is set.
+func (l *logger) panicValue(msg string, args []any) any {
+	if !l.panicErrors {
+		return msg
+	}
+	return &PanicError{Message: msg, Attrs: append(l.Attrs(), argsToAttrs(args)...)}
+}
+
+// defaultExitCode is the exit code used by Fatal if none is configured.
+const defaultExitCode = 1
+
+// fatalConfig is the behavior of Fatal configured by [Options.ExitCode], [Options.OnFatal] and [Options.Exit].
+type fatalConfig struct {
+	code  int
+	hooks []func(ctx context.Context)
+	exit  func(code int)
+}
+
+// newFatalConfig returns the fatal config of the provided Options or nil if the default behavior is used.
+func newFatalConfig(o Options) *fatalConfig {
+	if o.ExitCode == 0 && len(o.OnFatal) == 0 && o.Exit == nil {
+		return nil
+	}
+	return &fatalConfig{code: o.ExitCode, hooks: o.OnFatal, exit: o.Exit}
+}
 
-// Fatal logs at [LevelFatal] and then calls os.Exit(1).
+// Fatal logs at [LevelFatal], calls the [Options.OnFatal] hooks and then exits with [Options.ExitCode] (default 1).
 func (l *logger) Fatal(msg string, args ...any) {
 	l.logAttrs(context.Background(), LevelFatal, msg, args...)
-	exit(1)
+	l.exit(context.Background())
 }
 
-// Fatalf logs at LevelFatal and then calls os.Exit(1).
+// Fatalf logs at LevelFatal, calls the [Options.OnFatal] hooks and then exits with [Options.ExitCode] (default 1).
 // Arguments are handled in the manner of [fmt.Printf].
 func (l *logger) Fatalf(msg string, args ...any) {
 	l.logAttrs(context.Background(), LevelFatal, fmt.Sprintf(msg, args...))
-	exit(1)
+	l.exit(context.Background())
 }
 
-// FatalContext logs at [LevelFatal] and then calls os.Exit(1).
+// FatalContext logs at [LevelFatal], calls the [Options.OnFatal] hooks and then exits with [Options.ExitCode] (default 1).
 func (l *logger) FatalContext(ctx context.Context, msg string, args ...any) {
 	l.logAttrs(ctx, LevelFatal, msg, args...)
-	exit(1)
+	l.exit(ctx)
+}
+
+// exit calls the fatal hooks and exits the program with the configured exit code.
+func (l *logger) exit(ctx context.Context) {
+	code, exitFunc := defaultExitCode, exit
+	if f := l.fatal; f != nil {
+		for _, hook := range f.hooks {
+			hook(ctx)
+		}
+		if f.code != 0 {
+			code = f.code
+		}
+		if f.exit != nil {
+			exitFunc = f.exit
+		}
+	}
+	exitFunc(code)
+}
+
+// handlerErrorKey is the key used for the error of the handler in the last-resort line.
+const handlerErrorKey = "handler_error"
+
+// lastResortWriter is the writer of the last-resort line written if a panic or fatal record could not be handled.
+var lastResortWriter io.Writer = os.Stderr
+
+// handle passes the record to the handler of the logger.
+// Panic and fatal records are the last records before the program exits, so if the handler fails to handle them,
+// a plain line with the record and the error is written to stderr as last resort instead of losing the record.
+func (l *logger) handle(ctx context.Context, r slog.Record) { //nolint:gocritic // records are passed by value
+	err := l.Handler().Handle(ctx, r)
+	if err == nil || Level(r.Level) < LevelPanic {
+		return
+	}
+
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	line := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	line.AddAttrs(nestScopes(l.scopes, attrs)...)
+	line.AddAttrs(slog.String(handlerErrorKey, err.Error()))
+	_ = slog.NewTextHandler(lastResortWriter, &slog.HandlerOptions{ReplaceAttr: replaceAttr}).Handle(ctx, line)
 }
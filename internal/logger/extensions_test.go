@@ -1,8 +1,13 @@
 package logger
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"log/slog"
+	"os"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/lvlcn-t/loggerhead/internal/logger/test"
@@ -335,6 +340,137 @@ func TestLogger_CustomLevels(t *testing.T) {
 	}
 }
 
+// hookKey is the context key used to test the context passed to the fatal hooks.
+type hookKey struct{}
+
+func TestLogger_FatalOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      func(calls *[]string) Options
+		log       func(l Provider)
+		wantCode  int
+		wantCalls []string
+	}{
+		{
+			name: "custom exit code",
+			opts: func(*[]string) Options {
+				return Options{ExitCode: 3}
+			},
+			log:      func(l Provider) { l.Fatal("test") },
+			wantCode: 3,
+		},
+		{
+			name: "hooks in order",
+			opts: func(calls *[]string) Options {
+				return Options{OnFatal: []func(context.Context){
+					func(context.Context) { *calls = append(*calls, "flush") },
+					func(context.Context) { *calls = append(*calls, "close") },
+				}}
+			},
+			log:       func(l Provider) { l.Fatalf("test %d", 1) },
+			wantCode:  1,
+			wantCalls: []string{"flush", "close"},
+		},
+		{
+			name: "hooks receive context and propagate through With",
+			opts: func(calls *[]string) Options {
+				return Options{ExitCode: 2, OnFatal: []func(context.Context){
+					func(ctx context.Context) { *calls = append(*calls, ctx.Value(hookKey{}).(string)) },
+				}}
+			},
+			log: func(l Provider) {
+				l.With("key", "value").WithGroup("group").FatalContext(context.WithValue(context.Background(), hookKey{}, "ctx"), "test")
+			},
+			wantCode:  2,
+			wantCalls: []string{"ctx"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			code := -1
+			opts := tt.opts(&calls)
+			opts.Handler = test.MockHandler{
+				HandleFunc: func(context.Context, slog.Record) error {
+					calls = append(calls, "log")
+					return nil
+				},
+			}
+			opts.Exit = func(c int) {
+				calls = append(calls, "exit")
+				code = c
+			}
+
+			tt.log(NewLogger(opts))
+			if code != tt.wantCode {
+				t.Errorf("Expected exit code %d, got %d", tt.wantCode, code)
+			}
+			want := append(append([]string{"log"}, tt.wantCalls...), "exit")
+			if !slices.Equal(calls, want) {
+				t.Errorf("Expected calls %v, got %v", want, calls)
+			}
+		})
+	}
+}
+
+func TestLogger_LastResort(t *testing.T) {
+	tests := []struct {
+		name     string
+		log      func(l Provider)
+		wantLine string
+	}{
+		{
+			name: "failed fatal record",
+			log: func(l Provider) {
+				l.With("service", "api").WithGroup("db").Fatal("connection lost", "host", "localhost")
+			},
+			wantLine: `level=FATAL msg="connection lost" service=api db.host=localhost handler_error="sink failed"`,
+		},
+		{
+			name: "failed panic record",
+			log: func(l Provider) {
+				defer func() { _ = recover() }()
+				l.Panic("invariant violated")
+			},
+			wantLine: `level=PANIC msg="invariant violated" handler_error="sink failed"`,
+		},
+		{
+			name:     "failed error record",
+			log:      func(l Provider) { l.Error("test") },
+			wantLine: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			lastResortWriter = buf
+			defer func() { lastResortWriter = os.Stderr }()
+
+			l := NewLogger(Options{
+				Handler: test.MockHandler{
+					HandleFunc: func(context.Context, slog.Record) error { return errors.New("sink failed") },
+				},
+				Exit: func(int) {},
+			})
+			tt.log(l)
+
+			got := buf.String()
+			if tt.wantLine == "" {
+				if got != "" {
+					t.Errorf("Expected no last-resort line, got %q", got)
+				}
+				return
+			}
+			// The time differs between runs.
+			if _, line, _ := strings.Cut(got, " "); line != tt.wantLine+"\n" {
+				t.Errorf("Expected last-resort line %q, got %q", tt.wantLine, got)
+			}
+		})
+	}
+}
+
 func assertRecordLevel(t *testing.T, r *slog.Record, level Level, wantAttrs bool) error {
 	t.Helper()
 	if r.Level != slog.Level(level) {
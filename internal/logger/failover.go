@@ -0,0 +1,171 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// defaultFailoverProbeInterval is the default [FailoverOptions.ProbeInterval].
+const defaultFailoverProbeInterval = 30 * time.Second
+
+// FailoverOptions is the optional configuration for [NewFailoverHandler].
+type FailoverOptions struct {
+	// Timeout is the time a handler may take to handle a record before the next handler is tried, see [NewTimeoutHandler].
+	// If zero, the handlers are not limited in time.
+	Timeout time.Duration
+	// ProbeInterval is the interval in which the handlers before the active one are tried again
+	// to recover from a failover. Defaults to 30 seconds.
+	ProbeInterval time.Duration
+	// OnSwitch is called with the indexes of the handlers if the active handler changed,
+	// either because it failed or because a preceding handler recovered.
+	OnSwitch func(from, to int)
+}
+
+// newFailoverOptions returns the provided FailoverOptions merged with the default FailoverOptions.
+func newFailoverOptions(o ...FailoverOptions) FailoverOptions {
+	opts := FailoverOptions{ProbeInterval: defaultFailoverProbeInterval}
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided FailoverOptions with the receiver FailoverOptions.
+func (o *FailoverOptions) merge(d FailoverOptions) FailoverOptions {
+	if o.Timeout > 0 {
+		d.Timeout = o.Timeout
+	}
+	if o.ProbeInterval > 0 {
+		d.ProbeInterval = o.ProbeInterval
+	}
+	if o.OnSwitch != nil {
+		d.OnSwitch = o.OnSwitch
+	}
+	return d
+}
+
+// failoverState is the state shared by a [FailoverHandler] and the handlers derived from it.
+type failoverState struct {
+	opts FailoverOptions
+	mu   sync.Mutex
+	// active is the index of the handler the records are passed to first.
+	active int
+	// probe is the time the handlers before the active one are tried again.
+	probe time.Time
+}
+
+var _ slog.Handler = (*FailoverHandler)(nil)
+
+// FailoverHandler is a [slog.Handler] passing records to the first working handler of a chain.
+type FailoverHandler struct {
+	handlers []slog.Handler
+	state    *failoverState
+}
+
+// NewFailoverHandler returns a new [FailoverHandler] that passes the records to the first of the given handlers,
+// e.g. a remote collector, and transparently falls back to the next ones, e.g. a local file and stderr,
+// if it returns an error or does not return within [FailoverOptions.Timeout].
+//
+// The records are passed to the first working handler until it fails as well. Every [FailoverOptions.ProbeInterval],
+// a record is tried with the preceding handlers again, so the chain recovers once the primary handler works again.
+// Handle returns an error only if all handlers failed.
+func NewFailoverHandler(handlers []slog.Handler, o ...FailoverOptions) *FailoverHandler {
+	opts := newFailoverOptions(o...)
+	hs := make([]slog.Handler, len(handlers))
+	for i, h := range handlers {
+		hs[i] = NewTimeoutHandler(h, opts.Timeout, nil)
+	}
+	return &FailoverHandler{handlers: hs, state: &failoverState{opts: opts}}
+}
+
+// Active returns the index of the handler the records are currently passed to.
+func (h *FailoverHandler) Active() int {
+	h.state.mu.Lock()
+	defer h.state.mu.Unlock()
+	return h.state.active
+}
+
+// Enabled reports whether the active handler handles records at the given level.
+func (h *FailoverHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if len(h.handlers) == 0 {
+		return false
+	}
+	return h.handlers[h.Active()].Enabled(ctx, level)
+}
+
+// Handle passes the record to the active handler and falls back to the following handlers if it fails.
+// If a probe is due, the record is passed to the handlers before the active one first.
+func (h *FailoverHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	active, start := h.state.begin()
+	var errs []error
+	for i := start; i < len(h.handlers); i++ {
+		if !h.handlers[i].Enabled(ctx, r.Level) {
+			continue
+		}
+		// Every handler gets its own copy, since a failed handler may have modified the record.
+		err := h.handlers[i].Handle(ctx, r.Clone())
+		if err == nil {
+			h.state.switchTo(active, i)
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *FailoverHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	hs := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		hs[i] = handler.WithAttrs(attrs)
+	}
+	return &FailoverHandler{handlers: hs, state: h.state}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *FailoverHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	hs := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		hs[i] = handler.WithGroup(name)
+	}
+	return &FailoverHandler{handlers: hs, state: h.state}
+}
+
+// begin returns the index of the active handler and of the handler a record is passed to first,
+// which precedes the active one if a probe is due.
+func (s *failoverState) begin() (active, start int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.active > 0 && !time.Now().Before(s.probe) {
+		s.probe = time.Now().Add(s.opts.ProbeInterval)
+		return s.active, 0
+	}
+	return s.active, s.active
+}
+
+// switchTo makes the handler with the given index the active one, unless another record switched it concurrently.
+func (s *failoverState) switchTo(from, to int) {
+	if from == to {
+		return
+	}
+	s.mu.Lock()
+	if s.active != from {
+		s.mu.Unlock()
+		return
+	}
+	s.active = to
+	if to > 0 {
+		s.probe = time.Now().Add(s.opts.ProbeInterval)
+	}
+	s.mu.Unlock()
+
+	if s.opts.OnSwitch != nil {
+		s.opts.OnSwitch(from, to)
+	}
+}
@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyHandler is a text handler failing while fail is set.
+type flakyHandler struct {
+	slog.Handler
+	fail  *atomic.Bool
+	delay time.Duration
+}
+
+func newFlakyHandler(buf *strings.Builder) *flakyHandler {
+	return &flakyHandler{Handler: slog.NewTextHandler(buf, nil), fail: &atomic.Bool{}}
+}
+
+func (h *flakyHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	if h.delay > 0 {
+		select {
+		case <-time.After(h.delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if h.fail.Load() {
+		return errors.New("sink down")
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *flakyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &flakyHandler{Handler: h.Handler.WithAttrs(attrs), fail: h.fail, delay: h.delay}
+}
+
+func TestFailoverHandler(t *testing.T) {
+	var primaryOut, secondaryOut strings.Builder
+	primary, secondary := newFlakyHandler(&primaryOut), newFlakyHandler(&secondaryOut)
+	var switches [][2]int
+	h := NewFailoverHandler([]slog.Handler{primary, secondary}, FailoverOptions{
+		ProbeInterval: 20 * time.Millisecond,
+		OnSwitch:      func(from, to int) { switches = append(switches, [2]int{from, to}) },
+	})
+	log := slog.New(h).With("service", "api")
+
+	log.Info("first")
+	primary.fail.Store(true)
+	log.Info("second")
+	log.Info("third")
+	if h.Active() != 1 {
+		t.Errorf("Expected the secondary handler to be active, got %d", h.Active())
+	}
+
+	primary.fail.Store(false)
+	log.Info("fourth")
+	time.Sleep(30 * time.Millisecond)
+	log.Info("fifth")
+	if h.Active() != 0 {
+		t.Errorf("Expected the primary handler to recover, got %d", h.Active())
+	}
+
+	for out, want := range map[*strings.Builder][]string{
+		&primaryOut:   {"msg=first service=api", "msg=fifth"},
+		&secondaryOut: {"msg=second service=api", "msg=third", "msg=fourth"},
+	} {
+		if got := strings.Count(out.String(), "\n"); got != len(want) {
+			t.Errorf("Expected %d records, got %s", len(want), out.String())
+		}
+		for _, w := range want {
+			if !strings.Contains(out.String(), w) {
+				t.Errorf("Expected %s, got %s", w, out.String())
+			}
+		}
+	}
+	if len(switches) != 2 || switches[0] != [2]int{0, 1} || switches[1] != [2]int{1, 0} {
+		t.Errorf("Expected a failover and a recovery, got %v", switches)
+	}
+}
+
+func TestFailoverHandler_Timeout(t *testing.T) {
+	var primaryOut, secondaryOut strings.Builder
+	primary, secondary := newFlakyHandler(&primaryOut), newFlakyHandler(&secondaryOut)
+	primary.delay = time.Second
+	h := NewFailoverHandler([]slog.Handler{primary, secondary}, FailoverOptions{Timeout: 10 * time.Millisecond})
+
+	if err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "slow", 0)); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if !strings.Contains(secondaryOut.String(), "msg=slow") || h.Active() != 1 {
+		t.Errorf("Expected the record to fail over after the timeout, got %q", secondaryOut.String())
+	}
+}
+
+func TestFailoverHandler_AllFailed(t *testing.T) {
+	var out strings.Builder
+	primary, secondary := newFlakyHandler(&out), newFlakyHandler(&out)
+	primary.fail.Store(true)
+	secondary.fail.Store(true)
+	h := NewFailoverHandler([]slog.Handler{primary, secondary})
+
+	err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "lost", 0))
+	if err == nil || strings.Count(err.Error(), "sink down") != 2 {
+		t.Errorf("Expected the errors of both handlers, got %v", err)
+	}
+	if h.Active() != 0 {
+		t.Errorf("Expected the primary handler to stay active, got %d", h.Active())
+	}
+}
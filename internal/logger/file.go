@@ -0,0 +1,262 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+// WritableFile is a file opened by a [WritableFS].
+type WritableFile interface {
+	io.WriteCloser
+	// Stat returns the [fs.FileInfo] describing the file.
+	Stat() (fs.FileInfo, error)
+}
+
+// WritableFS is a writable file system used by a [FileSink].
+// It allows to use the rotation logic without a real file system, e.g. in tests or on platforms
+// like WASM or read-only roots with an in-memory file system.
+type WritableFS interface {
+	// OpenFile opens the named file with the given flags (os.O_APPEND etc.) and permissions.
+	OpenFile(name string, flag int, perm fs.FileMode) (WritableFile, error)
+	// Rename renames (moves) oldpath to newpath.
+	Rename(oldpath, newpath string) error
+	// Remove removes the named file.
+	Remove(name string) error
+}
+
+// OSFS is the [WritableFS] of the operating system.
+type OSFS struct{}
+
+// OpenFile calls [os.OpenFile].
+func (OSFS) OpenFile(name string, flag int, perm fs.FileMode) (WritableFile, error) {
+	return os.OpenFile(name, flag, perm) //nolint:gosec // the path is provided by the user
+}
+
+// Rename calls [os.Rename].
+func (OSFS) Rename(oldpath, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
+
+// Remove calls [os.Remove].
+func (OSFS) Remove(name string) error {
+	return os.Remove(name)
+}
+
+// FileSinkOptions is the configuration for a [FileSink].
+type FileSinkOptions struct {
+	// Path is the path of the log file.
+	Path string
+	// MaxSize is the maximum size of the log file in bytes before it is rotated.
+	// Zero disables rotation.
+	MaxSize int64
+	// MaxBackups is the maximum number of rotated files to keep as Path.1, Path.2 and so on,
+	// the newest being Path.1. Zero discards the file on rotation.
+	MaxBackups int
+	// FS is the file system the log file is written to. Defaults to [OSFS].
+	FS WritableFS
+	// OnError is called with the errors of failed rotations, which cannot be returned to the caller.
+	// The records are written to the current file until a later write rotates it successfully.
+	OnError func(err error)
+}
+
+// newFileSinkOptions returns the FileSinkOptions with the defaults applied.
+func newFileSinkOptions(o FileSinkOptions) FileSinkOptions {
+	if o.FS == nil {
+		o.FS = OSFS{}
+	}
+	return o
+}
+
+// filePerm is the permission of created log files.
+const filePerm fs.FileMode = 0o600
+
+var (
+	// fileSinksMu guards fileSinks.
+	fileSinksMu sync.Mutex
+	// fileSinks are the open FileSinks reopened by [ReopenFiles].
+	fileSinks = map[*FileSink]struct{}{}
+)
+
+var _ io.WriteCloser = (*FileSink)(nil)
+
+// FileSink is an [io.WriteCloser] writing to a log file that is rotated once it exceeds a maximum size.
+// It is safe for concurrent use.
+type FileSink struct {
+	opts FileSinkOptions
+	mu   sync.Mutex
+	file WritableFile
+	size int64
+	// rotated reports whether the log file was moved away by a rotation, but a new one could not be opened yet.
+	rotated bool
+}
+
+// NewFileSink opens the log file of the given options for appending, creating it if necessary.
+//
+// Example:
+//
+//	sink, err := logger.NewFileSink(logger.FileSinkOptions{Path: "app.log", MaxSize: 10 << 20, MaxBackups: 3})
+//	if err != nil {
+//		return err
+//	}
+//	defer sink.Close()
+//	log := logger.NewLogger(logger.Options{Handler: slog.NewJSONHandler(sink, nil)})
+func NewFileSink(o FileSinkOptions) (*FileSink, error) {
+	s := &FileSink{opts: newFileSinkOptions(o)}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+
+	fileSinksMu.Lock()
+	defer fileSinksMu.Unlock()
+	fileSinks[s] = struct{}{}
+	return s, nil
+}
+
+// ReopenFiles reopens the log files of all open [FileSink]s, see [FileSink.Reopen].
+// It is called on SIGHUP by [HandleSignals].
+func ReopenFiles() error {
+	fileSinksMu.Lock()
+	sinks := make([]*FileSink, 0, len(fileSinks))
+	for s := range fileSinks {
+		sinks = append(sinks, s)
+	}
+	fileSinksMu.Unlock()
+
+	var errs []error
+	for _, s := range sinks {
+		errs = append(errs, s.Reopen())
+	}
+	return errors.Join(errs...)
+}
+
+// Write writes p to the log file, rotating it first if p would exceed the maximum size.
+// If the rotation fails, p is written to the current log file and the rotation is retried with the next write.
+func (s *FileSink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return 0, fs.ErrClosed
+	}
+	if s.rotated || s.opts.MaxSize > 0 && s.size > 0 && s.size+int64(len(p)) > s.opts.MaxSize {
+		if err := s.rotate(); err != nil && s.opts.OnError != nil {
+			s.opts.OnError(err)
+		}
+	}
+	n, err := s.file.Write(p)
+	s.size += int64(n)
+	return n, err
+}
+
+// Close closes the log file.
+func (s *FileSink) Close() error {
+	fileSinksMu.Lock()
+	delete(fileSinks, s)
+	fileSinksMu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// Reopen closes the log file and opens the file at the path again, creating it if necessary.
+// This lets external tools like logrotate move the file away and signal the program to write to a new one.
+// Returns [fs.ErrClosed] if the sink is closed.
+func (s *FileSink) Reopen() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return fs.ErrClosed
+	}
+	return s.replace()
+}
+
+// open opens the log file for appending and reads its current size.
+func (s *FileSink) open() error {
+	f, size, err := s.openFile()
+	if err != nil {
+		return err
+	}
+	s.file, s.size = f, size
+	return nil
+}
+
+// openFile opens the log file for appending and returns it with its current size.
+func (s *FileSink) openFile() (WritableFile, int64, error) {
+	f, err := s.opts.FS.OpenFile(s.opts.Path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, filePerm)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return nil, 0, errors.Join(fmt.Errorf("failed to stat log file: %w", err), f.Close())
+	}
+	return f, info.Size(), nil
+}
+
+// replace opens the log file at the path and closes the current one once the new one is open,
+// so the current one is kept if the new one cannot be opened.
+func (s *FileSink) replace() error {
+	f, size, err := s.openFile()
+	if err != nil {
+		return err
+	}
+	old := s.file
+	s.file, s.size, s.rotated = f, size, false
+	if err = old.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	return nil
+}
+
+// rotate shifts the backups, moves the log file away and opens a new log file.
+// The current log file is kept open until the new one is opened, so records can still be written to it
+// if the rotation fails. If the log file was moved away before, only the new one is opened.
+func (s *FileSink) rotate() error {
+	if !s.rotated {
+		if err := s.moveAway(); err != nil {
+			return err
+		}
+		s.rotated = true
+	}
+	return s.replace()
+}
+
+// moveAway shifts the backups and renames the log file to the first backup or removes it if no backups are kept.
+func (s *FileSink) moveAway() error {
+	if s.opts.MaxBackups <= 0 {
+		if err := s.opts.FS.Remove(s.opts.Path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to remove log file: %w", err)
+		}
+		return nil
+	}
+
+	if err := s.opts.FS.Remove(s.backup(s.opts.MaxBackups)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove oldest backup: %w", err)
+	}
+	for i := s.opts.MaxBackups - 1; i > 0; i-- {
+		if err := s.opts.FS.Rename(s.backup(i), s.backup(i+1)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to shift backup: %w", err)
+		}
+	}
+	if err := s.opts.FS.Rename(s.opts.Path, s.backup(1)); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	return nil
+}
+
+// backup returns the path of the n-th backup.
+func (s *FileSink) backup(n int) string {
+	return fmt.Sprintf("%s.%d", s.opts.Path, n)
+}
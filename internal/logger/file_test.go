@@ -0,0 +1,231 @@
+package logger
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// memFS is an in-memory [WritableFS] backed by a [fstest.MapFS].
+type memFS struct {
+	files fstest.MapFS
+	// failOpen is the error returned by OpenFile, if set.
+	failOpen error
+}
+
+// memFile is a file of a [memFS]. It keeps writing to the same file if it is renamed or removed.
+type memFile struct {
+	fsys *memFS
+	name string
+	file *fstest.MapFile
+}
+
+func (m *memFS) OpenFile(name string, flag int, _ fs.FileMode) (WritableFile, error) {
+	if m.failOpen != nil {
+		return nil, m.failOpen
+	}
+	if _, ok := m.files[name]; !ok {
+		if flag&os.O_CREATE == 0 {
+			return nil, fs.ErrNotExist
+		}
+		m.files[name] = &fstest.MapFile{}
+	}
+	return &memFile{fsys: m, name: name, file: m.files[name]}, nil
+}
+
+func (m *memFS) Rename(oldpath, newpath string) error {
+	f, ok := m.files[oldpath]
+	if !ok {
+		return fs.ErrNotExist
+	}
+	m.files[newpath] = f
+	delete(m.files, oldpath)
+	return nil
+}
+
+func (m *memFS) Remove(name string) error {
+	if _, ok := m.files[name]; !ok {
+		return fs.ErrNotExist
+	}
+	delete(m.files, name)
+	return nil
+}
+
+func (f *memFile) Write(p []byte) (int, error) {
+	f.file.Data = append(f.file.Data, p...)
+	return len(p), nil
+}
+
+func (f *memFile) Close() error { return nil }
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f.fsys.files.Stat(f.name) }
+
+func TestFileSink(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxSize    int64
+		maxBackups int
+		writes     []string
+		want       map[string]string
+	}{
+		{
+			name:   "no rotation",
+			writes: []string{"a\n", "b\n", "c\n"},
+			want:   map[string]string{"app.log": "a\nb\nc\n"},
+		},
+		{
+			name:       "rotates and keeps backups",
+			maxSize:    4,
+			maxBackups: 2,
+			writes:     []string{"a\n", "b\n", "c\n", "d\n", "e\n", "f\n", "g\n", "h\n"},
+			want:       map[string]string{"app.log": "g\nh\n", "app.log.1": "e\nf\n", "app.log.2": "c\nd\n"},
+		},
+		{
+			name:       "discards without backups",
+			maxSize:    4,
+			maxBackups: 0,
+			writes:     []string{"a\n", "b\n", "c\n"},
+			want:       map[string]string{"app.log": "c\n"},
+		},
+		{
+			name:       "oversized write is not split",
+			maxSize:    2,
+			maxBackups: 1,
+			writes:     []string{"long\n", "x\n"},
+			want:       map[string]string{"app.log": "x\n", "app.log.1": "long\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := &memFS{files: fstest.MapFS{}}
+			sink, err := NewFileSink(FileSinkOptions{Path: "app.log", MaxSize: tt.maxSize, MaxBackups: tt.maxBackups, FS: fsys})
+			if err != nil {
+				t.Fatalf("NewFileSink() error = %v", err)
+			}
+			for _, w := range tt.writes {
+				if _, err := sink.Write([]byte(w)); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+			}
+			if err := sink.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			if len(fsys.files) != len(tt.want) {
+				t.Errorf("Expected files %v, got %v", tt.want, fsys.files)
+			}
+			for name, want := range tt.want {
+				data, err := fs.ReadFile(fsys.files, name)
+				if err != nil {
+					t.Fatalf("Failed to read %s: %v", name, err)
+				}
+				if string(data) != want {
+					t.Errorf("Expected %s to contain %q, got %q", name, want, data)
+				}
+			}
+			if _, err := sink.Write([]byte("closed")); err == nil {
+				t.Error("Expected an error writing to a closed sink")
+			}
+		})
+	}
+}
+
+func TestFileSink_RotationFails(t *testing.T) {
+	errOpen := errors.New("too many open files")
+	fsys := &memFS{files: fstest.MapFS{}}
+	var reported []error
+	sink, err := NewFileSink(FileSinkOptions{
+		Path:       "app.log",
+		MaxSize:    4,
+		MaxBackups: 1,
+		FS:         fsys,
+		OnError:    func(err error) { reported = append(reported, err) },
+	})
+	if err != nil {
+		t.Fatalf("NewFileSink() error = %v", err)
+	}
+	defer func() { _ = sink.Close() }()
+
+	fsys.failOpen = errOpen
+	for _, w := range []string{"a\n", "b\n", "c\n", "d\n"} {
+		if _, err = sink.Write([]byte(w)); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if len(reported) != 2 || !errors.Is(reported[0], errOpen) {
+		t.Errorf("Expected the failed rotations to be reported, got %v", reported)
+	}
+
+	fsys.failOpen = nil
+	if _, err = sink.Write([]byte("e\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	for name, want := range map[string]string{"app.log": "e\n", "app.log.1": "a\nb\nc\nd\n"} {
+		if data, _ := fs.ReadFile(fsys.files, name); string(data) != want {
+			t.Errorf("Expected %s to contain %q, got %q", name, want, data)
+		}
+	}
+}
+
+func TestFileSink_OSFS(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte("existing\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	sink, err := NewFileSink(FileSinkOptions{Path: path, MaxSize: 16, MaxBackups: 1})
+	if err != nil {
+		t.Fatalf("NewFileSink() error = %v", err)
+	}
+	defer func() { _ = sink.Close() }()
+	if _, err := sink.Write([]byte("rotated\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	backup, err := os.ReadFile(path + ".1")
+	if err != nil {
+		t.Fatalf("Failed to read backup: %v", err)
+	}
+	if !strings.Contains(string(backup), "existing") {
+		t.Errorf("Expected the existing content to be rotated, got %q", backup)
+	}
+}
+
+func TestFileSink_Reopen(t *testing.T) {
+	fsys := &memFS{files: fstest.MapFS{}}
+	sink, err := NewFileSink(FileSinkOptions{Path: "app.log", FS: fsys})
+	if err != nil {
+		t.Fatalf("NewFileSink() error = %v", err)
+	}
+	if _, err = sink.Write([]byte("before\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	// Move the file away like logrotate does.
+	if err = fsys.Rename("app.log", "app.log.1"); err != nil {
+		t.Fatal(err)
+	}
+	// The sinks left open by other tests may fail to reopen, because their directories were removed.
+	_ = ReopenFiles()
+	if _, err = sink.Write([]byte("after\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	for name, want := range map[string]string{"app.log": "after\n", "app.log.1": "before\n"} {
+		if data, _ := fs.ReadFile(fsys.files, name); string(data) != want {
+			t.Errorf("Expected %s to contain %q, got %q", name, want, data)
+		}
+	}
+
+	if err = sink.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err = sink.Reopen(); !errors.Is(err, fs.ErrClosed) {
+		t.Errorf("Reopen() error = %v, want %v", err, fs.ErrClosed)
+	}
+}
@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+)
+
+// RecordPredicate reports whether a record matches, e.g. to filter records with [NewFilterHandler].
+type RecordPredicate func(ctx context.Context, r slog.Record) bool
+
+// MatchLevel returns a [RecordPredicate] matching the records at the given level or above.
+func MatchLevel(level Level) RecordPredicate {
+	return func(_ context.Context, r slog.Record) bool {
+		return Level(r.Level) >= level
+	}
+}
+
+// MatchAttr returns a [RecordPredicate] matching the records with the top-level attribute key
+// whose value equals the given value, compared by its string representation. Any value matches if nil.
+//
+// Only the attributes of the record are matched, not those added by [Provider.With].
+func MatchAttr(key string, value any) RecordPredicate {
+	want := fmt.Sprint(value)
+	return func(_ context.Context, r slog.Record) bool {
+		found := false
+		r.Attrs(func(a slog.Attr) bool {
+			found = a.Key == key && (value == nil || a.Value.Resolve().String() == want)
+			return !found
+		})
+		return found
+	}
+}
+
+// MatchMessageRegexp returns a [RecordPredicate] matching the records whose message matches the regular expression.
+func MatchMessageRegexp(re *regexp.Regexp) RecordPredicate {
+	return func(_ context.Context, r slog.Record) bool {
+		return re.MatchString(r.Message)
+	}
+}
+
+// Not returns a [RecordPredicate] matching the records the given predicate does not match.
+func Not(p RecordPredicate) RecordPredicate {
+	return func(ctx context.Context, r slog.Record) bool {
+		return !p(ctx, r)
+	}
+}
+
+var _ slog.Handler = (*filterHandler)(nil)
+
+// filterHandler is a [slog.Handler] passing only the records matching a predicate to the wrapped handler.
+type filterHandler struct {
+	slog.Handler
+	keep RecordPredicate
+}
+
+// NewFilterHandler returns a new [slog.Handler] that passes only the records matching the given predicate
+// to the given handler and drops the others, e.g. to suppress noisy health check access logs:
+//
+//	h := NewFilterHandler(h, Not(MatchAttr("path", "/healthz")))
+func NewFilterHandler(h slog.Handler, keep RecordPredicate) slog.Handler {
+	return &filterHandler{Handler: h, keep: keep}
+}
+
+// Handle passes the record to the wrapped handler if it matches the predicate.
+func (h *filterHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	if !h.keep(ctx, r) {
+		return nil
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *filterHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &filterHandler{Handler: h.Handler.WithAttrs(attrs), keep: h.keep}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *filterHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &filterHandler{Handler: h.Handler.WithGroup(name), keep: h.keep}
+}
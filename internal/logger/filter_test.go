@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordPredicates(t *testing.T) {
+	r := slog.NewRecord(time.Now(), slog.LevelWarn, "GET /healthz", 0)
+	r.AddAttrs(slog.String("path", "/healthz"), slog.Int("status", 200))
+
+	tests := []struct {
+		name string
+		p    RecordPredicate
+		want bool
+	}{
+		{name: "level at minimum", p: MatchLevel(LevelWarn), want: true},
+		{name: "level below minimum", p: MatchLevel(LevelError), want: false},
+		{name: "attr value", p: MatchAttr("path", "/healthz"), want: true},
+		{name: "attr non-string value", p: MatchAttr("status", 200), want: true},
+		{name: "attr other value", p: MatchAttr("path", "/orders"), want: false},
+		{name: "attr any value", p: MatchAttr("status", nil), want: true},
+		{name: "attr missing", p: MatchAttr("user", nil), want: false},
+		{name: "message", p: MatchMessageRegexp(regexp.MustCompile(`^GET /health`)), want: true},
+		{name: "message mismatch", p: MatchMessageRegexp(regexp.MustCompile(`POST`)), want: false},
+		{name: "not", p: Not(MatchLevel(LevelError)), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p(context.Background(), r); got != tt.want {
+				t.Errorf("predicate = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterHandler(t *testing.T) {
+	var buf strings.Builder
+	h := NewFilterHandler(slog.NewTextHandler(&buf, nil), Not(MatchAttr("path", "/healthz")))
+	log := slog.New(h).With("service", "api").WithGroup("http")
+
+	log.Info("request", "path", "/healthz")
+	log.Info("request", "path", "/orders")
+	if got := buf.String(); strings.Count(got, "\n") != 1 || !strings.Contains(got, "service=api http.path=/orders") {
+		t.Errorf("Expected only the orders request, got %s", got)
+	}
+}
@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"flag"
+	"fmt"
+)
+
+// RegisterFlags adds the --log-level, --log-format and --log-output flags setting the [Config.Level],
+// [Config.Format] and [Config.Output] to the flag set, so CLIs get consistent logging flags.
+// The current values of the configuration are the defaults of the flags, e.g. the values read by [LoadConfig].
+// Unset settings default to INFO, JSON and stderr like the [Config].
+// Invalid levels and formats are rejected when the flags are parsed.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.Var(&configFlag{value: &c.Level, validate: validateLevelFlag}, "log-level",
+		"the minimum `level` of the logs: TRACE, DEBUG, INFO, NOTICE, WARN or ERROR")
+	fs.Var(&configFlag{value: &c.Format, validate: validateFormatFlag}, "log-format",
+		"the `format` of the logs: JSON, TEXT or DEV")
+	fs.Var(&configFlag{value: &c.Output}, "log-output",
+		"the `destination` of the logs: stderr, stdout, discard, a file path or a URI like tcp://host:514")
+}
+
+// configFlag is a [flag.Value] setting a string of a [Config] after validating it.
+type configFlag struct {
+	value    *string
+	validate func(string) error
+}
+
+// String returns the current value.
+func (f *configFlag) String() string {
+	if f.value == nil {
+		return ""
+	}
+	return *f.value
+}
+
+// Set validates and sets the value.
+func (f *configFlag) Set(s string) error {
+	if f.validate != nil {
+		if err := f.validate(s); err != nil {
+			return err
+		}
+	}
+	*f.value = s
+	return nil
+}
+
+// validateLevelFlag returns an error if the level is unknown.
+func validateLevelFlag(level string) error {
+	_, err := parseLevel(level)
+	return err
+}
+
+// validateFormatFlag returns an error if the format is not supported by [Config].
+func validateFormatFlag(format string) error {
+	if !isConfigFormat(format) {
+		return fmt.Errorf("unknown format %q", format)
+	}
+	return nil
+}
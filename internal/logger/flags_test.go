@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"flag"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfig_RegisterFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		args    []string
+		want    Config
+		wantErr bool
+	}{
+		{
+			name: "flags set",
+			args: []string{"--log-level=debug", "--log-format", "TEXT", "-log-output=/var/log/app.log"},
+			want: Config{Level: "debug", Format: "TEXT", Output: "/var/log/app.log"},
+		},
+		{
+			name: "defaults kept",
+			cfg:  Config{Level: "WARN", Output: "stdout", AddSource: true},
+			args: []string{"--log-format=DEV"},
+			want: Config{Level: "WARN", Format: "DEV", Output: "stdout", AddSource: true},
+		},
+		{
+			name:    "unknown level",
+			args:    []string{"--log-level=LOUD"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown format",
+			args:    []string{"--log-format=XML"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			cfg := tt.cfg
+			cfg.RegisterFlags(fs)
+
+			err := fs.Parse(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(cfg, tt.want) {
+				t.Errorf("Expected config %+v, got %+v", tt.want, cfg)
+			}
+		})
+	}
+}
+
+func TestConfig_RegisterFlags_Usage(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var buf strings.Builder
+	fs.SetOutput(&buf)
+	cfg := Config{Level: "WARN"}
+	cfg.RegisterFlags(fs)
+	fs.PrintDefaults()
+
+	for _, want := range []string{"-log-level level", "(default WARN)", "-log-format format", "-log-output destination"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected %q in the usage, got %s", want, buf.String())
+		}
+	}
+}
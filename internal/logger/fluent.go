@@ -0,0 +1,328 @@
+package logger
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultFluentAddress is the default [FluentOptions.Address], the default forward input of Fluentd and Fluent Bit.
+	DefaultFluentAddress = "localhost:24224"
+	// defaultFluentTimeout is the default [FluentOptions.Timeout].
+	defaultFluentTimeout = 5 * time.Second
+)
+
+// ErrFluentAck is returned by a [FluentHandler] if the acknowledgment of a message does not match its chunk ID.
+var ErrFluentAck = errors.New("fluent ack does not match the chunk")
+
+// FluentOptions is the optional configuration for [NewFluentHandler].
+type FluentOptions struct {
+	// Level is the minimum log level.
+	Level Level
+	// Network is the network of the forward input: "tcp" or "unix". Defaults to "tcp".
+	Network string
+	// Address is the address of the forward input. Defaults to [DefaultFluentAddress].
+	Address string
+	// Tag is the tag of the events, which is used by Fluentd to route them. Defaults to the name of the executable.
+	Tag string
+	// RequireAck is a flag to wait for the acknowledgment of every message by the server,
+	// so Handle only returns nil once the event is received. The delivery is reported to the callers of [Ack] as well.
+	RequireAck bool
+	// Timeout is the timeout of writing a message and of waiting for its acknowledgment. Defaults to 5 seconds.
+	Timeout time.Duration
+}
+
+// newFluentOptions returns the provided FluentOptions merged with the default FluentOptions.
+func newFluentOptions(o ...FluentOptions) FluentOptions {
+	opts := FluentOptions{
+		Level:   LevelInfo,
+		Network: "tcp",
+		Address: DefaultFluentAddress,
+		Tag:     filepath.Base(os.Args[0]),
+		Timeout: defaultFluentTimeout,
+	}
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided FluentOptions with the receiver FluentOptions.
+func (o *FluentOptions) merge(d FluentOptions) FluentOptions {
+	if o.Level != 0 {
+		d.Level = o.Level
+	}
+	for _, f := range []struct{ src, dst *string }{
+		{&o.Network, &d.Network},
+		{&o.Address, &d.Address},
+		{&o.Tag, &d.Tag},
+	} {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+	if o.RequireAck {
+		d.RequireAck = true
+	}
+	if o.Timeout > 0 {
+		d.Timeout = o.Timeout
+	}
+	return d
+}
+
+// fluentConn is the connection to the forward input shared by a [FluentHandler] and the handlers derived from it.
+type fluentConn struct {
+	mu     sync.Mutex
+	opts   FluentOptions
+	conn   net.Conn
+	reader *bufio.Reader
+	closed bool
+}
+
+var _ slog.Handler = (*FluentHandler)(nil)
+
+// FluentHandler is a [slog.Handler] writing records as events to Fluentd or Fluent Bit via the forward protocol.
+type FluentHandler struct {
+	opts FluentOptions
+	conn *fluentConn
+	// scopes are the root scope and the groups of the handler.
+	scopes []scope
+}
+
+// NewFluentHandler returns a new [FluentHandler] writing records as events to the forward input of Fluentd
+// or Fluent Bit. Every record is sent as MessagePack encoded message with the [FluentOptions.Tag],
+// the time of the record and a map with the level, the message, the source and the attributes, with groups as nested maps.
+//
+// If [FluentOptions.RequireAck] is set, every message carries a chunk ID and Handle waits until the server acknowledged it,
+// which requires require_ack_response to be enabled for the forward output of an aggregator forwarding the events further.
+//
+// It returns an error if the connection cannot be established. Close the handler to close the connection.
+func NewFluentHandler(o ...FluentOptions) (*FluentHandler, error) {
+	opts := newFluentOptions(o...)
+	c := &fluentConn{opts: opts}
+	if err := c.dial(); err != nil {
+		return nil, err
+	}
+	return &FluentHandler{opts: opts, conn: c, scopes: []scope{{}}}, nil
+}
+
+// Enabled reports whether the handler handles records at the given level.
+func (h *FluentHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= slog.Level(h.opts.Level)
+}
+
+// Handle writes the record as event. If acknowledgments are required, it waits until the event is acknowledged.
+func (h *FluentHandler) Handle(_ context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+
+	record := []slog.Attr{slog.String(slog.LevelKey, Level(r.Level).String()), slog.String(slog.MessageKey, r.Message)}
+	if r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		record = append(record, slog.Group(slog.SourceKey,
+			slog.String("function", frame.Function),
+			slog.String("file", frame.File),
+			slog.Int("line", frame.Line),
+		))
+	}
+	record = append(record, nestScopes(h.scopes, attrs)...)
+
+	t := r.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+	err := h.conn.write(t, record)
+	if h.opts.RequireAck {
+		acknowledgeRecord(r, err)
+	}
+	return err
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *FluentHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return &FluentHandler{opts: h.opts, conn: h.conn, scopes: withScopeAttrs(h.scopes, attrs)}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *FluentHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &FluentHandler{opts: h.opts, conn: h.conn, scopes: withScope(h.scopes, name)}
+}
+
+// Close closes the connection to the forward input. Records handled afterwards return [ErrHandlerClosed].
+// It closes the connection of the handlers derived by WithAttrs and WithGroup as well.
+func (h *FluentHandler) Close() error {
+	h.conn.mu.Lock()
+	defer h.conn.mu.Unlock()
+	h.conn.closed = true
+	if h.conn.conn == nil {
+		return nil
+	}
+	err := h.conn.conn.Close()
+	h.conn.conn = nil
+	return err
+}
+
+// appendFluentMap appends the attributes as MessagePack map to b.
+// Groups are encoded as nested maps, groups with an empty key are inlined and empty groups are omitted.
+func appendFluentMap(b []byte, attrs []slog.Attr) []byte {
+	attrs = fluentAttrs(attrs)
+	b = appendMsgpackMapHeader(b, len(attrs))
+	for _, a := range attrs {
+		b = appendMsgpackString(b, a.Key)
+		b = appendFluentValue(b, a.Value)
+	}
+	return b
+}
+
+// fluentAttrs returns the resolved attributes with groups with an empty key inlined
+// and attributes with an empty key and empty groups removed.
+func fluentAttrs(attrs []slog.Attr) []slog.Attr {
+	out := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		a.Value = a.Value.Resolve()
+		if a.Value.Kind() == slog.KindGroup {
+			group := fluentAttrs(a.Value.Group())
+			if a.Key == "" {
+				out = append(out, group...)
+				continue
+			}
+			if len(group) == 0 {
+				continue
+			}
+			a.Value = slog.GroupValue(group...)
+		}
+		if a.Key != "" {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// appendFluentValue appends the value as MessagePack value to b.
+// Durations are encoded as nanoseconds and times as RFC 3339 strings, like by the JSON handler.
+func appendFluentValue(b []byte, v slog.Value) []byte {
+	switch v.Kind() {
+	case slog.KindString:
+		return appendMsgpackString(b, v.String())
+	case slog.KindInt64:
+		return appendMsgpackInt(b, v.Int64())
+	case slog.KindUint64:
+		return appendMsgpackUint(b, v.Uint64())
+	case slog.KindFloat64:
+		return appendMsgpackFloat(b, v.Float64())
+	case slog.KindBool:
+		return appendMsgpackBool(b, v.Bool())
+	case slog.KindDuration:
+		return appendMsgpackInt(b, int64(v.Duration()))
+	case slog.KindTime:
+		return appendMsgpackString(b, v.Time().Format(time.RFC3339Nano))
+	case slog.KindGroup:
+		return appendFluentMap(b, v.Group())
+	default:
+		switch x := v.Any().(type) {
+		case nil:
+			return appendMsgpackNil(b)
+		case error:
+			return appendMsgpackString(b, x.Error())
+		default:
+			return appendMsgpackString(b, v.String())
+		}
+	}
+}
+
+// dial connects to the forward input. Must be called with the lock held or before the connection is shared.
+func (c *fluentConn) dial() error {
+	conn, err := net.DialTimeout(c.opts.Network, c.opts.Address, c.opts.Timeout)
+	if err != nil {
+		return fmt.Errorf("failed to connect to fluent: %w", err)
+	}
+	c.conn, c.reader = conn, bufio.NewReader(conn)
+	return nil
+}
+
+// write sends the event and waits for its acknowledgment if required.
+// If the write or the acknowledgment fails, the connection is re-established and the event is sent once more.
+func (c *fluentConn) write(t time.Time, record []slog.Attr) error {
+	// The message mode of the forward protocol: [tag, time, record, option].
+	var chunk string
+	fields := 3 //nolint:mnd // tag, time and record
+	if c.opts.RequireAck {
+		fields++
+	}
+	msg := appendMsgpackArrayHeader(nil, fields)
+	msg = appendMsgpackString(msg, c.opts.Tag)
+	msg = appendMsgpackEventTime(msg, t)
+	msg = appendFluentMap(msg, record)
+	if c.opts.RequireAck {
+		id := make([]byte, 16)                            //nolint:mnd // 128 bit chunk ID
+		binary.BigEndian.PutUint64(id, rand.Uint64())     //nolint:gosec // chunk IDs only need to be unique
+		binary.BigEndian.PutUint64(id[8:], rand.Uint64()) //nolint:gosec // chunk IDs only need to be unique
+		chunk = base64.StdEncoding.EncodeToString(id)
+		msg = appendMsgpackMapHeader(msg, 1)
+		msg = appendMsgpackString(msg, "chunk")
+		msg = appendMsgpackString(msg, chunk)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return ErrHandlerClosed
+	}
+	if c.conn != nil {
+		err := c.send(msg, chunk)
+		if err == nil {
+			return nil
+		}
+		_ = c.conn.Close()
+		c.conn = nil
+		if errors.Is(err, ErrFluentAck) {
+			// The event was received, but the stream is out of sync, so it is not sent again.
+			return err
+		}
+	}
+	if err := c.dial(); err != nil {
+		return err
+	}
+	return c.send(msg, chunk)
+}
+
+// send writes the message and, if the chunk is not empty, waits for its acknowledgment. Must be called with the lock held.
+func (c *fluentConn) send(msg []byte, chunk string) error {
+	_ = c.conn.SetDeadline(time.Now().Add(c.opts.Timeout))
+	if _, err := c.conn.Write(msg); err != nil {
+		return err
+	}
+	if chunk == "" {
+		return nil
+	}
+
+	resp, err := readMsgpackStringMap(c.reader)
+	if err != nil {
+		return fmt.Errorf("failed to read fluent ack: %w", err)
+	}
+	if resp["ack"] != chunk {
+		return fmt.Errorf("%w: got %q, want %q", ErrFluentAck, resp["ack"], chunk)
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+package logger
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// decodeMsgpack decodes the next MessagePack value of r into nil, bool, int64, uint64, float64, string,
+// []any, map[string]any or time.Time for EventTime extensions.
+func decodeMsgpack(r *bufio.Reader) (any, error) {
+	c, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	read := func(n int) ([]byte, error) {
+		b := make([]byte, n)
+		_, err := io.ReadFull(r, b)
+		return b, err
+	}
+	length := func(size int) (int, error) {
+		v, err := readMsgpackUint(r, size)
+		return int(v), err //nolint:gosec // test data
+	}
+
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil //nolint:gosec // negative fixint
+	case c&0xe0 == 0xa0, c == msgpackStr8, c == msgpackStr16, c == msgpackStr32:
+		_ = r.UnreadByte()
+		return readMsgpackString(r)
+	case c&0xf0 == 0x90, c == msgpackArray16:
+		n := int(c & 0x0f)
+		if c == msgpackArray16 {
+			if n, err = length(2); err != nil {
+				return nil, err
+			}
+		}
+		arr := make([]any, n)
+		for i := range arr {
+			if arr[i], err = decodeMsgpack(r); err != nil {
+				return nil, err
+			}
+		}
+		return arr, nil
+	case c&0xf0 == 0x80, c == msgpackMap16:
+		n := int(c & 0x0f)
+		if c == msgpackMap16 {
+			if n, err = length(2); err != nil {
+				return nil, err
+			}
+		}
+		m := make(map[string]any, n)
+		for range n {
+			k, err := readMsgpackString(r)
+			if err != nil {
+				return nil, err
+			}
+			if m[k], err = decodeMsgpack(r); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	}
+
+	switch c {
+	case msgpackNil:
+		return nil, nil
+	case msgpackFalse, msgpackTrue:
+		return c == msgpackTrue, nil
+	case msgpackUint8, msgpackUint16, msgpackUint32, msgpackUint64:
+		return readMsgpackUint(r, 1<<(c-msgpackUint8))
+	case msgpackInt8, msgpackInt16, msgpackInt32, msgpackInt64:
+		size := 1 << (c - msgpackInt8)
+		v, err := readMsgpackUint(r, size)
+		shift := 64 - 8*size
+		return int64(v<<shift) >> shift, err //nolint:gosec // sign extension
+	case msgpackFloat64:
+		v, err := readMsgpackUint(r, 8)
+		return math.Float64frombits(v), err
+	case msgpackFixExt8:
+		b, err := read(9)
+		if err != nil {
+			return nil, err
+		}
+		return time.Unix(int64(binary.BigEndian.Uint32(b[1:5])), int64(binary.BigEndian.Uint32(b[5:]))), nil
+	}
+	return nil, fmt.Errorf("unexpected msgpack type 0x%x", c)
+}
+
+func TestMsgpack_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		b    []byte
+		want any
+	}{
+		{name: "positive fixint", b: appendMsgpackInt(nil, 7), want: int64(7)},
+		{name: "negative fixint", b: appendMsgpackInt(nil, -7), want: int64(-7)},
+		{name: "int16", b: appendMsgpackInt(nil, -1000), want: int64(-1000)},
+		{name: "int64", b: appendMsgpackInt(nil, math.MinInt64), want: int64(math.MinInt64)},
+		{name: "uint32", b: appendMsgpackUint(nil, 70000), want: uint64(70000)},
+		{name: "uint64", b: appendMsgpackUint(nil, math.MaxUint64), want: uint64(math.MaxUint64)},
+		{name: "float", b: appendMsgpackFloat(nil, 1.5), want: 1.5},
+		{name: "bool", b: appendMsgpackBool(nil, true), want: true},
+		{name: "nil", b: appendMsgpackNil(nil), want: nil},
+		{name: "fixstr", b: appendMsgpackString(nil, "hello"), want: "hello"},
+		{name: "str16", b: appendMsgpackString(nil, strings.Repeat("x", 300)), want: strings.Repeat("x", 300)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeMsgpack(bufio.NewReader(strings.NewReader(string(tt.b))))
+			if err != nil {
+				t.Fatalf("decodeMsgpack() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("decodeMsgpack() = %v (%T), want %v (%T)", got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
+// fluentServer accepts a single connection and sends the decoded messages to the returned channel.
+// If ack is set, it acknowledges the messages carrying a chunk ID with the value returned by ack.
+func fluentServer(t *testing.T, ack func(chunk string) string) (addr string, messages <-chan []any) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	ch := make(chan []any, 10)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			v, err := decodeMsgpack(r)
+			if err != nil {
+				return
+			}
+			msg, _ := v.([]any)
+			if len(msg) == 4 && ack != nil {
+				chunk, _ := msg[3].(map[string]any)["chunk"].(string)
+				resp := appendMsgpackMapHeader(nil, 1)
+				resp = appendMsgpackString(resp, "ack")
+				resp = appendMsgpackString(resp, ack(chunk))
+				_, _ = conn.Write(resp)
+			}
+			ch <- msg
+		}
+	}()
+	return ln.Addr().String(), ch
+}
+
+func TestFluentHandler(t *testing.T) {
+	addr, messages := fluentServer(t, nil)
+	h, err := NewFluentHandler(FluentOptions{Address: addr, Tag: "app.api"})
+	if err != nil {
+		t.Fatalf("NewFluentHandler() error = %v", err)
+	}
+	defer h.Close()
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	log := NewLogger(Options{Handler: h}).With("service", "api").WithGroup("req").WithGroup("empty")
+	r := slog.NewRecord(ts, slog.LevelWarn, "slow request", 0)
+	r.AddAttrs(slog.Int("status", 200), slog.Duration("took", time.Second), slog.Any("err", errors.New("boom")))
+	if err := log.Handler().WithGroup("inner").Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	msg := <-messages
+	if len(msg) != 3 || msg[0] != "app.api" || !msg[1].(time.Time).Equal(ts) {
+		t.Fatalf("Unexpected message %v", msg)
+	}
+	got := fmt.Sprint(msg[2])
+	want := "map[level:WARN msg:slow request req:map[empty:map[inner:map[err:boom status:200 took:1000000000]]] service:api]"
+	if got != want {
+		t.Errorf("Expected record %s, got %s", want, got)
+	}
+
+	log.Info("empty groups are omitted")
+	msg = <-messages
+	record := msg[2].(map[string]any)
+	if _, ok := record["req"]; ok {
+		t.Errorf("Expected empty groups to be omitted, got %v", record)
+	}
+	if _, ok := record["source"].(map[string]any)["line"]; !ok {
+		t.Errorf("Expected the source, got %v", record)
+	}
+
+	if err := h.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := h.Handle(context.Background(), r); !errors.Is(err, ErrHandlerClosed) {
+		t.Errorf("Expected ErrHandlerClosed, got %v", err)
+	}
+}
+
+func TestFluentHandler_Ack(t *testing.T) {
+	tests := []struct {
+		name    string
+		ack     func(chunk string) string
+		wantErr error
+	}{
+		{name: "acknowledged", ack: func(chunk string) string { return chunk }},
+		{name: "mismatch", ack: func(string) string { return "other" }, wantErr: ErrFluentAck},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, messages := fluentServer(t, tt.ack)
+			h, err := NewFluentHandler(FluentOptions{Address: addr, RequireAck: true, Timeout: time.Second})
+			if err != nil {
+				t.Fatalf("NewFluentHandler() error = %v", err)
+			}
+			defer h.Close()
+
+			id := NewULID()
+			r := slog.NewRecord(time.Now(), slog.LevelInfo, "hello", 0)
+			r.AddAttrs(slog.String(RecordIDKey, id))
+			err = h.Handle(context.Background(), r)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Handle() error = %v, want %v", err, tt.wantErr)
+			}
+			if err := <-Ack(context.Background(), id); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Ack() = %v, want %v", err, tt.wantErr)
+			}
+			msg := <-messages
+			if chunk, _ := msg[3].(map[string]any)["chunk"].(string); chunk == "" {
+				t.Errorf("Expected a chunk ID, got %v", msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,357 @@
+package logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// defaultGELFChunkSize is the default [GELFOptions.ChunkSize], which fits into the MTU of most networks.
+	defaultGELFChunkSize = 1420
+	// gelfChunkHeaderSize is the size of the header of a chunk: magic bytes, message ID, sequence number and count.
+	gelfChunkHeaderSize = 12
+	// maxGELFChunks is the maximum number of chunks of a message.
+	maxGELFChunks = 128
+)
+
+// gelfChunkMagic are the magic bytes starting every chunk of a chunked GELF message.
+var gelfChunkMagic = [2]byte{0x1e, 0x0f}
+
+// ErrGELFMessageTooLarge is returned by a [GELFHandler] if a record exceeds the maximum number of chunks of a UDP message.
+var ErrGELFMessageTooLarge = errors.New("gelf message exceeds 128 chunks")
+
+// GELFOptions is the configuration for [NewGELFHandler].
+type GELFOptions struct {
+	// Address is the address of the GELF input of Graylog, e.g. "graylog:12201".
+	Address string
+	// Level is the minimum log level.
+	Level Level
+	// Network is the network of the GELF input: "udp" or "tcp". Defaults to "udp".
+	Network string
+	// TLSConfig is the TLS configuration of a TCP connection. If nil, TCP connections are not encrypted.
+	TLSConfig *tls.Config
+	// Hostname is the name of the host. Defaults to [os.Hostname].
+	Hostname string
+	// ChunkSize is the maximum size of a UDP datagram. Larger messages are split into chunks. Defaults to 1420.
+	ChunkSize int
+	// Compress is a flag to compress UDP messages with gzip.
+	Compress bool
+}
+
+// newGELFOptions returns the provided GELFOptions merged with the default GELFOptions.
+func newGELFOptions(o GELFOptions) GELFOptions {
+	hostname, _ := os.Hostname()
+	return o.merge(GELFOptions{
+		Level:     LevelInfo,
+		Network:   "udp",
+		Hostname:  hostname,
+		ChunkSize: defaultGELFChunkSize,
+	})
+}
+
+// merge merges the provided GELFOptions with the receiver GELFOptions.
+func (o *GELFOptions) merge(d GELFOptions) GELFOptions {
+	if o.Level != 0 {
+		d.Level = o.Level
+	}
+	for _, f := range []struct{ src, dst *string }{
+		{&o.Address, &d.Address},
+		{&o.Network, &d.Network},
+		{&o.Hostname, &d.Hostname},
+	} {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+	if o.TLSConfig != nil {
+		d.TLSConfig = o.TLSConfig
+	}
+	if o.ChunkSize > gelfChunkHeaderSize {
+		d.ChunkSize = o.ChunkSize
+	}
+	if o.Compress {
+		d.Compress = true
+	}
+	return d
+}
+
+// gelfConn is the connection to Graylog shared by a [GELFHandler] and the handlers derived from it.
+type gelfConn struct {
+	mu     sync.Mutex
+	opts   GELFOptions
+	conn   net.Conn
+	closed bool
+}
+
+var _ slog.Handler = (*GELFHandler)(nil)
+
+// GELFHandler is a [slog.Handler] writing records as GELF 1.1 messages to Graylog.
+type GELFHandler struct {
+	opts GELFOptions
+	conn *gelfConn
+	// prefix is the prefix of the field names of the groups of the handler.
+	prefix string
+	// fields are the encoded additional fields of the attributes added by WithAttrs.
+	fields []string
+}
+
+// NewGELFHandler returns a new [GELFHandler] writing records as GELF 1.1 messages to a GELF input of Graylog.
+// The message is written as short_message, the level as syslog severity like by [NewSyslogHandler]
+// and the source as _file, _line and _function. The attributes are written as additional fields prefixed by an underscore,
+// with the keys of nested attributes joined by underscores. Numbers are written as numbers, all other values as strings.
+//
+// Messages sent via UDP are optionally compressed and split into chunks if they exceed [GELFOptions.ChunkSize].
+// Messages sent via TCP, optionally encrypted with TLS, are terminated by a null byte.
+//
+// It returns an error if the connection cannot be established. Close the handler to close the connection.
+func NewGELFHandler(o GELFOptions) (*GELFHandler, error) {
+	opts := newGELFOptions(o)
+	c := &gelfConn{opts: opts}
+	if err := c.dial(); err != nil {
+		return nil, err
+	}
+	return &GELFHandler{opts: opts, conn: c}, nil
+}
+
+// Enabled reports whether the handler handles records at the given level.
+func (h *GELFHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= slog.Level(h.opts.Level)
+}
+
+// Handle writes the record as GELF message.
+func (h *GELFHandler) Handle(_ context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	fields := slices.Clip(h.fields)
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendGELFFields(fields, h.prefix, a)
+		return true
+	})
+	return h.conn.write(h.format(r, fields))
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *GELFHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := slices.Clip(h.fields)
+	for _, a := range attrs {
+		fields = appendGELFFields(fields, h.prefix, a)
+	}
+	return &GELFHandler{opts: h.opts, conn: h.conn, prefix: h.prefix, fields: fields}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *GELFHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &GELFHandler{opts: h.opts, conn: h.conn, prefix: h.prefix + name + "_", fields: h.fields}
+}
+
+// Close closes the connection to Graylog. Records handled afterwards return [ErrHandlerClosed].
+// It closes the connection of the handlers derived by WithAttrs and WithGroup as well.
+func (h *GELFHandler) Close() error {
+	h.conn.mu.Lock()
+	defer h.conn.mu.Unlock()
+	h.conn.closed = true
+	if h.conn.conn == nil {
+		return nil
+	}
+	err := h.conn.conn.Close()
+	h.conn.conn = nil
+	return err
+}
+
+// format returns the GELF message of the record with the given encoded additional fields.
+func (h *GELFHandler) format(r slog.Record, fields []string) []byte { //nolint:gocritic // records are passed by value
+	var b bytes.Buffer
+	b.WriteString(`{"version":"1.1","host":`)
+	b.Write(gelfString(h.opts.Hostname))
+	b.WriteString(`,"short_message":`)
+	b.Write(gelfString(r.Message))
+	if !r.Time.IsZero() {
+		b.WriteString(`,"timestamp":`)
+		b.WriteString(strconv.FormatFloat(float64(r.Time.UnixMicro())/1e6, 'f', 6, 64)) //nolint:mnd // seconds with microseconds
+	}
+	b.WriteString(`,"level":`)
+	b.WriteString(strconv.Itoa(syslogSeverity(Level(r.Level))))
+	if r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		b.WriteString(`,"_file":`)
+		b.Write(gelfString(frame.File))
+		b.WriteString(`,"_line":`)
+		b.WriteString(strconv.Itoa(frame.Line))
+		b.WriteString(`,"_function":`)
+		b.Write(gelfString(frame.Function))
+	}
+	for _, f := range fields {
+		b.WriteByte(',')
+		b.WriteString(f)
+	}
+	b.WriteByte('}')
+	return b.Bytes()
+}
+
+// appendGELFFields appends the encoded additional fields of the attribute to fields.
+// The keys of group attributes are prefixed by the group name, groups with an empty key are inlined.
+func appendGELFFields(fields []string, prefix string, a slog.Attr) []string {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "_"
+		}
+		for _, ga := range a.Value.Group() {
+			fields = appendGELFFields(fields, prefix, ga)
+		}
+		return fields
+	}
+	if a.Key == "" {
+		return fields
+	}
+
+	var value []byte
+	switch a.Value.Kind() {
+	case slog.KindInt64:
+		value = strconv.AppendInt(nil, a.Value.Int64(), 10)
+	case slog.KindUint64:
+		value = strconv.AppendUint(nil, a.Value.Uint64(), 10)
+	case slog.KindFloat64:
+		value, _ = json.Marshal(a.Value.Float64())
+	default:
+		s := a.Value.String()
+		if err, ok := a.Value.Any().(error); ok {
+			s = err.Error()
+		}
+		value = gelfString(s)
+	}
+	if value == nil {
+		// NaN and infinite floats cannot be encoded as JSON numbers.
+		value = gelfString(a.Value.String())
+	}
+	return append(fields, string(gelfString(gelfFieldName(prefix+a.Key)))+":"+string(value))
+}
+
+// gelfFieldName returns the key as name of an additional field: an underscore followed by letters, digits,
+// underscores, dots and dashes. The key "id" is written as "__id", since "_id" is reserved.
+func gelfFieldName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, key)
+	if name == "id" {
+		return "__id"
+	}
+	return "_" + name
+}
+
+// gelfString returns the string encoded as JSON string.
+func gelfString(s string) []byte {
+	b, _ := json.Marshal(s)
+	return b
+}
+
+// dial connects to Graylog. Must be called with the lock held or before the connection is shared.
+func (c *gelfConn) dial() error {
+	var conn net.Conn
+	var err error
+	if c.opts.TLSConfig != nil && !c.udp() {
+		conn, err = tls.Dial(c.opts.Network, c.opts.Address, c.opts.TLSConfig)
+	} else {
+		conn, err = net.Dial(c.opts.Network, c.opts.Address)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to graylog: %w", err)
+	}
+	c.conn = conn
+	return nil
+}
+
+// udp reports whether the messages are sent via UDP.
+func (c *gelfConn) udp() bool {
+	return strings.HasPrefix(c.opts.Network, "udp")
+}
+
+// write writes the message to Graylog. If the write fails, the connection is re-established once.
+func (c *gelfConn) write(msg []byte) error {
+	packets, err := c.packets(msg)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return ErrHandlerClosed
+	}
+	if c.conn != nil {
+		if err = writePackets(c.conn, packets); err == nil {
+			return nil
+		}
+		_ = c.conn.Close()
+		c.conn = nil
+	}
+	if err = c.dial(); err != nil {
+		return err
+	}
+	return writePackets(c.conn, packets)
+}
+
+// packets returns the packets of the message: the null-terminated message for TCP
+// and the optionally compressed message, split into chunks if necessary, for UDP.
+func (c *gelfConn) packets(msg []byte) ([][]byte, error) {
+	if !c.udp() {
+		return [][]byte{append(msg, 0)}, nil
+	}
+
+	if c.opts.Compress {
+		var b bytes.Buffer
+		zw := gzip.NewWriter(&b)
+		_, _ = zw.Write(msg)
+		_ = zw.Close()
+		msg = b.Bytes()
+	}
+	if len(msg) <= c.opts.ChunkSize {
+		return [][]byte{msg}, nil
+	}
+
+	size := c.opts.ChunkSize - gelfChunkHeaderSize
+	count := (len(msg) + size - 1) / size
+	if count > maxGELFChunks {
+		return nil, ErrGELFMessageTooLarge
+	}
+	id := rand.Uint64() //nolint:gosec // the message ID does not need to be cryptographically secure
+	packets := make([][]byte, 0, count)
+	for seq := range count {
+		chunk := make([]byte, 0, c.opts.ChunkSize)
+		chunk = append(chunk, gelfChunkMagic[:]...)
+		chunk = binary.BigEndian.AppendUint64(chunk, id)
+		chunk = append(chunk, byte(seq), byte(count))
+		chunk = append(chunk, msg[seq*size:min((seq+1)*size, len(msg))]...)
+		packets = append(packets, chunk)
+	}
+	return packets, nil
+}
+
+// writePackets writes the packets to the connection.
+func writePackets(conn net.Conn, packets [][]byte) error {
+	for _, p := range packets {
+		if _, err := conn.Write(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGELFHandler_UDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket() error = %v", err)
+	}
+	defer pc.Close()
+
+	h, err := NewGELFHandler(GELFOptions{Address: pc.LocalAddr().String(), Hostname: "host"})
+	if err != nil {
+		t.Fatalf("NewGELFHandler() error = %v", err)
+	}
+	defer h.Close()
+
+	log := NewLogger(Options{Handler: h}).With("id", "abc", "service", "api").WithGroup("req")
+	log.Warn("slow request", "status", 200, "took", 1.5, "path", "/a b", "err", errors.New("boom"))
+
+	buf := make([]byte, 8192)
+	_ = pc.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := pc.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("ReadFrom() error = %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(buf[:n], &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v, message %q", err, buf[:n])
+	}
+	want := map[string]any{
+		"version":       "1.1",
+		"host":          "host",
+		"short_message": "slow request",
+		"level":         float64(4),
+		"__id":          "abc",
+		"_service":      "api",
+		"_req_status":   float64(200),
+		"_req_took":     1.5,
+		"_req_path":     "/a b",
+		"_req_err":      "boom",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("Expected %s = %v, got %v", k, v, got[k])
+		}
+	}
+	for _, k := range []string{"timestamp", "_file", "_line", "_function"} {
+		if _, ok := got[k]; !ok {
+			t.Errorf("Expected field %s, got %v", k, got)
+		}
+	}
+}
+
+func TestGELFHandler_Chunked(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket() error = %v", err)
+	}
+	defer pc.Close()
+
+	h, err := NewGELFHandler(GELFOptions{Address: pc.LocalAddr().String(), ChunkSize: 100, Compress: true})
+	if err != nil {
+		t.Fatalf("NewGELFHandler() error = %v", err)
+	}
+	defer h.Close()
+
+	// Random-looking data does not compress well, so the message is chunked.
+	var payload strings.Builder
+	for i := range 300 {
+		payload.WriteString(time.Duration(i * 7919).String())
+	}
+	NewLogger(Options{Handler: h}).Info("large", "payload", payload.String())
+
+	var compressed []byte
+	var count int
+	for seq := 0; count == 0 || seq < count; seq++ {
+		buf := make([]byte, 100)
+		_ = pc.SetReadDeadline(time.Now().Add(time.Second))
+		n, _, err := pc.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("ReadFrom() error = %v", err)
+		}
+		chunk := buf[:n]
+		if !bytes.HasPrefix(chunk, gelfChunkMagic[:]) || int(chunk[10]) != seq {
+			t.Fatalf("Unexpected chunk header % x", chunk[:gelfChunkHeaderSize])
+		}
+		count = int(chunk[11])
+		compressed = append(compressed, chunk[gelfChunkHeaderSize:]...)
+	}
+	if count < 2 {
+		t.Fatalf("Expected multiple chunks, got %d", count)
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v", err)
+	}
+	msg, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if !strings.Contains(string(msg), `"_payload":"`+payload.String()+`"`) {
+		t.Errorf("Expected message with the payload, got %q", msg)
+	}
+
+	h.conn.opts.ChunkSize = gelfChunkHeaderSize + 1
+	if err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, payload.String(), 0)); !errors.Is(err, ErrGELFMessageTooLarge) {
+		t.Errorf("Expected ErrGELFMessageTooLarge, got %v", err)
+	}
+}
+
+func TestGELFHandler_TCP(t *testing.T) {
+	// The test server provides a certificate valid for 127.0.0.1.
+	srv := httptest.NewTLSServer(nil)
+	defer srv.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+
+	tests := []struct {
+		name   string
+		server *tls.Config
+		client *tls.Config
+	}{
+		{name: "plain"},
+		{
+			name:   "tls",
+			server: &tls.Config{Certificates: srv.TLS.Certificates, MinVersion: tls.VersionTLS12},
+			client: &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatalf("Listen() error = %v", err)
+			}
+			if tt.server != nil {
+				ln = tls.NewListener(ln, tt.server)
+			}
+			defer ln.Close()
+
+			received := make(chan []string, 1)
+			go func() {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				var msgs []string
+				for range 2 {
+					msg, err := r.ReadString(0)
+					if err != nil {
+						break
+					}
+					msgs = append(msgs, msg)
+				}
+				received <- msgs
+			}()
+
+			h, err := NewGELFHandler(GELFOptions{Network: "tcp", Address: ln.Addr().String(), TLSConfig: tt.client})
+			if err != nil {
+				t.Fatalf("NewGELFHandler() error = %v", err)
+			}
+			defer h.Close()
+			log := NewLogger(Options{Handler: h})
+			log.Info("first")
+			log.Error("second")
+
+			select {
+			case msgs := <-received:
+				if len(msgs) != 2 || !strings.Contains(msgs[0], `"short_message":"first"`) || !strings.HasSuffix(msgs[1], "}\x00") {
+					t.Errorf("Unexpected messages %q", msgs)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("Timed out waiting for the messages")
+			}
+
+			if err := h.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			if err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "closed", 0)); !errors.Is(err, ErrHandlerClosed) {
+				t.Errorf("Expected ErrHandlerClosed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGELFFieldName(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "user", want: "_user"},
+		{key: "http.method", want: "_http.method"},
+		{key: "user id", want: "_user_id"},
+		{key: "id", want: "__id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := gelfFieldName(tt.key); got != tt.want {
+				t.Errorf("gelfFieldName(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// HeadersKey is the key used for the group of HTTP headers returned by [Headers].
+const HeadersKey = "headers"
+
+const (
+	// maxHeaders is the maximum number of headers added by [Headers].
+	maxHeaders = 64
+	// maxHeaderValueLength is the maximum length in bytes of the value of a header added by [Headers].
+	maxHeaderValueLength = 256
+)
+
+// sensitiveHeaders are the canonical names of the headers whose values are masked by [Headers].
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// Headers returns a group attribute with the given HTTP headers under [HeadersKey], sorted by name.
+// The values of sensitive headers (Authorization, Proxy-Authorization, Cookie and Set-Cookie) are replaced by [RedactedValue]
+// and the values of headers with multiple values are joined by commas.
+//
+// The size of the group is capped: values longer than 256 bytes are truncated with an ellipsis
+// and only the first 64 headers are added, followed by the number of omitted headers under [TruncatedKey].
+func Headers(h http.Header) slog.Attr {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	attrs := make([]any, 0, min(len(names), maxHeaders)+1)
+	for i, name := range names {
+		if i == maxHeaders {
+			attrs = append(attrs, slog.String(TruncatedKey, fmt.Sprintf("+%d headers", len(names)-maxHeaders)))
+			break
+		}
+		value := RedactedValue
+		if !slices.Contains(sensitiveHeaders, http.CanonicalHeaderKey(name)) {
+			value = truncateValue(strings.Join(h[name], ", "), maxHeaderValueLength)
+		}
+		attrs = append(attrs, slog.String(name, value))
+	}
+	return slog.Group(HeadersKey, attrs...)
+}
+
+// truncateValue returns the value truncated to at most maxLen bytes followed by an ellipsis if it is longer.
+// The value is not cut within a UTF-8 sequence.
+func truncateValue(value string, maxLen int) string {
+	if len(value) <= maxLen {
+		return value
+	}
+	cut := maxLen
+	for cut > 0 && !utf8.RuneStart(value[cut]) {
+		cut--
+	}
+	return value[:cut] + "…"
+}
@@ -0,0 +1,100 @@
+package logger
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHeaders(t *testing.T) {
+	many := http.Header{}
+	for i := range maxHeaders + 2 {
+		many.Set(fmt.Sprintf("X-Header-%03d", i), "v")
+	}
+
+	tests := []struct {
+		name   string
+		header http.Header
+		want   map[string]string
+		len    int
+	}{
+		{
+			name: "values are joined",
+			header: http.Header{
+				"Accept":          {"text/html", "application/json"},
+				"X-Forwarded-For": {"10.0.0.1"},
+			},
+			want: map[string]string{"Accept": "text/html, application/json", "X-Forwarded-For": "10.0.0.1"},
+			len:  2,
+		},
+		{
+			name: "sensitive headers are masked",
+			header: http.Header{
+				"Authorization": {"Bearer abc"},
+				"Cookie":        {"session=abc"},
+				"Set-Cookie":    {"session=abc"},
+				"Content-Type":  {"text/plain"},
+			},
+			want: map[string]string{
+				"Authorization": RedactedValue,
+				"Cookie":        RedactedValue,
+				"Set-Cookie":    RedactedValue,
+				"Content-Type":  "text/plain",
+			},
+			len: 4,
+		},
+		{
+			name:   "long values are truncated",
+			header: http.Header{"X-Long": {strings.Repeat("a", maxHeaderValueLength+10)}},
+			want:   map[string]string{"X-Long": strings.Repeat("a", maxHeaderValueLength) + "…"},
+			len:    1,
+		},
+		{
+			name:   "number of headers is capped",
+			header: many,
+			want:   map[string]string{TruncatedKey: "+2 headers", "X-Header-000": "v"},
+			len:    maxHeaders + 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := Headers(tt.header)
+			if a.Key != HeadersKey {
+				t.Errorf("Key = %q, want %q", a.Key, HeadersKey)
+			}
+			group := a.Value.Group()
+			if len(group) != tt.len {
+				t.Errorf("len = %d, want %d", len(group), tt.len)
+			}
+			got := map[string]string{}
+			for _, ga := range group {
+				got[ga.Key] = ga.Value.String()
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("%s = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestTruncateValue(t *testing.T) {
+	tests := []struct {
+		value  string
+		maxLen int
+		want   string
+	}{
+		{value: "short", maxLen: 10, want: "short"},
+		{value: "abcdef", maxLen: 3, want: "abc…"},
+		{value: "aäb", maxLen: 2, want: "a…"},
+	}
+
+	for _, tt := range tests {
+		if got := truncateValue(tt.value, tt.maxLen); got != tt.want {
+			t.Errorf("truncateValue(%q, %d) = %q, want %q", tt.value, tt.maxLen, got, tt.want)
+		}
+	}
+}
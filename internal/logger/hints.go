@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+const (
+	// ColorKey is the key used for the color hint of a record's level.
+	// The value is the ansi color code of the level as defined in [LevelColors].
+	ColorKey = "color"
+	// SeverityKey is the key used for the numeric severity of a record's level.
+	SeverityKey = "severity"
+)
+
+var _ slog.Handler = (*levelHintHandler)(nil)
+
+// levelHintHandler is a [slog.Handler] that adds the color hint and the numeric severity
+// of a record's level as top-level attributes.
+// This allows log viewers like lnav or stern to highlight JSON output without switching to the text format.
+type levelHintHandler struct {
+	// base is the wrapped handler without any attributes or groups applied.
+	base slog.Handler
+	// ops are the WithAttrs and WithGroup calls applied to the handler in order.
+	ops []func(slog.Handler) slog.Handler
+	// handlers caches the handler with the hints and ops applied per [slog.Level].
+	handlers *sync.Map
+}
+
+// newLevelHintHandler returns a new [slog.Handler] that adds level hints to every record.
+func newLevelHintHandler(h slog.Handler) slog.Handler {
+	return &levelHintHandler{base: h, handlers: &sync.Map{}}
+}
+
+// Enabled reports whether the wrapped handler handles records at the given level.
+func (h *levelHintHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.base.Enabled(ctx, level)
+}
+
+// Handle adds the level hints to the record and passes it to the wrapped handler.
+func (h *levelHintHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	return h.forLevel(r.Level).Handle(ctx, r)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *levelHintHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(next slog.Handler) slog.Handler { return next.WithAttrs(attrs) })
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *levelHintHandler) WithGroup(name string) slog.Handler {
+	return h.with(func(next slog.Handler) slog.Handler { return next.WithGroup(name) })
+}
+
+// with returns a copy of the handler with the given operation appended.
+func (h *levelHintHandler) with(op func(slog.Handler) slog.Handler) slog.Handler {
+	ops := make([]func(slog.Handler) slog.Handler, len(h.ops), len(h.ops)+1)
+	copy(ops, h.ops)
+	return &levelHintHandler{base: h.base, ops: append(ops, op), handlers: &sync.Map{}}
+}
+
+// forLevel returns the handler for the given level.
+// The hints must be added before any group is opened to stay at the top level of the output,
+// which is why the operations are replayed on top of the hinted base handler.
+func (h *levelHintHandler) forLevel(level slog.Level) slog.Handler {
+	if cached, ok := h.handlers.Load(level); ok {
+		return cached.(slog.Handler)
+	}
+
+	next := h.base.WithAttrs(levelHints(Level(level)))
+	for _, op := range h.ops {
+		next = op(next)
+	}
+	cached, _ := h.handlers.LoadOrStore(level, next)
+	return cached.(slog.Handler)
+}
+
+// levelHints returns the hint attributes for the given level.
+func levelHints(level Level) []slog.Attr {
+	attrs := []slog.Attr{slog.Int(SeverityKey, int(level))}
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+	if color, ok := LevelColors[level]; ok {
+		attrs = append(attrs, slog.String(ColorKey, color))
+	}
+	return attrs
+}
@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestLevelHintHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		level     Level
+		group     string
+		wantColor string
+	}{
+		{
+			name:      "info level",
+			level:     LevelInfo,
+			wantColor: LevelColors[LevelInfo],
+		},
+		{
+			name:      "custom level without color",
+			level:     Level(1),
+			wantColor: "",
+		},
+		{
+			name:      "error level within group",
+			level:     LevelError,
+			group:     "group",
+			wantColor: LevelColors[LevelError],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			h := newLevelHintHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.Level(LevelTrace)}))
+			if tt.group != "" {
+				h = h.WithGroup(tt.group).WithAttrs([]slog.Attr{slog.String("key", "value")})
+			}
+
+			l := NewLogger(Options{Handler: h})
+			l.Log(context.Background(), tt.level, "test")
+
+			var got map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("Failed to unmarshal record: %v", err)
+			}
+
+			if got[SeverityKey] != float64(tt.level) {
+				t.Errorf("Expected severity %d, got %v", tt.level, got[SeverityKey])
+			}
+			color, ok := got[ColorKey]
+			if tt.wantColor == "" && ok {
+				t.Errorf("Expected no color hint, got %v", color)
+			}
+			if tt.wantColor != "" && color != tt.wantColor {
+				t.Errorf("Expected color %q, got %v", tt.wantColor, color)
+			}
+			if tt.group != "" {
+				group, ok := got[tt.group].(map[string]any)
+				if !ok || group["key"] != "value" {
+					t.Errorf("Expected group %q with attributes, got %v", tt.group, got[tt.group])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,363 @@
+package logger
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// RequestIDHeader is the default header used to read and write the request ID.
+	RequestIDHeader = "X-Request-ID"
+	// RequestIDKey is the key used for the request ID.
+	RequestIDKey = "request_id"
+)
+
+// maxRequestIDLength is the maximum length of a request ID accepted from a request header.
+const maxRequestIDLength = 128
+
+// requestIDKey is the key used to store the request ID in the context.
+type requestIDKey struct{}
+
+// RequestID returns a middleware that reads the request ID from the given header or generates a new UUID if it is missing.
+// The request ID is set as header of the response, stored in the request context and added to the attributes
+// of the logger in the request context, so all downstream logs carry the ID.
+// If the header is empty, [RequestIDHeader] is used.
+//
+// Invalid request IDs (too long or containing non-printable characters) are replaced by a new UUID
+// to prevent log injection.
+func RequestID(header string) func(http.Handler) http.Handler {
+	if header == "" {
+		header = RequestIDHeader
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(header)
+			if !isValidRequestID(id) {
+				id = newUUID()
+			}
+			w.Header().Set(header, id)
+
+			ctx := context.WithValue(r.Context(), requestIDKey{}, id)
+			ctx = IntoContext(ctx, FromContext(ctx).With(RequestIDKey, id))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequestIDFromContext returns the request ID stored in the context by the [RequestID] middleware.
+// Returns an empty string if the context does not carry a request ID.
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// isValidRequestID reports whether the request ID is non-empty, not too long and only contains printable ASCII characters.
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// newUUID returns a new random (version 4) UUID.
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// AccessLogOptions is the optional configuration for the [AccessLog] middleware.
+// Empty fields are replaced by their defaults.
+type AccessLogOptions struct {
+	// Message is the message of the access log records. Defaults to "HTTP request".
+	Message string
+	// MethodKey is the key used for the request method. Defaults to "method".
+	MethodKey string
+	// PathKey is the key used for the request path. Defaults to "path".
+	PathKey string
+	// StatusKey is the key used for the response status code. Defaults to "status".
+	StatusKey string
+	// SizeKey is the key used for the response size in bytes. Defaults to "size".
+	SizeKey string
+	// DurationKey is the key used for the request duration. Defaults to "duration".
+	DurationKey string
+	// RemoteAddrKey is the key used for the remote address. Defaults to "remote_addr".
+	RemoteAddrKey string
+	// UserAgentKey is the key used for the user agent. Defaults to "user_agent".
+	UserAgentKey string
+	// Debug is a flag to add the request headers under [HeadersKey], see [Headers].
+	Debug bool
+}
+
+// newAccessLogOptions returns the provided AccessLogOptions merged with the default AccessLogOptions.
+func newAccessLogOptions(o ...AccessLogOptions) AccessLogOptions {
+	opts := AccessLogOptions{
+		Message:       "HTTP request",
+		MethodKey:     "method",
+		PathKey:       "path",
+		StatusKey:     "status",
+		SizeKey:       "size",
+		DurationKey:   "duration",
+		RemoteAddrKey: "remote_addr",
+		UserAgentKey:  "user_agent",
+	}
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided AccessLogOptions with the receiver AccessLogOptions.
+func (o *AccessLogOptions) merge(d AccessLogOptions) AccessLogOptions {
+	for _, f := range []struct{ src, dst *string }{
+		{&o.Message, &d.Message},
+		{&o.MethodKey, &d.MethodKey},
+		{&o.PathKey, &d.PathKey},
+		{&o.StatusKey, &d.StatusKey},
+		{&o.SizeKey, &d.SizeKey},
+		{&o.DurationKey, &d.DurationKey},
+		{&o.RemoteAddrKey, &d.RemoteAddrKey},
+		{&o.UserAgentKey, &d.UserAgentKey},
+	} {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+	if o.Debug {
+		d.Debug = o.Debug
+	}
+	return d
+}
+
+// AccessLog returns a middleware that logs every request with the logger found in the request context.
+// The record contains the method, path, status code, response size, duration, remote address and user agent.
+// The level is chosen by the status class: 5xx are logged at [LevelError], 4xx at [LevelWarn] and all others at [LevelInfo].
+// With [AccessLogOptions.Debug], the request headers are added as well, with the sensitive ones masked.
+//
+// Use it after [Middleware] and [RequestID] to log with the request's logger.
+func AccessLog(o ...AccessLogOptions) func(http.Handler) http.Handler {
+	opts := newAccessLogOptions(o...)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &responseRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+
+			status := rec.Status()
+			attrs := []slog.Attr{
+				slog.String(opts.MethodKey, r.Method),
+				slog.String(opts.PathKey, r.URL.Path),
+				slog.Int(opts.StatusKey, status),
+				slog.Int64(opts.SizeKey, rec.size),
+				slog.Duration(opts.DurationKey, time.Since(start)),
+				slog.String(opts.RemoteAddrKey, r.RemoteAddr),
+				slog.String(opts.UserAgentKey, r.UserAgent()),
+			}
+			if opts.Debug {
+				attrs = append(attrs, Headers(r.Header))
+			}
+			FromContext(r.Context()).LogAttrs(r.Context(), statusLevel(status), opts.Message, attrs...)
+		})
+	}
+}
+
+// Recover returns a middleware that recovers from panics in downstream handlers.
+// The panic is logged at [LevelPanic] with the logger found in the request context,
+// including a stack trace under [StacktraceKey] and the request's method, path and remote address.
+// If the response was not written yet, it responds with [http.StatusInternalServerError].
+//
+// Panics with [http.ErrAbortHandler] are re-panicked to abort the response as intended.
+// Use it after [Middleware] and [RequestID] to log with the request's logger.
+func Recover() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := &responseRecorder{ResponseWriter: w}
+			defer func() {
+				v := recover()
+				if v == nil {
+					return
+				}
+				if err, ok := v.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					panic(v)
+				}
+
+				logRecovered(r.Context(), v, captureStack(2), //nolint:mnd // skip the deferred function and runtime.gopanic
+					slog.String("method", r.Method),
+					slog.String("path", r.URL.Path),
+					slog.String("remote_addr", r.RemoteAddr),
+				)
+				if rec.status == 0 {
+					http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				}
+			}()
+			next.ServeHTTP(rec, r)
+		})
+	}
+}
+
+var _ http.RoundTripper = (*transport)(nil)
+
+// transport is a [http.RoundTripper] that logs outbound requests.
+type transport struct {
+	base http.RoundTripper
+}
+
+// NewTransport returns a new [http.RoundTripper] that logs every outbound request with the logger found in the request context.
+// The record contains the method, URL, status code, duration and the error if the request failed.
+// The query and the fragment of the URL are omitted and its password is redacted, since they often carry tokens or personal data.
+// Failed requests are logged at [LevelError], otherwise the level is chosen by the status class like in [AccessLog].
+// If base is nil, [http.DefaultTransport] is used.
+//
+// Example:
+//
+//	client := &http.Client{Transport: logger.NewTransport(nil)}
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base}
+}
+
+// RoundTrip executes the request with the base transport and logs it.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+
+	ctx := req.Context()
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", logURL(req.URL)),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if err != nil {
+		FromContext(ctx).LogAttrs(ctx, LevelError, "Outbound HTTP request failed", append(attrs, Err(err))...)
+		return resp, err
+	}
+	FromContext(ctx).LogAttrs(ctx, statusLevel(resp.StatusCode), "Outbound HTTP request", append(attrs, slog.Int("status", resp.StatusCode))...)
+	return resp, nil
+}
+
+// logURL returns the URL without its query and fragment and with its password redacted.
+func logURL(u *url.URL) string {
+	stripped := *u
+	stripped.RawQuery, stripped.ForceQuery = "", false
+	stripped.Fragment, stripped.RawFragment = "", ""
+	return stripped.Redacted()
+}
+
+// statusLevel returns the log level for the given HTTP status code.
+func statusLevel(status int) Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return LevelError
+	case status >= http.StatusBadRequest:
+		return LevelWarn
+	default:
+		return LevelInfo
+	}
+}
+
+var (
+	_ http.Flusher  = (*responseRecorder)(nil)
+	_ http.Hijacker = (*responseRecorder)(nil)
+	_ io.ReaderFrom = (*responseRecorder)(nil)
+)
+
+// responseRecorder is a [http.ResponseWriter] that records the status code and the number of bytes written.
+// It passes the optional [http.Flusher], [http.Hijacker] and [io.ReaderFrom] interfaces through to the wrapped writer.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	size   int64
+}
+
+// WriteHeader records the status code and writes it to the wrapped [http.ResponseWriter].
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write records the number of bytes written to the wrapped [http.ResponseWriter].
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.size += int64(n)
+	return n, err
+}
+
+// Flush flushes the wrapped [http.ResponseWriter] if it is a [http.Flusher].
+func (r *responseRecorder) Flush() {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack hijacks the connection of the wrapped [http.ResponseWriter] if it is a [http.Hijacker].
+// Returns [http.ErrNotSupported] otherwise.
+func (r *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("hijack: %w", http.ErrNotSupported)
+	}
+	conn, rw, err := hj.Hijack()
+	if err == nil && r.status == 0 {
+		r.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+// ReadFrom records the number of bytes read from src into the wrapped [http.ResponseWriter],
+// using its [io.ReaderFrom] implementation if it has one, e.g. to send files with sendfile.
+func (r *responseRecorder) ReadFrom(src io.Reader) (int64, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	rf, ok := r.ResponseWriter.(io.ReaderFrom)
+	if !ok {
+		// The writer is wrapped to hide the ReadFrom method, so io.Copy does not call it again.
+		return io.Copy(struct{ io.Writer }{r}, src)
+	}
+	n, err := rf.ReadFrom(src)
+	r.size += n
+	return n, err
+}
+
+// Status returns the recorded status code or [http.StatusOK] if none was written.
+func (r *responseRecorder) Status() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+// Unwrap returns the wrapped [http.ResponseWriter] for use with [http.ResponseController].
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,436 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		incoming string
+		wantID   string
+	}{
+		{
+			name:     "propagates incoming request ID",
+			header:   "",
+			incoming: "abc-123",
+			wantID:   "abc-123",
+		},
+		{
+			name:     "generates missing request ID",
+			header:   "",
+			incoming: "",
+		},
+		{
+			name:     "custom header",
+			header:   "X-Correlation-ID",
+			incoming: "correlation",
+			wantID:   "correlation",
+		},
+		{
+			name:     "replaces invalid request ID",
+			header:   "",
+			incoming: "bad\nid",
+		},
+		{
+			name:     "replaces too long request ID",
+			header:   "",
+			incoming: strings.Repeat("a", maxRequestIDLength+1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := tt.header
+			if header == "" {
+				header = RequestIDHeader
+			}
+
+			var logged string
+			log := NewLogger(Options{Handler: test.MockHandler{
+				HandleFunc: func(_ context.Context, r slog.Record) error {
+					r.Attrs(func(a slog.Attr) bool {
+						if a.Key == RequestIDKey {
+							logged = a.Value.String()
+						}
+						return true
+					})
+					return nil
+				},
+				WithAttrsFunc: func(attrs []slog.Attr) slog.Handler {
+					for _, a := range attrs {
+						if a.Key == RequestIDKey {
+							logged = a.Value.String()
+						}
+					}
+					return test.MockHandler{}
+				},
+			}})
+
+			var gotID string
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotID = RequestIDFromContext(r.Context())
+				FromContext(r.Context()).InfoContext(r.Context(), "test")
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			if tt.incoming != "" {
+				req.Header.Set(header, tt.incoming)
+			}
+			w := httptest.NewRecorder()
+			Middleware(IntoContext(context.Background(), log))(RequestID(tt.header)(handler)).ServeHTTP(w, req)
+
+			if tt.wantID != "" && gotID != tt.wantID {
+				t.Errorf("Expected request ID %q, got %q", tt.wantID, gotID)
+			}
+			if tt.wantID == "" && !uuidPattern.MatchString(gotID) {
+				t.Errorf("Expected generated UUID, got %q", gotID)
+			}
+			if w.Header().Get(header) != gotID {
+				t.Errorf("Expected response header %q, got %q", gotID, w.Header().Get(header))
+			}
+			if logged != gotID {
+				t.Errorf("Expected logger attribute %q, got %q", gotID, logged)
+			}
+		})
+	}
+}
+
+func TestRequestIDFromContext_Missing(t *testing.T) {
+	if id := RequestIDFromContext(context.Background()); id != "" {
+		t.Errorf("Expected empty request ID, got %q", id)
+	}
+	if id := RequestIDFromContext(nil); id != "" { //nolint:staticcheck // testing nil context
+		t.Errorf("Expected empty request ID, got %q", id)
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []AccessLogOptions
+		status    int
+		body      string
+		wantLevel Level
+		wantKeys  []string
+	}{
+		{
+			name:      "implicit ok",
+			status:    0,
+			body:      "hello",
+			wantLevel: LevelInfo,
+			wantKeys:  []string{"method", "path", "status", "size", "duration", "remote_addr", "user_agent"},
+		},
+		{
+			name:      "redirect",
+			status:    http.StatusFound,
+			wantLevel: LevelInfo,
+			wantKeys:  []string{"status"},
+		},
+		{
+			name:      "client error",
+			status:    http.StatusNotFound,
+			wantLevel: LevelWarn,
+			wantKeys:  []string{"status"},
+		},
+		{
+			name:      "server error with custom keys",
+			opts:      []AccessLogOptions{{StatusKey: "http.status_code", Message: "access"}},
+			status:    http.StatusBadGateway,
+			body:      "bad gateway",
+			wantLevel: LevelError,
+			wantKeys:  []string{"http.status_code", "method"},
+		},
+		{
+			name:      "debug adds headers",
+			opts:      []AccessLogOptions{{Debug: true}},
+			status:    http.StatusOK,
+			wantLevel: LevelInfo,
+			wantKeys:  []string{"status", HeadersKey},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var record slog.Record
+			log := NewLogger(Options{Handler: test.MockHandler{
+				HandleFunc: func(_ context.Context, r slog.Record) error {
+					record = r
+					return nil
+				},
+			}})
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+				}
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/users", http.NoBody)
+			req.Header.Set("User-Agent", "test-agent")
+			Middleware(IntoContext(context.Background(), log))(AccessLog(tt.opts...)(handler)).ServeHTTP(httptest.NewRecorder(), req)
+
+			if record.Level != slog.Level(tt.wantLevel) {
+				t.Errorf("Expected level %v, got %v", tt.wantLevel, record.Level)
+			}
+			wantMsg := "HTTP request"
+			if len(tt.opts) > 0 && tt.opts[0].Message != "" {
+				wantMsg = tt.opts[0].Message
+			}
+			if record.Message != wantMsg {
+				t.Errorf("Expected message %q, got %q", wantMsg, record.Message)
+			}
+
+			got := map[string]slog.Value{}
+			record.Attrs(func(a slog.Attr) bool {
+				got[a.Key] = a.Value
+				return true
+			})
+			for _, key := range tt.wantKeys {
+				if _, ok := got[key]; !ok {
+					t.Errorf("Expected attribute %q, got %v", key, got)
+				}
+			}
+			statusKey := newAccessLogOptions(tt.opts...).StatusKey
+			wantStatus := tt.status
+			if wantStatus == 0 {
+				wantStatus = http.StatusOK
+			}
+			if got[statusKey].Int64() != int64(wantStatus) {
+				t.Errorf("Expected status %d, got %v", wantStatus, got[statusKey])
+			}
+			if got["size"].Int64() != int64(len(tt.body)) {
+				t.Errorf("Expected size %d, got %v", len(tt.body), got["size"])
+			}
+		})
+	}
+}
+
+func TestRecover(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantLog    bool
+		wantStatus int
+		wantPanic  bool
+	}{
+		{
+			name:       "no panic",
+			handler:    func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusAccepted) },
+			wantLog:    false,
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:       "panic before response",
+			handler:    func(http.ResponseWriter, *http.Request) { panic("boom") },
+			wantLog:    true,
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "panic after response",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				panic("boom")
+			},
+			wantLog:    true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:      "abort handler",
+			handler:   func(http.ResponseWriter, *http.Request) { panic(http.ErrAbortHandler) },
+			wantLog:   false,
+			wantPanic: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var records []slog.Record
+			log := NewLogger(Options{Handler: test.MockHandler{
+				HandleFunc: func(_ context.Context, r slog.Record) error {
+					records = append(records, r)
+					return nil
+				},
+			}})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/panic", http.NoBody)
+			func() {
+				defer func() {
+					if r := recover(); (r != nil) != tt.wantPanic {
+						t.Errorf("Expected panic: %v, got %v", tt.wantPanic, r)
+					}
+				}()
+				Middleware(IntoContext(context.Background(), log))(Recover()(tt.handler)).ServeHTTP(w, req)
+			}()
+
+			if (len(records) == 1) != tt.wantLog {
+				t.Fatalf("Expected record to be logged: %v, got %d records", tt.wantLog, len(records))
+			}
+			if tt.wantPanic {
+				return
+			}
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if !tt.wantLog {
+				return
+			}
+
+			if records[0].Level != slog.Level(LevelPanic) {
+				t.Errorf("Expected level %v, got %v", LevelPanic, records[0].Level)
+			}
+			records[0].Attrs(func(a slog.Attr) bool {
+				if a.Key == StacktraceKey && !strings.Contains(a.Value.String(), "TestRecover") {
+					t.Errorf("Expected stack trace to start at the panicking handler, got %s", a.Value.String())
+				}
+				return true
+			})
+		})
+	}
+}
+
+func TestTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		url        string
+		wantURL    string
+		wantLevel  Level
+		wantStatus int64
+		wantErr    bool
+	}{
+		{name: "ok", url: srv.URL + "/users", wantLevel: LevelInfo, wantStatus: http.StatusOK},
+		{name: "client error", url: srv.URL + "/missing", wantLevel: LevelWarn, wantStatus: http.StatusNotFound},
+		{
+			name:       "query and password omitted",
+			url:        "http://user:secret@" + strings.TrimPrefix(srv.URL, "http://") + "/users?token=abc#section",
+			wantURL:    "http://user:xxxxx@" + strings.TrimPrefix(srv.URL, "http://") + "/users",
+			wantLevel:  LevelInfo,
+			wantStatus: http.StatusOK,
+		},
+		{name: "connection error", url: "http://127.0.0.1:1/", wantLevel: LevelError, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var record slog.Record
+			log := NewLogger(Options{Handler: test.MockHandler{
+				HandleFunc: func(_ context.Context, r slog.Record) error {
+					record = r
+					return nil
+				},
+			}})
+
+			req, err := http.NewRequestWithContext(IntoContext(context.Background(), log), http.MethodGet, tt.url, http.NoBody)
+			if err != nil {
+				t.Fatalf("Failed to create request: %v", err)
+			}
+			resp, err := (&http.Client{Transport: NewTransport(nil)}).Do(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error = %v, got %v", tt.wantErr, err)
+			}
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+
+			if record.Level != slog.Level(tt.wantLevel) {
+				t.Errorf("Expected level %v, got %v", tt.wantLevel, record.Level)
+			}
+			got := map[string]slog.Value{}
+			record.Attrs(func(a slog.Attr) bool {
+				got[a.Key] = a.Value
+				return true
+			})
+			wantURL := tt.wantURL
+			if wantURL == "" {
+				wantURL = tt.url
+			}
+			if got["url"].String() != wantURL {
+				t.Errorf("Expected url %q, got %v", wantURL, got["url"])
+			}
+			if tt.wantErr {
+				if _, ok := got[""]; !ok {
+					t.Errorf("Expected the error group to be logged, got %v", got)
+				}
+				return
+			}
+			if got["status"].Int64() != tt.wantStatus {
+				t.Errorf("Expected status %d, got %v", tt.wantStatus, got["status"])
+			}
+		})
+	}
+}
+
+func TestResponseRecorder_Interfaces(t *testing.T) {
+	t.Run("flush", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		rec := &responseRecorder{ResponseWriter: w}
+		rec.Flush()
+		if !w.Flushed || rec.Status() != http.StatusOK {
+			t.Errorf("Expected the wrapped writer to be flushed with status 200, got %v and %d", w.Flushed, rec.Status())
+		}
+	})
+
+	t.Run("read from", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		rec := &responseRecorder{ResponseWriter: w}
+		n, err := rec.ReadFrom(strings.NewReader("hello"))
+		if err != nil || n != 5 || rec.size != 5 || w.Body.String() != "hello" {
+			t.Errorf("Expected 5 bytes to be written and recorded, got %d, %d, %q and %v", n, rec.size, w.Body.String(), err)
+		}
+	})
+
+	t.Run("hijack not supported", func(t *testing.T) {
+		rec := &responseRecorder{ResponseWriter: httptest.NewRecorder()}
+		if _, _, err := rec.Hijack(); !errors.Is(err, http.ErrNotSupported) {
+			t.Errorf("Expected %v, got %v", http.ErrNotSupported, err)
+		}
+	})
+
+	t.Run("hijack", func(t *testing.T) {
+		srv := httptest.NewServer(AccessLog()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			conn, rw, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("Failed to hijack the connection: %v", err)
+				return
+			}
+			defer conn.Close()
+			_, _ = rw.WriteString("HTTP/1.1 204 No Content\r\n\r\n")
+			_ = rw.Flush()
+		})))
+		defer srv.Close()
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL, http.NoBody)
+		if err != nil {
+			t.Fatalf("Failed to create request: %v", err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to send the request: %v", err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusNoContent {
+			t.Errorf("Expected status %d from the hijacked connection, got %d", http.StatusNoContent, resp.StatusCode)
+		}
+	})
+}
@@ -0,0 +1,220 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// RecordIDKey is the key used for the unique ID of a record.
+const RecordIDKey = "record_id"
+
+// recordIDOverhead is the estimated size of the record ID added to a record.
+const recordIDOverhead = len(`,"record_id":"01ARZ3NDEKTSV4RRFFQ69G5FAV"`)
+
+// IDGenerator generates unique IDs for records.
+// Implementations must be safe for concurrent use.
+type IDGenerator interface {
+	// NewID returns a new unique ID.
+	NewID() string
+}
+
+var _ IDGenerator = (*ULIDGenerator)(nil)
+
+// crockford is the Crockford base32 alphabet used to encode ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ulidLength is the length of an encoded ULID.
+const ulidLength = 26
+
+// ULIDGenerator generates monotonic ULIDs (https://github.com/ulid/spec).
+// IDs generated within the same millisecond are strictly increasing, so they are sortable by creation order.
+type ULIDGenerator struct {
+	mu      sync.Mutex
+	lastMs  uint64
+	entropy [10]byte
+}
+
+// NewULIDGenerator returns a new [ULIDGenerator].
+func NewULIDGenerator() *ULIDGenerator {
+	return &ULIDGenerator{}
+}
+
+// defaultIDGenerator is the generator used by [NewULID].
+var defaultIDGenerator = NewULIDGenerator()
+
+// NewULID returns a new monotonic ULID.
+func NewULID() string {
+	return defaultIDGenerator.NewID()
+}
+
+// NewID returns a new monotonic ULID.
+func (g *ULIDGenerator) NewID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := uint64(time.Now().UnixMilli()) //nolint:gosec // the unix time is positive
+	if ms <= g.lastMs {
+		// Increment the entropy to keep the IDs monotonic within the same millisecond.
+		// On overflow, the timestamp is advanced instead.
+		if incrementEntropy(&g.entropy) {
+			g.lastMs++
+		}
+		ms = g.lastMs
+	} else {
+		_, _ = rand.Read(g.entropy[:])
+		g.lastMs = ms
+	}
+
+	var id [16]byte
+	binary.BigEndian.PutUint16(id[0:], uint16(ms>>32)) //nolint:gosec // the ULID timestamp has 48 bits
+	binary.BigEndian.PutUint32(id[2:], uint32(ms))     //nolint:gosec // the ULID timestamp has 48 bits
+	copy(id[6:], g.entropy[:])
+	return encodeULID(id)
+}
+
+// incrementEntropy increments the entropy by one and reports whether it overflowed.
+func incrementEntropy(e *[10]byte) bool {
+	for i := len(e) - 1; i >= 0; i-- {
+		e[i]++
+		if e[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// encodeULID encodes the 128 bit ULID with the Crockford base32 alphabet.
+func encodeULID(id [16]byte) string {
+	hi := binary.BigEndian.Uint64(id[0:])
+	lo := binary.BigEndian.Uint64(id[8:])
+
+	var out [ulidLength]byte
+	// The 128 bits are encoded in 26 characters of 5 bits each, the first character only holds 3 bits.
+	for i := ulidLength - 1; i >= 0; i-- {
+		out[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+var _ slog.Handler = (*recordIDHandler)(nil)
+
+// recordIDHandler is a [slog.Handler] that adds a unique ID to every record.
+type recordIDHandler struct {
+	slog.Handler
+	gen IDGenerator
+}
+
+// NewRecordIDHandler returns a new [slog.Handler] that adds a unique ID generated by gen to every record under [RecordIDKey].
+// Downstream consumers of at-least-once sinks can use the ID to deduplicate records.
+// Records already carrying a [RecordIDKey] attribute keep their ID, e.g. to wait for their delivery with [Ack].
+// If gen is nil, monotonic ULIDs are generated.
+func NewRecordIDHandler(h slog.Handler, gen IDGenerator) slog.Handler {
+	if gen == nil {
+		gen = defaultIDGenerator
+	}
+	return &recordIDHandler{Handler: h, gen: gen}
+}
+
+// Handle adds the record ID to the record unless it has one and passes it to the wrapped handler.
+func (h *recordIDHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	hasID := false
+	r.Attrs(func(a slog.Attr) bool {
+		hasID = a.Key == RecordIDKey
+		return !hasID
+	})
+	if !hasID {
+		r.AddAttrs(slog.String(RecordIDKey, h.gen.NewID()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *recordIDHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &recordIDHandler{Handler: h.Handler.WithAttrs(attrs), gen: h.gen}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *recordIDHandler) WithGroup(name string) slog.Handler {
+	return &recordIDHandler{Handler: h.Handler.WithGroup(name), gen: h.gen}
+}
+
+// Envelope is the format in which records are shipped to at-least-once sinks.
+// The ID is always at the top level, so consumers can deduplicate records without knowing their schema.
+type Envelope struct {
+	// ID is the unique ID of the record.
+	ID string `json:"id"`
+	// Time is the time of the record.
+	Time time.Time `json:"time"`
+	// Level is the name of the record's level.
+	Level string `json:"level"`
+	// Message is the message of the record.
+	Message string `json:"msg"`
+	// Attrs are the attributes of the record. Groups are represented as nested maps.
+	Attrs map[string]any `json:"attrs,omitempty"`
+}
+
+// NewEnvelope returns the [Envelope] of the record.
+// The ID is taken from the record's [RecordIDKey] attribute if present, otherwise a new ID is generated by gen.
+// If gen is nil, monotonic ULIDs are generated.
+func NewEnvelope(r slog.Record, gen IDGenerator) Envelope { //nolint:gocritic // records are passed by value
+	env := Envelope{
+		Time:    r.Time,
+		Level:   Level(r.Level).String(),
+		Message: r.Message,
+	}
+
+	var attrs []slog.Attr
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == RecordIDKey && env.ID == "" {
+			env.ID = a.Value.String()
+			return true
+		}
+		attrs = append(attrs, a)
+		return true
+	})
+	env.Attrs = attrsToMap(attrs)
+
+	if env.ID == "" {
+		if gen == nil {
+			gen = defaultIDGenerator
+		}
+		env.ID = gen.NewID()
+	}
+	return env
+}
+
+// attrsToMap converts the attributes to a map. Groups are converted to nested maps and groups with an empty key are inlined.
+// Returns nil if there are no attributes.
+func attrsToMap(attrs []slog.Attr) map[string]any {
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	m := make(map[string]any, len(attrs))
+	for _, a := range attrs {
+		a.Value = a.Value.Resolve()
+		switch {
+		case a.Value.Kind() == slog.KindGroup && a.Key == "":
+			for k, v := range attrsToMap(a.Value.Group()) {
+				m[k] = v
+			}
+		case a.Value.Kind() == slog.KindGroup:
+			if group := attrsToMap(a.Value.Group()); group != nil {
+				m[a.Key] = group
+			}
+		case a.Key != "":
+			if err, ok := a.Value.Any().(error); ok {
+				m[a.Key] = err.Error()
+				continue
+			}
+			m[a.Key] = a.Value.Any()
+		}
+	}
+	return m
+}
@@ -0,0 +1,182 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+)
+
+var ulidPattern = regexp.MustCompile(`^[0-9A-HJKMNP-TV-Z]{26}$`)
+
+func TestULIDGenerator(t *testing.T) {
+	g := NewULIDGenerator()
+	const n = 1000
+
+	var mu sync.Mutex
+	ids := make(map[string]bool, n)
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range n / 4 {
+				id := g.NewID()
+				mu.Lock()
+				ids[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(ids) != n {
+		t.Errorf("Expected %d unique IDs, got %d", n, len(ids))
+	}
+	for id := range ids {
+		if !ulidPattern.MatchString(id) {
+			t.Errorf("Expected ULID, got %q", id)
+		}
+	}
+}
+
+func TestULIDGenerator_Monotonic(t *testing.T) {
+	g := NewULIDGenerator()
+	prev := g.NewID()
+	for range 1000 {
+		id := g.NewID()
+		if id <= prev {
+			t.Fatalf("Expected monotonic IDs, got %q after %q", id, prev)
+		}
+		prev = id
+	}
+}
+
+func TestEncodeULID(t *testing.T) {
+	tests := []struct {
+		name string
+		id   [16]byte
+		want string
+	}{
+		{name: "zero", id: [16]byte{}, want: "00000000000000000000000000"},
+		{
+			name: "max",
+			id:   [16]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+			want: "7ZZZZZZZZZZZZZZZZZZZZZZZZZ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := encodeULID(tt.id); got != tt.want {
+				t.Errorf("encodeULID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+type staticIDGenerator string
+
+func (g staticIDGenerator) NewID() string { return string(g) }
+
+func TestRecordIDHandler(t *testing.T) {
+	var got string
+	h := NewRecordIDHandler(test.MockHandler{
+		HandleFunc: func(_ context.Context, r slog.Record) error {
+			r.Attrs(func(a slog.Attr) bool {
+				if a.Key == RecordIDKey {
+					got = a.Value.String()
+				}
+				return true
+			})
+			return nil
+		},
+	}, staticIDGenerator("id-1"))
+
+	NewLogger(Options{Handler: h}).With("key", "value").Info("test")
+	if got != "id-1" {
+		t.Errorf("Expected record ID %q, got %q", "id-1", got)
+	}
+}
+
+func TestRecordIDHandler_Existing(t *testing.T) {
+	var got []string
+	h := NewRecordIDHandler(test.MockHandler{
+		HandleFunc: func(_ context.Context, r slog.Record) error {
+			r.Attrs(func(a slog.Attr) bool {
+				if a.Key == RecordIDKey {
+					got = append(got, a.Value.String())
+				}
+				return true
+			})
+			return nil
+		},
+	}, staticIDGenerator("id-1"))
+
+	NewLogger(Options{Handler: h}).Info("test", RecordIDKey, "existing")
+	if len(got) != 1 || got[0] != "existing" {
+		t.Errorf("Expected record IDs [existing], got %v", got)
+	}
+}
+
+func TestNewEnvelope(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		attrs  []slog.Attr
+		wantID string
+		want   string
+	}{
+		{
+			name:   "generated ID",
+			attrs:  []slog.Attr{slog.String("key", "value")},
+			wantID: "generated",
+			want:   `{"key":"value"}`,
+		},
+		{
+			name:   "ID from record",
+			attrs:  []slog.Attr{slog.String(RecordIDKey, "existing"), slog.Int("count", 1)},
+			wantID: "existing",
+			want:   `{"count":1}`,
+		},
+		{
+			name: "groups and errors",
+			attrs: []slog.Attr{
+				slog.Group("http", slog.String("method", "GET")),
+				slog.Group("", slog.Any("error", errors.New("boom"))),
+				slog.Group("empty"),
+			},
+			wantID: "generated",
+			want:   `{"error":"boom","http":{"method":"GET"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := slog.NewRecord(now, slog.LevelWarn, "test", 0)
+			r.AddAttrs(tt.attrs...)
+
+			env := NewEnvelope(r, staticIDGenerator("generated"))
+			if env.ID != tt.wantID {
+				t.Errorf("Expected ID %q, got %q", tt.wantID, env.ID)
+			}
+			if env.Level != "WARN" || env.Message != "test" || !env.Time.Equal(now) {
+				t.Errorf("Unexpected envelope %+v", env)
+			}
+			attrs, err := json.Marshal(env.Attrs)
+			if err != nil {
+				t.Fatalf("Failed to marshal attributes: %v", err)
+			}
+			if string(attrs) != tt.want {
+				t.Errorf("Expected attributes %s, got %s", tt.want, attrs)
+			}
+		})
+	}
+}
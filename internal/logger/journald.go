@@ -0,0 +1,261 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultJournalSocket is the default [JournaldOptions.Socket].
+const DefaultJournalSocket = "/run/systemd/journal/socket"
+
+// ErrJournaldUnsupported is returned by [NewJournaldHandler] on platforms other than Linux.
+var ErrJournaldUnsupported = errors.New("journald is only supported on linux")
+
+// maxJournalFieldName is the maximum length of a journal field name.
+const maxJournalFieldName = 64
+
+// journalAttrPrefix is the prefix of the field names of attributes colliding with the fields set by the handler.
+const journalAttrPrefix = "ATTR_"
+
+// reservedJournalFields are the names of the fields set by a [JournaldHandler] for every record.
+var reservedJournalFields = map[string]struct{}{
+	"MESSAGE":           {},
+	"PRIORITY":          {},
+	"SYSLOG_IDENTIFIER": {},
+	"CODE_FILE":         {},
+	"CODE_LINE":         {},
+	"CODE_FUNC":         {},
+}
+
+// isJournaldFormat reports whether the given format is the journald format.
+func isJournaldFormat(format string) bool {
+	return strings.EqualFold(format, "JOURNALD")
+}
+
+// JournaldOptions is the optional configuration for [NewJournaldHandler].
+type JournaldOptions struct {
+	// Level is the minimum log level.
+	Level Level
+	// Identifier is the SYSLOG_IDENTIFIER of the entries. Defaults to the name of the executable.
+	Identifier string
+	// Socket is the path of the socket of the journal. Defaults to [DefaultJournalSocket].
+	Socket string
+}
+
+// newJournaldOptions returns the provided JournaldOptions merged with the default JournaldOptions.
+func newJournaldOptions(o ...JournaldOptions) JournaldOptions {
+	opts := JournaldOptions{
+		Level:      LevelInfo,
+		Identifier: filepath.Base(os.Args[0]),
+		Socket:     DefaultJournalSocket,
+	}
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided JournaldOptions with the receiver JournaldOptions.
+func (o *JournaldOptions) merge(d JournaldOptions) JournaldOptions {
+	if o.Level != 0 {
+		d.Level = o.Level
+	}
+	if o.Identifier != "" {
+		d.Identifier = o.Identifier
+	}
+	if o.Socket != "" {
+		d.Socket = o.Socket
+	}
+	return d
+}
+
+// journalField is a field of a journal entry.
+type journalField struct {
+	name  string
+	value string
+}
+
+// journalSocket is the socket of the journal shared by a [JournaldHandler] and the handlers derived from it.
+type journalSocket struct {
+	mu     sync.Mutex
+	conn   *journalConn
+	closed bool
+}
+
+var _ slog.Handler = (*JournaldHandler)(nil)
+
+// JournaldHandler is a [slog.Handler] writing records to the systemd journal via its native protocol.
+type JournaldHandler struct {
+	opts   JournaldOptions
+	socket *journalSocket
+	// prefix is the prefix of the field names of the groups of the handler.
+	prefix string
+	// fields are the fields of the attributes added by WithAttrs.
+	fields []journalField
+}
+
+// NewJournaldHandler returns a new [JournaldHandler] writing records to the systemd journal via its native protocol.
+// Every record is written as entry with the following fields:
+//   - MESSAGE is the message of the record.
+//   - PRIORITY is the syslog severity of the level, mapped like by [NewSyslogHandler].
+//   - CODE_FILE, CODE_LINE and CODE_FUNC are the source of the record.
+//   - SYSLOG_IDENTIFIER is the [JournaldOptions.Identifier].
+//
+// The attributes are written as fields with upper-case names, e.g. "user_id" as USER_ID. The names of nested attributes
+// are joined by underscores and characters not allowed in field names are replaced by underscores.
+// Attributes whose names collide with the fields above are prefixed with "ATTR_", e.g. "message" as ATTR_MESSAGE,
+// so they cannot overwrite the message, priority or source of the entry.
+//
+// It is only supported on Linux and returns [ErrJournaldUnsupported] on other platforms.
+// It returns an error if the socket of the journal cannot be opened. Close the handler to close the socket.
+func NewJournaldHandler(o ...JournaldOptions) (*JournaldHandler, error) {
+	opts := newJournaldOptions(o...)
+	conn, err := dialJournal(opts.Socket)
+	if err != nil {
+		return nil, err
+	}
+	return &JournaldHandler{opts: opts, socket: &journalSocket{conn: conn}}, nil
+}
+
+// Enabled reports whether the handler handles records at the given level.
+func (h *JournaldHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= slog.Level(h.opts.Level)
+}
+
+// Handle writes the record as journal entry.
+func (h *JournaldHandler) Handle(_ context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	fields := []journalField{
+		{name: "MESSAGE", value: r.Message},
+		{name: "PRIORITY", value: strconv.Itoa(syslogSeverity(Level(r.Level)))},
+		{name: "SYSLOG_IDENTIFIER", value: h.opts.Identifier},
+	}
+	if r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		fields = append(fields,
+			journalField{name: "CODE_FILE", value: frame.File},
+			journalField{name: "CODE_LINE", value: strconv.Itoa(frame.Line)},
+			journalField{name: "CODE_FUNC", value: frame.Function},
+		)
+	}
+	fields = append(fields, h.fields...)
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendJournalFields(fields, h.prefix, a)
+		return true
+	})
+
+	h.socket.mu.Lock()
+	defer h.socket.mu.Unlock()
+	if h.socket.closed {
+		return ErrHandlerClosed
+	}
+	return h.socket.conn.send(encodeJournalEntry(fields))
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *JournaldHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := slices.Clip(h.fields)
+	for _, a := range attrs {
+		fields = appendJournalFields(fields, h.prefix, a)
+	}
+	return &JournaldHandler{opts: h.opts, socket: h.socket, prefix: h.prefix, fields: fields}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *JournaldHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &JournaldHandler{opts: h.opts, socket: h.socket, prefix: h.prefix + name + "_", fields: h.fields}
+}
+
+// Close closes the socket of the journal. Records handled afterwards return [ErrHandlerClosed].
+// It closes the socket of the handlers derived by WithAttrs and WithGroup as well.
+func (h *JournaldHandler) Close() error {
+	h.socket.mu.Lock()
+	defer h.socket.mu.Unlock()
+	if h.socket.closed {
+		return nil
+	}
+	h.socket.closed = true
+	return h.socket.conn.close()
+}
+
+// appendJournalFields appends the fields of the attribute to fields.
+// The names of group attributes are prefixed by the group name, groups with an empty key are inlined.
+func appendJournalFields(fields []journalField, prefix string, a slog.Attr) []journalField {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "_"
+		}
+		for _, ga := range a.Value.Group() {
+			fields = appendJournalFields(fields, prefix, ga)
+		}
+		return fields
+	}
+	if a.Key == "" {
+		return fields
+	}
+
+	name := journalFieldName(prefix + a.Key)
+	if name == "" {
+		return fields
+	}
+	if _, ok := reservedJournalFields[name]; ok {
+		name = journalAttrPrefix + name
+	}
+	value := a.Value.String()
+	if err, ok := a.Value.Any().(error); ok {
+		value = err.Error()
+	}
+	return append(fields, journalField{name: name, value: value})
+}
+
+// journalFieldName returns the key as journal field name: upper-case letters, digits and underscores,
+// not starting with an underscore or a digit and at most 64 characters long.
+// Returns an empty string if nothing of the key remains.
+func journalFieldName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+	// Fields starting with an underscore are trusted fields set by the journal itself.
+	name = strings.TrimLeft(name, "_0123456789")
+	return name[:min(len(name), maxJournalFieldName)]
+}
+
+// encodeJournalEntry encodes the fields in the native protocol of the journal.
+// Values containing newlines are written with their length, all others as NAME=value lines.
+func encodeJournalEntry(fields []journalField) []byte {
+	var b bytes.Buffer
+	for _, f := range fields {
+		b.WriteString(f.name)
+		if !strings.Contains(f.value, "\n") {
+			b.WriteByte('=')
+			b.WriteString(f.value)
+			b.WriteByte('\n')
+			continue
+		}
+		b.WriteByte('\n')
+		_ = binary.Write(&b, binary.LittleEndian, uint64(len(f.value)))
+		b.WriteString(f.value)
+		b.WriteByte('\n')
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,67 @@
+//go:build linux
+
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// journalConn is the datagram socket of the journal.
+type journalConn struct {
+	conn *net.UnixConn
+	addr *net.UnixAddr
+}
+
+// dialJournal opens an unbound datagram socket to send entries to the journal socket at the given path.
+func dialJournal(path string) (*journalConn, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("failed to open journal socket: %w", err)
+	}
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal socket: %w", err)
+	}
+	return &journalConn{conn: conn, addr: &net.UnixAddr{Name: path, Net: "unixgram"}}, nil
+}
+
+// send sends the entry to the journal. Entries too large for a datagram are passed as sealed memory file.
+func (c *journalConn) send(entry []byte) error {
+	_, err := c.conn.WriteToUnix(entry, c.addr)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, unix.EMSGSIZE) && !errors.Is(err, unix.ENOBUFS) {
+		return err
+	}
+	return c.sendFile(entry)
+}
+
+// sendFile writes the entry to a sealed memory file and passes its descriptor to the journal.
+func (c *journalConn) sendFile(entry []byte) error {
+	fd, err := unix.MemfdCreate("journal-entry", unix.MFD_CLOEXEC|unix.MFD_ALLOW_SEALING)
+	if err != nil {
+		return fmt.Errorf("failed to create journal entry file: %w", err)
+	}
+	f := os.NewFile(uintptr(fd), "journal-entry") //nolint:gosec // file descriptors are non-negative
+	defer f.Close()
+
+	if _, err = f.Write(entry); err != nil {
+		return fmt.Errorf("failed to write journal entry file: %w", err)
+	}
+	// The journal only accepts sealed files, so the entry cannot be changed after it is sent.
+	if _, err = unix.FcntlInt(f.Fd(), unix.F_ADD_SEALS, unix.F_SEAL_SHRINK|unix.F_SEAL_GROW|unix.F_SEAL_WRITE|unix.F_SEAL_SEAL); err != nil {
+		return fmt.Errorf("failed to seal journal entry file: %w", err)
+	}
+	_, _, err = c.conn.WriteMsgUnix(nil, unix.UnixRights(int(f.Fd())), c.addr) //nolint:gosec // file descriptors fit into an int
+	return err
+}
+
+// close closes the socket.
+func (c *journalConn) close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,126 @@
+//go:build linux
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// listenJournal returns a datagram socket in a temporary directory receiving the entries of a [JournaldHandler].
+func listenJournal(t *testing.T) (*net.UnixConn, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("ListenUnixgram() error = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn, path
+}
+
+// readJournalEntry reads the next entry from the socket, following passed memory files.
+func readJournalEntry(t *testing.T, conn *net.UnixConn) []byte {
+	t.Helper()
+	buf := make([]byte, 1<<16)
+	oob := make([]byte, unix.CmsgSpace(4)) //nolint:mnd // size of one file descriptor
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, oobn, _, _, err := conn.ReadMsgUnix(buf, oob)
+	if err != nil {
+		t.Fatalf("ReadMsgUnix() error = %v", err)
+	}
+	if oobn == 0 {
+		return buf[:n]
+	}
+
+	msgs, err := unix.ParseSocketControlMessage(oob[:oobn])
+	if err != nil || len(msgs) != 1 {
+		t.Fatalf("ParseSocketControlMessage() = %v, %v", msgs, err)
+	}
+	fds, err := unix.ParseUnixRights(&msgs[0])
+	if err != nil || len(fds) != 1 {
+		t.Fatalf("ParseUnixRights() = %v, %v", fds, err)
+	}
+	f := os.NewFile(uintptr(fds[0]), "entry") //nolint:gosec // file descriptors are non-negative
+	defer f.Close()
+	entry, err := io.ReadAll(io.NewSectionReader(f, 0, 1<<30))
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	return entry
+}
+
+func TestJournaldHandler(t *testing.T) {
+	conn, path := listenJournal(t)
+	h, err := NewJournaldHandler(JournaldOptions{Level: LevelDebug, Identifier: "myservice", Socket: path})
+	if err != nil {
+		t.Fatalf("NewJournaldHandler() error = %v", err)
+	}
+	defer h.Close()
+
+	log := NewLogger(Options{Handler: h}).With("user_id", 42).WithGroup("http")
+	log.Warn("slow request", "status", 200, "err", errors.New("multi\nline"))
+
+	entry := string(readJournalEntry(t, conn))
+	for _, want := range []string{
+		"MESSAGE=slow request\n",
+		"PRIORITY=4\n",
+		"SYSLOG_IDENTIFIER=myservice\n",
+		"CODE_FILE=",
+		"journald_linux_test.go\n",
+		"CODE_LINE=",
+		"CODE_FUNC=",
+		"USER_ID=42\n",
+		"HTTP_STATUS=200\n",
+		"HTTP_ERR\n",
+	} {
+		if !strings.Contains(entry, want) {
+			t.Errorf("Expected entry to contain %q, got %q", want, entry)
+		}
+	}
+
+	if err := h.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "closed", 0)); !errors.Is(err, ErrHandlerClosed) {
+		t.Errorf("Expected ErrHandlerClosed, got %v", err)
+	}
+}
+
+func TestJournaldHandler_Large(t *testing.T) {
+	conn, path := listenJournal(t)
+	h, err := NewJournaldHandler(JournaldOptions{Socket: path})
+	if err != nil {
+		t.Fatalf("NewJournaldHandler() error = %v", err)
+	}
+	defer h.Close()
+
+	// The entry exceeds the maximum size of a datagram, so it is passed as memory file.
+	value := strings.Repeat("x", 1<<20)
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "large", 0)
+	r.AddAttrs(slog.String("payload", value))
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	entry := readJournalEntry(t, conn)
+	if !bytes.Contains(entry, []byte("PAYLOAD="+value+"\n")) {
+		t.Errorf("Expected entry to contain the payload, got %d bytes", len(entry))
+	}
+}
+
+func TestNewJournaldHandler_NoSocket(t *testing.T) {
+	if _, err := NewJournaldHandler(JournaldOptions{Socket: filepath.Join(t.TempDir(), "missing")}); err == nil {
+		t.Error("Expected an error for a missing socket")
+	}
+}
@@ -0,0 +1,21 @@
+//go:build !linux
+
+package logger
+
+// journalConn is the datagram socket of the journal, which is only available on Linux.
+type journalConn struct{}
+
+// dialJournal returns [ErrJournaldUnsupported].
+func dialJournal(string) (*journalConn, error) {
+	return nil, ErrJournaldUnsupported
+}
+
+// send is never called, because no journalConn is created.
+func (*journalConn) send([]byte) error {
+	return ErrJournaldUnsupported
+}
+
+// close is never called, because no journalConn is created.
+func (*journalConn) close() error {
+	return nil
+}
@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"log/slog"
+	"testing"
+)
+
+func TestJournalFieldName(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want string
+	}{
+		{name: "lower case", key: "user_id", want: "USER_ID"},
+		{name: "dots and dashes", key: "http.status-code", want: "HTTP_STATUS_CODE"},
+		{name: "leading underscore", key: "_trusted", want: "TRUSTED"},
+		{name: "leading digits", key: "1st", want: "ST"},
+		{name: "unicode", key: "größe", want: "GR__E"},
+		{name: "nothing left", key: "_1", want: ""},
+		{name: "too long", key: string(bytes.Repeat([]byte("a"), 100)), want: string(bytes.Repeat([]byte("A"), maxJournalFieldName))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := journalFieldName(tt.key); got != tt.want {
+				t.Errorf("journalFieldName(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeJournalEntry(t *testing.T) {
+	got := encodeJournalEntry([]journalField{
+		{name: "MESSAGE", value: "hello"},
+		{name: "STACK", value: "a\nb"},
+	})
+
+	var want bytes.Buffer
+	want.WriteString("MESSAGE=hello\nSTACK\n")
+	_ = binary.Write(&want, binary.LittleEndian, uint64(3))
+	want.WriteString("a\nb\n")
+	if !bytes.Equal(got, want.Bytes()) {
+		t.Errorf("encodeJournalEntry() = %q, want %q", got, want.Bytes())
+	}
+}
+
+func TestAppendJournalFields_Reserved(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		attr   slog.Attr
+		want   string
+	}{
+		{name: "message", attr: slog.String("message", "v"), want: "ATTR_MESSAGE"},
+		{name: "priority", attr: slog.String("Priority", "v"), want: "ATTR_PRIORITY"},
+		{name: "grouped source", prefix: "code_", attr: slog.String("file", "v"), want: "ATTR_CODE_FILE"},
+		{name: "not reserved", attr: slog.String("message_id", "v"), want: "MESSAGE_ID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := appendJournalFields(nil, tt.prefix, tt.attr)
+			if len(got) != 1 || got[0].name != tt.want {
+				t.Errorf("appendJournalFields() = %v, want the field %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// isStandardKey reports whether the key is one of the standard keys of a record.
+func isStandardKey(key string) bool {
+	switch key {
+	case slog.TimeKey, slog.LevelKey, slog.MessageKey, slog.SourceKey:
+		return true
+	default:
+		return false
+	}
+}
+
+// keyNames returns the names of the standard keys of the options.
+// The LOG_KEYS environment variable takes precedence as comma-separated list of key=name pairs,
+// e.g. "time=timestamp,level=severity,msg=message".
+func keyNames(o Options) map[string]string {
+	env, ok := os.LookupEnv("LOG_KEYS")
+	if !ok {
+		return o.KeyNames
+	}
+
+	names := map[string]string{}
+	for _, pair := range strings.Split(env, ",") {
+		key, name, found := strings.Cut(pair, "=")
+		key, name = strings.TrimSpace(key), strings.TrimSpace(name)
+		if found && key != "" && name != "" {
+			names[key] = name
+		}
+	}
+	return names
+}
+
+// renameReplaceAttr returns the replacement function for slog.HandlerOptions
+// that renames the standard keys of a record according to the given names.
+// Returns next if there is nothing to rename.
+func renameReplaceAttr(names map[string]string, next func([]string, slog.Attr) slog.Attr) func([]string, slog.Attr) slog.Attr {
+	if len(names) == 0 {
+		return next
+	}
+	return func(groups []string, a slog.Attr) slog.Attr {
+		a = next(groups, a)
+		if len(groups) > 0 || !isStandardKey(a.Key) {
+			return a
+		}
+		if name, ok := names[a.Key]; ok {
+			a.Key = name
+		}
+		return a
+	}
+}
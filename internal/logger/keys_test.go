@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestRenameReplaceAttr(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   map[string]string
+		compact bool
+		want    []string
+	}{
+		{
+			name: "no names",
+			want: []string{"group", "level", "msg", "source", "time"},
+		},
+		{
+			name:  "standard keys",
+			names: map[string]string{"time": "timestamp", "level": "severity", "msg": "message", "source": "caller"},
+			want:  []string{"caller", "group", "message", "severity", "timestamp"},
+		},
+		{
+			name:  "other keys are kept",
+			names: map[string]string{"group": "renamed", "level": "severity"},
+			want:  []string{"group", "msg", "severity", "source", "time"},
+		},
+		{
+			name:    "names take precedence over compact keys",
+			names:   map[string]string{"msg": "message"},
+			compact: true,
+			want:    []string{"group", "l", "message", "s", "t"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replace := renameReplaceAttr(tt.names, replaceAttr)
+			if tt.compact {
+				replace = compactReplaceAttr(replace)
+			}
+			buf := &bytes.Buffer{}
+			log := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{AddSource: true, ReplaceAttr: replace}))
+			log.Info("test", slog.Group("group", "level", "nested"))
+
+			var got map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("Failed to parse record: %v", err)
+			}
+			if keys := slices.Sorted(maps.Keys(got)); !slices.Equal(keys, tt.want) {
+				t.Errorf("Expected keys %v, got %v", tt.want, keys)
+			}
+			if group, _ := got["group"].(map[string]any); group["level"] != "nested" {
+				t.Errorf("Expected keys in groups to be kept, got %v", got["group"])
+			}
+		})
+	}
+}
+
+func TestKeyNames(t *testing.T) {
+	opts := Options{KeyNames: map[string]string{"msg": "message"}}
+	if got := keyNames(opts); !maps.Equal(got, opts.KeyNames) {
+		t.Errorf("Expected names of the options, got %v", got)
+	}
+
+	t.Setenv("LOG_KEYS", " time = timestamp,level=severity,invalid,=x,")
+	want := map[string]string{"time": "timestamp", "level": "severity"}
+	if got := keyNames(opts); !maps.Equal(got, want) {
+		t.Errorf("Expected names %v of the environment variable, got %v", want, got)
+	}
+}
+
+func TestNewDockerHandler_KeyNames(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log := slog.New(NewDockerHandler(DockerOptions{Stdout: buf, KeyNames: map[string]string{"msg": "message"}}))
+	log.Info("test")
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Failed to parse record: %v", err)
+	}
+	if got["message"] != "test" || got[DockerStreamKey] != "stdout" {
+		t.Errorf("Expected message to be renamed, got %v", got)
+	}
+}
@@ -0,0 +1,185 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LazyOptions is the optional configuration for [NewLazyHandler].
+type LazyOptions struct {
+	// Level is the minimum level reported as enabled before the handler is constructed. Defaults to [LevelInfo].
+	// Once the handler is constructed, its own Enabled method is used.
+	Level Level
+	// Prewarm is a flag to construct the handler in the background right away,
+	// so the first record does not wait for e.g. a network dial.
+	Prewarm bool
+	// MinBackoff is the wait before the construction is retried after it failed,
+	// which is doubled with every failure. Defaults to 1 second.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum wait before the construction is retried. Defaults to 1 minute.
+	MaxBackoff time.Duration
+}
+
+const (
+	// defaultLazyMinBackoff is the default [LazyOptions.MinBackoff].
+	defaultLazyMinBackoff = time.Second
+	// defaultLazyMaxBackoff is the default [LazyOptions.MaxBackoff].
+	defaultLazyMaxBackoff = time.Minute
+)
+
+// newLazyOptions returns the provided LazyOptions merged with the default LazyOptions.
+func newLazyOptions(o ...LazyOptions) LazyOptions {
+	opts := LazyOptions{Level: LevelInfo, MinBackoff: defaultLazyMinBackoff, MaxBackoff: defaultLazyMaxBackoff}
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided LazyOptions with the receiver LazyOptions.
+func (o *LazyOptions) merge(d LazyOptions) LazyOptions {
+	if o.Level != 0 {
+		d.Level = o.Level
+	}
+	if o.Prewarm {
+		d.Prewarm = o.Prewarm
+	}
+	if o.MinBackoff > 0 {
+		d.MinBackoff = o.MinBackoff
+	}
+	if o.MaxBackoff > 0 {
+		d.MaxBackoff = o.MaxBackoff
+	}
+	return d
+}
+
+// lazySink is the lazily constructed handler shared by a [lazyHandler] and the handlers derived from it.
+type lazySink struct {
+	build func() (slog.Handler, error)
+	opts  LazyOptions
+	// mu guards handler, err, backoff and retryAt.
+	mu      sync.Mutex
+	handler slog.Handler
+	// err is the error of the last failed construction.
+	err error
+	// backoff is the current wait before the construction is retried.
+	backoff time.Duration
+	// retryAt is the time before which the construction is not retried after it failed.
+	retryAt time.Time
+	// built reports whether the construction succeeded.
+	built atomic.Bool
+}
+
+// get constructs the handler unless it is constructed already and returns it.
+// After a failed construction, the error is returned until the backoff has elapsed.
+func (s *lazySink) get() (slog.Handler, error) {
+	if s.built.Load() {
+		return s.handler, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.built.Load() {
+		return s.handler, nil
+	}
+	if s.err != nil && time.Now().Before(s.retryAt) {
+		return nil, s.err
+	}
+
+	handler, err := s.build()
+	if err != nil {
+		s.backoff = min(max(2*s.backoff, s.opts.MinBackoff), s.opts.MaxBackoff)
+		s.err, s.retryAt = err, time.Now().Add(s.backoff)
+		return nil, err
+	}
+	s.handler, s.err = handler, nil
+	s.built.Store(true)
+	return handler, nil
+}
+
+var _ slog.Handler = (*lazyHandler)(nil)
+
+// lazyHandler is a [slog.Handler] constructing its wrapped handler on the first record.
+type lazyHandler struct {
+	sink  *lazySink
+	level Level
+	// ops are the WithAttrs and WithGroup calls applied to the handler in order.
+	ops []func(slog.Handler) slog.Handler
+	// derived is the constructed handler with the ops applied, nil until it is constructed.
+	derived atomic.Pointer[slog.Handler]
+}
+
+// NewLazyHandler returns a new [slog.Handler] that defers the construction of an expensive handler,
+// e.g. one dialing a remote sink or opening a file, until the first record is handled.
+// This speeds up the start of programs that rarely log to the sink.
+// If the construction fails, the error is returned for every record until the construction is retried
+// with the next record after [LazyOptions.MinBackoff], doubling the wait up to [LazyOptions.MaxBackoff]
+// for every further failure, so a sink that is temporarily unavailable is used once it recovers.
+// With [LazyOptions.Prewarm], the handler is constructed in the background right away.
+func NewLazyHandler(build func() (slog.Handler, error), o ...LazyOptions) slog.Handler {
+	opts := newLazyOptions(o...)
+	h := &lazyHandler{sink: &lazySink{build: build, opts: opts}, level: opts.Level}
+	if opts.Prewarm {
+		go func() { _, _ = h.sink.get() }()
+	}
+	return h
+}
+
+// Enabled reports whether the handler handles records at the given level.
+// Before the handler is constructed, [LazyOptions.Level] is used.
+func (h *lazyHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if !h.sink.built.Load() {
+		return Level(level) >= h.level
+	}
+	handler, err := h.handler()
+	if err != nil {
+		return Level(level) >= h.level
+	}
+	return handler.Enabled(ctx, level)
+}
+
+// Handle constructs the handler if necessary and passes the record to it.
+func (h *lazyHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	handler, err := h.handler()
+	if err != nil {
+		return err
+	}
+	return handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *lazyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(next slog.Handler) slog.Handler { return next.WithAttrs(attrs) })
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *lazyHandler) WithGroup(name string) slog.Handler {
+	return h.with(func(next slog.Handler) slog.Handler { return next.WithGroup(name) })
+}
+
+// with returns a copy of the handler with the given operation appended.
+func (h *lazyHandler) with(op func(slog.Handler) slog.Handler) slog.Handler {
+	ops := make([]func(slog.Handler) slog.Handler, len(h.ops), len(h.ops)+1)
+	copy(ops, h.ops)
+	return &lazyHandler{sink: h.sink, level: h.level, ops: append(ops, op)}
+}
+
+// handler returns the constructed handler with the operations applied.
+func (h *lazyHandler) handler() (slog.Handler, error) {
+	if derived := h.derived.Load(); derived != nil {
+		return *derived, nil
+	}
+	next, err := h.sink.get()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range h.ops {
+		next = op(next)
+	}
+	// Concurrent calls may apply the operations as well, only the first result is kept.
+	h.derived.CompareAndSwap(nil, &next)
+	return *h.derived.Load(), nil
+}
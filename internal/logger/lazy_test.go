@@ -0,0 +1,122 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+)
+
+func TestNewLazyHandler(t *testing.T) {
+	errDial := errors.New("dial failed")
+	tests := []struct {
+		name       string
+		opts       []LazyOptions
+		err        error
+		log        func(l Provider)
+		wantBuilds int32
+		wantGroups []string
+	}{
+		{
+			name:       "never logged",
+			log:        func(Provider) {},
+			wantBuilds: 0,
+		},
+		{
+			name:       "disabled records do not construct",
+			log:        func(l Provider) { l.Debug("test") },
+			wantBuilds: 0,
+		},
+		{
+			name: "constructed once on first record",
+			log: func(l Provider) {
+				l.Info("first")
+				l.With("key", "value").WithGroup("group").Info("second")
+			},
+			wantBuilds: 1,
+			wantGroups: []string{"group"},
+		},
+		{
+			name:       "prewarm",
+			opts:       []LazyOptions{{Prewarm: true}},
+			log:        func(Provider) { time.Sleep(10 * time.Millisecond) },
+			wantBuilds: 1,
+		},
+		{
+			name:       "construction error",
+			err:        errDial,
+			log:        func(l Provider) { l.Info("first"); l.Info("second") },
+			wantBuilds: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var builds atomic.Int32
+			var groups []string
+			h := NewLazyHandler(func() (slog.Handler, error) {
+				builds.Add(1)
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				return &test.MockHandler{
+					WithGroupFunc: func(name string) slog.Handler {
+						groups = append(groups, name)
+						return &test.MockHandler{}
+					},
+				}, nil
+			}, tt.opts...)
+
+			tt.log(NewLogger(Options{Handler: h}))
+
+			if got := builds.Load(); got != tt.wantBuilds {
+				t.Errorf("Expected %d constructions, got %d", tt.wantBuilds, got)
+			}
+			if len(groups) != len(tt.wantGroups) {
+				t.Errorf("Expected groups %v to be replayed, got %v", tt.wantGroups, groups)
+			}
+			if tt.err != nil {
+				if err := h.Handle(context.Background(), slog.Record{}); !errors.Is(err, tt.err) {
+					t.Errorf("Expected error %v, got %v", tt.err, err)
+				}
+			}
+		})
+	}
+}
+
+func TestNewLazyHandler_Retry(t *testing.T) {
+	errDial := errors.New("dial failed")
+	var builds atomic.Int32
+	handled := 0
+	h := NewLazyHandler(func() (slog.Handler, error) {
+		if builds.Add(1) == 1 {
+			return nil, errDial
+		}
+		return &test.MockHandler{
+			HandleFunc: func(context.Context, slog.Record) error {
+				handled++
+				return nil
+			},
+		}, nil
+	}, LazyOptions{MinBackoff: 20 * time.Millisecond})
+
+	ctx := context.Background()
+	if err := h.Handle(ctx, slog.Record{}); !errors.Is(err, errDial) {
+		t.Fatalf("Expected error %v, got %v", errDial, err)
+	}
+	if err := h.Handle(ctx, slog.Record{}); !errors.Is(err, errDial) || builds.Load() != 1 {
+		t.Fatalf("Expected the error without a retry during the backoff, got %v after %d constructions", err, builds.Load())
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if err := h.Handle(ctx, slog.Record{}); err != nil {
+		t.Fatalf("Expected the construction to be retried after the backoff, got %v", err)
+	}
+	if builds.Load() != 2 || handled != 1 {
+		t.Errorf("Expected 2 constructions and 1 handled record, got %d and %d", builds.Load(), handled)
+	}
+}
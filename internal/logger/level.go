@@ -3,6 +3,7 @@ package logger
 import (
 	"log/slog"
 	"strings"
+	"sync"
 )
 
 // Level is a custom type for log levels.
@@ -20,8 +21,9 @@ const (
 	LevelFatal  = Level(slog.Level(16))
 )
 
-// LevelNames is a map of log levels to their respective names.
-var LevelNames = map[Level]string{
+// levelNames is a map of log levels to their respective names.
+// Custom levels are added by [RegisterLevel].
+var levelNames = map[Level]string{
 	LevelTrace:  "TRACE",
 	LevelDebug:  "DEBUG",
 	LevelInfo:   "INFO",
@@ -44,6 +46,30 @@ var LevelColors = map[Level]string{
 	LevelFatal:  "160", // FATAL - Dark Red
 }
 
+var (
+	// levelsMu guards levelNames, [LevelColors] and customLevels.
+	levelsMu sync.RWMutex
+	// customLevels is a map of the upper-cased names of registered levels to their levels.
+	customLevels = map[string]Level{}
+)
+
+// RegisterLevel registers a custom log level with the given name, e.g. AUDIT or SECURITY.
+// Registered levels are rendered by their name in both the JSON and the text format
+// and can be used as the minimum log level, e.g. via the LOG_LEVEL environment variable.
+// It is safe for concurrent use with logging.
+// Registering a name for an already named level replaces its name.
+//
+// Levels must be registered before the logger is created to be rendered by the text format.
+func RegisterLevel(name string, level Level) {
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+	if old, ok := levelNames[level]; ok {
+		delete(customLevels, strings.ToUpper(old))
+	}
+	levelNames[level] = name
+	customLevels[strings.ToUpper(name)] = level
+}
+
 // newLevel returns the log level based on the provided string.
 // Returns [LevelInfo] if the level is not recognized.
 func newLevel(level string) Level {
@@ -60,13 +86,26 @@ func newLevel(level string) Level {
 		return LevelWarn
 	case "ERROR":
 		return LevelError
+	case "PANIC":
+		return LevelPanic
+	case "FATAL":
+		return LevelFatal
 	default:
+		levelsMu.RLock()
+		defer levelsMu.RUnlock()
+		if l, ok := customLevels[strings.ToUpper(level)]; ok {
+			return l
+		}
 		return LevelInfo
 	}
 }
 
+// String returns the name of the level.
+// If the level has no name, it is rendered like a [slog.Level], e.g. "INFO+1".
 func (l Level) String() string {
-	if s, ok := LevelNames[l]; ok {
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+	if s, ok := levelNames[l]; ok {
 		return s
 	}
 	return slog.Level(l).String()
@@ -0,0 +1,24 @@
+//go:build !js && !wasip1
+
+package logger
+
+import (
+	"testing"
+
+	clog "github.com/charmbracelet/log"
+)
+
+func TestRegisterLevel_Style(t *testing.T) {
+	const levelAudit = Level(10)
+	RegisterLevel("Audit", levelAudit)
+	t.Cleanup(func() {
+		levelsMu.Lock()
+		defer levelsMu.Unlock()
+		delete(levelNames, levelAudit)
+		delete(customLevels, "AUDIT")
+	})
+
+	if _, ok := newCustomStyles().Levels[clog.Level(levelAudit)]; !ok {
+		t.Errorf("Expected a text style for the registered level")
+	}
+}
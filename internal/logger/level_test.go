@@ -1,6 +1,10 @@
 package logger
 
 import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
 	"testing"
 )
 
@@ -18,6 +22,8 @@ func TestGetLevel(t *testing.T) {
 		{"Warn level", "WARN", LevelWarn},
 		{"Warning level", "WARNING", LevelWarn},
 		{"Error level", "ERROR", LevelError},
+		{"Panic level", "PANIC", LevelPanic},
+		{"Fatal level", "FATAL", LevelFatal},
 		{"Invalid level", "UNKNOWN", LevelInfo},
 	}
 
@@ -30,3 +36,28 @@ func TestGetLevel(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterLevel(t *testing.T) {
+	const levelAudit = Level(10)
+	RegisterLevel("Audit", levelAudit)
+	t.Cleanup(func() {
+		levelsMu.Lock()
+		defer levelsMu.Unlock()
+		delete(levelNames, levelAudit)
+		delete(customLevels, "AUDIT")
+	})
+
+	if got := newLevel("audit"); got != levelAudit {
+		t.Errorf("newLevel(audit) = %v, want %v", got, levelAudit)
+	}
+	if got := levelAudit.String(); got != "Audit" {
+		t.Errorf("String() = %q, want %q", got, "Audit")
+	}
+
+	var buf bytes.Buffer
+	h := slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: replaceAttr})
+	NewLogger(Options{Handler: h}).Log(context.Background(), levelAudit, "test")
+	if !strings.Contains(buf.String(), `"level":"Audit"`) {
+		t.Errorf("Expected JSON output to contain the level name, got %s", buf.String())
+	}
+}
@@ -0,0 +1,193 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+)
+
+// TruncatedRecordKey is the key used to flag the records truncated by [NewLimitHandler].
+const TruncatedRecordKey = "truncated"
+
+// TruncationMarker is appended to the strings truncated by [NewLimitHandler].
+const TruncationMarker = "…"
+
+// truncatedOverhead is the estimated size of the attribute flagging a truncated record.
+const truncatedOverhead = len(`,"truncated":true`)
+
+// LimitOptions is the configuration for [NewLimitHandler]. Zero values disable the limits.
+type LimitOptions struct {
+	// MaxStringLength is the maximum length of string attribute values in bytes, including the values in groups.
+	// Longer values are truncated and end with the [TruncationMarker].
+	MaxStringLength int `json:"maxStringLength,omitempty" yaml:"maxStringLength,omitempty"`
+	// MaxAttrs is the maximum number of attributes of a record, not counting the attributes of the logger.
+	// A group counts as one attribute. The attributes beyond the maximum are dropped.
+	MaxAttrs int `json:"maxAttrs,omitempty" yaml:"maxAttrs,omitempty"`
+	// MaxRecordSize is the maximum size of a record in bytes, estimated from its JSON encoding.
+	// The attributes exceeding it are dropped and the last string attribute partially fitting is truncated.
+	// If the message alone exceeds it, the message is truncated and all attributes are dropped.
+	MaxRecordSize int `json:"maxRecordSize,omitempty" yaml:"maxRecordSize,omitempty"`
+}
+
+var _ slog.Handler = (*limitHandler)(nil)
+
+// limitHandler is a [slog.Handler] that truncates records exceeding the limits of its [LimitOptions].
+type limitHandler struct {
+	slog.Handler
+	opts LimitOptions
+	// overhead is the estimated size of the attributes and groups added by WithAttrs and WithGroup.
+	overhead int
+	// truncated reports whether attributes added by WithAttrs were truncated.
+	truncated bool
+}
+
+// NewLimitHandler returns a new [slog.Handler] that truncates records exceeding the given limits before
+// passing them to the given handler, which protects downstream ingestion from accidental huge payloads,
+// e.g. a whole response body logged as attribute. Truncated records carry [TruncatedRecordKey] set to true.
+//
+// In contrast to [NewSplitHandler], the data exceeding the limits is lost.
+// Returns the given handler if no limit is set.
+func NewLimitHandler(h slog.Handler, o LimitOptions) slog.Handler {
+	if o.MaxStringLength <= 0 && o.MaxAttrs <= 0 && o.MaxRecordSize <= 0 {
+		return h
+	}
+	return &limitHandler{Handler: h, opts: o}
+}
+
+// Handle truncates the record if it exceeds the limits and passes it to the wrapped handler.
+func (h *limitHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	truncated := h.truncated
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		if h.opts.MaxAttrs > 0 && len(attrs) == h.opts.MaxAttrs {
+			truncated = true
+			return false
+		}
+		a, t := h.truncateAttr(a)
+		truncated = truncated || t
+		attrs = append(attrs, a)
+		return true
+	})
+
+	msg := r.Message
+	if h.opts.MaxRecordSize > 0 {
+		var t bool
+		msg, attrs, t = h.fit(r, attrs)
+		truncated = truncated || t
+	}
+	if !truncated {
+		return h.Handler.Handle(ctx, r)
+	}
+
+	out := slog.NewRecord(r.Time, r.Level, msg, r.PC)
+	out.AddAttrs(attrs...)
+	out.AddAttrs(slog.Bool(TruncatedRecordKey, true))
+	return h.Handler.Handle(ctx, out)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by the truncated attrs.
+func (h *limitHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handler := *h
+	limited := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		var t bool
+		limited[i], t = h.truncateAttr(a)
+		handler.truncated = handler.truncated || t
+	}
+	r := slog.Record{}
+	r.AddAttrs(limited...)
+	handler.Handler = h.Handler.WithAttrs(limited)
+	handler.overhead += encodedSize(r) - encodedSize(slog.Record{})
+	return &handler
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *limitHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	handler := *h
+	handler.Handler = h.Handler.WithGroup(name)
+	handler.overhead += len(`,"":{}`) + len(name)
+	return &handler
+}
+
+// truncateAttr returns the attribute with its string values truncated to the maximum string length
+// and reports whether any value was truncated.
+func (h *limitHandler) truncateAttr(a slog.Attr) (slog.Attr, bool) {
+	if h.opts.MaxStringLength <= 0 {
+		return a, false
+	}
+
+	a.Value = a.Value.Resolve()
+	switch a.Value.Kind() { //nolint:exhaustive // only strings and groups can exceed the length
+	case slog.KindString:
+		if s := a.Value.String(); len(s) > h.opts.MaxStringLength {
+			a.Value = slog.StringValue(truncateString(s, h.opts.MaxStringLength))
+			return a, true
+		}
+	case slog.KindGroup:
+		group := a.Value.Group()
+		attrs := make([]slog.Attr, len(group))
+		truncated := false
+		for i, ga := range group {
+			var t bool
+			attrs[i], t = h.truncateAttr(ga)
+			truncated = truncated || t
+		}
+		if truncated {
+			a.Value = slog.GroupValue(attrs...)
+		}
+		return a, truncated
+	}
+	return a, false
+}
+
+// fit returns the message and the attributes of the record fitting into the maximum record size
+// and reports whether anything was truncated.
+func (h *limitHandler) fit(r slog.Record, attrs []slog.Attr) (string, []slog.Attr, bool) { //nolint:gocritic // records are passed by value
+	full := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	full.AddAttrs(attrs...)
+	if h.overhead+encodedSize(full) <= h.opts.MaxRecordSize {
+		return r.Message, attrs, false
+	}
+
+	empty := encodedSize(slog.NewRecord(r.Time, r.Level, "", r.PC))
+	budget := h.opts.MaxRecordSize - h.overhead - empty - truncatedOverhead
+	if budget <= 0 {
+		// Nothing but the standard fields fit, so the record is passed on as is.
+		return r.Message, attrs, false
+	}
+
+	msgSize := encodedSize(slog.NewRecord(r.Time, r.Level, r.Message, r.PC)) - empty
+	if msgSize > budget {
+		return truncateString(r.Message, budget), nil, true
+	}
+
+	remaining := budget - msgSize
+	emptyAttrs := encodedSize(slog.Record{})
+	kept := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		size := attrSize(a, emptyAttrs)
+		if size <= remaining {
+			kept = append(kept, a)
+			remaining -= size
+			continue
+		}
+		if a.Value.Kind() == slog.KindString {
+			if valueBudget := remaining - (size - len(a.Value.String())); valueBudget > len(TruncationMarker) {
+				kept = append(kept, slog.String(a.Key, truncateString(a.Value.String(), valueBudget)))
+			}
+		}
+		break
+	}
+	return r.Message, kept, true
+}
+
+// truncateString returns the string shortened to at most size bytes including the [TruncationMarker]
+// without splitting runes. At least one rune is kept.
+func truncateString(s string, size int) string {
+	if len(s) <= size {
+		return s
+	}
+	return chunkString(s, max(size-len(TruncationMarker), 1))[0] + TruncationMarker
+}
@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestNewLimitHandler(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          LimitOptions
+		with          []any
+		msg           string
+		attrs         []any
+		want          map[string]any
+		wantTruncated bool
+	}{
+		{
+			name:  "within limits",
+			opts:  LimitOptions{MaxStringLength: 10, MaxAttrs: 2, MaxRecordSize: 1024},
+			msg:   "test",
+			attrs: []any{"a", "value", "b", 42},
+			want:  map[string]any{"a": "value", "b": float64(42)},
+		},
+		{
+			name:          "long strings",
+			opts:          LimitOptions{MaxStringLength: 8},
+			msg:           "test",
+			attrs:         []any{"a", strings.Repeat("x", 20), slog.Group("g", "b", "äääää")},
+			want:          map[string]any{"a": "xxxxx…", "g": map[string]any{"b": "ää…"}},
+			wantTruncated: true,
+		},
+		{
+			name:          "long logger attributes",
+			opts:          LimitOptions{MaxStringLength: 8},
+			with:          []any{"a", strings.Repeat("x", 20)},
+			msg:           "test",
+			want:          map[string]any{"a": "xxxxx…"},
+			wantTruncated: true,
+		},
+		{
+			name:          "too many attributes",
+			opts:          LimitOptions{MaxAttrs: 2},
+			msg:           "test",
+			attrs:         []any{"a", 1, "b", 2, "c", 3},
+			want:          map[string]any{"a": float64(1), "b": float64(2)},
+			wantTruncated: true,
+		},
+		{
+			name:          "oversized record",
+			opts:          LimitOptions{MaxRecordSize: 400},
+			msg:           "test",
+			attrs:         []any{"a", 1, "payload", strings.Repeat("x", 1000), "b", 2},
+			want:          map[string]any{"a": float64(1)},
+			wantTruncated: true,
+		},
+		{
+			name:          "oversized message",
+			opts:          LimitOptions{MaxRecordSize: 400},
+			msg:           strings.Repeat("m", 1000),
+			attrs:         []any{"a", 1},
+			wantTruncated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			slog.New(NewLimitHandler(slog.NewJSONHandler(buf, nil), tt.opts)).With(tt.with...).Info(tt.msg, tt.attrs...)
+
+			if limit := tt.opts.MaxRecordSize; limit > 0 && buf.Len() > limit {
+				t.Errorf("Expected a record of at most %d bytes, got %d bytes", limit, buf.Len())
+			}
+			var rec map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+				t.Fatalf("Failed to unmarshal record %q: %v", buf.String(), err)
+			}
+			for k, want := range tt.want {
+				got, _ := json.Marshal(rec[k])
+				wantJSON, _ := json.Marshal(want)
+				if !bytes.Equal(got, wantJSON) {
+					t.Errorf("Expected %s=%s, got %s", k, wantJSON, got)
+				}
+			}
+			if truncated := rec[TruncatedRecordKey] == true; truncated != tt.wantTruncated {
+				t.Errorf("Expected %s=%v, got %v", TruncatedRecordKey, tt.wantTruncated, rec[TruncatedRecordKey])
+			}
+			if msg, _ := rec[slog.MessageKey].(string); len(tt.msg) > len(msg) && !strings.HasSuffix(msg, TruncationMarker) {
+				t.Errorf("Expected the truncated message to end with %q, got %q", TruncationMarker, msg)
+			}
+		})
+	}
+}
+
+func TestNewLimitHandler_NoLimits(t *testing.T) {
+	h := slog.NewJSONHandler(&bytes.Buffer{}, nil)
+	if got := NewLimitHandler(h, LimitOptions{}); got != h {
+		t.Errorf("Expected the given handler without limits, got %T", got)
+	}
+}
@@ -0,0 +1,476 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// lokiPushPath is the path of Loki's push endpoint.
+const lokiPushPath = "/loki/api/v1/push"
+
+const (
+	// defaultLokiBatchSize is the default [LokiOptions.BatchSize].
+	defaultLokiBatchSize = 1000
+	// defaultLokiBatchWait is the default [LokiOptions.BatchWait].
+	defaultLokiBatchWait = time.Second
+	// defaultLokiMaxRetries is the default [LokiOptions.MaxRetries].
+	defaultLokiMaxRetries = 5
+	// defaultLokiMinBackoff is the default [LokiOptions.MinBackoff].
+	defaultLokiMinBackoff = 500 * time.Millisecond
+	// defaultLokiMaxBackoff is the default [LokiOptions.MaxBackoff].
+	defaultLokiMaxBackoff = 30 * time.Second
+	// defaultLokiTimeout is the timeout of the default [LokiOptions.Client].
+	defaultLokiTimeout = 10 * time.Second
+	// maxLokiErrorLength is the maximum length of the response body included in the error of a failed push.
+	maxLokiErrorLength = 1024
+	// lokiBufferBatches is the default [LokiOptions.BufferSize] in multiples of the [LokiOptions.BatchSize].
+	lokiBufferBatches = 10
+)
+
+// ErrLokiDropped is returned by a [LokiHandler] and reported to [LokiOptions.OnError]
+// if records were dropped because the buffer was full.
+var ErrLokiDropped = errors.New("loki buffer full, records dropped")
+
+// LokiOptions is the configuration for [NewLokiHandler].
+type LokiOptions struct {
+	// URL is the base URL of Loki, e.g. "http://loki:3100". The records are pushed to its /loki/api/v1/push endpoint.
+	URL string
+	// Level is the minimum log level.
+	Level Level
+	// Labels are the static labels of all streams. Defaults to a "job" label with the name of the executable.
+	Labels map[string]string
+	// LabelKeys are the dotted paths of the attributes promoted to stream labels, e.g. "service" or "http.method".
+	// The label names are the paths with all characters not allowed in label names replaced by underscores.
+	// Only use attributes with few distinct values, since every combination of labels is a separate stream.
+	LabelKeys []string
+	// Username and Password are the credentials for basic authentication.
+	Username string
+	Password string
+	// BearerToken is the token for bearer authentication. It takes precedence over basic authentication.
+	BearerToken string
+	// TenantID is the tenant of a multi-tenant Loki, sent as X-Scope-OrgID header.
+	TenantID string
+	// BatchSize is the number of records pushed at once. Defaults to 1000.
+	BatchSize int
+	// BatchWait is the maximum time records are buffered before they are pushed. Defaults to 1 second.
+	BatchWait time.Duration
+	// BufferSize is the maximum number of records buffered while a batch is pushed, e.g. while a failed push is retried.
+	// If the buffer is full, new records are dropped. Defaults to 10 times the BatchSize.
+	BufferSize int
+	// MaxRetries is the number of retries of a failed push. Defaults to 5.
+	MaxRetries int
+	// MinBackoff is the wait before the first retry, which is doubled with every retry. Defaults to 500 milliseconds.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum wait between retries. Defaults to 30 seconds.
+	MaxBackoff time.Duration
+	// Client is the HTTP client used to push the records. Defaults to a client with a timeout of 10 seconds.
+	Client *http.Client
+	// OnError is called with the errors of pushes that failed after all retries, which cannot be returned to the caller.
+	OnError func(err error)
+}
+
+// newLokiOptions returns the provided LokiOptions merged with the default LokiOptions.
+func newLokiOptions(o LokiOptions) LokiOptions {
+	opts := o.merge(LokiOptions{
+		Level:      LevelInfo,
+		Labels:     map[string]string{"job": filepath.Base(os.Args[0])},
+		BatchSize:  defaultLokiBatchSize,
+		BatchWait:  defaultLokiBatchWait,
+		MaxRetries: defaultLokiMaxRetries,
+		MinBackoff: defaultLokiMinBackoff,
+		MaxBackoff: defaultLokiMaxBackoff,
+		Client:     &http.Client{Timeout: defaultLokiTimeout},
+	})
+	if opts.BufferSize == 0 {
+		opts.BufferSize = lokiBufferBatches * opts.BatchSize
+	}
+	return opts
+}
+
+// merge merges the provided LokiOptions with the receiver LokiOptions.
+func (o *LokiOptions) merge(d LokiOptions) LokiOptions {
+	if o.Level != 0 {
+		d.Level = o.Level
+	}
+	if o.Labels != nil {
+		d.Labels = o.Labels
+	}
+	if o.LabelKeys != nil {
+		d.LabelKeys = o.LabelKeys
+	}
+	for _, f := range []struct{ src, dst *string }{
+		{&o.URL, &d.URL},
+		{&o.Username, &d.Username},
+		{&o.Password, &d.Password},
+		{&o.BearerToken, &d.BearerToken},
+		{&o.TenantID, &d.TenantID},
+	} {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+	if o.BatchSize > 0 {
+		d.BatchSize = o.BatchSize
+	}
+	if o.BatchWait > 0 {
+		d.BatchWait = o.BatchWait
+	}
+	if o.BufferSize > 0 {
+		d.BufferSize = o.BufferSize
+	}
+	if o.MaxRetries > 0 {
+		d.MaxRetries = o.MaxRetries
+	}
+	if o.MinBackoff > 0 {
+		d.MinBackoff = o.MinBackoff
+	}
+	if o.MaxBackoff > 0 {
+		d.MaxBackoff = o.MaxBackoff
+	}
+	if o.Client != nil {
+		d.Client = o.Client
+	}
+	if o.OnError != nil {
+		d.OnError = o.OnError
+	}
+	return d
+}
+
+// lokiStream is a stream of a push request: the labels and the timestamps and lines of its entries.
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// lokiBatch is the batch and worker shared by a [LokiHandler] and the handlers derived from it.
+// It is the writer of the JSON handler encoding the lines, which writes every record with a single call.
+type lokiBatch struct {
+	opts LokiOptions
+	mu   sync.Mutex
+	// streams are the buffered streams by their canonical labels.
+	streams map[string]*lokiStream
+	size    int
+	// dropped is the number of records dropped since the last push because the buffer was full.
+	dropped int
+	// ids are the IDs of the buffered records, whose delivery is reported to the callers of [Ack] once they are pushed.
+	ids []string
+	// labels, time and id are the labels, the time and the ID of the record currently written.
+	labels map[string]string
+	time   time.Time
+	id     string
+	closed bool
+	full   chan struct{}
+	stop   chan struct{}
+	done   chan struct{}
+	errMu  sync.Mutex
+	// err is the error of the last push that failed after all retries.
+	err error
+}
+
+var _ slog.Handler = (*LokiHandler)(nil)
+
+// LokiHandler is a [slog.Handler] pushing records in batches to Grafana Loki.
+// It must be closed to push the buffered records.
+type LokiHandler struct {
+	slog.Handler
+	batch *lokiBatch
+	// prefix is the dotted path of the groups of the handler.
+	prefix string
+	// labels are the labels of the attributes added by WithAttrs.
+	labels map[string]string
+}
+
+// NewLokiHandler returns a new [LokiHandler] pushing records as JSON lines to the /loki/api/v1/push endpoint of Loki.
+// The records are buffered and pushed once [LokiOptions.BatchSize] records are buffered
+// or [LokiOptions.BatchWait] elapsed. Failed pushes are retried with exponential backoff
+// if Loki is unreachable, rate limits the requests or returns a server error.
+//
+// Every stream has the [LokiOptions.Labels] and the values of the attributes at [LokiOptions.LabelKeys] as labels.
+// The promoted attributes are still written to the lines.
+//
+// The delivery of records with a [RecordIDKey] attribute is reported to the callers of [Ack]
+// once Loki accepted their batch or the push failed after all retries.
+func NewLokiHandler(o LokiOptions) *LokiHandler {
+	opts := newLokiOptions(o)
+	b := &lokiBatch{
+		opts:    opts,
+		streams: map[string]*lokiStream{},
+		full:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go b.run()
+	return &LokiHandler{
+		Handler: slog.NewJSONHandler(b, &slog.HandlerOptions{
+			AddSource:   true,
+			Level:       slog.Level(opts.Level),
+			ReplaceAttr: replaceAttr,
+		}),
+		batch:  b,
+		labels: map[string]string{},
+	}
+}
+
+// Handle buffers the record in the stream of its labels.
+// It returns [ErrHandlerClosed] once the handler is closed.
+func (h *LokiHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	labels := maps.Clone(h.labels)
+	r.Attrs(func(a slog.Attr) bool {
+		h.batch.addLabels(labels, h.prefix, a)
+		return true
+	})
+
+	h.batch.mu.Lock()
+	if h.batch.closed {
+		h.batch.mu.Unlock()
+		return ErrHandlerClosed
+	}
+	if h.batch.size >= h.batch.opts.BufferSize {
+		h.batch.dropped++
+		h.batch.mu.Unlock()
+		return ErrLokiDropped
+	}
+	h.batch.labels, h.batch.time, h.batch.id = labels, r.Time, recordID(r)
+	err := h.Handler.Handle(ctx, r)
+	full := h.batch.size >= h.batch.opts.BatchSize
+	h.batch.mu.Unlock()
+
+	if full {
+		select {
+		case h.batch.full <- struct{}{}:
+		default:
+		}
+	}
+	return err
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *LokiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	labels := maps.Clone(h.labels)
+	for _, a := range attrs {
+		h.batch.addLabels(labels, h.prefix, a)
+	}
+	return &LokiHandler{Handler: h.Handler.WithAttrs(attrs), batch: h.batch, prefix: h.prefix, labels: labels}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *LokiHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &LokiHandler{Handler: h.Handler.WithGroup(name), batch: h.batch, prefix: h.prefix + name + ".", labels: h.labels}
+}
+
+// Close stops accepting records, pushes the buffered records and returns the error of the last failed push.
+// Failed pushes are not retried anymore once the handler is closed.
+// It closes the handlers derived by WithAttrs and WithGroup as well.
+func (h *LokiHandler) Close() error {
+	h.batch.mu.Lock()
+	if !h.batch.closed {
+		h.batch.closed = true
+		close(h.batch.stop)
+	}
+	h.batch.mu.Unlock()
+
+	<-h.batch.done
+	h.batch.errMu.Lock()
+	defer h.batch.errMu.Unlock()
+	return h.batch.err
+}
+
+// addLabels adds the values of the attribute at the label keys to labels.
+func (b *lokiBatch) addLabels(labels map[string]string, prefix string, a slog.Attr) {
+	if len(b.opts.LabelKeys) == 0 {
+		return
+	}
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			b.addLabels(labels, prefix, ga)
+		}
+		return
+	}
+	if path := prefix + a.Key; slices.Contains(b.opts.LabelKeys, path) {
+		labels[lokiLabelName(path)] = a.Value.String()
+	}
+}
+
+// lokiLabelName returns the path as Loki label name, which consists of letters, digits and underscores
+// and does not start with a digit.
+func lokiLabelName(path string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, path)
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+// Write buffers the line in the stream of the labels of the record currently written.
+// Must be called with the lock held, which the JSON handler does since it is only called by [LokiHandler.Handle].
+func (b *lokiBatch) Write(p []byte) (int, error) {
+	labels := maps.Clone(b.opts.Labels)
+	maps.Copy(labels, b.labels)
+	key := lokiStreamKey(labels)
+
+	s, ok := b.streams[key]
+	if !ok {
+		s = &lokiStream{Stream: labels}
+		b.streams[key] = s
+	}
+	ts := b.time
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	s.Values = append(s.Values, [2]string{strconv.FormatInt(ts.UnixNano(), 10), string(bytes.TrimSuffix(p, []byte("\n")))})
+	b.size++
+	if b.id != "" {
+		b.ids = append(b.ids, b.id)
+	}
+	return len(p), nil
+}
+
+// lokiStreamKey returns the canonical representation of the labels.
+func lokiStreamKey(labels map[string]string) string {
+	var b strings.Builder
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(strconv.Quote(labels[k]))
+		b.WriteByte(',')
+	}
+	return b.String()
+}
+
+// run pushes the buffered records once the batch is full or the batch wait elapsed until the handler is closed.
+func (b *lokiBatch) run() {
+	defer close(b.done)
+	ticker := time.NewTicker(b.opts.BatchWait)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-b.full:
+		case <-b.stop:
+			b.flush()
+			return
+		}
+		b.flush()
+	}
+}
+
+// flush pushes the buffered records and reports a failed push.
+func (b *lokiBatch) flush() {
+	b.mu.Lock()
+	streams := slices.SortedFunc(maps.Values(b.streams), func(a, c *lokiStream) int {
+		return strings.Compare(lokiStreamKey(a.Stream), lokiStreamKey(c.Stream))
+	})
+	ids, dropped := b.ids, b.dropped
+	b.streams, b.size, b.ids, b.dropped = map[string]*lokiStream{}, 0, nil, 0
+	b.mu.Unlock()
+	if dropped > 0 {
+		b.report(fmt.Errorf("%w: %d", ErrLokiDropped, dropped))
+	}
+	if len(streams) == 0 {
+		return
+	}
+
+	err := b.push(streams)
+	for _, id := range ids {
+		Acknowledge(id, err)
+	}
+	if err == nil {
+		return
+	}
+	b.errMu.Lock()
+	b.err = err
+	b.errMu.Unlock()
+	b.report(err)
+}
+
+// report passes the error to [LokiOptions.OnError].
+func (b *lokiBatch) report(err error) {
+	if b.opts.OnError != nil {
+		b.opts.OnError(err)
+	}
+}
+
+// push sends the streams to Loki, retrying with exponential backoff if the push may succeed later,
+// until the handler is closed.
+func (b *lokiBatch) push(streams []*lokiStream) error {
+	body, err := json.Marshal(struct {
+		Streams []*lokiStream `json:"streams"`
+	}{Streams: streams})
+	if err != nil {
+		return fmt.Errorf("failed to encode loki push request: %w", err)
+	}
+
+	backoff := b.opts.MinBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := b.send(body)
+		if err == nil || !retry || attempt >= b.opts.MaxRetries {
+			return err
+		}
+		t := time.NewTimer(backoff)
+		select {
+		case <-t.C:
+		case <-b.stop:
+			t.Stop()
+			return err
+		}
+		backoff = min(backoff*2, b.opts.MaxBackoff) //nolint:mnd // the backoff is doubled with every retry
+	}
+}
+
+// send sends the push request once and reports whether a failed request should be retried.
+func (b *lokiBatch) send(body []byte) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, strings.TrimSuffix(b.opts.URL, "/")+lokiPushPath, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create loki push request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	switch {
+	case b.opts.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+b.opts.BearerToken)
+	case b.opts.Username != "" || b.opts.Password != "":
+		req.SetBasicAuth(b.opts.Username, b.opts.Password)
+	}
+	if b.opts.TenantID != "" {
+		req.Header.Set("X-Scope-OrgID", b.opts.TenantID)
+	}
+
+	resp, err := b.opts.Client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to push to loki: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 { //nolint:mnd // 2xx status codes
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return false, nil
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxLokiErrorLength))
+	err = fmt.Errorf("failed to push to loki: %s: %s", resp.Status, bytes.TrimSpace(msg))
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError, err
+}
@@ -0,0 +1,284 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lokiServer is a fake Loki recording the push requests.
+type lokiServer struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	streams  []lokiStream
+	// fail is the number of requests answered with the status before succeeding.
+	fail   int
+	status int
+}
+
+func (s *lokiServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r)
+	if s.fail > 0 {
+		s.fail--
+		http.Error(w, "try again", s.status)
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	var req struct {
+		Streams []lokiStream `json:"streams"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.streams = append(s.streams, req.Streams...)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// count returns the number of requests received.
+func (s *lokiServer) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.requests)
+}
+
+func TestLokiHandler(t *testing.T) {
+	srv := &lokiServer{}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	h := NewLokiHandler(LokiOptions{
+		URL:         ts.URL + "/",
+		Labels:      map[string]string{"job": "api"},
+		LabelKeys:   []string{"env", "http.method"},
+		BearerToken: "secret",
+		TenantID:    "team-a",
+	})
+	log := NewLogger(Options{Handler: h}).With("env", "prod")
+	log.Info("first", "http", map[string]any{"ignored": true})
+	log.WithGroup("http").Info("second", "method", "GET", "path", "/")
+	log.Info("third")
+	if err := h.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if len(srv.requests) != 1 {
+		t.Fatalf("Expected 1 push, got %d", len(srv.requests))
+	}
+	req := srv.requests[0]
+	if req.URL.Path != lokiPushPath {
+		t.Errorf("Expected path %q, got %q", lokiPushPath, req.URL.Path)
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("Expected bearer auth, got %q", got)
+	}
+	if got := req.Header.Get("X-Scope-OrgID"); got != "team-a" {
+		t.Errorf("Expected tenant team-a, got %q", got)
+	}
+
+	if len(srv.streams) != 2 {
+		t.Fatalf("Expected 2 streams, got %+v", srv.streams)
+	}
+	// The streams are sorted by their labels.
+	get, plain := srv.streams[0], srv.streams[1]
+	if len(plain.Stream) != 2 || plain.Stream["job"] != "api" || plain.Stream["env"] != "prod" {
+		t.Errorf("Unexpected labels %v", plain.Stream)
+	}
+	if len(plain.Values) != 2 || !strings.Contains(plain.Values[0][1], `"msg":"first"`) || !strings.Contains(plain.Values[1][1], `"msg":"third"`) {
+		t.Errorf("Unexpected values %v", plain.Values)
+	}
+	if get.Stream["http_method"] != "GET" {
+		t.Errorf("Expected label http_method=GET, got %v", get.Stream)
+	}
+	if len(get.Values) != 1 || !strings.Contains(get.Values[0][1], `"http":{"method":"GET","path":"/"}`) {
+		t.Errorf("Unexpected values %v", get.Values)
+	}
+
+	if err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "closed", 0)); !errors.Is(err, ErrHandlerClosed) {
+		t.Errorf("Expected ErrHandlerClosed, got %v", err)
+	}
+}
+
+func TestLokiHandler_Retry(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		fail     int
+		wantReqs int
+		wantErr  bool
+	}{
+		{name: "server error", status: http.StatusServiceUnavailable, fail: 2, wantReqs: 3},
+		{name: "rate limited", status: http.StatusTooManyRequests, fail: 1, wantReqs: 2},
+		{name: "retries exhausted", status: http.StatusInternalServerError, fail: 10, wantReqs: 4, wantErr: true},
+		{name: "client error", status: http.StatusBadRequest, fail: 1, wantReqs: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &lokiServer{fail: tt.fail, status: tt.status}
+			ts := httptest.NewServer(srv)
+			defer ts.Close()
+
+			var reported error
+			h := NewLokiHandler(LokiOptions{
+				URL:        ts.URL,
+				Username:   "user",
+				Password:   "pass",
+				BatchSize:  1,
+				MaxRetries: 3,
+				MinBackoff: time.Millisecond,
+				OnError:    func(err error) { reported = err },
+			})
+			NewLogger(Options{Handler: h}).Info("hello")
+			// The push is retried until the handler is closed.
+			for deadline := time.Now().Add(time.Second); srv.count() < tt.wantReqs && time.Now().Before(deadline); {
+				time.Sleep(time.Millisecond)
+			}
+			err := h.Close()
+
+			if len(srv.requests) != tt.wantReqs {
+				t.Errorf("Expected %d requests, got %d", tt.wantReqs, len(srv.requests))
+			}
+			if user, pass, ok := srv.requests[0].BasicAuth(); !ok || user != "user" || pass != "pass" {
+				t.Errorf("Expected basic auth, got %q %q", user, pass)
+			}
+			if (err != nil) != tt.wantErr || !errors.Is(reported, err) {
+				t.Errorf("Close() error = %v, reported %v, wantErr %v", err, reported, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLokiHandler_BatchSize(t *testing.T) {
+	srv := &lokiServer{}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	h := NewLokiHandler(LokiOptions{URL: ts.URL, BatchSize: 2, BatchWait: time.Hour})
+	defer h.Close()
+	log := NewLogger(Options{Handler: h})
+	log.Info("one")
+	log.Info("two")
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		srv.mu.Lock()
+		n := len(srv.streams)
+		srv.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected a push once the batch is full")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLokiHandler_Close(t *testing.T) {
+	srv := &lokiServer{fail: 100, status: http.StatusServiceUnavailable}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	var reported []error
+	h := NewLokiHandler(LokiOptions{
+		URL:        ts.URL,
+		BatchSize:  1,
+		BufferSize: 2,
+		MinBackoff: time.Hour,
+		OnError:    func(err error) { reported = append(reported, err) },
+	})
+	log := NewLogger(Options{Handler: h})
+	log.Info("pushed")
+	for deadline := time.Now().Add(time.Second); srv.count() < 1 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+
+	// The first record is retried, so the following ones are buffered until the buffer is full.
+	log.Info("buffered")
+	log.Info("buffered")
+	if err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "dropped", 0)); !errors.Is(err, ErrLokiDropped) {
+		t.Errorf("Expected %v, got %v", ErrLokiDropped, err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- h.Close() }()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("Expected the error of the failed push")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected Close to abort the backoff")
+	}
+
+	var dropped bool
+	for _, err := range reported {
+		dropped = dropped || errors.Is(err, ErrLokiDropped)
+	}
+	if !dropped {
+		t.Errorf("Expected the dropped records to be reported, got %v", reported)
+	}
+}
+
+func TestLokiHandler_Ack(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "accepted"},
+		{name: "rejected", status: http.StatusBadRequest, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &lokiServer{status: tt.status}
+			if tt.status != 0 {
+				srv.fail = 1
+			}
+			ts := httptest.NewServer(srv)
+			defer ts.Close()
+
+			h := NewLokiHandler(LokiOptions{URL: ts.URL, BatchWait: time.Hour})
+			id := NewULID()
+			NewLogger(Options{Handler: h}).Info("payment captured", RecordIDKey, id)
+			_ = h.Close()
+
+			if err := <-Ack(context.Background(), id); (err != nil) != tt.wantErr {
+				t.Errorf("Ack() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLokiLabelName(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "service", want: "service"},
+		{path: "http.method", want: "http_method"},
+		{path: "k8s-pod", want: "k8s_pod"},
+		{path: "1st", want: "_1st"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := lokiLabelName(tt.path); got != tt.want {
+				t.Errorf("lokiLabelName(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
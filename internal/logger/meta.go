@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+)
+
+// MetaKey is the key of the group carrying the metadata of a record, see [AddMeta].
+const MetaKey = "_meta"
+
+// AddMeta adds the given attributes to the metadata of the record.
+// The metadata is a side channel for wrapper handlers to pass decisions like routing or sampling downstream.
+// It is carried in a group under [MetaKey] and stripped before the record is written by the built-in handlers,
+// so it never shows up in the output. Custom terminal handlers can be wrapped with [NewMetaStripHandler].
+//
+// Example:
+//
+//	func (h *samplingHandler) Handle(ctx context.Context, r slog.Record) error {
+//		logger.AddMeta(&r, slog.Bool("sampled", true))
+//		return h.next.Handle(ctx, r)
+//	}
+func AddMeta(r *slog.Record, attrs ...slog.Attr) {
+	if len(attrs) == 0 {
+		return
+	}
+	r.AddAttrs(slog.Attr{Key: MetaKey, Value: slog.GroupValue(attrs...)})
+}
+
+// Meta returns the metadata of the record added by [AddMeta], in the order it was added.
+func Meta(r slog.Record) []slog.Attr { //nolint:gocritic // records are passed by value
+	var meta []slog.Attr
+	r.Attrs(func(a slog.Attr) bool {
+		if isMeta(a) {
+			meta = append(meta, a.Value.Group()...)
+		}
+		return true
+	})
+	return meta
+}
+
+// MetaValue returns the value of the metadata of the record with the given key.
+// If the key was added multiple times, the last value is returned.
+func MetaValue(r slog.Record, key string) (slog.Value, bool) { //nolint:gocritic // records are passed by value
+	var (
+		value slog.Value
+		found bool
+	)
+	for _, a := range Meta(r) {
+		if a.Key == key {
+			value, found = a.Value, true
+		}
+	}
+	return value, found
+}
+
+// isMeta reports whether the attribute is the metadata group of a record.
+func isMeta(a slog.Attr) bool {
+	return a.Key == MetaKey && a.Value.Kind() == slog.KindGroup
+}
+
+var _ slog.Handler = (*metaStripHandler)(nil)
+
+// metaStripHandler is a [slog.Handler] that removes the metadata from records.
+type metaStripHandler struct {
+	slog.Handler
+}
+
+// NewMetaStripHandler returns a new [slog.Handler] that removes the metadata added by [AddMeta]
+// from every record before passing it to the given handler.
+func NewMetaStripHandler(h slog.Handler) slog.Handler {
+	return &metaStripHandler{Handler: h}
+}
+
+// Handle removes the metadata from the record and passes it to the wrapped handler.
+func (h *metaStripHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	hasMeta := false
+	r.Attrs(func(a slog.Attr) bool {
+		hasMeta = isMeta(a)
+		return !hasMeta
+	})
+	if !hasMeta {
+		return h.Handler.Handle(ctx, r)
+	}
+
+	out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		if !isMeta(a) {
+			out.AddAttrs(a)
+		}
+		return true
+	})
+	return h.Handler.Handle(ctx, out)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *metaStripHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &metaStripHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *metaStripHandler) WithGroup(name string) slog.Handler {
+	return &metaStripHandler{Handler: h.Handler.WithGroup(name)}
+}
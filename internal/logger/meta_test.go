@@ -0,0 +1,98 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+)
+
+func TestMeta(t *testing.T) {
+	tests := []struct {
+		name     string
+		meta     [][]slog.Attr
+		wantLen  int
+		wantKey  string
+		wantVal  string
+		wantMiss bool
+	}{
+		{
+			name:     "no metadata",
+			wantMiss: true,
+			wantKey:  "route",
+		},
+		{
+			name:    "single",
+			meta:    [][]slog.Attr{{slog.String("route", "audit")}},
+			wantLen: 1,
+			wantKey: "route",
+			wantVal: "audit",
+		},
+		{
+			name: "last value wins",
+			meta: [][]slog.Attr{
+				{slog.String("route", "audit"), slog.Bool("sampled", true)},
+				{slog.String("route", "archive")},
+			},
+			wantLen: 3,
+			wantKey: "route",
+			wantVal: "archive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := slog.NewRecord(time.Now(), slog.LevelInfo, "test", 0)
+			r.AddAttrs(slog.String("key", "value"))
+			for _, m := range tt.meta {
+				AddMeta(&r, m...)
+			}
+
+			if got := len(Meta(r)); got != tt.wantLen {
+				t.Errorf("Expected %d metadata attributes, got %d", tt.wantLen, got)
+			}
+			v, ok := MetaValue(r, tt.wantKey)
+			if ok == tt.wantMiss {
+				t.Fatalf("Expected found to be %v, got %v", !tt.wantMiss, ok)
+			}
+			if ok && v.String() != tt.wantVal {
+				t.Errorf("Expected value %q, got %q", tt.wantVal, v.String())
+			}
+		})
+	}
+}
+
+func TestNewMetaStripHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	var seen slog.Value
+	reader := test.MockHandler{
+		HandleFunc: func(ctx context.Context, r slog.Record) error {
+			seen, _ = MetaValue(r, "route")
+			return NewMetaStripHandler(slog.NewJSONHandler(buf, nil)).Handle(ctx, r)
+		},
+	}
+	log := slog.New(&metaTestHandler{Handler: &reader})
+
+	log.Info("test", "key", "value")
+
+	if seen.String() != "audit" {
+		t.Errorf("Expected metadata to be passed downstream, got %q", seen.String())
+	}
+	if out := buf.String(); strings.Contains(out, MetaKey) || !strings.Contains(out, `"key":"value"`) {
+		t.Errorf("Expected metadata to be stripped from output, got %s", out)
+	}
+}
+
+// metaTestHandler adds metadata to every record.
+type metaTestHandler struct {
+	slog.Handler
+}
+
+func (h *metaTestHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	AddMeta(&r, slog.String("route", "audit"))
+	return h.Handler.Handle(ctx, r)
+}
@@ -0,0 +1,259 @@
+package logger
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// DropReasonSampler is the reason of the records dropped by [NewSamplingHandler].
+	DropReasonSampler = "sampler"
+	// DropReasonAsyncQueue is the reason of the records dropped by an [AsyncHandler] after it was closed.
+	DropReasonAsyncQueue = "async_queue"
+)
+
+// metricsContentType is the content type of the Prometheus text exposition format.
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// emittedKey identifies the records counted together by a [PipelineMetrics].
+type emittedKey struct {
+	handler string
+	level   string
+}
+
+// PipelineMetrics collects counters of the logging pipeline: the records emitted per handler and level,
+// the records dropped per reason, e.g. by the sampler or a closed async queue, and the errors per handler.
+// The counters are exposed in the Prometheus text format by ServeHTTP and WriteTo,
+// so dashboards can alert on error log surges or log loss, and as attributes by Metrics, e.g. for [SnapshotEvery].
+//
+// Records are not counted by a nil PipelineMetrics, so handlers can report to an optional collector unconditionally.
+type PipelineMetrics struct {
+	mu      sync.RWMutex
+	emitted map[emittedKey]*atomic.Uint64
+	dropped map[string]*atomic.Uint64
+	errors  map[string]*atomic.Uint64
+}
+
+var (
+	_ MetricsProvider = (*PipelineMetrics)(nil)
+	_ http.Handler    = (*PipelineMetrics)(nil)
+	_ io.WriterTo     = (*PipelineMetrics)(nil)
+)
+
+// NewPipelineMetrics returns a new [PipelineMetrics] without any counts.
+//
+// Example:
+//
+//	metrics := logger.NewPipelineMetrics()
+//	log := logger.NewLogger(logger.Options{Metrics: metrics, Sampling: &logger.SamplingOptions{}})
+//	http.Handle("/metrics/logging", metrics)
+func NewPipelineMetrics() *PipelineMetrics {
+	return &PipelineMetrics{
+		emitted: map[emittedKey]*atomic.Uint64{},
+		dropped: map[string]*atomic.Uint64{},
+		errors:  map[string]*atomic.Uint64{},
+	}
+}
+
+// emit counts a record emitted by the handler.
+func (m *PipelineMetrics) emit(handler string, level slog.Level) {
+	if m == nil {
+		return
+	}
+	metricsCounter(m, m.emitted, emittedKey{handler: handler, level: Level(level).String()}).Add(1)
+}
+
+// drop counts a record dropped for the reason.
+func (m *PipelineMetrics) drop(reason string) {
+	if m == nil {
+		return
+	}
+	metricsCounter(m, m.dropped, reason).Add(1)
+}
+
+// fail counts an error of the handler.
+func (m *PipelineMetrics) fail(handler string) {
+	if m == nil {
+		return
+	}
+	metricsCounter(m, m.errors, handler).Add(1)
+}
+
+// metricsCounter returns the counter of the key, creating it if it does not exist yet.
+func metricsCounter[K comparable](m *PipelineMetrics, counters map[K]*atomic.Uint64, key K) *atomic.Uint64 {
+	m.mu.RLock()
+	c, ok := counters[key]
+	m.mu.RUnlock()
+	if ok {
+		return c
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if c, ok = counters[key]; !ok {
+		c = &atomic.Uint64{}
+		counters[key] = c
+	}
+	return c
+}
+
+// metricSample is a sample of a counter with its label.
+type metricSample struct {
+	labels string
+	value  uint64
+}
+
+// metricFamily is a counter with its samples, sorted by their labels.
+type metricFamily struct {
+	name    string
+	help    string
+	samples []metricSample
+}
+
+// families returns the counters of the metrics.
+func (m *PipelineMetrics) families() []metricFamily {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	emitted := metricFamily{name: "loggerhead_records_emitted_total", help: "Records emitted by the handlers of the logging pipeline."}
+	for k, c := range m.emitted {
+		emitted.samples = append(emitted.samples, metricSample{
+			labels: fmt.Sprintf(`handler="%s",level="%s"`, escapeLabel(k.handler), escapeLabel(k.level)),
+			value:  c.Load(),
+		})
+	}
+	dropped := metricFamily{name: "loggerhead_records_dropped_total", help: "Records dropped by the logging pipeline."}
+	for reason, c := range m.dropped {
+		dropped.samples = append(dropped.samples, metricSample{labels: fmt.Sprintf(`reason="%s"`, escapeLabel(reason)), value: c.Load()})
+	}
+	errs := metricFamily{name: "loggerhead_handler_errors_total", help: "Errors returned by the handlers of the logging pipeline."}
+	for handler, c := range m.errors {
+		errs.samples = append(errs.samples, metricSample{labels: fmt.Sprintf(`handler="%s"`, escapeLabel(handler)), value: c.Load()})
+	}
+
+	families := []metricFamily{emitted, dropped, errs}
+	for _, f := range families {
+		slices.SortFunc(f.samples, func(a, b metricSample) int { return cmp.Compare(a.labels, b.labels) })
+	}
+	return families
+}
+
+// WriteTo writes the counters to w in the Prometheus text exposition format.
+func (m *PipelineMetrics) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	for _, f := range m.families() {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s counter\n", f.name, f.help, f.name)
+		for _, s := range f.samples {
+			fmt.Fprintf(&b, "%s{%s} %d\n", f.name, s.labels, s.value)
+		}
+	}
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// ServeHTTP writes the counters in the Prometheus text exposition format, so they can be scraped by Prometheus.
+func (m *PipelineMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", metricsContentType)
+	_, _ = m.WriteTo(w)
+}
+
+// Metrics returns the counters grouped as "emitted" by handler and level, "dropped" by reason and "errors" by handler.
+func (m *PipelineMetrics) Metrics(_ context.Context) []slog.Attr {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	handlers := map[string][]any{}
+	for k, c := range m.emitted {
+		handlers[k.handler] = append(handlers[k.handler], slog.Uint64(k.level, c.Load()))
+	}
+	emitted := make([]slog.Attr, 0, len(handlers))
+	for handler, levels := range handlers {
+		emitted = append(emitted, slog.Group(handler, levels...))
+	}
+	dropped := make([]slog.Attr, 0, len(m.dropped))
+	for reason, c := range m.dropped {
+		dropped = append(dropped, slog.Uint64(reason, c.Load()))
+	}
+	errs := make([]slog.Attr, 0, len(m.errors))
+	for handler, c := range m.errors {
+		errs = append(errs, slog.Uint64(handler, c.Load()))
+	}
+
+	return []slog.Attr{
+		slog.Group("emitted", attrsToArgs(sortAttrs(emitted))...),
+		slog.Group("dropped", attrsToArgs(sortAttrs(dropped))...),
+		slog.Group("errors", attrsToArgs(sortAttrs(errs))...),
+	}
+}
+
+// sortAttrs sorts the attributes by their keys and returns them.
+func sortAttrs(attrs []slog.Attr) []slog.Attr {
+	slices.SortFunc(attrs, func(a, b slog.Attr) int { return cmp.Compare(a.Key, b.Key) })
+	return attrs
+}
+
+// labelEscaper escapes the characters not allowed in the label values of the Prometheus text exposition format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabel returns the label value escaped for the Prometheus text exposition format.
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+var _ slog.Handler = (*metricsHandler)(nil)
+
+// metricsHandler is a [slog.Handler] counting the records and errors of the wrapped handler.
+type metricsHandler struct {
+	slog.Handler
+	metrics *PipelineMetrics
+	name    string
+}
+
+// NewMetricsHandler returns a new [slog.Handler] that counts the records handled by the given handler
+// per level and the errors it returns in the metrics under the given handler name, e.g. the name of the output.
+// Returns the given handler if the metrics are nil.
+func NewMetricsHandler(h slog.Handler, m *PipelineMetrics, name string) slog.Handler {
+	if m == nil {
+		return h
+	}
+	return &metricsHandler{Handler: h, metrics: m, name: name}
+}
+
+// Handle passes the record to the wrapped handler and counts it if it was handled or the error otherwise.
+func (h *metricsHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	if err := h.Handler.Handle(ctx, r); err != nil {
+		h.metrics.fail(h.name)
+		return err
+	}
+	h.metrics.emit(h.name, r.Level)
+	return nil
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *metricsHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &metricsHandler{Handler: h.Handler.WithAttrs(attrs), metrics: h.metrics, name: h.name}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *metricsHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &metricsHandler{Handler: h.Handler.WithGroup(name), metrics: h.metrics, name: h.name}
+}
+
+// metricsHandlerName returns the name the records written to the output are counted under.
+func metricsHandlerName(output string) string {
+	if output == "" {
+		return "stderr"
+	}
+	return output
+}
@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPipelineMetrics(t *testing.T) {
+	tests := []struct {
+		name string
+		log  func(m *PipelineMetrics)
+		want string
+	}{
+		{
+			name: "no records",
+			log:  func(*PipelineMetrics) {},
+			want: "",
+		},
+		{
+			name: "emitted and errors",
+			log: func(m *PipelineMetrics) {
+				var out strings.Builder
+				failing := newFlakyHandler(&out)
+				failing.fail.Store(true)
+				ok := slog.New(NewMetricsHandler(slog.NewJSONHandler(&bytes.Buffer{}, nil), m, "file"))
+				ok.Info("test")
+				ok.Info("test")
+				ok.WithGroup("g").With("a", 1).Error("test")
+				slog.New(NewMetricsHandler(failing, m, `sink "b"`)).Warn("test")
+			},
+			want: `loggerhead_records_emitted_total{handler="file",level="ERROR"} 1
+loggerhead_records_emitted_total{handler="file",level="INFO"} 2
+loggerhead_handler_errors_total{handler="sink \"b\""} 1
+`,
+		},
+		{
+			name: "sampled",
+			log: func(m *PipelineMetrics) {
+				log := NewLogger(Options{Output: "discard", Metrics: m, Sampling: &SamplingOptions{Initial: 1, Thereafter: 100}})
+				for range 3 {
+					log.Info("test")
+				}
+			},
+			want: `loggerhead_records_emitted_total{handler="discard",level="INFO"} 1
+loggerhead_records_dropped_total{reason="sampler"} 2
+`,
+		},
+		{
+			name: "closed async queue",
+			log: func(m *PipelineMetrics) {
+				h := NewAsyncHandler(slog.NewJSONHandler(&bytes.Buffer{}, nil), AsyncOptions{Metrics: m})
+				_ = h.Close()
+				if err := h.Handle(context.Background(), slog.Record{}); !errors.Is(err, ErrHandlerClosed) {
+					t.Errorf("Expected %v, got %v", ErrHandlerClosed, err)
+				}
+			},
+			want: `loggerhead_records_dropped_total{reason="async_queue"} 1
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewPipelineMetrics()
+			tt.log(m)
+
+			rec := httptest.NewRecorder()
+			m.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+			if ct := rec.Header().Get("Content-Type"); ct != metricsContentType {
+				t.Errorf("Expected content type %q, got %q", metricsContentType, ct)
+			}
+			var samples strings.Builder
+			for _, line := range strings.SplitAfter(rec.Body.String(), "\n") {
+				if line != "" && !strings.HasPrefix(line, "#") {
+					samples.WriteString(line)
+				}
+			}
+			if got := samples.String(); got != tt.want {
+				t.Errorf("Expected samples\n%s\ngot\n%s", tt.want, got)
+			}
+			if !strings.Contains(rec.Body.String(), "# TYPE loggerhead_records_dropped_total counter\n") {
+				t.Errorf("Expected the type of the counters, got\n%s", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestPipelineMetrics_Metrics(t *testing.T) {
+	m := NewPipelineMetrics()
+	slog.New(NewMetricsHandler(slog.NewJSONHandler(&bytes.Buffer{}, nil), m, "stdout")).Info("test")
+	m.drop(DropReasonSampler)
+
+	buf := &bytes.Buffer{}
+	slog.New(slog.NewJSONHandler(buf, nil)).LogAttrs(context.Background(), slog.LevelInfo, "snapshot", m.Metrics(context.Background())...)
+	if want := `"emitted":{"stdout":{"INFO":1}},"dropped":{"sampler":1}`; !strings.Contains(buf.String(), want) {
+		t.Errorf("Expected %s, got %s", want, buf.String())
+	}
+}
+
+func TestNewMetricsHandler_NoMetrics(t *testing.T) {
+	h := slog.NewJSONHandler(&bytes.Buffer{}, nil)
+	if got := NewMetricsHandler(h, nil, "stderr"); got != h {
+		t.Errorf("Expected the given handler without metrics, got %T", got)
+	}
+}
@@ -0,0 +1,217 @@
+package logger
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// The subset of the MessagePack format used by the Fluent forward protocol.
+// See https://github.com/msgpack/msgpack/blob/master/spec.md.
+const (
+	msgpackNil     = 0xc0
+	msgpackFalse   = 0xc2
+	msgpackTrue    = 0xc3
+	msgpackFloat64 = 0xcb
+	msgpackUint8   = 0xcc
+	msgpackUint16  = 0xcd
+	msgpackUint32  = 0xce
+	msgpackUint64  = 0xcf
+	msgpackInt8    = 0xd0
+	msgpackInt16   = 0xd1
+	msgpackInt32   = 0xd2
+	msgpackInt64   = 0xd3
+	msgpackFixExt8 = 0xd7
+	msgpackStr8    = 0xd9
+	msgpackStr16   = 0xda
+	msgpackStr32   = 0xdb
+	msgpackArray16 = 0xdc
+	msgpackArray32 = 0xdd
+	msgpackMap16   = 0xde
+	msgpackMap32   = 0xdf
+)
+
+// errMsgpackType is returned when decoding a MessagePack value of an unsupported type.
+var errMsgpackType = errors.New("unsupported msgpack type")
+
+// appendMsgpackNil appends the MessagePack nil value to b.
+func appendMsgpackNil(b []byte) []byte {
+	return append(b, msgpackNil)
+}
+
+// appendMsgpackBool appends the bool as MessagePack value to b.
+func appendMsgpackBool(b []byte, v bool) []byte {
+	if v {
+		return append(b, msgpackTrue)
+	}
+	return append(b, msgpackFalse)
+}
+
+// appendMsgpackInt appends the integer as MessagePack value of the smallest possible size to b.
+func appendMsgpackInt(b []byte, v int64) []byte {
+	switch {
+	case v >= 0:
+		return appendMsgpackUint(b, uint64(v))
+	case v >= -32:
+		return append(b, byte(v))
+	case v >= math.MinInt8:
+		return append(b, msgpackInt8, byte(v))
+	case v >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(b, msgpackInt16), uint16(v)) //nolint:gosec // two's complement
+	case v >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(b, msgpackInt32), uint32(v)) //nolint:gosec // two's complement
+	default:
+		return binary.BigEndian.AppendUint64(append(b, msgpackInt64), uint64(v)) //nolint:gosec // two's complement
+	}
+}
+
+// appendMsgpackUint appends the unsigned integer as MessagePack value of the smallest possible size to b.
+func appendMsgpackUint(b []byte, v uint64) []byte {
+	switch {
+	case v <= math.MaxInt8:
+		return append(b, byte(v))
+	case v <= math.MaxUint8:
+		return append(b, msgpackUint8, byte(v))
+	case v <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, msgpackUint16), uint16(v))
+	case v <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, msgpackUint32), uint32(v))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, msgpackUint64), v)
+	}
+}
+
+// appendMsgpackFloat appends the float as MessagePack value to b.
+func appendMsgpackFloat(b []byte, v float64) []byte {
+	return binary.BigEndian.AppendUint64(append(b, msgpackFloat64), math.Float64bits(v))
+}
+
+// appendMsgpackString appends the string as MessagePack value to b.
+func appendMsgpackString(b []byte, s string) []byte {
+	switch n := len(s); {
+	case n < 32:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, msgpackStr8, byte(n))
+	case n <= math.MaxUint16:
+		b = binary.BigEndian.AppendUint16(append(b, msgpackStr16), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, msgpackStr32), uint32(n)) //nolint:gosec // strings are smaller than 4 GiB
+	}
+	return append(b, s...)
+}
+
+// appendMsgpackArrayHeader appends the header of an array with n elements to b.
+func appendMsgpackArrayHeader(b []byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(b, 0x90|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, msgpackArray16), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(b, msgpackArray32), uint32(n)) //nolint:gosec // arrays are smaller than 4 Gi
+	}
+}
+
+// appendMsgpackMapHeader appends the header of a map with n entries to b.
+func appendMsgpackMapHeader(b []byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(b, 0x80|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, msgpackMap16), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(b, msgpackMap32), uint32(n)) //nolint:gosec // maps are smaller than 4 Gi
+	}
+}
+
+// appendMsgpackEventTime appends the time as EventTime extension of the Fluent forward protocol to b,
+// which carries the seconds and nanoseconds since the unix epoch.
+func appendMsgpackEventTime(b []byte, t time.Time) []byte {
+	b = append(b, msgpackFixExt8, 0)
+	b = binary.BigEndian.AppendUint32(b, uint32(t.Unix()))          //nolint:gosec // valid until 2106
+	return binary.BigEndian.AppendUint32(b, uint32(t.Nanosecond())) //nolint:gosec // nanoseconds are below 1e9
+}
+
+// readMsgpackStringMap reads a MessagePack map with string keys and string values from r.
+func readMsgpackStringMap(r io.ByteReader) (map[string]string, error) {
+	c, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	var n int
+	switch {
+	case c&0xf0 == 0x80:
+		n = int(c & 0x0f)
+	case c == msgpackMap16:
+		size, err := readMsgpackUint(r, 2) //nolint:mnd // size of the length
+		if err != nil {
+			return nil, err
+		}
+		n = int(size) //nolint:gosec // at most 16 bits
+	default:
+		return nil, fmt.Errorf("%w: 0x%x, expected map", errMsgpackType, c)
+	}
+
+	m := make(map[string]string, n)
+	for range n {
+		k, err := readMsgpackString(r)
+		if err != nil {
+			return nil, err
+		}
+		v, err := readMsgpackString(r)
+		if err != nil {
+			return nil, err
+		}
+		m[k] = v
+	}
+	return m, nil
+}
+
+// readMsgpackString reads a MessagePack string from r.
+func readMsgpackString(r io.ByteReader) (string, error) {
+	c, err := r.ReadByte()
+	if err != nil {
+		return "", err
+	}
+	var n uint64
+	switch {
+	case c&0xe0 == 0xa0:
+		n = uint64(c & 0x1f)
+	case c == msgpackStr8:
+		n, err = readMsgpackUint(r, 1)
+	case c == msgpackStr16:
+		n, err = readMsgpackUint(r, 2) //nolint:mnd // size of the length
+	case c == msgpackStr32:
+		n, err = readMsgpackUint(r, 4) //nolint:mnd // size of the length
+	default:
+		return "", fmt.Errorf("%w: 0x%x, expected string", errMsgpackType, c)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	s := make([]byte, n)
+	for i := range s {
+		if s[i], err = r.ReadByte(); err != nil {
+			return "", err
+		}
+	}
+	return string(s), nil
+}
+
+// readMsgpackUint reads a big-endian unsigned integer of the given size in bytes from r.
+func readMsgpackUint(r io.ByteReader, size int) (uint64, error) {
+	var v uint64
+	for range size {
+		c, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		v = v<<8 | uint64(c)
+	}
+	return v, nil
+}
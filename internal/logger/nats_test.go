@@ -0,0 +1,236 @@
+package logger
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// natsServer is a fake NATS server accepting connections on a listener and recording the published messages.
+type natsServer struct {
+	ln net.Listener
+	// reject is the JetStream error description returned for the published messages, if set.
+	reject string
+	mu     sync.Mutex
+	// connect is the CONNECT line of the last connection.
+	connect  string
+	messages chan natsMsg
+}
+
+// newNATSServer returns a new fake NATS server listening on the given address.
+func newNATSServer(t *testing.T, addr string) *natsServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	s := &natsServer{ln: ln, messages: make(chan natsMsg, 100)}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *natsServer) serve(conn net.Conn) {
+	defer conn.Close()
+	_, _ = io.WriteString(conn, "INFO {\"server_id\":\"test\"}\r\n")
+	r := bufio.NewReader(conn)
+	var seq int
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		op, args, _ := strings.Cut(strings.TrimSpace(line), " ")
+		switch op {
+		case "CONNECT":
+			s.mu.Lock()
+			s.connect = args
+			s.mu.Unlock()
+		case "PING":
+			_, _ = io.WriteString(conn, "PONG\r\n")
+		case "PUB":
+			fields := strings.Fields(args)
+			n, _ := strconv.Atoi(fields[len(fields)-1])
+			payload := make([]byte, n+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return
+			}
+			s.messages <- natsMsg{subject: fields[0], payload: payload[:n]}
+			if len(fields) == 3 {
+				seq++
+				ack := fmt.Sprintf(`{"stream":"LOGS","seq":%d}`, seq)
+				if s.reject != "" {
+					ack = fmt.Sprintf(`{"error":{"code":400,"description":%q}}`, s.reject)
+				}
+				_, _ = fmt.Fprintf(conn, "MSG %s 1 %d\r\n%s\r\n", fields[1], len(ack), ack)
+			}
+		}
+	}
+}
+
+// receive returns the next published message or fails the test after a second.
+func (s *natsServer) receive(t *testing.T) natsMsg {
+	t.Helper()
+	select {
+	case msg := <-s.messages:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for a message")
+		return natsMsg{}
+	}
+}
+
+func TestNATSHandler(t *testing.T) {
+	srv := newNATSServer(t, "127.0.0.1:0")
+	h := NewNATSHandler(NATSOptions{URL: "nats://user:secret@" + srv.ln.Addr().String(), Subject: "app.logs"})
+	defer h.Close()
+
+	NewLogger(Options{Handler: h}).With("service", "api").Info("hello")
+	msg := srv.receive(t)
+	if msg.subject != "app.logs" {
+		t.Errorf("Expected subject app.logs, got %q", msg.subject)
+	}
+	if !strings.Contains(string(msg.payload), `"msg":"hello","service":"api"`) {
+		t.Errorf("Unexpected payload %s", msg.payload)
+	}
+	srv.mu.Lock()
+	connect := srv.connect
+	srv.mu.Unlock()
+	if !strings.Contains(connect, `"user":"user"`) || !strings.Contains(connect, `"pass":"secret"`) {
+		t.Errorf("Expected the credentials, got %s", connect)
+	}
+
+	if err := h.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := h.publisher.Write([]byte("{}")); !errors.Is(err, ErrHandlerClosed) {
+		t.Errorf("Expected ErrHandlerClosed, got %v", err)
+	}
+}
+
+func TestNATSHandler_JetStream(t *testing.T) {
+	tests := []struct {
+		name    string
+		reject  string
+		wantErr error
+	}{
+		{name: "acknowledged"},
+		{name: "rejected", reject: "maximum messages exceeded", wantErr: ErrNATSPublish},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newNATSServer(t, "127.0.0.1:0")
+			srv.reject = tt.reject
+			var mu sync.Mutex
+			var reported []error
+			h := NewNATSHandler(NATSOptions{
+				URL:       "nats://" + srv.ln.Addr().String(),
+				JetStream: true,
+				OnError: func(err error) {
+					mu.Lock()
+					defer mu.Unlock()
+					reported = append(reported, err)
+				},
+			})
+
+			id := NewULID()
+			log := NewLogger(Options{Handler: h})
+			log.Info("first", RecordIDKey, id)
+			log.Info("second")
+			if err := h.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			if err := <-Ack(context.Background(), id); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Ack() = %v, want %v", err, tt.wantErr)
+			}
+
+			// Both records are published once, even if they are rejected.
+			for range 2 {
+				_ = srv.receive(t)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if tt.wantErr == nil && len(reported) > 0 {
+				t.Errorf("Expected no errors, got %v", reported)
+			}
+			if tt.wantErr != nil && (len(reported) != 2 || !errors.Is(reported[0], tt.wantErr)) {
+				t.Errorf("Expected 2 errors wrapping %v, got %v", tt.wantErr, reported)
+			}
+		})
+	}
+}
+
+func TestNATSHandler_Reconnect(t *testing.T) {
+	// Reserve a port, which is unreachable until the server is started.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close()
+
+	dropped := make(chan error, 10)
+	h := NewNATSHandler(NATSOptions{
+		URL:           "nats://" + addr,
+		BufferSize:    2,
+		ReconnectWait: 10 * time.Millisecond,
+		OnError: func(err error) {
+			if errors.Is(err, ErrNATSDropped) {
+				dropped <- err
+			}
+		},
+	})
+	defer h.Close()
+
+	log := NewLogger(Options{Handler: h})
+	for i := range 3 {
+		log.Info("record", "i", i)
+	}
+
+	srv := newNATSServer(t, addr)
+	for _, want := range []string{`"i":1`, `"i":2`} {
+		if msg := srv.receive(t); !strings.Contains(string(msg.payload), want) {
+			t.Errorf("Expected payload with %s, got %s", want, msg.payload)
+		}
+	}
+	select {
+	case err := <-dropped:
+		if !strings.HasSuffix(err.Error(), ": 1") {
+			t.Errorf("Expected 1 dropped record, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected the dropped records to be reported")
+	}
+}
+
+func TestNATSHandler_CloseUnreachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close()
+
+	h := NewNATSHandler(NATSOptions{URL: "nats://" + addr, ReconnectWait: 10 * time.Millisecond, Timeout: 50 * time.Millisecond})
+	NewLogger(Options{Handler: h}).Info("lost")
+	if err := h.Close(); err == nil || !strings.Contains(err.Error(), "1 records") {
+		t.Errorf("Expected an error for the undelivered record, got %v", err)
+	}
+}
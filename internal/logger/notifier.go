@@ -0,0 +1,333 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+const (
+	// defaultNotifierInterval is the default [NotifierOptions.Interval].
+	defaultNotifierInterval = time.Minute
+	// defaultNotifierTimeout is the timeout of the default [NotifierOptions.Client].
+	defaultNotifierTimeout = 5 * time.Second
+)
+
+// NotifierOptions is the configuration for [NewNotifierHandler].
+type NotifierOptions struct {
+	// URL is the incoming webhook URL of the Slack or Microsoft Teams channel.
+	URL string
+	// Format is the payload format of the webhook, either "SLACK" or "TEAMS". Defaults to "SLACK".
+	Format string
+	// Level is the minimum level of the records notified about. Defaults to [LevelPanic].
+	Level Level
+	// Service is the name of the service in the notifications. Defaults to the name of the executable.
+	Service string
+	// Hostname is the name of the host in the notifications. Defaults to [os.Hostname].
+	Hostname string
+	// Link is a [text/template] of a link added to the notifications, e.g. to the logs of the service.
+	// It is executed with the fields Service, Hostname, Level, Message, Time and Attrs of the notification,
+	// e.g. "https://grafana/explore?service={{urlquery .Service}}&from={{.Time.UnixMilli}}".
+	Link string
+	// Interval is the minimum time between two notifications. The records in between are suppressed
+	// and counted in the next notification. Defaults to 1 minute.
+	Interval time.Duration
+	// Client is the HTTP client used to send the notifications. Defaults to a client with a timeout of 5 seconds.
+	Client *http.Client
+	// OnError is called with the errors of sending notifications, which are not returned to the caller.
+	OnError func(err error)
+}
+
+// newNotifierOptions returns the provided NotifierOptions merged with the default NotifierOptions.
+func newNotifierOptions(o NotifierOptions) NotifierOptions {
+	hostname, _ := os.Hostname()
+	return o.merge(NotifierOptions{
+		Format:   "SLACK",
+		Level:    LevelPanic,
+		Service:  filepath.Base(os.Args[0]),
+		Hostname: hostname,
+		Interval: defaultNotifierInterval,
+		Client:   &http.Client{Timeout: defaultNotifierTimeout},
+	})
+}
+
+// merge merges the provided NotifierOptions with the receiver NotifierOptions.
+func (o *NotifierOptions) merge(d NotifierOptions) NotifierOptions {
+	if o.Level != 0 {
+		d.Level = o.Level
+	}
+	for _, f := range []struct{ src, dst *string }{
+		{&o.URL, &d.URL},
+		{&o.Format, &d.Format},
+		{&o.Service, &d.Service},
+		{&o.Hostname, &d.Hostname},
+		{&o.Link, &d.Link},
+	} {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+	if o.Interval > 0 {
+		d.Interval = o.Interval
+	}
+	if o.Client != nil {
+		d.Client = o.Client
+	}
+	if o.OnError != nil {
+		d.OnError = o.OnError
+	}
+	return d
+}
+
+// notification is the content of a notification about a record.
+type notification struct {
+	Service  string
+	Hostname string
+	Level    Level
+	Message  string
+	Time     time.Time
+	// Attrs are the attributes of the record by their dotted path, in the order they were added.
+	Attrs []notificationAttr
+	// Suppressed is the number of records suppressed since the last notification.
+	Suppressed int
+}
+
+// notificationAttr is an attribute of a [notification].
+type notificationAttr struct {
+	Key   string
+	Value string
+}
+
+// notifier sends the notifications of a handler and the handlers derived from it.
+type notifier struct {
+	opts NotifierOptions
+	link *template.Template
+	// encode returns the webhook payload of the notification and its link.
+	encode func(n *notification, link string) any
+	mu     sync.Mutex
+	// last is the time of the last notification.
+	last       time.Time
+	suppressed int
+}
+
+var _ slog.Handler = (*notifierHandler)(nil)
+
+// notifierHandler is a [slog.Handler] sending notifications about severe records to Slack or Teams.
+type notifierHandler struct {
+	slog.Handler
+	notifier *notifier
+	scopes   []scope
+}
+
+// NewNotifierHandler returns a new [slog.Handler] that passes all records to the given handler
+// and sends a notification to a Slack or Microsoft Teams webhook for the records at or above [NotifierOptions.Level],
+// which defaults to PANIC and FATAL records. The notification has the service, the host, the message,
+// the attributes and the [NotifierOptions.Link].
+//
+// At most one notification is sent per [NotifierOptions.Interval] to prevent alert storms.
+// The notifications are sent synchronously, so they are delivered before a FATAL record exits the program.
+// Errors are passed to [NotifierOptions.OnError].
+//
+// It returns an error if the URL is empty, the format is unknown or the link template cannot be parsed.
+func NewNotifierHandler(h slog.Handler, o NotifierOptions) (slog.Handler, error) {
+	opts := newNotifierOptions(o)
+	if opts.URL == "" {
+		return nil, errors.New("notifier webhook url is required")
+	}
+
+	n := &notifier{opts: opts}
+	switch strings.ToUpper(opts.Format) {
+	case "SLACK":
+		n.encode = slackPayload
+	case "TEAMS":
+		n.encode = teamsPayload
+	default:
+		return nil, fmt.Errorf("unknown notifier format %q", opts.Format)
+	}
+	if opts.Link != "" {
+		var err error
+		if n.link, err = template.New("link").Parse(opts.Link); err != nil {
+			return nil, fmt.Errorf("failed to parse notifier link template: %w", err)
+		}
+	}
+	return &notifierHandler{Handler: h, notifier: n, scopes: []scope{{}}}, nil
+}
+
+// Enabled reports whether the wrapped handler handles records at the given level or the level is notified about.
+func (h *notifierHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.Handler.Enabled(ctx, level) || Level(level) >= h.notifier.opts.Level
+}
+
+// Handle passes the record to the wrapped handler and sends a notification if the record is severe enough.
+func (h *notifierHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	var err error
+	if h.Handler.Enabled(ctx, r.Level) {
+		err = h.Handler.Handle(ctx, r.Clone())
+	}
+
+	if Level(r.Level) >= h.notifier.opts.Level {
+		attrs := make([]slog.Attr, 0, r.NumAttrs())
+		r.Attrs(func(a slog.Attr) bool {
+			attrs = append(attrs, a)
+			return true
+		})
+		if nerr := h.notifier.notify(r, nestScopes(h.scopes, attrs)); nerr != nil && h.notifier.opts.OnError != nil {
+			h.notifier.opts.OnError(nerr)
+		}
+	}
+	return err
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *notifierHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &notifierHandler{Handler: h.Handler.WithAttrs(attrs), notifier: h.notifier, scopes: withScopeAttrs(h.scopes, attrs)}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *notifierHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &notifierHandler{Handler: h.Handler.WithGroup(name), notifier: h.notifier, scopes: withScope(h.scopes, name)}
+}
+
+// notify sends the notification about the record, unless the last notification was sent less than the interval ago.
+func (n *notifier) notify(r slog.Record, attrs []slog.Attr) error { //nolint:gocritic // records are passed by value
+	now := time.Now()
+	n.mu.Lock()
+	if !n.last.IsZero() && now.Sub(n.last) < n.opts.Interval {
+		n.suppressed++
+		n.mu.Unlock()
+		return nil
+	}
+	suppressed := n.suppressed
+	n.last, n.suppressed = now, 0
+	n.mu.Unlock()
+
+	note := &notification{
+		Service:    n.opts.Service,
+		Hostname:   n.opts.Hostname,
+		Level:      Level(r.Level),
+		Message:    r.Message,
+		Time:       r.Time,
+		Attrs:      flattenNotificationAttrs(nil, "", attrs),
+		Suppressed: suppressed,
+	}
+	var link string
+	if n.link != nil {
+		var buf strings.Builder
+		if err := n.link.Execute(&buf, note); err != nil {
+			return fmt.Errorf("failed to render notifier link: %w", err)
+		}
+		link = buf.String()
+	}
+	return n.send(n.encode(note, link))
+}
+
+// flattenNotificationAttrs appends the resolved attributes by their dotted path to dst.
+func flattenNotificationAttrs(dst []notificationAttr, prefix string, attrs []slog.Attr) []notificationAttr {
+	for _, a := range attrs {
+		v := a.Value.Resolve()
+		if v.Kind() == slog.KindGroup {
+			p := prefix
+			if a.Key != "" {
+				p += a.Key + "."
+			}
+			dst = flattenNotificationAttrs(dst, p, v.Group())
+			continue
+		}
+		dst = append(dst, notificationAttr{Key: prefix + a.Key, Value: v.String()})
+	}
+	return dst
+}
+
+// title returns the headline of the notification.
+func (n *notification) title() string {
+	return fmt.Sprintf("%s in %s on %s", n.Level, n.Service, n.Hostname)
+}
+
+// slackPayload returns the payload of a Slack incoming webhook.
+// See https://api.slack.com/messaging/webhooks.
+func slackPayload(n *notification, link string) any {
+	var b strings.Builder
+	fmt.Fprintf(&b, ":rotating_light: *%s*\n>%s", n.title(), n.Message)
+	for _, a := range n.Attrs {
+		fmt.Fprintf(&b, "\n• *%s*: %s", a.Key, a.Value)
+	}
+	if n.Suppressed > 0 {
+		fmt.Fprintf(&b, "\n_%d more notifications were suppressed_", n.Suppressed)
+	}
+	if link != "" {
+		fmt.Fprintf(&b, "\n<%s|View logs>", link)
+	}
+	return map[string]string{"text": b.String()}
+}
+
+// teamsPayload returns the payload of a Microsoft Teams incoming webhook as message card.
+// See https://learn.microsoft.com/en-us/outlook/actionable-messages/message-card-reference.
+func teamsPayload(n *notification, link string) any {
+	type fact struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+	facts := make([]fact, 0, len(n.Attrs))
+	for _, a := range n.Attrs {
+		facts = append(facts, fact{Name: a.Key, Value: a.Value})
+	}
+	text := n.Message
+	if n.Suppressed > 0 {
+		text += fmt.Sprintf("\n\n_%d more notifications were suppressed_", n.Suppressed)
+	}
+
+	card := map[string]any{
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"themeColor": "D00000",
+		"summary":    n.title(),
+		"title":      n.title(),
+		"text":       text,
+		"sections":   []any{map[string]any{"facts": facts}},
+	}
+	if link != "" {
+		card["potentialAction"] = []any{map[string]any{
+			"@type":   "OpenUri",
+			"name":    "View logs",
+			"targets": []any{map[string]string{"os": "default", "uri": link}},
+		}}
+	}
+	return card
+}
+
+// send posts the payload to the webhook.
+func (n *notifier) send(payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, n.opts.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.opts.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 { //nolint:mnd // 2xx status codes
+		return fmt.Errorf("failed to send notification: %s", resp.Status)
+	}
+	return nil
+}
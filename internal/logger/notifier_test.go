@@ -0,0 +1,165 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// notifierServer returns a fake webhook sending the decoded payloads to the returned channel.
+func notifierServer(t *testing.T) (url string, payloads <-chan map[string]any) {
+	t.Helper()
+	ch := make(chan map[string]any, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]any
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("Failed to decode payload %s: %v", body, err)
+		}
+		ch <- payload
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL, ch
+}
+
+func TestNewNotifierHandler_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opts NotifierOptions
+	}{
+		{name: "no url"},
+		{name: "unknown format", opts: NotifierOptions{URL: "http://localhost", Format: "IRC"}},
+		{name: "invalid link", opts: NotifierOptions{URL: "http://localhost", Link: "{{.Service"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewNotifierHandler(slog.Default().Handler(), tt.opts); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestNotifierHandler(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		check  func(t *testing.T, payload map[string]any)
+	}{
+		{
+			name:   "slack",
+			format: "SLACK",
+			check: func(t *testing.T, payload map[string]any) {
+				text, _ := payload["text"].(string)
+				for _, want := range []string{
+					"*PANIC in api on host-1*", ">database unreachable", "• *req.id*: 7",
+					"<https://logs.example.com/?service=api|View logs>",
+				} {
+					if !strings.Contains(text, want) {
+						t.Errorf("Expected %q in %q", want, text)
+					}
+				}
+			},
+		},
+		{
+			name:   "teams",
+			format: "teams",
+			check: func(t *testing.T, payload map[string]any) {
+				if payload["@type"] != "MessageCard" || payload["title"] != "PANIC in api on host-1" || payload["text"] != "database unreachable" {
+					t.Errorf("Unexpected card %v", payload)
+				}
+				fact := payload["sections"].([]any)[0].(map[string]any)["facts"].([]any)[0].(map[string]any)
+				if fact["name"] != "req.id" || fact["value"] != "7" {
+					t.Errorf("Unexpected fact %v", fact)
+				}
+				target := payload["potentialAction"].([]any)[0].(map[string]any)["targets"].([]any)[0].(map[string]any)
+				if target["uri"] != "https://logs.example.com/?service=api" {
+					t.Errorf("Unexpected link %v", target)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, payloads := notifierServer(t)
+			var buf strings.Builder
+			h, err := NewNotifierHandler(slog.NewTextHandler(&buf, nil), NotifierOptions{
+				URL:      url,
+				Format:   tt.format,
+				Service:  "api",
+				Hostname: "host-1",
+				Link:     "https://logs.example.com/?service={{urlquery .Service}}",
+				OnError:  func(err error) { t.Errorf("Unexpected error: %v", err) },
+			})
+			if err != nil {
+				t.Fatalf("NewNotifierHandler() error = %v", err)
+			}
+
+			log := slog.New(h).WithGroup("req").With("id", 7)
+			log.Error("not notified")
+			log.Log(context.Background(), slog.Level(LevelPanic), "database unreachable")
+
+			tt.check(t, <-payloads)
+			select {
+			case p := <-payloads:
+				t.Errorf("Expected a single notification, got %v", p)
+			default:
+			}
+			if !strings.Contains(buf.String(), "not notified") {
+				t.Errorf("Expected the records to be passed to the wrapped handler, got %s", buf.String())
+			}
+		})
+	}
+}
+
+func TestNotifierHandler_RateLimit(t *testing.T) {
+	url, payloads := notifierServer(t)
+	h, err := NewNotifierHandler(slog.NewTextHandler(io.Discard, nil), NotifierOptions{URL: url, Interval: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewNotifierHandler() error = %v", err)
+	}
+	log := slog.New(h)
+
+	for range 3 {
+		log.Log(context.Background(), slog.Level(LevelFatal), "storm")
+	}
+	if text := (<-payloads)["text"].(string); strings.Contains(text, "suppressed") {
+		t.Errorf("Expected no suppressed notifications in the first one, got %q", text)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	log.Log(context.Background(), slog.Level(LevelFatal), "storm")
+	if text := (<-payloads)["text"].(string); !strings.Contains(text, "2 more notifications were suppressed") {
+		t.Errorf("Expected the suppressed notifications to be counted, got %q", text)
+	}
+}
+
+func TestNotifierHandler_SendError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	var reported error
+	h, err := NewNotifierHandler(slog.NewTextHandler(io.Discard, nil), NotifierOptions{
+		URL:     srv.URL,
+		OnError: func(err error) { reported = err },
+	})
+	if err != nil {
+		t.Fatalf("NewNotifierHandler() error = %v", err)
+	}
+	if err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.Level(LevelPanic), "panic", 0)); err != nil {
+		t.Errorf("Expected the error to be reported only, got %v", err)
+	}
+	if reported == nil || !strings.Contains(reported.Error(), "403") {
+		t.Errorf("Expected the status to be reported, got %v", reported)
+	}
+}
@@ -0,0 +1,101 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// OperationKey is the key used for the name of an [Operation].
+	OperationKey = "operation"
+	// OperationIDKey is the key used for the ID shared by all records of an [Operation].
+	OperationIDKey = "operation_id"
+	// OperationDurationKey is the key used for the duration of an [Operation].
+	OperationDurationKey = "duration"
+)
+
+// Operation is a long-running operation started by [Provider.Begin].
+// All records of an operation carry its name under [OperationKey] and its ID under [OperationIDKey].
+// An operation must be ended by either [Operation.Success] or [Operation.Fail], further calls are ignored.
+type Operation struct {
+	// log is the logger with the operation's attributes.
+	log *logger
+	// ctx is the context the operation was started with.
+	ctx context.Context
+	// id is the ID of the operation.
+	id string
+	// start is the time the operation was started.
+	start time.Time
+	// ended reports whether the operation was ended.
+	ended atomic.Bool
+}
+
+// Begin starts a new [Operation] with the given name and logs its start at [LevelInfo].
+// The given attributes are added to all records of the operation.
+//
+// Example:
+//
+//	op := log.Begin(ctx, "reindex", "index", "users")
+//	if err := reindex(ctx); err != nil {
+//		op.Fail(err)
+//		return
+//	}
+//	op.Success("documents", n)
+func (l *logger) Begin(ctx context.Context, name string, args ...any) *Operation {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	id := NewULID()
+	attrs := append([]slog.Attr{slog.String(OperationKey, name), slog.String(OperationIDKey, id)}, argsToAttrs(args)...)
+	op := &Operation{
+		log:   l.With(attrsToArgs(attrs)...).(*logger),
+		ctx:   ctx,
+		id:    id,
+		start: time.Now(),
+	}
+	op.log.logAttrs(ctx, LevelInfo, "Operation started")
+	return op
+}
+
+// ID returns the ID of the operation.
+func (o *Operation) ID() string {
+	return o.id
+}
+
+// Logger returns the logger of the operation, whose records carry the operation's attributes.
+func (o *Operation) Logger() Provider {
+	return o.log
+}
+
+// Progress logs the progress of the operation at [LevelInfo] with the duration since its start.
+// It is ignored once the operation has ended.
+func (o *Operation) Progress(msg string, args ...any) {
+	if o.ended.Load() {
+		return
+	}
+	o.log.logAttrs(o.ctx, LevelInfo, msg, append(args, o.duration())...)
+}
+
+// Success ends the operation and logs its success at [LevelInfo] with its duration.
+func (o *Operation) Success(args ...any) {
+	if o.ended.Swap(true) {
+		return
+	}
+	o.log.logAttrs(o.ctx, LevelInfo, "Operation succeeded", append(args, o.duration())...)
+}
+
+// Fail ends the operation and logs the error at [LevelError] with its duration.
+func (o *Operation) Fail(err error, args ...any) {
+	if o.ended.Swap(true) {
+		return
+	}
+	o.log.logAttrs(o.ctx, LevelError, "Operation failed", append(append([]any{Err(err)}, args...), o.duration())...)
+}
+
+// duration returns the duration since the start of the operation.
+func (o *Operation) duration() slog.Attr {
+	return slog.Duration(OperationDurationKey, time.Since(o.start))
+}
@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogger_Begin(t *testing.T) {
+	tests := []struct {
+		name     string
+		run      func(op *Operation)
+		wantMsgs []string
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			run: func(op *Operation) {
+				op.Progress("Halfway", "done", 50)
+				op.Success("documents", 100)
+			},
+			wantMsgs: []string{"Operation started", "Halfway", "Operation succeeded"},
+		},
+		{
+			name:     "failure",
+			run:      func(op *Operation) { op.Fail(errors.New("boom")) },
+			wantMsgs: []string{"Operation started", "Operation failed"},
+			wantErr:  true,
+		},
+		{
+			name: "ended only once",
+			run: func(op *Operation) {
+				op.Success()
+				op.Fail(errors.New("boom"))
+				op.Progress("Ignored")
+			},
+			wantMsgs: []string{"Operation started", "Operation succeeded"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			log := NewLogger(Options{Handler: slog.NewJSONHandler(buf, &slog.HandlerOptions{AddSource: true})})
+
+			op := log.Begin(context.Background(), "reindex", "index", "users")
+			tt.run(op)
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if len(lines) != len(tt.wantMsgs) {
+				t.Fatalf("Expected %d records, got %d: %s", len(tt.wantMsgs), len(lines), buf.String())
+			}
+			for i, line := range lines {
+				var rec map[string]any
+				if err := json.Unmarshal([]byte(line), &rec); err != nil {
+					t.Fatalf("Failed to unmarshal record: %v", err)
+				}
+				if rec[slog.MessageKey] != tt.wantMsgs[i] {
+					t.Errorf("Expected message %q, got %v", tt.wantMsgs[i], rec[slog.MessageKey])
+				}
+				if rec[OperationKey] != "reindex" || rec["index"] != "users" {
+					t.Errorf("Expected operation attributes, got %v", rec)
+				}
+				if rec[OperationIDKey] != op.ID() {
+					t.Errorf("Expected operation ID %q, got %v", op.ID(), rec[OperationIDKey])
+				}
+				if _, ok := rec[OperationDurationKey]; ok != (i > 0) {
+					t.Errorf("Expected duration only after the start record, got %v", rec)
+				}
+				if src, _ := rec[slog.SourceKey].(map[string]any); !strings.HasSuffix(src["file"].(string), "operation_test.go") {
+					t.Errorf("Expected source to be the caller, got %v", src)
+				}
+				if i == len(lines)-1 && tt.wantErr && rec[slog.LevelKey] != LevelError.String() {
+					t.Errorf("Expected level %v, got %v", LevelError, rec[slog.LevelKey])
+				}
+			}
+		})
+	}
+}
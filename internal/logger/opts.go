@@ -1,36 +1,158 @@
 package logger
 
 import (
+	"context"
 	"log/slog"
 	"os"
+	"time"
 )
 
 // Options is the optional configuration for the logger.
 type Options struct {
 	// Level is the minimum log level.
 	Level string
-	// Format is the log format.
+	// Format is the log format: "JSON", "TEXT", "DEV", "DOCKER", "DATADOG" or "JOURNALD".
+	// Defaults to "DEV" if the output is a terminal and to "JSON" otherwise.
+	// "JOURNALD" writes to the systemd journal and falls back to "JSON" if the journal is not available.
 	Format string
+	// Output is the destination of the records: "stderr", "stdout", "discard", a file as "file:///var/log/app.log"
+	// or a plain path, or a socket as "tcp://collector:514", "udp://rsyslog:514" or "unix:///run/log.sock".
+	// Defaults to "stderr". If it cannot be opened, the records are written to stderr after a warning.
+	// It is ignored by the "JOURNALD" format.
+	Output string
 	// OpenTelemetry is a flag to enable OpenTelemetry support.
 	OpenTelemetry bool
+	// TraceContext is a flag to add the trace and span ID of the OpenTelemetry span found in the context to every record.
+	// It is implied by OpenTelemetry, which additionally records the log events on the span.
+	TraceContext bool
 	// Handler is the log handler.
 	Handler slog.Handler
+	// LevelHints is a flag to add the color hint and the numeric severity of the level to JSON records.
+	// This allows log viewers like lnav or stern to highlight the output without switching to the text format.
+	LevelHints bool
+	// MaxRecordSize is the maximum size of a record in bytes.
+	// Records exceeding it are split into linked continuation records, see [NewSplitHandler].
+	// Zero disables splitting.
+	MaxRecordSize int
+	// RecordIDs is a flag to add a unique ID (ULID) to every record, see [NewRecordIDHandler].
+	RecordIDs bool
+	// StacktraceLevel is the minimum level of records to which the stack trace of the logging call is added,
+	// see [NewStacktraceHandler]. Empty disables stack traces.
+	StacktraceLevel string
+	// ExitCode is the exit code used by Fatal. Defaults to 1.
+	ExitCode int
+	// OnFatal are the hooks called by Fatal in the given order after the record is logged and before the program exits,
+	// e.g. to flush buffers, close connections or emit a final metric.
+	OnFatal []func(ctx context.Context)
+	// Exit is the function called by Fatal to exit the program. Defaults to [os.Exit].
+	// Override it to test code paths calling Fatal.
+	Exit func(code int)
+	// PanicErrors is a flag to panic with a [*PanicError] carrying the message and the attributes
+	// instead of the message only, see [RecoverAndLog].
+	PanicErrors bool
+	// CompactKeys is a flag to abbreviate the standard keys of JSON records (time, level, msg and source)
+	// to "t", "l", "m" and "s", which reduces the size of every record for high volume services.
+	CompactKeys bool
+	// KeyNames maps the standard keys of JSON records (time, level, msg and source) to the names required
+	// by downstream systems, e.g. {"time": "timestamp", "level": "severity", "msg": "message"}.
+	// The names take precedence over the abbreviations of CompactKeys.
+	KeyNames map[string]string
+	// KeyDictionary is a flag to write a record mapping the short keys back to the standard keys
+	// under [DictionaryKey] before the first record. It requires CompactKeys.
+	KeyDictionary bool
+	// Environment is the environment the program runs in, e.g. "production" or "development".
+	// Production environments ("prod" or "production") default to the [RedactStandard] policy.
+	Environment string
+	// Redaction is the [RedactionPolicy] applied to all records: "strict", "standard" or "off".
+	// Defaults to the policy of the Environment. Unknown policies redact with [RedactStandard] and log a warning.
+	Redaction string
+	// RedactPaths are the dotted paths of attributes redacted in addition to the Redaction policy,
+	// e.g. "http.request.headers.authorization" or "**.cookie", see [RedactionOptions.Paths].
+	RedactPaths []string
+	// RedactKeys are the keys of attributes whose values are redacted in addition to the Redaction policy
+	// at any depth, e.g. "password" or "api_key", see [RedactionOptions.Keys].
+	RedactKeys []string
+	// RedactPatterns are the names of the patterns redacted in messages and string values in addition to the
+	// Redaction policy, e.g. "email" or "iban", see [RedactionOptions.Patterns].
+	RedactPatterns []string
+	// Transforms are the rules transforming every record, e.g. renaming or dropping attributes, see [NewTransformer].
+	// The LOG_TRANSFORMS environment variable takes precedence as JSON array of rules.
+	// Invalid rules are ignored and logged as warning.
+	Transforms []TransformRule
+	// HashKeys are the keys of attributes whose values are replaced by their keyed hash, e.g. "user_id" or "ip",
+	// so the records remain correlatable without identifying anyone directly, see [RedactionOptions.HashKeys].
+	HashKeys []string
+	// HashSecret is the secret key of the hashes of HashKeys. Without a secret, the values are redacted instead.
+	HashSecret []byte
+	// AllowKeys are the only keys of attributes emitted if set, all other attributes are dropped,
+	// see [RedactionOptions.AllowKeys].
+	AllowKeys []string
+	// AllowPlaceholder is a flag to replace the values of the attributes not allowed by AllowKeys
+	// by [RedactedValue] instead of dropping them.
+	AllowPlaceholder bool
+	// Deterministic is a flag to omit the fields differing between runs from the output of the built-in handlers,
+	// i.e. the time and the source, and to number the record IDs sequentially,
+	// so the output can be compared with golden files. See [NormalizeJSON] for existing output.
+	Deterministic bool
+	// TimeFormat is the layout of the times of JSON, text and dev records, e.g. [time.RFC3339] or [time.DateTime].
+	// Defaults to [time.RFC3339Nano] for JSON, [time.Kitchen] for text and "15:04:05.000" for dev records.
+	TimeFormat string
+	// TimeZone is the location the times of JSON, text and dev records are converted to, e.g. [time.UTC].
+	// Defaults to the local time zone.
+	TimeZone *time.Location
+	// AutoName is a flag to add the path of the package logging a record as name to the records of loggers
+	// without a name, so the output identifies the emitting component without using [NewNamedLogger].
+	AutoName bool
+	// Sampling limits the repeated records with the same level and message, see [NewSamplingHandler].
+	// Nil disables sampling.
+	Sampling *SamplingOptions
+	// Limits truncates the string attributes, the number of attributes and the size of records exceeding them,
+	// see [NewLimitHandler]. Nil disables the limits.
+	Limits *LimitOptions
+	// Metrics counts the records written per output and level, the errors of the output and the records
+	// dropped by [Options.Sampling], see [PipelineMetrics]. Nil disables the counting.
+	// It is ignored if a Handler is set, which can be wrapped with [NewMetricsHandler] instead.
+	Metrics *PipelineMetrics
+	// OmitSource is a flag to omit the source of the logging call from the records of all formats,
+	// which saves the cost of resolving it. LOG_SOURCE=false or LOG_SOURCE=true override it.
+	OmitSource bool
+	// CallerSkip is the number of additional stack frames skipped to find the source of the records,
+	// e.g. 1 for a logger used only by a wrapper function, see [Provider.WithCallerSkip].
+	CallerSkip int
+	// SourcePath is the form of the file paths of the sources of JSON records: "full" for the absolute paths,
+	// "module" for the paths relative to the main module or "package" for the directory and the file,
+	// see [SourcePathStyle]. The trimmed paths keep the records small and do not leak the paths of the build host.
+	// Defaults to "full".
+	SourcePath string
+	// CompactSource is a flag to write the source of JSON records as a single "file:line" string,
+	// e.g. "server/server.go:42", instead of an object with the function, the file and the line.
+	CompactSource bool
 }
 
 // newDefaultOptions returns the default Options.
 func newDefaultOptions() Options {
+	omitSource, _ := omitSourceEnv()
 	return Options{
 		Level:         os.Getenv("LOG_LEVEL"),
 		Format:        os.Getenv("LOG_FORMAT"),
+		Output:        os.Getenv("LOG_OUTPUT"),
 		OpenTelemetry: false,
+		Environment:   os.Getenv("LOG_ENV"),
+		Redaction:     os.Getenv("LOG_REDACTION"),
+		OmitSource:    omitSource,
 	}
 }
 
 // newOptions creates a new Options instance with the provided Options merged with the default Options.
+// If neither the options nor LOG_FORMAT select a format or a handler, the human-readable "DEV" format is selected
+// if the output is stderr or stdout and a terminal or colors are forced by FORCE_COLOR or CLICOLOR_FORCE, and JSON otherwise.
 func newOptions(o ...Options) Options {
 	opts := newDefaultOptions()
 	if len(o) > 0 {
-		return o[0].merge(opts)
+		opts = o[0].merge(opts)
+	}
+	if f := standardStream(opts.Output); opts.Format == "" && opts.Handler == nil && f != nil && prefersHumanFormat(f) {
+		opts.Format = devFormat
 	}
 	return opts
 }
@@ -45,11 +167,116 @@ func (o *Options) merge(d Options) Options {
 	if !ok {
 		d.Format = o.Format
 	}
+	_, ok = os.LookupEnv("LOG_OUTPUT")
+	if !ok {
+		d.Output = o.Output
+	}
+	_, ok = os.LookupEnv("LOG_ENV")
+	if !ok {
+		d.Environment = o.Environment
+	}
+	_, ok = os.LookupEnv("LOG_REDACTION")
+	if !ok {
+		d.Redaction = o.Redaction
+	}
 	if o.OpenTelemetry {
 		d.OpenTelemetry = o.OpenTelemetry
 	}
+	if o.TraceContext {
+		d.TraceContext = o.TraceContext
+	}
 	if o.Handler != nil {
 		d.Handler = o.Handler
 	}
+	if o.LevelHints {
+		d.LevelHints = o.LevelHints
+	}
+	if o.MaxRecordSize != 0 {
+		d.MaxRecordSize = o.MaxRecordSize
+	}
+	if o.RecordIDs {
+		d.RecordIDs = o.RecordIDs
+	}
+	if o.StacktraceLevel != "" {
+		d.StacktraceLevel = o.StacktraceLevel
+	}
+	if o.ExitCode != 0 {
+		d.ExitCode = o.ExitCode
+	}
+	if len(o.OnFatal) > 0 {
+		d.OnFatal = o.OnFatal
+	}
+	if o.Exit != nil {
+		d.Exit = o.Exit
+	}
+	if o.PanicErrors {
+		d.PanicErrors = o.PanicErrors
+	}
+	if o.CompactKeys {
+		d.CompactKeys = o.CompactKeys
+	}
+	if o.KeyDictionary {
+		d.KeyDictionary = o.KeyDictionary
+	}
+	if len(o.KeyNames) > 0 {
+		d.KeyNames = o.KeyNames
+	}
+	if o.Deterministic {
+		d.Deterministic = o.Deterministic
+	}
+	if o.AutoName {
+		d.AutoName = o.AutoName
+	}
+	if len(o.RedactPaths) > 0 {
+		d.RedactPaths = o.RedactPaths
+	}
+	if len(o.RedactKeys) > 0 {
+		d.RedactKeys = o.RedactKeys
+	}
+	if len(o.RedactPatterns) > 0 {
+		d.RedactPatterns = o.RedactPatterns
+	}
+	if len(o.Transforms) > 0 {
+		d.Transforms = o.Transforms
+	}
+	if len(o.HashKeys) > 0 {
+		d.HashKeys = o.HashKeys
+	}
+	if len(o.HashSecret) > 0 {
+		d.HashSecret = o.HashSecret
+	}
+	if len(o.AllowKeys) > 0 {
+		d.AllowKeys = o.AllowKeys
+	}
+	if o.AllowPlaceholder {
+		d.AllowPlaceholder = o.AllowPlaceholder
+	}
+	if o.TimeFormat != "" {
+		d.TimeFormat = o.TimeFormat
+	}
+	if o.TimeZone != nil {
+		d.TimeZone = o.TimeZone
+	}
+	if o.Sampling != nil {
+		d.Sampling = o.Sampling
+	}
+	if o.Limits != nil {
+		d.Limits = o.Limits
+	}
+	if o.Metrics != nil {
+		d.Metrics = o.Metrics
+	}
+	if _, ok = omitSourceEnv(); !ok && o.OmitSource {
+		d.OmitSource = o.OmitSource
+	}
+	if o.CallerSkip != 0 {
+		d.CallerSkip = o.CallerSkip
+	}
+	if o.SourcePath != "" {
+		d.SourcePath = o.SourcePath
+	}
+	if o.CompactSource {
+		d.CompactSource = o.CompactSource
+	}
 	return d
 }
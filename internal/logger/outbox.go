@@ -0,0 +1,277 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// outboxOptions is the configuration of an [outbox].
+type outboxOptions struct {
+	// name is the name of the destination used in errors, e.g. "nats".
+	name string
+	// bufferSize is the number of records buffered while the destination is not reachable.
+	bufferSize int
+	// reconnectWait is the wait between attempts to reconnect.
+	reconnectWait time.Duration
+	// timeout is the timeout of flushing the buffer on close.
+	timeout time.Duration
+	// errDropped is the error reported with the number of records dropped because the buffer was full.
+	errDropped error
+	onError    func(err error)
+	// ack is a flag to report the delivery of the records to the callers of [Ack],
+	// if the destination acknowledges the records it stored.
+	ack bool
+}
+
+// outboxConn is a connection of an [outbox] to its destination.
+type outboxConn interface {
+	// send sends the record and reports whether a failed record should be retried on a new connection.
+	send(msg []byte) (retry bool, err error)
+	// close closes the connection.
+	close()
+}
+
+// outboxMsg is a record buffered by an [outbox] with its ID, see [RecordIDKey].
+type outboxMsg struct {
+	data []byte
+	id   string
+}
+
+// outbox is a bounded buffer of records sent by a background worker, which reconnects if the connection is lost.
+// It is the writer of the JSON handler encoding the records, which writes every record with a single call.
+type outbox struct {
+	opts outboxOptions
+	dial func() (outboxConn, error)
+	mu   sync.Mutex
+	// queue are the records not sent yet, the oldest first.
+	queue []outboxMsg
+	// removed is the number of records removed from the queue, which identifies the oldest buffered record.
+	removed uint64
+	dropped int
+	closed  bool
+	// err is the error returned by close if records could not be sent.
+	err    error
+	notify chan struct{}
+	stop   chan struct{}
+	done   chan struct{}
+	// wmu serializes the handlers writing records with their ID, id is the ID of the record currently written.
+	wmu sync.Mutex
+	id  string
+}
+
+// newOutbox returns a new outbox sending the records over the connections returned by dial.
+func newOutbox(opts outboxOptions, dial func() (outboxConn, error)) *outbox {
+	o := &outbox{
+		opts:   opts,
+		dial:   dial,
+		notify: make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go o.run()
+	return o
+}
+
+// handle passes the record to the handler encoding it to the outbox, so the record is buffered with its ID.
+func (o *outbox) handle(ctx context.Context, h slog.Handler, r slog.Record) error { //nolint:gocritic // records are passed by value
+	if !o.opts.ack {
+		return h.Handle(ctx, r)
+	}
+	o.wmu.Lock()
+	defer o.wmu.Unlock()
+	o.id = recordID(r)
+	defer func() { o.id = "" }()
+	return h.Handle(ctx, r)
+}
+
+// Write buffers the record, dropping the oldest buffered record if the buffer is full.
+// It returns [ErrHandlerClosed] once the outbox is closed.
+func (o *outbox) Write(b []byte) (int, error) {
+	msg := outboxMsg{data: bytes.Clone(bytes.TrimSuffix(b, []byte("\n"))), id: o.id}
+
+	o.mu.Lock()
+	if o.closed {
+		o.mu.Unlock()
+		return 0, ErrHandlerClosed
+	}
+	if len(o.queue) >= o.opts.bufferSize {
+		o.acknowledge(o.queue[0], o.opts.errDropped)
+		o.removeOldest()
+		o.dropped++
+	}
+	o.queue = append(o.queue, msg)
+	o.mu.Unlock()
+
+	select {
+	case o.notify <- struct{}{}:
+	default:
+	}
+	return len(b), nil
+}
+
+// close stops accepting records and waits until the buffered records are sent or the timeout elapsed.
+// It returns an error if records could not be sent.
+func (o *outbox) close() error {
+	o.mu.Lock()
+	if !o.closed {
+		o.closed = true
+		close(o.stop)
+	}
+	o.mu.Unlock()
+
+	<-o.done
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.err
+}
+
+// run sends the buffered records until the outbox is closed and the buffer is flushed.
+func (o *outbox) run() {
+	defer close(o.done)
+	var conn outboxConn
+	defer func() {
+		if conn != nil {
+			conn.close()
+		}
+	}()
+
+	var deadline time.Time
+	for {
+		id, msg, ok := o.next()
+		if !ok {
+			return
+		}
+		if deadline.IsZero() && o.stopped() {
+			deadline = time.Now().Add(o.opts.timeout)
+		}
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			o.giveUp()
+			return
+		}
+
+		if conn == nil {
+			var err error
+			if conn, err = o.dial(); err != nil {
+				o.report(err)
+				o.wait(deadline)
+				continue
+			}
+			o.reportDropped()
+		}
+
+		retry, err := conn.send(msg.data)
+		if err != nil && retry {
+			o.report(err)
+			conn.close()
+			conn = nil
+			o.wait(deadline)
+			continue
+		}
+		if err != nil {
+			// The destination rejected the record, so it is not retried.
+			o.report(err)
+		}
+		o.pop(id)
+		o.acknowledge(msg, err)
+	}
+}
+
+// next waits for the oldest buffered record and returns it with its ID.
+// It returns false once the outbox is closed and the buffer is empty.
+func (o *outbox) next() (id uint64, msg outboxMsg, ok bool) {
+	for {
+		o.mu.Lock()
+		if len(o.queue) > 0 {
+			id, msg = o.removed, o.queue[0]
+			o.mu.Unlock()
+			return id, msg, true
+		}
+		closed := o.closed
+		o.mu.Unlock()
+		if closed {
+			return 0, outboxMsg{}, false
+		}
+
+		select {
+		case <-o.notify:
+		case <-o.stop:
+		}
+	}
+}
+
+// pop removes the record with the given ID after it was sent, unless it was dropped in the meantime.
+func (o *outbox) pop(id uint64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.removed == id && len(o.queue) > 0 {
+		o.removeOldest()
+	}
+}
+
+// removeOldest removes the oldest buffered record. Must be called with the lock held.
+func (o *outbox) removeOldest() {
+	o.queue[0] = outboxMsg{}
+	o.queue = o.queue[1:]
+	o.removed++
+}
+
+// acknowledge reports the delivery of the record to the callers of [Ack] if the destination acknowledges records.
+func (o *outbox) acknowledge(msg outboxMsg, err error) {
+	if o.opts.ack && msg.id != "" {
+		Acknowledge(msg.id, err)
+	}
+}
+
+// stopped reports whether the outbox is closed.
+func (o *outbox) stopped() bool {
+	select {
+	case <-o.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// wait waits for the reconnect wait, but not beyond the deadline of flushing the buffer if the outbox is closed.
+func (o *outbox) wait(deadline time.Time) {
+	wait := o.opts.reconnectWait
+	if !deadline.IsZero() {
+		wait = min(wait, time.Until(deadline))
+	}
+	t := time.NewTimer(wait)
+	defer t.Stop()
+	<-t.C
+}
+
+// giveUp discards the buffered records once the deadline of flushing the buffer elapsed.
+func (o *outbox) giveUp() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.err = fmt.Errorf("failed to send %d records to %s before closing", len(o.queue), o.opts.name)
+	for _, msg := range o.queue {
+		o.acknowledge(msg, o.err)
+	}
+	o.queue = nil
+}
+
+// report passes the error to the error callback of the options.
+func (o *outbox) report(err error) {
+	if o.opts.onError != nil {
+		o.opts.onError(err)
+	}
+}
+
+// reportDropped reports the records dropped since the last report.
+func (o *outbox) reportDropped() {
+	o.mu.Lock()
+	dropped := o.dropped
+	o.dropped = 0
+	o.mu.Unlock()
+	if dropped > 0 {
+		o.report(fmt.Errorf("%w: %d", o.opts.errDropped, dropped))
+	}
+}
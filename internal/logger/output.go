@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+// newOutput returns the writer of the output target:
+//   - "stderr" or empty and "stdout" for the standard streams,
+//   - "discard" to drop the records,
+//   - "file:///var/log/app.log" or a plain path for a [FileSink] appending to the file,
+//   - a socket address like "tcp://collector:514", "udp://rsyslog:514" or "unix:///run/log.sock",
+//     see [SocketOptions.Address], for a socket the records are written to in the background.
+//
+// Files and sockets are returned as [io.WriteCloser].
+func newOutput(target string) (io.Writer, error) {
+	switch strings.ToLower(target) {
+	case "", "stderr":
+		return os.Stderr, nil
+	case "stdout":
+		return os.Stdout, nil
+	case "discard":
+		return io.Discard, nil
+	}
+
+	scheme, path, ok := strings.Cut(target, "://")
+	if !ok {
+		return NewFileSink(FileSinkOptions{Path: target})
+	}
+	if strings.EqualFold(scheme, "file") {
+		if path == "" {
+			return nil, errors.New("missing path of file output")
+		}
+		return NewFileSink(FileSinkOptions{Path: path})
+	}
+	ob, err := newSocketOutbox(newSocketOptions(SocketOptions{Address: target}))
+	if err != nil {
+		return nil, err
+	}
+	return &socketOutput{outbox: ob}, nil
+}
+
+// standardStream returns the standard stream of the output target, nil if it is none.
+func standardStream(target string) *os.File {
+	switch strings.ToLower(target) {
+	case "", "stderr":
+		return os.Stderr
+	case "stdout":
+		return os.Stdout
+	default:
+		return nil
+	}
+}
+
+var _ io.WriteCloser = (*socketOutput)(nil)
+
+// socketOutput is the [io.WriteCloser] of a socket output.
+type socketOutput struct {
+	*outbox
+}
+
+// Close stops accepting records and waits until the buffered records are written.
+func (s *socketOutput) Close() error {
+	return s.close()
+}
@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewOutput(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		target  string
+		want    io.Writer
+		closer  bool
+		wantErr bool
+	}{
+		{name: "empty", target: "", want: os.Stderr},
+		{name: "stderr", target: "STDERR", want: os.Stderr},
+		{name: "stdout", target: "stdout", want: os.Stdout},
+		{name: "discard", target: "discard", want: io.Discard},
+		{name: "plain path", target: filepath.Join(dir, "plain.log"), closer: true},
+		{name: "file uri", target: "file://" + filepath.Join(dir, "uri.log"), closer: true},
+		{name: "file uri without path", target: "file://", wantErr: true},
+		{name: "unsupported scheme", target: "http://collector:514", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := newOutput(tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newOutput() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.want != nil && w != tt.want {
+				t.Errorf("newOutput() = %T, want %T", w, tt.want)
+			}
+			if c, ok := w.(io.Closer); ok && tt.closer {
+				t.Cleanup(func() { _ = c.Close() })
+			} else if tt.closer {
+				t.Errorf("Expected an io.Closer, got %T", w)
+			}
+		})
+	}
+}
+
+func TestNewOutput_Socket(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	records := socketServer(t, ln, "NEWLINE")
+
+	w, err := newOutput("tcp://" + ln.Addr().String())
+	if err != nil {
+		t.Fatalf("newOutput() error = %v", err)
+	}
+	if _, err = w.Write([]byte(`{"msg":"hello"}` + "\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if rec := receiveRecord(t, records); !strings.Contains(rec, `"msg":"hello"`) {
+		t.Errorf("Expected the record, got %s", rec)
+	}
+	if err = w.(io.Closer).Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
+
+func TestNewLogger_Output(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	t.Setenv("LOG_FORMAT", "JSON")
+	t.Setenv("LOG_OUTPUT", "file://"+path)
+
+	NewLogger(Options{Output: "stdout"}).Info("hello")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"msg":"hello"`) {
+		t.Errorf("Expected the record in the file, got %q", data)
+	}
+}
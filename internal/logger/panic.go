@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// PanicError is the value the logger panics with if [Options.PanicErrors] is set.
+type PanicError struct {
+	// Message is the message of the panic.
+	Message string
+	// Attrs are the attributes of the logger followed by the attributes passed to the panic call.
+	Attrs []slog.Attr
+}
+
+// Error returns the message of the panic.
+func (e *PanicError) Error() string {
+	return e.Message
+}
+
+// RecoverAndLog recovers from a panic and logs it at [LevelPanic] with the logger found in the context,
+// including a stack trace under [StacktraceKey].
+// The attributes of a [*PanicError] are added to the record, so panics raised by the logger
+// with [Options.PanicErrors] keep their structured context.
+// It must be deferred directly:
+//
+//	defer logger.RecoverAndLog(ctx)
+func RecoverAndLog(ctx context.Context) {
+	v := recover()
+	if v == nil {
+		return
+	}
+	logRecovered(ctx, v, captureStack(2)) //nolint:mnd // skip RecoverAndLog and runtime.gopanic
+}
+
+// logRecovered logs the recovered panic value with the logger found in the context.
+func logRecovered(ctx context.Context, v any, stack []uintptr, attrs ...slog.Attr) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var pe *PanicError
+	if err, ok := v.(error); ok && errors.As(err, &pe) {
+		attrs = append([]slog.Attr{Err(pe)}, append(pe.Attrs, attrs...)...)
+	} else {
+		attrs = append([]slog.Attr{slog.Any("panic", v)}, attrs...)
+	}
+	attrs = append(attrs, slog.String(StacktraceKey, formatStack(stack)))
+	FromContext(ctx).LogAttrs(ctx, LevelPanic, "Recovered from panic", attrs...)
+}
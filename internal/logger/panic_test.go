@@ -0,0 +1,145 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+)
+
+func TestLogger_PanicErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      Options
+		panic     func(l Provider)
+		wantError bool
+		wantMsg   string
+		wantAttrs []string
+	}{
+		{
+			name:    "message by default",
+			panic:   func(l Provider) { l.Panic("boom", "key", "value") },
+			wantMsg: "boom",
+		},
+		{
+			name:      "typed error",
+			opts:      Options{PanicErrors: true},
+			panic:     func(l Provider) { l.With("scope", 1).PanicContext(context.Background(), "boom", "key", "value") },
+			wantError: true,
+			wantMsg:   "boom",
+			wantAttrs: []string{"scope", "key"},
+		},
+		{
+			name:      "typed error with formatted message",
+			opts:      Options{PanicErrors: true},
+			panic:     func(l Provider) { l.Panicf("boom %d", 1) },
+			wantError: true,
+			wantMsg:   "boom 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Handler = test.MockHandler{}
+			v := func() (v any) {
+				defer func() { v = recover() }()
+				tt.panic(NewLogger(tt.opts))
+				return nil
+			}()
+
+			pe, ok := v.(*PanicError)
+			if ok != tt.wantError {
+				t.Fatalf("Expected *PanicError: %v, got %T", tt.wantError, v)
+			}
+			if !tt.wantError {
+				if v != tt.wantMsg {
+					t.Errorf("Expected panic value %q, got %v", tt.wantMsg, v)
+				}
+				return
+			}
+			if pe.Error() != tt.wantMsg {
+				t.Errorf("Expected message %q, got %q", tt.wantMsg, pe.Error())
+			}
+			var keys []string
+			for _, a := range pe.Attrs {
+				keys = append(keys, a.Key)
+			}
+			if strings.Join(keys, ",") != strings.Join(tt.wantAttrs, ",") {
+				t.Errorf("Expected attributes %v, got %v", tt.wantAttrs, keys)
+			}
+		})
+	}
+}
+
+func TestRecoverAndLog(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		wantKeys []string
+	}{
+		{
+			name:     "panic error",
+			value:    &PanicError{Message: "boom", Attrs: []slog.Attr{slog.String("key", "value")}},
+			wantKeys: []string{"", "key", StacktraceKey},
+		},
+		{
+			name:     "wrapped panic error",
+			value:    Wrap(&PanicError{Message: "boom"}, "wrap", true),
+			wantKeys: []string{"", StacktraceKey},
+		},
+		{
+			name:     "any value",
+			value:    errors.New("boom"),
+			wantKeys: []string{"panic", StacktraceKey},
+		},
+		{
+			name: "no panic",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var records []slog.Record
+			ctx := IntoContext(context.Background(), NewLogger(Options{Handler: test.MockHandler{
+				HandleFunc: func(_ context.Context, r slog.Record) error {
+					records = append(records, r)
+					return nil
+				},
+			}}))
+
+			func() {
+				defer RecoverAndLog(ctx)
+				if tt.value != nil {
+					panic(tt.value)
+				}
+			}()
+
+			if tt.value == nil {
+				if len(records) != 0 {
+					t.Errorf("Expected no records, got %d", len(records))
+				}
+				return
+			}
+			if len(records) != 1 {
+				t.Fatalf("Expected 1 record, got %d", len(records))
+			}
+			if records[0].Level != slog.Level(LevelPanic) {
+				t.Errorf("Expected level %v, got %v", LevelPanic, records[0].Level)
+			}
+			var keys []string
+			records[0].Attrs(func(a slog.Attr) bool {
+				keys = append(keys, a.Key)
+				if a.Key == StacktraceKey && !strings.Contains(a.Value.String(), "TestRecoverAndLog") {
+					t.Errorf("Expected stack trace to start at the panicking function, got %s", a.Value.String())
+				}
+				return true
+			})
+			if strings.Join(keys, ",") != strings.Join(tt.wantKeys, ",") {
+				t.Errorf("Expected attributes %v, got %v", tt.wantKeys, keys)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Prepare returns a function logging records at the given level with the given message and static attributes
+// followed by the dynamic attributes passed to the function.
+//
+// It is meant for hot paths logging records of the same shape many times, e.g. in tight loops:
+// the static attributes are passed to the handler once, so handlers pre-encoding attributes
+// (like the JSON handler) do not encode them again for every record.
+// The level is checked on every call, so records are logged once the level is enabled, e.g. by [SetLevel].
+//
+// Records at [LevelPanic] and [LevelFatal] are logged without panicking or exiting.
+func (l *logger) Prepare(level Level, msg string, attrs ...slog.Attr) func(attrs ...slog.Attr) {
+	ctx := context.Background()
+	prepare := sync.OnceValue(func() *logger {
+		if prepared, ok := l.WithAttrs(attrs...).(*logger); ok {
+			return prepared
+		}
+		return l
+	})
+	return func(attrs ...slog.Attr) {
+		if !l.Enabled(ctx, level) {
+			return
+		}
+		prepared := prepare()
+		if prepared.isSilenced() {
+			return
+		}
+
+		// Skip this function to find the caller.
+		r := slog.NewRecord(time.Now(), slog.Level(level), msg, prepared.pc(1))
+		r.AddAttrs(attrs...)
+		prepared.handle(ctx, r)
+	}
+}
@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+)
+
+func TestLogger_Prepare(t *testing.T) {
+	var (
+		records   []slog.Record
+		withAttrs [][]slog.Attr
+		minLevel  = slog.LevelInfo
+	)
+	var h test.MockHandler
+	h = test.MockHandler{
+		EnabledFunc: func(_ context.Context, level slog.Level) bool {
+			return level >= minLevel
+		},
+		HandleFunc: func(_ context.Context, r slog.Record) error {
+			records = append(records, r)
+			return nil
+		},
+		WithAttrsFunc: func(attrs []slog.Attr) slog.Handler {
+			withAttrs = append(withAttrs, attrs)
+			return h
+		},
+	}
+	log := NewLogger(Options{Handler: h})
+
+	debug := log.Prepare(LevelDebug, "disabled", slog.String("static", "value"))
+	debug(slog.Int("i", 0))
+	if len(records) != 0 || len(withAttrs) != 0 {
+		t.Fatalf("Expected no records and no attributes for a disabled level, got %d and %d", len(records), len(withAttrs))
+	}
+
+	info := log.Prepare(LevelInfo, "item processed", slog.String("static", "value"))
+	for i := range 3 {
+		info(slog.Int("i", i))
+	}
+
+	if len(withAttrs) != 1 || withAttrs[0][0].Key != "static" {
+		t.Errorf("Expected the static attributes to be passed once, got %v", withAttrs)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %d", len(records))
+	}
+	for i, r := range records {
+		if r.Message != "item processed" || r.Level != slog.LevelInfo {
+			t.Errorf("Unexpected record %q at %v", r.Message, r.Level)
+		}
+		var got []slog.Attr
+		r.Attrs(func(a slog.Attr) bool {
+			got = append(got, a)
+			return true
+		})
+		if len(got) != 1 || got[0].Key != "i" || got[0].Value.Int64() != int64(i) {
+			t.Errorf("Expected the dynamic attribute i=%d, got %v", i, got)
+		}
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		if !strings.HasSuffix(frame.File, "prepare_test.go") {
+			t.Errorf("Expected the caller to be prepare_test.go, got %s", frame.File)
+		}
+	}
+
+	minLevel = slog.LevelDebug
+	debug(slog.Int("i", 0))
+	if len(records) != 4 || records[3].Message != "disabled" {
+		t.Errorf("Expected a record once the level is enabled, got %d records", len(records))
+	}
+	minLevel = slog.LevelError
+	info(slog.Int("i", 3))
+	if len(records) != 4 {
+		t.Errorf("Expected no record once the level is disabled, got %d records", len(records))
+	}
+}
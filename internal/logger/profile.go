@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// The profiles of [NewDevelopment], [NewProduction] and [NewTesting].
+var (
+	// developmentProfile writes colored text records with their source at [LevelDebug].
+	developmentProfile = Options{Level: "DEBUG", Format: "TEXT", Environment: "development"}
+	// productionProfile writes JSON records at [LevelInfo], sampled with the default [SamplingOptions]
+	// and redacted with the [RedactStandard] policy of production environments.
+	productionProfile = Options{Level: "INFO", Format: "JSON", Environment: "production", Sampling: &SamplingOptions{}}
+	// testingProfile captures the records of all levels without the fields differing between runs.
+	testingProfile = Options{Level: "TRACE", Environment: "test", Deterministic: true}
+)
+
+// NewDevelopment returns a new logger for local development, writing colored text records
+// with their source at [LevelDebug]. The given options take precedence over the profile,
+// and the environment variables like LOG_LEVEL over both, see [NewLogger].
+//
+// Example:
+//
+//	log := logger.NewDevelopment()
+func NewDevelopment(o ...Options) Provider {
+	return NewLogger(withProfile(developmentProfile, o...))
+}
+
+// NewProduction returns a new logger for production, writing JSON records at [LevelInfo].
+// Repeated records are sampled with the default [SamplingOptions] and sensitive attributes are redacted
+// with the [RedactStandard] policy. The given options take precedence over the profile,
+// and the environment variables like LOG_LEVEL over both, see [NewLogger].
+//
+// Example:
+//
+//	log := logger.NewProduction(logger.Options{RecordIDs: true})
+func NewProduction(o ...Options) Provider {
+	return NewLogger(withProfile(productionProfile, o...))
+}
+
+// NewTesting returns a new logger for tests, capturing the records of all levels through the test
+// like [NewTestHandler]. The output is deterministic, i.e. it omits the time and the source
+// and numbers the record IDs sequentially, see [Options.Deterministic].
+// Unlike a logger with the [NewTestHandler], the wrappers enabled by the given options, e.g. redaction, are applied.
+// [Options.Handler] is ignored.
+//
+// Example:
+//
+//	func TestServer(t *testing.T) {
+//		srv := NewServer(logger.NewTesting(t))
+//		// ...
+//	}
+func NewTesting(tb TB, o ...Options) Provider {
+	tb.Helper()
+	opts := newOptions(withProfile(testingProfile, o...))
+	level, control := newLevel(opts.Level), &levelControl{}
+	swap := NewSwapHandler(newLevelHandler(newTestHandler(tb), level, control))
+	return &logger{
+		Logger:      slog.New(withControl(newPipeline(swap, opts), swap, control)),
+		swap:        swap,
+		level:       control,
+		fatal:       newFatalConfig(opts),
+		panicErrors: opts.PanicErrors,
+		caller:      callerConfig{skip: max(opts.CallerSkip, 0)},
+	}
+}
+
+// NewFromAppEnv returns a new logger with the profile of the environment in the APP_ENV environment variable:
+// [NewDevelopment] for "dev", "development" or "local" and [NewProduction] for "prod" or "production".
+// For "test" or "testing", the records are written to stderr with the deterministic output of [NewTesting].
+// Otherwise, it returns [NewLogger] with the given options.
+//
+// Example:
+//
+//	log := logger.NewFromAppEnv()
+func NewFromAppEnv(o ...Options) Provider {
+	switch strings.ToLower(os.Getenv("APP_ENV")) {
+	case "dev", "development", "local":
+		return NewDevelopment(o...)
+	case "prod", "production":
+		return NewProduction(o...)
+	case "test", "testing":
+		return NewLogger(withProfile(testingProfile, o...))
+	default:
+		return NewLogger(o...)
+	}
+}
+
+// withProfile returns the provided Options with the unset settings of the profile applied.
+func withProfile(profile Options, o ...Options) Options {
+	if len(o) == 0 {
+		return profile
+	}
+
+	opts := o[0]
+	for _, f := range []struct{ src, dst *string }{
+		{&profile.Level, &opts.Level},
+		{&profile.Format, &opts.Format},
+		{&profile.Environment, &opts.Environment},
+	} {
+		if *f.dst == "" {
+			*f.dst = *f.src
+		}
+	}
+	if opts.Sampling == nil {
+		opts.Sampling = profile.Sampling
+	}
+	opts.Deterministic = opts.Deterministic || profile.Deterministic
+	return opts
+}
@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWithProfile(t *testing.T) {
+	sampling := &SamplingOptions{Initial: 1}
+	tests := []struct {
+		name    string
+		profile Options
+		opts    []Options
+		want    Options
+	}{
+		{
+			name:    "profile only",
+			profile: productionProfile,
+			want:    productionProfile,
+		},
+		{
+			name:    "options take precedence",
+			profile: productionProfile,
+			opts:    []Options{{Level: "WARN", Sampling: sampling, RecordIDs: true}},
+			want:    Options{Level: "WARN", Format: "JSON", Environment: "production", Sampling: sampling, RecordIDs: true},
+		},
+		{
+			name:    "deterministic is kept",
+			profile: testingProfile,
+			opts:    []Options{{Format: "TEXT"}},
+			want:    Options{Level: "TRACE", Format: "TEXT", Environment: "test", Deterministic: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withProfile(tt.profile, tt.opts...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("withProfile() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTesting(t *testing.T) {
+	tb := &recordingTB{}
+	log := NewTesting(tb, Options{RecordIDs: true})
+	log.Trace("first", "key", "value")
+	log.Info("second")
+
+	assertOutput(t, "log", tb.logs[:1], []string{"level=TRACE", "msg=first", "key=value", "record_id=1"})
+	assertOutput(t, "log", tb.logs[1:], []string{"level=INFO", "msg=second", "record_id=2"})
+	for _, line := range tb.logs {
+		if strings.Contains(line, "time=") || strings.Contains(line, "source=") {
+			t.Errorf("Expected deterministic output, got %q", line)
+		}
+	}
+}
+
+func TestNewFromAppEnv(t *testing.T) {
+	tests := []struct {
+		env  string
+		want Level
+	}{
+		{env: "development", want: LevelDebug},
+		{env: "PROD", want: LevelInfo},
+		{env: "test", want: LevelTrace},
+		{env: "", want: LevelInfo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.env, func(t *testing.T) {
+			t.Setenv("APP_ENV", tt.env)
+			log := NewFromAppEnv(Options{Format: "JSON"})
+			if !log.Enabled(context.Background(), tt.want) || log.Enabled(context.Background(), tt.want-1) {
+				t.Errorf("Expected the minimum level %s", tt.want)
+			}
+		})
+	}
+}
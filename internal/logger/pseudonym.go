@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+)
+
+// hashSize is the number of bytes of the HMAC written for a hashed value.
+const hashSize = 16
+
+// hashValue returns the hex-encoded HMAC-SHA256 of the value keyed by the secret, truncated to [hashSize] bytes.
+// The same value always results in the same hash for the same secret, so the records remain correlatable.
+func hashValue(secret []byte, value string) string {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil)[:hashSize])
+}
+
+// pseudonymize returns the value of the attribute replaced by its hash, or [RedactedValue] without a secret.
+// It reports false if the values of the key are not hashed.
+func (r *redactionRules) pseudonymize(a slog.Attr) (slog.Value, bool) {
+	if len(r.hashed) == 0 || a.Value.Kind() == slog.KindGroup || !slices.Contains(r.hashed, strings.ToLower(a.Key)) {
+		return slog.Value{}, false
+	}
+	if len(r.secret) == 0 {
+		return slog.StringValue(RedactedValue), true
+	}
+	return slog.StringValue(hashValue(r.secret, a.Value.String())), true
+}
+
+// hashKeys returns the hashed keys of the options.
+// The LOG_HASH_KEYS environment variable takes precedence as comma-separated list.
+func hashKeys(o Options) []string {
+	if env, ok := os.LookupEnv("LOG_HASH_KEYS"); ok {
+		return splitList(env)
+	}
+	return o.HashKeys
+}
+
+// hashSecret returns the secret of the hashed values of the options.
+// The LOG_HASH_SECRET environment variable takes precedence.
+func hashSecret(o Options) []byte {
+	if env, ok := os.LookupEnv("LOG_HASH_SECRET"); ok {
+		return []byte(env)
+	}
+	return o.HashSecret
+}
@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestNewRedactionHandler_HashKeys(t *testing.T) {
+	secret := []byte("secret")
+	tests := []struct {
+		name   string
+		secret []byte
+		log    func(l *slog.Logger)
+		want   map[string]any
+	}{
+		{
+			name:   "hashed values",
+			secret: secret,
+			log: func(l *slog.Logger) {
+				l.Info("test", "user_id", 42, "IP", "10.0.0.1", "name", "alice")
+			},
+			want: map[string]any{"user_id": hashValue(secret, "42"), "IP": hashValue(secret, "10.0.0.1"), "name": "alice"},
+		},
+		{
+			name:   "nested values",
+			secret: secret,
+			log: func(l *slog.Logger) {
+				l.WithGroup("request").Info("test", slog.Group("client", "ip", "10.0.0.1"))
+			},
+			want: map[string]any{"request": map[string]any{"client": map[string]any{"ip": hashValue(secret, "10.0.0.1")}}},
+		},
+		{
+			name: "redacted without secret",
+			log: func(l *slog.Logger) {
+				l.With("user_id", 42).Info("test")
+			},
+			want: map[string]any{"user_id": RedactedValue},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewRedactionHandler(slog.NewJSONHandler(buf, nil), RedactOff, RedactionOptions{
+				HashKeys:   []string{"user_id", "ip"},
+				HashSecret: tt.secret,
+			})
+			tt.log(slog.New(h))
+
+			var rec map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+				t.Fatalf("Failed to unmarshal record %q: %v", buf.String(), err)
+			}
+			for k, want := range tt.want {
+				got, _ := json.Marshal(rec[k])
+				wantJSON, _ := json.Marshal(want)
+				if !bytes.Equal(got, wantJSON) {
+					t.Errorf("Expected %s=%s, got %s", k, wantJSON, got)
+				}
+			}
+		})
+	}
+}
+
+func TestHashValue(t *testing.T) {
+	a, b := hashValue([]byte("secret"), "42"), hashValue([]byte("secret"), "42")
+	if a != b || len(a) != 2*hashSize {
+		t.Errorf("Expected stable hashes of %d characters, got %q and %q", 2*hashSize, a, b)
+	}
+	if c := hashValue([]byte("other"), "42"); c == a {
+		t.Errorf("Expected different hashes for different secrets, got %q", c)
+	}
+}
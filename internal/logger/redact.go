@@ -0,0 +1,397 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// RedactedValue is the value replacing redacted data.
+const RedactedValue = "[REDACTED]"
+
+// RedactionPolicy is a preset of keys and value patterns to redact from records.
+type RedactionPolicy string
+
+const (
+	// RedactOff disables redaction.
+	RedactOff RedactionPolicy = "off"
+	// RedactStandard redacts credentials: the values of keys like password, secret, token, authorization or cookie,
+	// and bearer tokens, JWTs and AWS access keys anywhere in messages and string values.
+	RedactStandard RedactionPolicy = "standard"
+	// RedactStrict additionally redacts personal data: the values of keys like email, phone or iban,
+	// and email addresses, card numbers, IBANs and IPv4 addresses anywhere in messages and string values.
+	RedactStrict RedactionPolicy = "strict"
+)
+
+// RedactionOptions is the optional configuration for [NewRedactionHandler].
+type RedactionOptions struct {
+	// Paths are the dotted paths of attributes whose values are redacted in addition to the policy,
+	// e.g. "http.request.headers.authorization". The path of an attribute consists of the names of the groups
+	// it is nested in, including the groups of the logger, and its key. Segments are compared case-insensitively.
+	// A "*" segment matches any single segment, a "**" segment matches any number of segments,
+	// e.g. "*.headers.authorization" or "**.cookie".
+	Paths []string
+	// Keys are the keys of attributes whose values are redacted in addition to the policy at any depth,
+	// e.g. "password", "token", "authorization" or "api_key". Keys are compared case-insensitively.
+	Keys []string
+	// Patterns are the names of the patterns redacted in messages and string values in addition to the policy:
+	// "bearer", "jwt", "aws_key", "email", "card", "ipv4", "iban" or the name of a pattern
+	// registered by [RegisterRedactionPattern]. Unknown names are ignored.
+	Patterns []string
+	// HashKeys are the keys of attributes whose values are replaced by their keyed hash (HMAC-SHA256)
+	// at any depth, e.g. "user_id" or "ip", so the records remain correlatable without identifying anyone directly.
+	// Keys are compared case-insensitively. The hash is written as 32 hexadecimal characters.
+	HashKeys []string
+	// HashSecret is the secret key of the hashes of HashKeys. Without a secret, the values are redacted instead.
+	// Keep it secret and stable: anyone knowing it can test guesses, and a new secret breaks the correlation.
+	HashSecret []byte
+	// AllowKeys are the only keys of attributes emitted if set, all other attributes are dropped,
+	// e.g. for compliance requirements on what may appear in the logs. Keys are compared case-insensitively
+	// and apply at any depth: a group is kept if its key is allowed, and its attributes are filtered as well.
+	// The attributes added by other wrappers before the redaction, e.g. the trace context or the logger name,
+	// must be allowed as well. The message and the standard fields like the time are not affected.
+	AllowKeys []string
+	// AllowPlaceholder is a flag to replace the values of the attributes not allowed by AllowKeys
+	// by [RedactedValue] instead of dropping them, so it is visible which attributes were removed.
+	AllowPlaceholder bool
+}
+
+// redactionRules are the keys and value patterns redacted by a [RedactionPolicy].
+type redactionRules struct {
+	// keys are the normalized substrings of keys whose values are redacted.
+	keys []string
+	// patterns are the patterns redacted in messages and string values.
+	patterns []*regexp.Regexp
+	// paths are the split paths of attributes whose values are redacted.
+	paths [][]string
+	// denied are the lower-case keys whose values are redacted.
+	denied []string
+	// hashed are the lower-case keys whose values are hashed with the secret.
+	hashed []string
+	secret []byte
+	// allowed are the lower-case keys of the attributes emitted, all if empty.
+	allowed []string
+	// placeholder reports whether the values of attributes not allowed are redacted instead of dropped.
+	placeholder bool
+}
+
+var (
+	// standardRedaction are the rules of [RedactStandard].
+	standardRedaction = redactionRules{
+		keys:     []string{"password", "passwd", "secret", "token", "apikey", "authorization", "cookie", "privatekey", "credential"},
+		patterns: []*regexp.Regexp{bearerPattern, jwtPattern, awsKeyPattern},
+	}
+	// strictRedaction are the rules of [RedactStrict].
+	strictRedaction = redactionRules{
+		keys: append(append([]string{}, standardRedaction.keys...), "email", "phone", "ssn", "iban", "cardnumber", "birth"),
+		patterns: append(append([]*regexp.Regexp{}, standardRedaction.patterns...),
+			emailPattern, cardPattern, ibanPattern, ipv4Pattern),
+	}
+)
+
+// rules returns the redaction rules of the policy or nil if nothing is redacted.
+// Unknown policies, e.g. misspelled ones, fail closed with the rules of [RedactStandard].
+func (p RedactionPolicy) rules() *redactionRules {
+	switch RedactionPolicy(strings.ToLower(string(p))) {
+	case "", RedactOff:
+		return nil
+	case RedactStrict:
+		return &strictRedaction
+	default:
+		return &standardRedaction
+	}
+}
+
+// isKnown reports whether the policy is empty or one of the defined policies.
+func (p RedactionPolicy) isKnown() bool {
+	switch RedactionPolicy(strings.ToLower(string(p))) {
+	case "", RedactOff, RedactStandard, RedactStrict:
+		return true
+	default:
+		return false
+	}
+}
+
+// isProduction reports whether the given environment is a production environment.
+func isProduction(env string) bool {
+	switch strings.ToLower(env) {
+	case "prod", "production":
+		return true
+	default:
+		return false
+	}
+}
+
+// redactionPaths returns the redaction paths of the options.
+// The LOG_REDACT_PATHS environment variable takes precedence as comma-separated list.
+func redactionPaths(o Options) []string {
+	if env, ok := os.LookupEnv("LOG_REDACT_PATHS"); ok {
+		return splitList(env)
+	}
+	return o.RedactPaths
+}
+
+// redactionPatternNames returns the names of the redacted patterns of the options.
+// The LOG_REDACT_PATTERNS environment variable takes precedence as comma-separated list.
+func redactionPatternNames(o Options) []string {
+	if env, ok := os.LookupEnv("LOG_REDACT_PATTERNS"); ok {
+		return splitList(env)
+	}
+	return o.RedactPatterns
+}
+
+// redactionKeys returns the redacted keys of the options.
+// The LOG_REDACT_KEYS environment variable takes precedence as comma-separated list.
+func redactionKeys(o Options) []string {
+	if env, ok := os.LookupEnv("LOG_REDACT_KEYS"); ok {
+		return splitList(env)
+	}
+	return o.RedactKeys
+}
+
+// splitList returns the non-empty trimmed elements of the comma-separated list.
+func splitList(list string) []string {
+	var elems []string
+	for _, e := range strings.Split(list, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			elems = append(elems, e)
+		}
+	}
+	return elems
+}
+
+// redactionPolicy returns the redaction policy of the options.
+// Production environments default to [RedactStandard], all others to [RedactOff].
+func redactionPolicy(o Options) RedactionPolicy {
+	if o.Redaction != "" {
+		return RedactionPolicy(o.Redaction)
+	}
+	if isProduction(o.Environment) {
+		return RedactStandard
+	}
+	return RedactOff
+}
+
+var _ slog.Handler = (*redactionHandler)(nil)
+
+// redactionHandler is a [slog.Handler] redacting sensitive data from records.
+type redactionHandler struct {
+	slog.Handler
+	rules *redactionRules
+	// groups are the groups of the handler, which prefix the paths of the attributes.
+	groups []string
+}
+
+// NewRedactionHandler returns a new [slog.Handler] that replaces sensitive data by [RedactedValue]
+// according to the given policy before passing records to the given handler.
+// An unknown policy is treated as [RedactStandard], so a misspelled policy does not disable redaction.
+// The values of sensitive keys are redacted including nested groups,
+// the value patterns are redacted in the message and in all string values.
+// With [RedactionOptions.Paths], the values of the attributes at the given paths are redacted as well,
+// which targets nested groups precisely instead of matching keys everywhere.
+// With [RedactionOptions.Keys], the values of the attributes with the given keys are redacted at any depth,
+// so it can be installed around any handler as a deny-list of keys, e.g. with [RedactOff] as policy.
+// With [RedactionOptions.Patterns], the built-in or registered patterns with the given names are redacted
+// in the message and in all string values as well, e.g. "iban" in addition to [RedactStandard].
+// With [RedactionOptions.HashKeys], the values of the attributes with the given keys are replaced by their keyed hash,
+// unless they are redacted.
+// With [RedactionOptions.AllowKeys], only the attributes with the given keys are emitted.
+func NewRedactionHandler(h slog.Handler, policy RedactionPolicy, o ...RedactionOptions) slog.Handler {
+	var (
+		paths    [][]string
+		denied   []string
+		hashed   []string
+		allowed  []string
+		patterns []string
+		secret   []byte
+	)
+	if len(o) > 0 {
+		patterns, secret = o[0].Patterns, o[0].HashSecret
+		for _, p := range o[0].Paths {
+			paths = append(paths, strings.Split(strings.ToLower(p), "."))
+		}
+		for _, k := range o[0].Keys {
+			denied = append(denied, strings.ToLower(k))
+		}
+		for _, k := range o[0].HashKeys {
+			hashed = append(hashed, strings.ToLower(k))
+		}
+		for _, k := range o[0].AllowKeys {
+			allowed = append(allowed, strings.ToLower(k))
+		}
+	}
+
+	rules := policy.rules()
+	if rules == nil {
+		if len(paths) == 0 && len(denied) == 0 && len(hashed) == 0 && len(allowed) == 0 && len(patterns) == 0 {
+			return h
+		}
+		rules = &redactionRules{}
+	}
+	if len(paths) > 0 || len(denied) > 0 || len(hashed) > 0 || len(allowed) > 0 || len(patterns) > 0 {
+		custom := *rules
+		custom.paths, custom.denied = paths, denied
+		custom.hashed, custom.secret = hashed, secret
+		custom.allowed, custom.placeholder = allowed, len(allowed) > 0 && o[0].AllowPlaceholder
+		custom.patterns = withPatterns(rules.patterns, patterns)
+		rules = &custom
+	}
+	return &redactionHandler{Handler: h, rules: rules}
+}
+
+// Handle redacts the message and the attributes of the record and passes it to the wrapped handler.
+func (h *redactionHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	out := slog.NewRecord(r.Time, r.Level, h.rules.redactString(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		if a = h.rules.redactAttr(a, h.groups); !a.Equal(slog.Attr{}) {
+			out.AddAttrs(a)
+		}
+		return true
+	})
+	return h.Handler.Handle(ctx, out)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by the redacted attrs.
+func (h *redactionHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &redactionHandler{Handler: h.Handler.WithAttrs(h.rules.redactAttrs(attrs, h.groups)), rules: h.rules, groups: h.groups}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *redactionHandler) WithGroup(name string) slog.Handler {
+	return &redactionHandler{
+		Handler: h.Handler.WithGroup(name),
+		rules:   h.rules,
+		groups:  append(slices.Clip(h.groups), strings.ToLower(name)),
+	}
+}
+
+// redactAttrs returns the attributes nested in the given groups with their values redacted,
+// without the attributes dropped by the allow-list.
+func (r *redactionRules) redactAttrs(attrs []slog.Attr, groups []string) []slog.Attr {
+	redacted := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		if a = r.redactAttr(a, groups); !a.Equal(slog.Attr{}) {
+			redacted = append(redacted, a)
+		}
+	}
+	return redacted
+}
+
+// redactAttr returns the attribute nested in the given groups with its value redacted.
+// It returns an empty attribute if the attribute is dropped by the allow-list.
+func (r *redactionRules) redactAttr(a slog.Attr, groups []string) slog.Attr {
+	a.Value = a.Value.Resolve()
+	path := groups
+	if a.Key != "" {
+		if !r.isAllowed(a.Key) {
+			if !r.placeholder {
+				return slog.Attr{}
+			}
+			a.Value = slog.StringValue(RedactedValue)
+			return a
+		}
+		// The paths are only tracked if there are paths to match.
+		if len(r.paths) > 0 {
+			path = append(slices.Clip(groups), strings.ToLower(a.Key))
+		}
+		if r.isSensitiveKey(a.Key) || r.isSensitivePath(path) {
+			a.Value = slog.StringValue(RedactedValue)
+			return a
+		}
+		if v, ok := r.pseudonymize(a); ok {
+			a.Value = v
+			return a
+		}
+	}
+
+	switch a.Value.Kind() { //nolint:exhaustive // only strings, groups and arbitrary values can contain sensitive data
+	case slog.KindGroup:
+		a.Value = slog.GroupValue(r.redactAttrs(a.Value.Group(), path)...)
+	case slog.KindString:
+		a.Value = slog.StringValue(r.redactString(a.Value.String()))
+	case slog.KindAny:
+		a.Value = r.redactAny(a.Value)
+	}
+	return a
+}
+
+// redactAny returns the arbitrary value, e.g. an error, a map or a [fmt.Stringer], as its redacted text
+// if its text contains any of the value patterns. Otherwise, the value is returned unchanged.
+func (r *redactionRules) redactAny(v slog.Value) slog.Value {
+	if len(r.patterns) == 0 {
+		return v
+	}
+	var text string
+	if err, ok := v.Any().(error); ok {
+		text = err.Error()
+	} else {
+		text = fmt.Sprint(v.Any())
+	}
+	if redacted := r.redactString(text); redacted != text {
+		return slog.StringValue(redacted)
+	}
+	return v
+}
+
+// isSensitiveKey reports whether the values of the key are redacted.
+func (r *redactionRules) isSensitiveKey(key string) bool {
+	lower := strings.ToLower(key)
+	if slices.Contains(r.denied, lower) {
+		return true
+	}
+	normalized := strings.NewReplacer("_", "", "-", "", ".", "", " ", "").Replace(lower)
+	for _, k := range r.keys {
+		if strings.Contains(normalized, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSensitivePath reports whether the values of the attribute at the lower-case path are redacted.
+func (r *redactionRules) isSensitivePath(path []string) bool {
+	for _, p := range r.paths {
+		if matchPath(p, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPath reports whether the path matches the pattern.
+// A "*" segment of the pattern matches any single segment, a "**" segment matches any number of segments.
+func matchPath(pattern, path []string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case "**":
+			for i := 0; i <= len(path); i++ {
+				if matchPath(pattern[1:], path[i:]) {
+					return true
+				}
+			}
+			return false
+		case "*":
+		default:
+			if len(path) == 0 || pattern[0] != path[0] {
+				return false
+			}
+		}
+		if len(path) == 0 {
+			return false
+		}
+		pattern, path = pattern[1:], path[1:]
+	}
+	return len(path) == 0
+}
+
+// redactString returns the string with all value patterns replaced by [RedactedValue].
+func (r *redactionRules) redactString(s string) string {
+	for _, p := range r.patterns {
+		s = p.ReplaceAllLiteralString(s, RedactedValue)
+	}
+	return s
+}
@@ -0,0 +1,361 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+)
+
+func TestNewRedactionHandler(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RedactionPolicy
+		msg     string
+		attrs   []any
+		wantMsg string
+		want    map[string]string
+	}{
+		{
+			name:    "off",
+			policy:  RedactOff,
+			msg:     "login alice@example.com",
+			attrs:   []any{"password", "hunter2"},
+			wantMsg: "login alice@example.com",
+			want:    map[string]string{"password": "hunter2"},
+		},
+		{
+			name:    "standard redacts credentials",
+			policy:  RedactStandard,
+			msg:     "calling with Bearer abc.def",
+			attrs:   []any{"password", "hunter2", "API-Key", "key", "auth", "token eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.sig", "email", "alice@example.com"},
+			wantMsg: "calling with " + RedactedValue,
+			want: map[string]string{
+				"password": RedactedValue,
+				"API-Key":  RedactedValue,
+				"auth":     "token " + RedactedValue,
+				"email":    "alice@example.com",
+			},
+		},
+		{
+			name:    "strict redacts personal data",
+			policy:  RedactStrict,
+			msg:     "login alice@example.com from 10.0.0.1",
+			attrs:   []any{"user_email", "alice@example.com", "card", "4111 1111 1111 1111", "count", 3},
+			wantMsg: "login " + RedactedValue + " from " + RedactedValue,
+			want: map[string]string{
+				"user_email": RedactedValue,
+				"card":       RedactedValue,
+				"count":      "3",
+			},
+		},
+		{
+			name:    "nested groups",
+			policy:  RedactStandard,
+			msg:     "test",
+			attrs:   []any{slog.Group("db", slog.String("user", "admin"), slog.String("password", "hunter2"))},
+			wantMsg: "test",
+			want:    map[string]string{"db": "[user=admin password=" + RedactedValue + "]"},
+		},
+		{
+			name:    "unknown policy fails closed",
+			policy:  "standrad",
+			msg:     "test",
+			attrs:   []any{"password", "hunter2"},
+			wantMsg: "test",
+			want:    map[string]string{"password": RedactedValue},
+		},
+		{
+			name:    "errors",
+			policy:  RedactStandard,
+			msg:     "test",
+			attrs:   []any{"error", errors.New("request failed: Bearer abc.def"), "cause", errors.New("timeout")},
+			wantMsg: "test",
+			want:    map[string]string{"error": "request failed: " + RedactedValue, "cause": "timeout"},
+		},
+		{
+			name:    "arbitrary values",
+			policy:  RedactStandard,
+			msg:     "test",
+			attrs:   []any{"headers", map[string]string{"auth": "Bearer abc"}, "ids", []int{1, 2}},
+			wantMsg: "test",
+			want:    map[string]string{"headers": "map[auth:" + RedactedValue + "]", "ids": "[1 2]"},
+		},
+		{
+			name:    "policy is case insensitive",
+			policy:  "STANDARD",
+			msg:     "test",
+			attrs:   []any{"secret", "value"},
+			wantMsg: "test",
+			want:    map[string]string{"secret": RedactedValue},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var record slog.Record
+			h := NewRedactionHandler(test.MockHandler{
+				HandleFunc: func(_ context.Context, r slog.Record) error {
+					record = r
+					return nil
+				},
+			}, tt.policy)
+			NewLogger(Options{Handler: h}).Info(tt.msg, tt.attrs...)
+
+			if record.Message != tt.wantMsg {
+				t.Errorf("Expected message %q, got %q", tt.wantMsg, record.Message)
+			}
+			got := map[string]string{}
+			record.Attrs(func(a slog.Attr) bool {
+				got[a.Key] = a.Value.String()
+				return true
+			})
+			for key, want := range tt.want {
+				if got[key] != want {
+					t.Errorf("Expected %s=%q, got %q", key, want, got[key])
+				}
+			}
+		})
+	}
+}
+
+func TestNewLogger_UnknownRedactionPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	NewLogger(Options{Output: path, Format: "JSON", Redaction: "standrad"}).Info("login", "password", "hunter2")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read the log file: %v", err)
+	}
+	out := string(data)
+	if !strings.Contains(out, "Unknown redaction policy") || !strings.Contains(out, `"password":"`+RedactedValue+`"`) {
+		t.Errorf("Expected a warning and the password redacted, got %s", out)
+	}
+}
+
+func TestNewRedactionHandler_WithAttrs(t *testing.T) {
+	var got []slog.Attr
+	h := NewRedactionHandler(test.MockHandler{
+		WithAttrsFunc: func(attrs []slog.Attr) slog.Handler {
+			got = attrs
+			return test.MockHandler{}
+		},
+	}, RedactStandard)
+	_ = h.WithAttrs([]slog.Attr{slog.String("token", "abc"), slog.String("user", "alice")})
+
+	if len(got) != 2 || got[0].Value.String() != RedactedValue || got[1].Value.String() != "alice" {
+		t.Errorf("Expected the token to be redacted, got %v", got)
+	}
+}
+
+func TestRedactionPolicy(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		env  map[string]string
+		want RedactionPolicy
+	}{
+		{name: "default is off", want: RedactOff},
+		{name: "production defaults to standard", opts: Options{Environment: "production"}, want: RedactStandard},
+		{name: "explicit policy wins", opts: Options{Environment: "prod", Redaction: "strict"}, want: RedactStrict},
+		{name: "explicit off in production", opts: Options{Environment: "prod", Redaction: "off"}, want: RedactOff},
+		{name: "environment variable", env: map[string]string{"LOG_ENV": "prod"}, want: RedactStandard},
+		{
+			name: "environment variables win",
+			opts: Options{Redaction: "off"},
+			env:  map[string]string{"LOG_REDACTION": "strict"},
+			want: RedactStrict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if got := redactionPolicy(newOptions(tt.opts)); got != tt.want {
+				t.Errorf("redactionPolicy() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRedactionHandler_Paths(t *testing.T) {
+	headers := slog.Group("headers", slog.String("Authorization", "Basic abc"), slog.String("accept", "*/*"))
+	tests := []struct {
+		name   string
+		policy RedactionPolicy
+		paths  []string
+		log    func(l Provider)
+		want   string
+	}{
+		{
+			name:  "exact path",
+			paths: []string{"http.request.headers.authorization"},
+			log: func(l Provider) {
+				l.WithGroup("http").Info("test", slog.Group("request", headers), slog.String("authorization", "kept"))
+			},
+			want: "http.request.headers.Authorization=[REDACTED] http.request.headers.accept=*/* http.authorization=kept",
+		},
+		{
+			name:  "single segment wildcard",
+			paths: []string{"*.headers.authorization"},
+			log: func(l Provider) {
+				l.Info("test", slog.Group("request", headers), slog.Group("response", headers), slog.Group("http", slog.Group("request", headers)))
+			},
+			want: "request.headers.Authorization=[REDACTED] request.headers.accept=*/* " +
+				"response.headers.Authorization=[REDACTED] response.headers.accept=*/* " +
+				"http.request.headers.Authorization=Basic abc http.request.headers.accept=*/*",
+		},
+		{
+			name:  "multi segment wildcard",
+			paths: []string{"**.authorization"},
+			log: func(l Provider) {
+				l.With("authorization", "top").Info("test", slog.Group("http", slog.Group("request", headers)))
+			},
+			want: "authorization=[REDACTED] http.request.headers.Authorization=[REDACTED] http.request.headers.accept=*/*",
+		},
+		{
+			name:  "group path",
+			paths: []string{"request.headers"},
+			log: func(l Provider) {
+				l.Info("test", slog.Group("request", headers, slog.String("method", "GET")))
+			},
+			want: "request.headers=[REDACTED] request.method=GET",
+		},
+		{
+			name:   "combined with policy",
+			policy: RedactStandard,
+			paths:  []string{"user.name"},
+			log: func(l Provider) {
+				l.Info("test", slog.Group("user", slog.String("name", "alice"), slog.String("password", "hunter2")))
+			},
+			want: "user.name=[REDACTED] user.password=[REDACTED]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewRedactionHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey) {
+						return slog.Attr{}
+					}
+					return a
+				},
+			}), tt.policy, RedactionOptions{Paths: tt.paths})
+			tt.log(NewLogger(Options{Handler: h}))
+
+			if got := strings.TrimSpace(buf.String()); got != strings.ReplaceAll(tt.want, "Basic abc", `"Basic abc"`) {
+				t.Errorf("Expected\n%s\ngot\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewRedactionHandler_Keys(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []string
+		log  func(l Provider)
+		want string
+	}{
+		{
+			name: "top-level keys",
+			keys: []string{"password", "API_KEY"},
+			log: func(l Provider) {
+				l.Info("test", "Password", "hunter2", "api_key", "abc", "user", "alice")
+			},
+			want: "Password=[REDACTED] api_key=[REDACTED] user=alice",
+		},
+		{
+			name: "nested keys",
+			keys: []string{"authorization"},
+			log: func(l Provider) {
+				l.WithGroup("http").Info("test", slog.Group("headers", slog.String("Authorization", "Basic abc"), slog.String("accept", "*/*")))
+			},
+			want: "http.headers.Authorization=[REDACTED] http.headers.accept=*/*",
+		},
+		{
+			name: "attributes of the logger",
+			keys: []string{"token"},
+			log: func(l Provider) {
+				l.With("token", "abc").Info("test", "tokens", 3)
+			},
+			want: "token=[REDACTED] tokens=3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewRedactionHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey) {
+						return slog.Attr{}
+					}
+					return a
+				},
+			}), RedactOff, RedactionOptions{Keys: tt.keys})
+			tt.log(NewLogger(Options{Handler: h}))
+
+			if got := strings.TrimSpace(buf.String()); got != tt.want {
+				t.Errorf("Expected\n%s\ngot\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "a.b", path: "a.b", want: true},
+		{pattern: "a.b", path: "a.b.c", want: false},
+		{pattern: "a.b.c", path: "a.b", want: false},
+		{pattern: "a.*", path: "a.b", want: true},
+		{pattern: "a.*", path: "a", want: false},
+		{pattern: "**.c", path: "c", want: true},
+		{pattern: "**.c", path: "a.b.c", want: true},
+		{pattern: "a.**", path: "a", want: true},
+		{pattern: "a.**.d", path: "a.b.c.d", want: true},
+		{pattern: "a.**.d", path: "a.b.c", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.path, func(t *testing.T) {
+			if got := matchPath(strings.Split(tt.pattern, "."), strings.Split(tt.path, ".")); got != tt.want {
+				t.Errorf("matchPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactionPaths(t *testing.T) {
+	if got := redactionPaths(Options{RedactPaths: []string{"a.b"}}); !slices.Equal(got, []string{"a.b"}) {
+		t.Errorf("Expected paths of the options, got %v", got)
+	}
+	t.Setenv("LOG_REDACT_PATHS", " http.cookie , **.token,")
+	if got := redactionPaths(Options{RedactPaths: []string{"a.b"}}); !slices.Equal(got, []string{"http.cookie", "**.token"}) {
+		t.Errorf("Expected paths of the environment variable, got %v", got)
+	}
+}
+
+func TestRedactionKeys(t *testing.T) {
+	if got := redactionKeys(Options{RedactKeys: []string{"password"}}); !slices.Equal(got, []string{"password"}) {
+		t.Errorf("Expected keys of the options, got %v", got)
+	}
+	t.Setenv("LOG_REDACT_KEYS", " api_key , session,")
+	if got := redactionKeys(Options{RedactKeys: []string{"password"}}); !slices.Equal(got, []string{"api_key", "session"}) {
+		t.Errorf("Expected keys of the environment variable, got %v", got)
+	}
+}
@@ -0,0 +1,182 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultRequestScopeMaxRecords is the default [RequestScopeOptions.MaxRecords].
+const defaultRequestScopeMaxRecords = 1000
+
+// RequestScopeOptions is the optional configuration for the [RequestScope] middleware.
+// Empty fields are replaced by their defaults.
+type RequestScopeOptions struct {
+	// Message is the message of the summary record. Defaults to "Request finished".
+	Message string
+	// MaxRecords is the maximum number of records buffered per request.
+	// If the buffer is full, the oldest record is dropped. Defaults to 1000.
+	MaxRecords int
+}
+
+// newRequestScopeOptions returns the provided RequestScopeOptions merged with the default RequestScopeOptions.
+func newRequestScopeOptions(o ...RequestScopeOptions) RequestScopeOptions {
+	opts := RequestScopeOptions{Message: "Request finished", MaxRecords: defaultRequestScopeMaxRecords}
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided RequestScopeOptions with the receiver RequestScopeOptions.
+func (o *RequestScopeOptions) merge(d RequestScopeOptions) RequestScopeOptions {
+	if o.Message != "" {
+		d.Message = o.Message
+	}
+	if o.MaxRecords > 0 {
+		d.MaxRecords = o.MaxRecords
+	}
+	return d
+}
+
+// RequestScope returns a middleware that buffers all records logged with the logger of the request context
+// and flushes them together with a summary record once the request context is done.
+// The flush is registered with [context.AfterFunc], so the records and the summary are emitted even if the client
+// disconnects and the request is abandoned while the handler is still running.
+// Records logged after the flush are passed through directly.
+//
+// At most [RequestScopeOptions.MaxRecords] records are buffered, dropping the oldest ones.
+// The summary contains the method, path, duration and number of buffered and dropped records,
+// the status code if the request finished and whether the request was aborted. Its level is chosen by the status class like in [AccessLog].
+//
+// Use it after [Middleware] and [RequestID] to buffer the request's logger.
+func RequestScope(o ...RequestScopeOptions) func(http.Handler) http.Handler {
+	opts := newRequestScopeOptions(o...)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			log := FromContext(r.Context())
+			buf := &recordBuffer{max: opts.MaxRecords}
+
+			ctx, cancel := context.WithCancel(r.Context())
+			defer cancel()
+			rec := &responseRecorder{ResponseWriter: w}
+
+			var once sync.Once
+			flush := func(finished bool) {
+				once.Do(func() {
+					count, dropped := buf.flush()
+					attrs := []slog.Attr{
+						slog.String("method", r.Method),
+						slog.String("path", r.URL.Path),
+						slog.Duration("duration", time.Since(start)),
+						slog.Int("records", count),
+						slog.Int("dropped", dropped),
+						slog.Bool("aborted", !finished),
+					}
+					level := LevelWarn
+					if finished {
+						level = statusLevel(rec.Status())
+						attrs = append(attrs, slog.Int("status", rec.Status()))
+					}
+					log.LogAttrs(context.WithoutCancel(ctx), level, opts.Message, attrs...)
+				})
+			}
+			stop := context.AfterFunc(ctx, func() { flush(false) })
+
+			next.ServeHTTP(rec, r.WithContext(IntoContext(ctx, withHandler(log, newBufferHandler(log.Handler(), buf)))))
+			if stop() {
+				flush(true)
+			}
+		})
+	}
+}
+
+// withHandler returns a copy of the logger using the given handler.
+func withHandler(p Provider, h slog.Handler) Provider {
+	if l, ok := p.(*logger); ok {
+		return &logger{Logger: slog.New(h), name: l.name, swap: l.swap, level: l.level, scopes: l.scopes, fatal: l.fatal, panicErrors: l.panicErrors, caller: l.caller}
+	}
+	return FromSlog(slog.New(h))
+}
+
+// bufferedRecord is a record held by a [recordBuffer] until it is flushed.
+type bufferedRecord struct {
+	ctx     context.Context //nolint:containedctx // the record is handled later with its context
+	handler slog.Handler
+	record  slog.Record
+}
+
+// recordBuffer holds records until it is flushed.
+type recordBuffer struct {
+	mu      sync.Mutex
+	records []bufferedRecord
+	flushed bool
+	// max is the maximum number of buffered records, dropped is the number of records dropped to stay within it.
+	max     int
+	dropped int
+}
+
+// add adds the record to the buffer.
+// Reports false if the buffer was already flushed and the record must be handled directly.
+func (b *recordBuffer) add(ctx context.Context, h slog.Handler, r slog.Record) bool { //nolint:gocritic // records are passed by value
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.flushed {
+		return false
+	}
+	if b.max > 0 && len(b.records) >= b.max {
+		b.records[0] = bufferedRecord{}
+		b.records = b.records[1:]
+		b.dropped++
+	}
+	b.records = append(b.records, bufferedRecord{ctx: context.WithoutCancel(ctx), handler: h, record: r.Clone()})
+	return true
+}
+
+// flush handles all buffered records in order and returns their number and the number of dropped records.
+// Records added afterwards are handled directly.
+func (b *recordBuffer) flush() (count, dropped int) {
+	b.mu.Lock()
+	records, dropped := b.records, b.dropped
+	b.records, b.flushed = nil, true
+	b.mu.Unlock()
+
+	for _, br := range records {
+		_ = br.handler.Handle(br.ctx, br.record)
+	}
+	return len(records), dropped
+}
+
+var _ slog.Handler = (*bufferHandler)(nil)
+
+// bufferHandler is a [slog.Handler] that holds records in a [recordBuffer] until it is flushed.
+type bufferHandler struct {
+	slog.Handler
+	buf *recordBuffer
+}
+
+// newBufferHandler returns a new [slog.Handler] that holds records in the buffer until it is flushed.
+func newBufferHandler(h slog.Handler, buf *recordBuffer) slog.Handler {
+	return &bufferHandler{Handler: h, buf: buf}
+}
+
+// Handle adds the record to the buffer or passes it to the wrapped handler if the buffer was already flushed.
+func (h *bufferHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	if h.buf.add(ctx, h.Handler, r) {
+		return nil
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *bufferHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &bufferHandler{Handler: h.Handler.WithAttrs(attrs), buf: h.buf}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *bufferHandler) WithGroup(name string) slog.Handler {
+	return &bufferHandler{Handler: h.Handler.WithGroup(name), buf: h.buf}
+}
@@ -0,0 +1,162 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+)
+
+// recordCollector collects the handled records.
+type recordCollector struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+func (c *recordCollector) handler() slog.Handler {
+	return test.MockHandler{
+		HandleFunc: func(_ context.Context, r slog.Record) error {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			c.records = append(c.records, r)
+			return nil
+		},
+	}
+}
+
+func (c *recordCollector) messages() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	msgs := make([]string, 0, len(c.records))
+	for _, r := range c.records {
+		msgs = append(msgs, r.Message)
+	}
+	return msgs
+}
+
+func (c *recordCollector) last() map[string]slog.Value {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	attrs := map[string]slog.Value{}
+	if len(c.records) == 0 {
+		return attrs
+	}
+	c.records[len(c.records)-1].Attrs(func(a slog.Attr) bool {
+		attrs[a.Key] = a.Value
+		return true
+	})
+	return attrs
+}
+
+func TestRequestScope(t *testing.T) {
+	c := &recordCollector{}
+	log := NewLogger(Options{Handler: c.handler()})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l := FromContext(r.Context())
+		l.Info("first")
+		l.With("key", "value").Warn("second")
+		if got := c.messages(); len(got) != 0 {
+			t.Errorf("Expected records to be buffered, got %v", got)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users", http.NoBody)
+	Middleware(IntoContext(context.Background(), log))(RequestScope()(handler)).ServeHTTP(httptest.NewRecorder(), req)
+
+	want := []string{"first", "second", "Request finished"}
+	got := c.messages()
+	if len(got) != len(want) {
+		t.Fatalf("Expected records %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected record %d to be %q, got %q", i, want[i], got[i])
+		}
+	}
+
+	summary := c.last()
+	if summary["status"].Int64() != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %v", http.StatusNotFound, summary["status"])
+	}
+	if summary["records"].Int64() != 2 {
+		t.Errorf("Expected 2 records, got %v", summary["records"])
+	}
+	if summary["aborted"].Bool() {
+		t.Error("Expected the request not to be aborted")
+	}
+}
+
+func TestRequestScope_MaxRecords(t *testing.T) {
+	c := &recordCollector{}
+	log := NewLogger(Options{Handler: c.handler()})
+
+	handler := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		for i := range 5 {
+			FromContext(r.Context()).Info(strconv.Itoa(i))
+		}
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody).WithContext(IntoContext(context.Background(), log))
+	RequestScope(RequestScopeOptions{MaxRecords: 2})(handler).ServeHTTP(httptest.NewRecorder(), req)
+
+	want := []string{"3", "4", "Request finished"}
+	if got := c.messages(); !slices.Equal(got, want) {
+		t.Errorf("Expected the newest records %v, got %v", want, got)
+	}
+	summary := c.last()
+	if summary["records"].Int64() != 2 || summary["dropped"].Int64() != 3 {
+		t.Errorf("Expected 2 records and 3 dropped, got %v and %v", summary["records"], summary["dropped"])
+	}
+}
+
+func TestRequestScope_Aborted(t *testing.T) {
+	c := &recordCollector{}
+	log := NewLogger(Options{Handler: c.handler()})
+
+	logged := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+	handler := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		defer close(done)
+		FromContext(r.Context()).Info("before disconnect")
+		close(logged)
+		<-release
+		FromContext(r.Context()).Info("after disconnect")
+	})
+
+	ctx, cancel := context.WithCancel(IntoContext(context.Background(), log))
+	req := httptest.NewRequest(http.MethodGet, "/slow", http.NoBody).WithContext(ctx)
+	go RequestScope(RequestScopeOptions{Message: "summary"})(handler).ServeHTTP(httptest.NewRecorder(), req)
+
+	<-logged
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+	for len(c.messages()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := c.messages(); len(got) != 2 || got[1] != "summary" {
+		t.Fatalf("Expected the records and the summary to be flushed on disconnect, got %v", got)
+	}
+	summary := c.last()
+	if !summary["aborted"].Bool() {
+		t.Error("Expected the request to be aborted")
+	}
+	if _, ok := summary["status"]; ok {
+		t.Error("Expected no status for an aborted request")
+	}
+
+	close(release)
+	<-done
+	if got := c.messages(); len(got) != 3 || got[2] != "after disconnect" {
+		t.Errorf("Expected records after the flush to pass through, got %v", got)
+	}
+}
@@ -0,0 +1,119 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	// defaultRetryMaxAttempts is the default [RetryPolicy.MaxAttempts].
+	defaultRetryMaxAttempts = 3
+	// defaultRetryMinBackoff is the default [RetryPolicy.MinBackoff].
+	defaultRetryMinBackoff = 100 * time.Millisecond
+	// defaultRetryMaxBackoff is the default [RetryPolicy.MaxBackoff].
+	defaultRetryMaxBackoff = 5 * time.Second
+)
+
+// RetryPolicy is the configuration for [NewRetryHandler].
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a record is passed to the wrapped handler,
+	// including the first attempt. Defaults to 3.
+	MaxAttempts int
+	// MinBackoff is the wait before the first retry, which is doubled with every retry. Defaults to 100 milliseconds.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum wait between retries. Defaults to 5 seconds.
+	MaxBackoff time.Duration
+	// Retryable reports whether the error returned by the wrapped handler is transient, so the record is retried.
+	// Defaults to all errors except [ErrHandlerClosed], [ErrCircuitOpen] and context errors.
+	Retryable func(err error) bool
+}
+
+// newRetryPolicy returns the provided RetryPolicy merged with the default RetryPolicy.
+func newRetryPolicy(p RetryPolicy) RetryPolicy {
+	return p.merge(RetryPolicy{
+		MaxAttempts: defaultRetryMaxAttempts,
+		MinBackoff:  defaultRetryMinBackoff,
+		MaxBackoff:  defaultRetryMaxBackoff,
+		Retryable:   isRetryable,
+	})
+}
+
+// merge merges the provided RetryPolicy with the receiver RetryPolicy.
+func (p *RetryPolicy) merge(d RetryPolicy) RetryPolicy {
+	if p.MaxAttempts > 0 {
+		d.MaxAttempts = p.MaxAttempts
+	}
+	if p.MinBackoff > 0 {
+		d.MinBackoff = p.MinBackoff
+	}
+	if p.MaxBackoff > 0 {
+		d.MaxBackoff = p.MaxBackoff
+	}
+	if p.Retryable != nil {
+		d.Retryable = p.Retryable
+	}
+	return d
+}
+
+// isRetryable is the default [RetryPolicy.Retryable].
+func isRetryable(err error) bool {
+	return !errors.Is(err, ErrHandlerClosed) && !errors.Is(err, ErrCircuitOpen) &&
+		!errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+var _ slog.Handler = (*retryHandler)(nil)
+
+// retryHandler is a [slog.Handler] retrying records the wrapped handler failed to handle.
+type retryHandler struct {
+	slog.Handler
+	policy RetryPolicy
+}
+
+// NewRetryHandler returns a new [slog.Handler] that retries passing a record to the given handler,
+// e.g. one sending it to a network sink, if it returns a transient error as reported by [RetryPolicy.Retryable].
+//
+// The record is passed at most [RetryPolicy.MaxAttempts] times. The wait between the attempts starts
+// at [RetryPolicy.MinBackoff] and doubles up to [RetryPolicy.MaxBackoff], with a random jitter of up to half of it
+// so the retries of many loggers do not synchronize. Handle blocks while waiting and stops if the context is done.
+// If all attempts failed, the error of the last one is returned.
+func NewRetryHandler(h slog.Handler, policy RetryPolicy) slog.Handler {
+	return &retryHandler{Handler: h, policy: newRetryPolicy(policy)}
+}
+
+// Handle passes the record to the wrapped handler and retries it with backoff while it fails transiently.
+func (h *retryHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	backoff := h.policy.MinBackoff
+	for attempt := 1; ; attempt++ {
+		// Every attempt gets its own copy, since a failed attempt may have modified the record.
+		err := h.Handler.Handle(ctx, r.Clone())
+		if err == nil || attempt >= h.policy.MaxAttempts || !h.policy.Retryable(err) {
+			return err
+		}
+
+		wait := backoff/2 + rand.N(backoff/2+1) //nolint:gosec,mnd // the jitter does not need to be cryptographically secure
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, h.policy.MaxBackoff) //nolint:mnd // the backoff is doubled with every retry
+	}
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *retryHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &retryHandler{Handler: h.Handler.WithAttrs(attrs), policy: h.policy}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *retryHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &retryHandler{Handler: h.Handler.WithGroup(name), policy: h.policy}
+}
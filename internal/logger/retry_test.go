@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countingHandler is a handler failing with the errors in order.
+type countingHandler struct {
+	slog.Handler
+	errs  []error
+	calls int
+}
+
+func (h *countingHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	h.calls++
+	if h.calls <= len(h.errs) {
+		return h.errs[h.calls-1]
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func TestRetryHandler(t *testing.T) {
+	transient := errors.New("connection reset")
+	tests := []struct {
+		name      string
+		errs      []error
+		policy    RetryPolicy
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "success", wantCalls: 1},
+		{name: "transient", errs: []error{transient, transient}, wantCalls: 3},
+		{name: "max attempts", errs: []error{transient, transient, transient}, policy: RetryPolicy{MaxAttempts: 2}, wantErr: transient, wantCalls: 2},
+		{name: "permanent", errs: []error{ErrHandlerClosed}, wantErr: ErrHandlerClosed, wantCalls: 1},
+		{
+			name:      "custom retryable",
+			errs:      []error{transient},
+			policy:    RetryPolicy{Retryable: func(err error) bool { return !errors.Is(err, transient) }},
+			wantErr:   transient,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf strings.Builder
+			text := slog.NewTextHandler(&buf, nil).WithAttrs([]slog.Attr{slog.String("service", "api")})
+			sink := &countingHandler{Handler: text, errs: tt.errs}
+			tt.policy.MinBackoff = time.Millisecond
+			h := NewRetryHandler(sink, tt.policy)
+
+			err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "hello", 0))
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("Handle() error = %v, want %v", err, tt.wantErr)
+			}
+			if sink.calls != tt.wantCalls {
+				t.Errorf("Expected %d attempts, got %d", tt.wantCalls, sink.calls)
+			}
+			if tt.wantErr == nil && strings.Count(buf.String(), "msg=hello service=api") != 1 {
+				t.Errorf("Expected the record once, got %q", buf.String())
+			}
+		})
+	}
+}
+
+func TestRetryHandler_Context(t *testing.T) {
+	sink := &countingHandler{Handler: slog.NewTextHandler(&strings.Builder{}, nil), errs: []error{errors.New("timeout"), nil}}
+	h := NewRetryHandler(sink, RetryPolicy{MinBackoff: time.Minute})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "hello", 0)); err == nil || sink.calls != 1 {
+		t.Errorf("Expected the retry to stop with the context, got %v after %d attempts", err, sink.calls)
+	}
+}
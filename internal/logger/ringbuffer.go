@@ -0,0 +1,179 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+)
+
+// defaultRingBufferSize is the default [RingBufferOptions.Size].
+const defaultRingBufferSize = 1000
+
+// RingBufferOptions is the optional configuration for [NewRingBufferHandler].
+type RingBufferOptions struct {
+	// Size is the number of records kept. Defaults to 1000.
+	Size int
+	// Level is the name of the minimum level of the records kept, independent of the level of the wrapped handler.
+	// Defaults to "DEBUG".
+	Level string
+	// DumpLevel is the name of the level of the records that dump the buffer to [RingBufferOptions.Output].
+	// Defaults to "PANIC".
+	DumpLevel string
+	// Output is the writer the buffer is dumped to. Defaults to [os.Stderr].
+	Output io.Writer
+}
+
+// newRingBufferOptions returns the provided RingBufferOptions merged with the default RingBufferOptions.
+func newRingBufferOptions(o ...RingBufferOptions) RingBufferOptions {
+	opts := RingBufferOptions{
+		Size:      defaultRingBufferSize,
+		Level:     "DEBUG",
+		DumpLevel: "PANIC",
+		Output:    os.Stderr,
+	}
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided RingBufferOptions with the receiver RingBufferOptions.
+func (o *RingBufferOptions) merge(d RingBufferOptions) RingBufferOptions {
+	if o.Size > 0 {
+		d.Size = o.Size
+	}
+	for _, f := range []struct{ src, dst *string }{
+		{&o.Level, &d.Level},
+		{&o.DumpLevel, &d.DumpLevel},
+	} {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+	if o.Output != nil {
+		d.Output = o.Output
+	}
+	return d
+}
+
+// ringBuffer is the buffer shared by a [RingBufferHandler] and the handlers derived from it.
+// It is the writer of the JSON handler encoding the records, which writes every record with a single call.
+type ringBuffer struct {
+	opts RingBufferOptions
+	// level and dumpLevel are the parsed levels of the options.
+	level     Level
+	dumpLevel Level
+	mu        sync.Mutex
+	// lines are the encoded records, next is the index of the oldest one once the buffer is full.
+	lines [][]byte
+	next  int
+}
+
+var _ slog.Handler = (*RingBufferHandler)(nil)
+
+// RingBufferHandler is a [slog.Handler] keeping the last records in memory for post-mortem debugging.
+type RingBufferHandler struct {
+	slog.Handler
+	// enc encodes the records into the buffer.
+	enc  slog.Handler
+	ring *ringBuffer
+}
+
+// NewRingBufferHandler returns a new [RingBufferHandler] that passes the records to the given handler
+// and keeps the last [RingBufferOptions.Size] records as JSON lines, including the records below the level
+// of the given handler down to [RingBufferOptions.Level].
+//
+// A record at or above [RingBufferOptions.DumpLevel], e.g. of Panic or Fatal, dumps and clears the buffer,
+// giving the context of the crash. The buffer can also be dumped on demand with [RingBufferHandler.Dump].
+func NewRingBufferHandler(h slog.Handler, o ...RingBufferOptions) *RingBufferHandler {
+	opts := newRingBufferOptions(o...)
+	ring := &ringBuffer{
+		opts:      opts,
+		level:     newLevel(opts.Level),
+		dumpLevel: newLevel(opts.DumpLevel),
+		lines:     make([][]byte, 0, opts.Size),
+	}
+	return &RingBufferHandler{
+		Handler: h,
+		enc: slog.NewJSONHandler(ring, &slog.HandlerOptions{
+			AddSource:   true,
+			Level:       slog.Level(ring.level),
+			ReplaceAttr: replaceAttr,
+		}),
+		ring: ring,
+	}
+}
+
+// Enabled reports whether the wrapped handler handles records at the given level or the level is kept.
+func (h *RingBufferHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.Handler.Enabled(ctx, level) || Level(level) >= h.ring.level
+}
+
+// Handle keeps the record, passes it to the wrapped handler if it is enabled and dumps the buffer
+// if the record is at or above the dump level.
+func (h *RingBufferHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	if Level(r.Level) >= h.ring.level {
+		h.ring.mu.Lock()
+		_ = h.enc.Handle(ctx, r)
+		h.ring.mu.Unlock()
+	}
+
+	var err error
+	if h.Handler.Enabled(ctx, r.Level) {
+		err = h.Handler.Handle(ctx, r)
+	}
+	if Level(r.Level) >= h.ring.dumpLevel {
+		h.ring.mu.Lock()
+		_ = h.ring.dump(h.ring.opts.Output)
+		h.ring.lines, h.ring.next = h.ring.lines[:0], 0
+		h.ring.mu.Unlock()
+	}
+	return err
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *RingBufferHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &RingBufferHandler{Handler: h.Handler.WithAttrs(attrs), enc: h.enc.WithAttrs(attrs), ring: h.ring}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *RingBufferHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &RingBufferHandler{Handler: h.Handler.WithGroup(name), enc: h.enc.WithGroup(name), ring: h.ring}
+}
+
+// Dump writes the kept records as JSON lines to w, the oldest first.
+// The records are kept, so the buffer can be dumped again.
+func (h *RingBufferHandler) Dump(w io.Writer) error {
+	h.ring.mu.Lock()
+	defer h.ring.mu.Unlock()
+	return h.ring.dump(w)
+}
+
+// Write keeps the record, replacing the oldest one if the buffer is full.
+// Must be called with the lock held, which the JSON handler does since it is only called by [RingBufferHandler.Handle].
+func (b *ringBuffer) Write(p []byte) (int, error) {
+	line := bytes.Clone(p)
+	if len(b.lines) < b.opts.Size {
+		b.lines = append(b.lines, line)
+		return len(p), nil
+	}
+	b.lines[b.next] = line
+	b.next = (b.next + 1) % b.opts.Size
+	return len(p), nil
+}
+
+// dump writes the kept records to w, the oldest first. Must be called with the lock held.
+func (b *ringBuffer) dump(w io.Writer) error {
+	for i := range b.lines {
+		if _, err := w.Write(b.lines[(b.next+i)%len(b.lines)]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
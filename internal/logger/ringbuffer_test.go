@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestRingBufferHandler(t *testing.T) {
+	var out, dump strings.Builder
+	h := NewRingBufferHandler(slog.NewTextHandler(&out, nil), RingBufferOptions{Size: 3, Output: &dump})
+	log := NewLogger(Options{Handler: h, Exit: func(int) {}}).With("service", "api")
+
+	for _, msg := range []string{"first", "second", "third", "fourth"} {
+		log.Debug(msg)
+	}
+	if out.Len() > 0 {
+		t.Errorf("Expected the debug records not to be passed to the wrapped handler, got %s", out.String())
+	}
+
+	var manual strings.Builder
+	if err := h.Dump(&manual); err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(manual.String()), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[0], `"msg":"second","service":"api"`) || !strings.Contains(lines[2], `"msg":"fourth"`) {
+		t.Errorf("Expected the last 3 records, got %v", lines)
+	}
+	if dump.Len() > 0 {
+		t.Errorf("Expected no automatic dump, got %s", dump.String())
+	}
+
+	log.Fatal("crash")
+	lines = strings.Split(strings.TrimSpace(dump.String()), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[0], `"msg":"third"`) || !strings.Contains(lines[2], `"level":"FATAL"`) || !strings.Contains(lines[2], `"msg":"crash"`) {
+		t.Errorf("Expected the records before the crash to be dumped, got %v", lines)
+	}
+	if !strings.Contains(out.String(), "msg=crash") {
+		t.Errorf("Expected the fatal record to be passed to the wrapped handler, got %s", out.String())
+	}
+
+	// The automatic dump clears the buffer.
+	manual.Reset()
+	_ = h.Dump(&manual)
+	if manual.Len() > 0 {
+		t.Errorf("Expected an empty buffer, got %s", manual.String())
+	}
+}
+
+func TestRingBufferHandler_Panic(t *testing.T) {
+	var dump strings.Builder
+	h := NewRingBufferHandler(slog.NewTextHandler(&strings.Builder{}, nil), RingBufferOptions{Output: &dump})
+	log := NewLogger(Options{Handler: h})
+
+	log.WithGroup("req").Debug("loading", "id", 7)
+	func() {
+		defer func() { _ = recover() }()
+		log.Panic("boom")
+	}()
+
+	if !strings.Contains(dump.String(), `"msg":"loading","req":{"id":7}`) || !strings.Contains(dump.String(), `"msg":"boom"`) {
+		t.Errorf("Expected the records to be dumped on panic, got %s", dump.String())
+	}
+}
+
+func TestRingBufferHandler_Level(t *testing.T) {
+	h := NewRingBufferHandler(slog.NewTextHandler(&strings.Builder{}, &slog.HandlerOptions{Level: slog.LevelError}), RingBufferOptions{Level: "INFO"})
+	ctx := context.Background()
+	for _, tt := range []struct {
+		level slog.Level
+		want  bool
+	}{
+		{slog.LevelDebug, false},
+		{slog.LevelInfo, true},
+		{slog.LevelError, true},
+	} {
+		if got := h.Enabled(ctx, tt.level); got != tt.want {
+			t.Errorf("Enabled(%v) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultSamplingInitial is the default [SamplingOptions.Initial].
+	defaultSamplingInitial = 100
+	// defaultSamplingThereafter is the default [SamplingOptions.Thereafter].
+	defaultSamplingThereafter = 100
+	// defaultSamplingTick is the default [SamplingOptions.Tick].
+	defaultSamplingTick = time.Second
+)
+
+// SamplingOptions is the optional configuration for [NewSamplingHandler].
+type SamplingOptions struct {
+	// Initial is the number of records with the same level and message passed on per tick. Defaults to 100.
+	Initial int `json:"initial,omitempty" yaml:"initial,omitempty"`
+	// Thereafter is the interval of the records passed on after the initial ones within a tick,
+	// e.g. 100 passes every 100th record. Defaults to 100.
+	Thereafter int `json:"thereafter,omitempty" yaml:"thereafter,omitempty"`
+	// Tick is the interval the counts are reset. Defaults to 1 second.
+	Tick time.Duration `json:"tick,omitempty" yaml:"tick,omitempty"`
+	// Metrics counts the dropped records with [DropReasonSampler]. Nil disables the counting.
+	Metrics *PipelineMetrics `json:"-" yaml:"-"`
+}
+
+// newSamplingOptions returns the provided SamplingOptions merged with the default SamplingOptions.
+func newSamplingOptions(o ...SamplingOptions) SamplingOptions {
+	opts := SamplingOptions{
+		Initial:    defaultSamplingInitial,
+		Thereafter: defaultSamplingThereafter,
+		Tick:       defaultSamplingTick,
+	}
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided SamplingOptions with the receiver SamplingOptions.
+func (o *SamplingOptions) merge(d SamplingOptions) SamplingOptions {
+	if o.Initial > 0 {
+		d.Initial = o.Initial
+	}
+	if o.Thereafter > 0 {
+		d.Thereafter = o.Thereafter
+	}
+	if o.Tick > 0 {
+		d.Tick = o.Tick
+	}
+	if o.Metrics != nil {
+		d.Metrics = o.Metrics
+	}
+	return d
+}
+
+// samplingKey identifies the records counted together by a [sampler].
+type samplingKey struct {
+	level slog.Level
+	msg   string
+}
+
+// sampler counts the records of a [samplingHandler] and the handlers derived from it.
+type sampler struct {
+	opts   SamplingOptions
+	mu     sync.Mutex
+	counts map[samplingKey]int
+	// reset is the time the counts are reset.
+	reset time.Time
+}
+
+var _ slog.Handler = (*samplingHandler)(nil)
+
+// samplingHandler is a [slog.Handler] dropping repeated records.
+type samplingHandler struct {
+	slog.Handler
+	sampler *sampler
+}
+
+// NewSamplingHandler returns a new [slog.Handler] that limits the records with the same level and message
+// passed to the given handler, which caps the cost of logging in hot paths while keeping a representative sample.
+// Within every [SamplingOptions.Tick], the first [SamplingOptions.Initial] records are passed on
+// and then every [SamplingOptions.Thereafter]th record, the others are dropped.
+func NewSamplingHandler(h slog.Handler, o ...SamplingOptions) slog.Handler {
+	return &samplingHandler{Handler: h, sampler: &sampler{opts: newSamplingOptions(o...), counts: map[samplingKey]int{}}}
+}
+
+// Handle passes the record to the wrapped handler if it is sampled.
+func (h *samplingHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	if !h.sampler.sample(samplingKey{level: r.Level, msg: r.Message}) {
+		h.sampler.opts.Metrics.drop(DropReasonSampler)
+		return nil
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingHandler{Handler: h.Handler.WithAttrs(attrs), sampler: h.sampler}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *samplingHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &samplingHandler{Handler: h.Handler.WithGroup(name), sampler: h.sampler}
+}
+
+// sample counts the record and reports whether it is passed on.
+func (s *sampler) sample(key samplingKey) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now := time.Now(); !now.Before(s.reset) {
+		clear(s.counts)
+		s.reset = now.Add(s.opts.Tick)
+	}
+
+	n := s.counts[key] + 1
+	s.counts[key] = n
+	return n <= s.opts.Initial || (n-s.opts.Initial)%s.opts.Thereafter == 0
+}
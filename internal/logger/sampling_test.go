@@ -0,0 +1,38 @@
+package logger
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSamplingHandler(t *testing.T) {
+	var buf strings.Builder
+	h := NewSamplingHandler(slog.NewTextHandler(&buf, nil), SamplingOptions{Initial: 2, Thereafter: 3, Tick: 50 * time.Millisecond})
+	log := slog.New(h).With("service", "api")
+
+	for i := range 8 {
+		log.Info("hot", "i", i)
+		log.Warn("hot", "i", i)
+	}
+	log.Info("cold")
+
+	got := buf.String()
+	for _, want := range []string{"level=INFO msg=hot service=api i=0", "INFO msg=hot service=api i=1", "INFO msg=hot service=api i=4", "INFO msg=hot service=api i=7", "WARN msg=hot service=api i=7", "msg=cold"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %s, got %s", want, got)
+		}
+	}
+	if n := strings.Count(got, "\n"); n != 9 {
+		t.Errorf("Expected 9 records, got %d: %s", n, got)
+	}
+
+	// The counts are reset after the tick.
+	buf.Reset()
+	time.Sleep(60 * time.Millisecond)
+	log.Info("hot", "i", 8)
+	if !strings.Contains(buf.String(), "i=8") {
+		t.Errorf("Expected the counts to be reset, got %s", buf.String())
+	}
+}
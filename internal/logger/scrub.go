@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	// bearerPattern matches bearer tokens, e.g. in authorization headers.
+	bearerPattern = regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9._~+/=-]+`)
+	// jwtPattern matches JSON web tokens.
+	jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	// awsKeyPattern matches AWS access key IDs.
+	awsKeyPattern = regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`)
+	// emailPattern matches email addresses.
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	// cardPattern matches card numbers of 13 to 19 digits, optionally separated by spaces or dashes.
+	cardPattern = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+	// ipv4Pattern matches IPv4 addresses.
+	ipv4Pattern = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	// ibanPattern matches IBANs, optionally separated by spaces in groups of four.
+	ibanPattern = regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,3})?\b`)
+)
+
+var (
+	// redactionPatternsMu guards redactionPatterns.
+	redactionPatternsMu sync.RWMutex
+	// redactionPatterns is a map of the names of the patterns selectable by [RedactionOptions.Patterns]
+	// to the patterns, including the ones added by [RegisterRedactionPattern].
+	redactionPatterns = map[string]*regexp.Regexp{
+		"bearer":  bearerPattern,
+		"jwt":     jwtPattern,
+		"aws_key": awsKeyPattern,
+		"email":   emailPattern,
+		"card":    cardPattern,
+		"ipv4":    ipv4Pattern,
+		"iban":    ibanPattern,
+	}
+)
+
+// RegisterRedactionPattern registers the pattern under the name, so it can be selected by [RedactionOptions.Patterns],
+// [Options.RedactPatterns] or LOG_REDACT_PATTERNS, e.g. for internal customer or order IDs.
+// Names are case-insensitive. A pattern registered under the name of another pattern replaces it,
+// including the built-in patterns "bearer", "jwt", "aws_key", "email", "card", "ipv4" and "iban".
+// Handlers already created keep the patterns they were created with.
+//
+// Example:
+//
+//	logger.RegisterRedactionPattern("customer_id", regexp.MustCompile(`\bCUST-\d{8}\b`))
+func RegisterRedactionPattern(name string, pattern *regexp.Regexp) {
+	redactionPatternsMu.Lock()
+	defer redactionPatternsMu.Unlock()
+	redactionPatterns[strings.ToLower(name)] = pattern
+}
+
+// redactionPattern returns the pattern registered under the name.
+func redactionPattern(name string) (*regexp.Regexp, bool) {
+	redactionPatternsMu.RLock()
+	defer redactionPatternsMu.RUnlock()
+	p, ok := redactionPatterns[strings.ToLower(name)]
+	return p, ok
+}
+
+// withPatterns returns the patterns followed by the registered patterns of the names that are not yet included.
+// Unknown names are ignored.
+func withPatterns(patterns []*regexp.Regexp, names []string) []*regexp.Regexp {
+	all := slices.Clip(patterns)
+	for _, name := range names {
+		if p, ok := redactionPattern(name); ok && !slices.Contains(all, p) {
+			all = append(all, p)
+		}
+	}
+	return all
+}
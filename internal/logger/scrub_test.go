@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRedactionPatterns(t *testing.T) {
+	RegisterRedactionPattern("Ticket", regexp.MustCompile(`\bTCK-\d{4}\b`))
+	t.Cleanup(func() {
+		redactionPatternsMu.Lock()
+		delete(redactionPatterns, "ticket")
+		redactionPatternsMu.Unlock()
+	})
+
+	tests := []struct {
+		name     string
+		patterns []string
+		input    string
+		want     string
+	}{
+		{name: "card", patterns: []string{"card"}, input: "paid with 4111 1111 1111 1111", want: "paid with [REDACTED]"},
+		{name: "email", patterns: []string{"EMAIL"}, input: "sent to alice@example.com", want: "sent to [REDACTED]"},
+		{name: "bearer", patterns: []string{"bearer"}, input: "Authorization: Bearer abc.def", want: "Authorization: [REDACTED]"},
+		{name: "iban", patterns: []string{"iban"}, input: "IBAN DE89 3704 0044 0532 0130 00 and DE89370400440532013000", want: "IBAN [REDACTED] and [REDACTED]"},
+		{name: "registered", patterns: []string{"ticket"}, input: "see TCK-1234", want: "see [REDACTED]"},
+		{name: "unknown names are ignored", patterns: []string{"unknown"}, input: "alice@example.com", want: "alice@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			h := NewRedactionHandler(slog.NewJSONHandler(buf, nil), RedactOff, RedactionOptions{Patterns: tt.patterns})
+			slog.New(h).Info(tt.input, "value", tt.input, slog.Group("nested", "value", tt.input))
+
+			want := `"msg":"` + tt.want + `","value":"` + tt.want + `","nested":{"value":"` + tt.want + `"}}`
+			if got := buf.String(); !strings.Contains(got, want) {
+				t.Errorf("Expected %s, got %s", want, got)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_RedactPatterns(t *testing.T) {
+	cfg := Config{RedactPatterns: []string{"iban", "unknown"}}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), `unknown redaction pattern "unknown"`) {
+		t.Errorf("Expected an error for the unknown pattern, got %v", err)
+	}
+}
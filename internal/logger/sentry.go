@@ -0,0 +1,492 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultSentryMaxBreadcrumbs is the default [SentryOptions.MaxBreadcrumbs].
+	defaultSentryMaxBreadcrumbs = 30
+	// defaultSentryTimeout is the timeout of the default [SentryOptions.Client].
+	defaultSentryTimeout = 5 * time.Second
+)
+
+// ErrInvalidSentryDSN is returned by [NewSentryHandler] if the DSN is empty or malformed.
+var ErrInvalidSentryDSN = errors.New("invalid sentry dsn")
+
+// SentryOptions is the optional configuration for [NewSentryHandler].
+type SentryOptions struct {
+	// DSN is the data source name of the Sentry project. Defaults to the SENTRY_DSN environment variable.
+	DSN string
+	// Level is the minimum level of the records sent as events. Defaults to [LevelError].
+	Level Level
+	// BreadcrumbLevel is the minimum level of the records recorded as breadcrumbs. Defaults to [LevelInfo].
+	BreadcrumbLevel Level
+	// MaxBreadcrumbs is the maximum number of breadcrumbs kept per context. Defaults to 30.
+	MaxBreadcrumbs int
+	// Environment is the environment of the events. Defaults to the SENTRY_ENVIRONMENT environment variable.
+	Environment string
+	// Release is the release of the events. Defaults to the SENTRY_RELEASE environment variable.
+	Release string
+	// ServerName is the name of the host. Defaults to [os.Hostname].
+	ServerName string
+	// Client is the HTTP client used to send the events. Defaults to a client with a timeout of 5 seconds.
+	Client *http.Client
+	// OnError is called with the errors of sending events, which are not returned to the caller.
+	OnError func(err error)
+}
+
+// newSentryOptions returns the provided SentryOptions merged with the default SentryOptions.
+func newSentryOptions(o ...SentryOptions) SentryOptions {
+	hostname, _ := os.Hostname()
+	opts := SentryOptions{
+		DSN:             os.Getenv("SENTRY_DSN"),
+		Level:           LevelError,
+		BreadcrumbLevel: LevelInfo,
+		MaxBreadcrumbs:  defaultSentryMaxBreadcrumbs,
+		Environment:     os.Getenv("SENTRY_ENVIRONMENT"),
+		Release:         os.Getenv("SENTRY_RELEASE"),
+		ServerName:      hostname,
+		Client:          &http.Client{Timeout: defaultSentryTimeout},
+	}
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided SentryOptions with the receiver SentryOptions.
+func (o *SentryOptions) merge(d SentryOptions) SentryOptions {
+	if o.Level != 0 {
+		d.Level = o.Level
+	}
+	if o.BreadcrumbLevel != 0 {
+		d.BreadcrumbLevel = o.BreadcrumbLevel
+	}
+	if o.MaxBreadcrumbs > 0 {
+		d.MaxBreadcrumbs = o.MaxBreadcrumbs
+	}
+	for _, f := range []struct{ src, dst *string }{
+		{&o.DSN, &d.DSN},
+		{&o.Environment, &d.Environment},
+		{&o.Release, &d.Release},
+		{&o.ServerName, &d.ServerName},
+	} {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+	if o.Client != nil {
+		d.Client = o.Client
+	}
+	if o.OnError != nil {
+		d.OnError = o.OnError
+	}
+	return d
+}
+
+// sentryBreadcrumb is a breadcrumb of a Sentry event.
+type sentryBreadcrumb struct {
+	Timestamp time.Time      `json:"timestamp"`
+	Level     string         `json:"level"`
+	Category  string         `json:"category"`
+	Message   string         `json:"message"`
+	Data      map[string]any `json:"data,omitempty"`
+}
+
+// breadcrumbsKey is the key used to store the breadcrumb trail in the context.
+type breadcrumbsKey struct{}
+
+// breadcrumbTrail are the breadcrumbs recorded for a context, the oldest first.
+type breadcrumbTrail struct {
+	mu     sync.Mutex
+	crumbs []sentryBreadcrumb
+}
+
+// WithBreadcrumbs returns a copy of the context carrying a new breadcrumb trail.
+// The records below [SentryOptions.Level] logged with the returned context are recorded as breadcrumbs
+// by a handler returned by [NewSentryHandler] and sent with the next event of the same context,
+// e.g. to see what happened during a request before it failed.
+func WithBreadcrumbs(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, breadcrumbsKey{}, &breadcrumbTrail{})
+}
+
+// breadcrumbsFromContext returns the breadcrumb trail of the context or nil if it has none.
+func breadcrumbsFromContext(ctx context.Context) *breadcrumbTrail {
+	if ctx == nil {
+		return nil
+	}
+	trail, _ := ctx.Value(breadcrumbsKey{}).(*breadcrumbTrail)
+	return trail
+}
+
+// add records the breadcrumb, dropping the oldest ones beyond max.
+func (t *breadcrumbTrail) add(b sentryBreadcrumb, maxCrumbs int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.crumbs = append(t.crumbs, b)
+	if len(t.crumbs) > maxCrumbs {
+		t.crumbs = slices.Clone(t.crumbs[len(t.crumbs)-maxCrumbs:])
+	}
+}
+
+// list returns a copy of the recorded breadcrumbs.
+func (t *breadcrumbTrail) list() []sentryBreadcrumb {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return slices.Clone(t.crumbs)
+}
+
+// sentryClient sends events to the envelope endpoint of a Sentry project.
+type sentryClient struct {
+	opts     SentryOptions
+	dsn      string
+	endpoint string
+	key      string
+}
+
+// newSentryClient returns a new client for the DSN of the options,
+// which has the form "https://<key>@<host>[/<path>]/<project>".
+func newSentryClient(opts SentryOptions) (*sentryClient, error) {
+	u, err := url.Parse(opts.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSentryDSN, err)
+	}
+	project := path.Base(u.Path)
+	if u.User == nil || u.User.Username() == "" || u.Host == "" || project == "." || project == "/" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSentryDSN, opts.DSN)
+	}
+	endpoint := url.URL{Scheme: u.Scheme, Host: u.Host, Path: path.Join(path.Dir(u.Path), "api", project, "envelope") + "/"}
+	return &sentryClient{opts: opts, dsn: opts.DSN, endpoint: endpoint.String(), key: u.User.Username()}, nil
+}
+
+var _ slog.Handler = (*sentryHandler)(nil)
+
+// sentryHandler is a [slog.Handler] sending records as events to Sentry and recording breadcrumbs.
+type sentryHandler struct {
+	slog.Handler
+	client *sentryClient
+	scopes []scope
+}
+
+// NewSentryHandler returns a new [slog.Handler] that passes all records to the given handler
+// and sends the records at or above [SentryOptions.Level] as events to Sentry:
+//   - The message and the level are the message and the level of the event, [LevelPanic] and [LevelFatal] map to fatal.
+//   - The attributes are added as extra data, groups as nested objects.
+//   - The first error attribute, e.g. added by [Err], is added as exception with the stack trace captured by [WithStack]
+//     or, if there is none, the stack trace added by [NewStacktraceHandler].
+//   - The records below the level logged with the same context are added as breadcrumbs, see [WithBreadcrumbs].
+//
+// The events are sent synchronously, errors are passed to [SentryOptions.OnError].
+// It returns [ErrInvalidSentryDSN] if the DSN is empty or malformed.
+func NewSentryHandler(h slog.Handler, o ...SentryOptions) (slog.Handler, error) {
+	client, err := newSentryClient(newSentryOptions(o...))
+	if err != nil {
+		return nil, err
+	}
+	return &sentryHandler{Handler: h, client: client, scopes: []scope{{}}}, nil
+}
+
+// Enabled reports whether the wrapped handler handles records at the given level,
+// the level is sent as event or recorded as breadcrumb.
+func (h *sentryHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.Handler.Enabled(ctx, level) ||
+		Level(level) >= h.client.opts.Level ||
+		Level(level) >= h.client.opts.BreadcrumbLevel && breadcrumbsFromContext(ctx) != nil
+}
+
+// Handle passes the record to the wrapped handler and sends it as event or records it as breadcrumb.
+func (h *sentryHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	var err error
+	if h.Handler.Enabled(ctx, r.Level) {
+		err = h.Handler.Handle(ctx, r.Clone())
+	}
+
+	level := Level(r.Level)
+	trail := breadcrumbsFromContext(ctx)
+	switch {
+	case level >= h.client.opts.Level:
+		if serr := h.client.send(h.event(r, trail)); serr != nil && h.client.opts.OnError != nil {
+			h.client.opts.OnError(serr)
+		}
+	case level >= h.client.opts.BreadcrumbLevel && trail != nil:
+		trail.add(sentryBreadcrumb{
+			Timestamp: r.Time,
+			Level:     sentryLevel(level),
+			Category:  "log",
+			Message:   r.Message,
+			Data:      attrsToMap(h.attrs(r)),
+		}, h.client.opts.MaxBreadcrumbs)
+	}
+	return err
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *sentryHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &sentryHandler{Handler: h.Handler.WithAttrs(attrs), client: h.client, scopes: withScopeAttrs(h.scopes, attrs)}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *sentryHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &sentryHandler{Handler: h.Handler.WithGroup(name), client: h.client, scopes: withScope(h.scopes, name)}
+}
+
+// attrs returns the attributes of the handler followed by the attributes of the record nested in the handler's groups.
+func (h *sentryHandler) attrs(r slog.Record) []slog.Attr { //nolint:gocritic // records are passed by value
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	return nestScopes(h.scopes, attrs)
+}
+
+// sentryEvent is an event as expected by Sentry.
+// See https://develop.sentry.dev/sdk/data-model/event-payloads/.
+type sentryEvent struct {
+	EventID     string         `json:"event_id"`
+	Timestamp   time.Time      `json:"timestamp"`
+	Platform    string         `json:"platform"`
+	Level       string         `json:"level"`
+	Logger      string         `json:"logger,omitempty"`
+	Message     sentryMessage  `json:"message"`
+	ServerName  string         `json:"server_name,omitempty"`
+	Environment string         `json:"environment,omitempty"`
+	Release     string         `json:"release,omitempty"`
+	Extra       map[string]any `json:"extra,omitempty"`
+	Exception   *struct {
+		Values []sentryException `json:"values"`
+	} `json:"exception,omitempty"`
+	Breadcrumbs *struct {
+		Values []sentryBreadcrumb `json:"values"`
+	} `json:"breadcrumbs,omitempty"`
+}
+
+// sentryMessage is the message of a Sentry event.
+type sentryMessage struct {
+	Formatted string `json:"formatted"`
+}
+
+// sentryException is an exception of a Sentry event.
+type sentryException struct {
+	Type       string            `json:"type"`
+	Value      string            `json:"value"`
+	Stacktrace *sentryStacktrace `json:"stacktrace,omitempty"`
+}
+
+// sentryStacktrace is a stack trace of a Sentry event, the outermost frame first.
+type sentryStacktrace struct {
+	Frames []sentryFrame `json:"frames"`
+}
+
+// sentryFrame is a frame of a Sentry stack trace.
+type sentryFrame struct {
+	Function string `json:"function"`
+	AbsPath  string `json:"abs_path"`
+	Lineno   int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
+
+// event returns the Sentry event of the record with the breadcrumbs of the trail.
+func (h *sentryHandler) event(r slog.Record, trail *breadcrumbTrail) sentryEvent { //nolint:gocritic // records are passed by value
+	ev := sentryEvent{
+		EventID:     fmt.Sprintf("%016x%016x", rand.Uint64(), rand.Uint64()), //nolint:gosec // event IDs only need to be unique
+		Timestamp:   r.Time,
+		Platform:    "go",
+		Level:       sentryLevel(Level(r.Level)),
+		Message:     sentryMessage{Formatted: r.Message},
+		ServerName:  h.client.opts.ServerName,
+		Environment: h.client.opts.Environment,
+		Release:     h.client.opts.Release,
+	}
+	if ev.Timestamp.IsZero() {
+		ev.Timestamp = time.Now()
+	}
+
+	var exception *sentryException
+	var stack string
+	extra := extractSentryAttrs(h.attrs(r), func(a slog.Attr) bool {
+		switch {
+		case a.Key == StacktraceKey:
+			stack = a.Value.String()
+		case exception == nil && isErrorAttr(a):
+			exception = sentryExceptionOf(a)
+		default:
+			return false
+		}
+		return true
+	})
+	for _, a := range extra {
+		if a.Key == NameKey {
+			ev.Logger = a.Value.String()
+		}
+	}
+	ev.Extra = attrsToMap(extra)
+
+	if exception != nil {
+		if exception.Stacktrace == nil && stack != "" {
+			exception.Stacktrace = parseSentryStack(stack)
+		}
+		ev.Exception = &struct {
+			Values []sentryException `json:"values"`
+		}{Values: []sentryException{*exception}}
+	}
+	if trail != nil {
+		if crumbs := trail.list(); len(crumbs) > 0 {
+			ev.Breadcrumbs = &struct {
+				Values []sentryBreadcrumb `json:"values"`
+			}{Values: crumbs}
+		}
+	}
+	return ev
+}
+
+// extractSentryAttrs returns the attributes without the ones extract reports as extracted,
+// which are searched in the groups as well. The groups with an empty key, e.g. the attribute of [Err], are inlined.
+func extractSentryAttrs(attrs []slog.Attr, extract func(a slog.Attr) bool) []slog.Attr {
+	out := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		switch {
+		case a.Key == "" && a.Value.Kind() == slog.KindGroup:
+			out = append(out, extractSentryAttrs(a.Value.Group(), extract)...)
+		case extract(a):
+		case a.Value.Kind() == slog.KindGroup:
+			if group := extractSentryAttrs(a.Value.Group(), extract); len(group) > 0 {
+				out = append(out, slog.Attr{Key: a.Key, Value: slog.GroupValue(group...)})
+			}
+		default:
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// isErrorAttr reports whether the attribute is an error or the error group added by [Err].
+func isErrorAttr(a slog.Attr) bool {
+	if a.Key == ErrorKey && a.Value.Kind() == slog.KindGroup {
+		return true
+	}
+	_, ok := a.Value.Any().(error)
+	return ok && a.Value.Kind() == slog.KindAny
+}
+
+// sentryExceptionOf returns the exception of the error attribute.
+func sentryExceptionOf(a slog.Attr) *sentryException {
+	v := a.Value
+	if err, ok := v.Any().(error); ok && v.Kind() == slog.KindAny {
+		v = errorValue(err)
+	}
+
+	exception := &sentryException{Type: "error"}
+	for _, ga := range v.Group() {
+		switch ga.Key {
+		case ErrorMessageKey:
+			exception.Value = ga.Value.String()
+		case ErrorTypeKey:
+			exception.Type = ga.Value.String()
+		case StacktraceKey:
+			exception.Stacktrace = parseSentryStack(ga.Value.String())
+		}
+	}
+	return exception
+}
+
+// parseSentryStack parses a stack trace formatted by formatStack, the innermost frame first,
+// into a Sentry stack trace, the outermost frame first. Returns nil if the stack trace has no frames.
+func parseSentryStack(stack string) *sentryStacktrace {
+	lines := strings.Split(strings.TrimSpace(stack), "\n")
+	var frames []sentryFrame
+	for i := 0; i+1 < len(lines); i += 2 {
+		// The file is split at the last colon, since it may contain colons itself, e.g. on Windows.
+		loc := strings.TrimSpace(lines[i+1])
+		file, line := loc, ""
+		if j := strings.LastIndexByte(loc, ':'); j >= 0 {
+			file, line = loc[:j], loc[j+1:]
+		}
+		lineno, _ := strconv.Atoi(line)
+		frames = append(frames, sentryFrame{
+			Function: lines[i],
+			AbsPath:  file,
+			Lineno:   lineno,
+			InApp:    !strings.HasPrefix(file, runtime.GOROOT()),
+		})
+	}
+	if len(frames) == 0 {
+		return nil
+	}
+	slices.Reverse(frames)
+	return &sentryStacktrace{Frames: frames}
+}
+
+// sentryLevel returns the Sentry level of the level.
+func sentryLevel(level Level) string {
+	switch {
+	case level >= LevelPanic:
+		return "fatal"
+	case level >= LevelError:
+		return "error"
+	case level >= LevelWarn:
+		return "warning"
+	case level >= LevelInfo:
+		return "info"
+	default:
+		return "debug"
+	}
+}
+
+// send sends the event as envelope to Sentry.
+// See https://develop.sentry.dev/sdk/data-model/envelopes/.
+func (c *sentryClient) send(ev sentryEvent) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("failed to encode sentry event: %w", err)
+	}
+	header, _ := json.Marshal(map[string]string{"event_id": ev.EventID, "dsn": c.dsn, "sent_at": time.Now().UTC().Format(time.RFC3339)})
+	item, _ := json.Marshal(map[string]any{"type": "event", "length": len(payload)})
+
+	var body bytes.Buffer
+	for _, part := range [][]byte{header, item, payload} {
+		body.Write(part)
+		body.WriteByte('\n')
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, c.endpoint, &body)
+	if err != nil {
+		return fmt.Errorf("failed to create sentry request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", "Sentry sentry_version=7, sentry_client=loggerhead, sentry_key="+c.key)
+	resp, err := c.opts.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send sentry event: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 { //nolint:mnd // 2xx status codes
+		return fmt.Errorf("failed to send sentry event: %s", resp.Status)
+	}
+	return nil
+}
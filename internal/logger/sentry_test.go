@@ -0,0 +1,176 @@
+package logger
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sentryServer returns a fake Sentry server sending the decoded events to the returned channel.
+func sentryServer(t *testing.T, status int) (dsn string, events <-chan map[string]any) {
+	t.Helper()
+	ch := make(chan map[string]any, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/42/envelope/" || !strings.Contains(r.Header.Get("X-Sentry-Auth"), "sentry_key=public") {
+			t.Errorf("Unexpected request %s with auth %q", r.URL.Path, r.Header.Get("X-Sentry-Auth"))
+		}
+		s := bufio.NewScanner(r.Body)
+		s.Buffer(nil, 1<<20)
+		var lines []string
+		for s.Scan() {
+			lines = append(lines, s.Text())
+		}
+		if len(lines) != 3 || !strings.Contains(lines[1], `"type":"event"`) {
+			t.Errorf("Unexpected envelope %v", lines)
+			return
+		}
+		var ev map[string]any
+		if err := json.Unmarshal([]byte(lines[2]), &ev); err != nil {
+			t.Errorf("Failed to decode event: %v", err)
+		}
+		ch <- ev
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return strings.Replace(srv.URL, "http://", "http://public@", 1) + "/42", ch
+}
+
+func TestNewSentryHandler_InvalidDSN(t *testing.T) {
+	for _, dsn := range []string{"", "https://sentry.io/42", "https://key@sentry.io", "://"} {
+		t.Run(dsn, func(t *testing.T) {
+			t.Setenv("SENTRY_DSN", "")
+			if _, err := NewSentryHandler(slog.Default().Handler(), SentryOptions{DSN: dsn}); !errors.Is(err, ErrInvalidSentryDSN) {
+				t.Errorf("Expected ErrInvalidSentryDSN, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSentryHandler(t *testing.T) {
+	dsn, events := sentryServer(t, http.StatusOK)
+	var buf strings.Builder
+	h, err := NewSentryHandler(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}), SentryOptions{
+		DSN:         dsn,
+		Environment: "test",
+		OnError:     func(err error) { t.Errorf("Unexpected error: %v", err) },
+	})
+	if err != nil {
+		t.Fatalf("NewSentryHandler() error = %v", err)
+	}
+	log := NewLogger(Options{Handler: h}).With("service", "api").WithGroup("req")
+
+	ctx := WithBreadcrumbs(context.Background())
+	log.DebugContext(ctx, "ignored")
+	log.InfoContext(ctx, "loading order", "id", 7)
+	log.WarnContext(ctx, "slow query")
+	log.ErrorContext(ctx, "failed to load order", Err(WithStack(errors.New("not found"))), "id", 7)
+
+	ev := <-events
+	if ev["level"] != "error" || ev["message"].(map[string]any)["formatted"] != "failed to load order" || ev["environment"] != "test" {
+		t.Errorf("Unexpected event %v", ev)
+	}
+	if got, want := fmt.Sprint(ev["extra"]), "map[req:map[id:7] service:api]"; got != want {
+		t.Errorf("Expected extra %s, got %s", want, got)
+	}
+
+	exception := ev["exception"].(map[string]any)["values"].([]any)[0].(map[string]any)
+	if exception["value"] != "not found" || exception["type"] != "*errors.errorString" {
+		t.Errorf("Unexpected exception %v", exception)
+	}
+	frames := exception["stacktrace"].(map[string]any)["frames"].([]any)
+	if last := frames[len(frames)-1].(map[string]any); !strings.HasSuffix(last["function"].(string), "TestSentryHandler") || last["in_app"] != true {
+		t.Errorf("Expected the innermost frame to be the test, got %v", last)
+	}
+
+	crumbs := ev["breadcrumbs"].(map[string]any)["values"].([]any)
+	if len(crumbs) != 2 {
+		t.Fatalf("Expected 2 breadcrumbs, got %v", crumbs)
+	}
+	if first := crumbs[0].(map[string]any); first["message"] != "loading order" || first["level"] != "info" ||
+		fmt.Sprint(first["data"]) != "map[req:map[id:7] service:api]" {
+		t.Errorf("Unexpected breadcrumb %v", first)
+	}
+
+	// The wrapped handler only receives the records it is enabled for.
+	if strings.Contains(buf.String(), "loading order") || !strings.Contains(buf.String(), "failed to load order") {
+		t.Errorf("Unexpected output of the wrapped handler: %s", buf.String())
+	}
+}
+
+func TestSentryHandler_Breadcrumbs(t *testing.T) {
+	dsn, events := sentryServer(t, http.StatusOK)
+	h, err := NewSentryHandler(slog.NewTextHandler(&strings.Builder{}, nil), SentryOptions{DSN: dsn, MaxBreadcrumbs: 2})
+	if err != nil {
+		t.Fatalf("NewSentryHandler() error = %v", err)
+	}
+	log := NewLogger(Options{Handler: h})
+
+	ctx := WithBreadcrumbs(context.Background())
+	for i := range 3 {
+		log.InfoContext(ctx, fmt.Sprint("step ", i))
+	}
+	log.ErrorContext(ctx, "failed")
+	crumbs := (<-events)["breadcrumbs"].(map[string]any)["values"].([]any)
+	if len(crumbs) != 2 || crumbs[0].(map[string]any)["message"] != "step 1" {
+		t.Errorf("Expected the 2 most recent breadcrumbs, got %v", crumbs)
+	}
+
+	// Records without a breadcrumb trail are not recorded.
+	log.Info("step")
+	log.Error("failed")
+	if ev := <-events; ev["breadcrumbs"] != nil {
+		t.Errorf("Expected no breadcrumbs, got %v", ev["breadcrumbs"])
+	}
+}
+
+func TestSentryHandler_SendError(t *testing.T) {
+	dsn, events := sentryServer(t, http.StatusTooManyRequests)
+	var reported error
+	h, err := NewSentryHandler(slog.NewTextHandler(&strings.Builder{}, nil), SentryOptions{
+		DSN:     dsn,
+		Level:   LevelFatal,
+		OnError: func(err error) { reported = err },
+	})
+	if err != nil {
+		t.Fatalf("NewSentryHandler() error = %v", err)
+	}
+
+	if err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.Level(LevelFatal), "fatal", 0)); err != nil {
+		t.Errorf("Expected the error to be reported only, got %v", err)
+	}
+	if ev := <-events; ev["level"] != "fatal" {
+		t.Errorf("Expected level fatal, got %v", ev["level"])
+	}
+	if reported == nil || !strings.Contains(reported.Error(), "429") {
+		t.Errorf("Expected the status to be reported, got %v", reported)
+	}
+}
+
+func TestSentryLevel(t *testing.T) {
+	tests := []struct {
+		level Level
+		want  string
+	}{
+		{LevelDebug, "debug"},
+		{LevelInfo, "info"},
+		{LevelNotice, "info"},
+		{LevelWarn, "warning"},
+		{LevelError, "error"},
+		{LevelPanic, "fatal"},
+		{LevelFatal, "fatal"},
+	}
+
+	for _, tt := range tests {
+		if got := sentryLevel(tt.level); got != tt.want {
+			t.Errorf("sentryLevel(%v) = %q, want %q", tt.level, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package logger
+
+import (
+	"context"
+	"os"
+	"os/signal"
+)
+
+// HandleSignals lets operators control the logger with signals until the context is done:
+//   - SIGHUP reopens the log files of all [FileSink]s, see [ReopenFiles], so logrotate can move them away.
+//   - SIGUSR1 enables the debug records of the logger, see [Provider.SetLevel].
+//   - SIGUSR2 restores the configured levels of the logger, see [Provider.ResetLevel].
+//
+// The actions are logged by the logger. The signals are only handled on Unix-like systems.
+// Once the context is done, they get their default behavior back.
+//
+// Example:
+//
+//	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+//	defer cancel()
+//	logger.HandleSignals(ctx, log)
+func HandleSignals(ctx context.Context, log Provider) {
+	if len(controlSignals) == 0 {
+		return
+	}
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, controlSignals...)
+	go func() {
+		defer signal.Stop(c)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case sig := <-c:
+				handleSignal(ctx, log, sig)
+			}
+		}
+	}()
+}
+
+// handleSignal performs the action of the signal.
+func handleSignal(ctx context.Context, log Provider, sig os.Signal) {
+	switch sig {
+	case reopenSignal:
+		if err := ReopenFiles(); err != nil {
+			log.ErrorContext(ctx, "Failed to reopen the log files", "signal", sig.String(), "error", err)
+			return
+		}
+		log.InfoContext(ctx, "Reopened the log files", "signal", sig.String())
+	case debugSignal:
+		log.SetLevel(LevelDebug)
+		log.NoticeContext(ctx, "Enabled the debug level", "signal", sig.String())
+	case resetSignal:
+		log.ResetLevel()
+		log.NoticeContext(ctx, "Restored the configured level", "signal", sig.String())
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package logger
+
+import "os"
+
+// The signals handled by [HandleSignals], which are only available on Unix-like systems.
+var (
+	reopenSignal os.Signal
+	debugSignal  os.Signal
+	resetSignal  os.Signal
+)
+
+// controlSignals are the signals handled by [HandleSignals].
+var controlSignals []os.Signal
@@ -0,0 +1,18 @@
+//go:build unix
+
+package logger
+
+import (
+	"os"
+	"syscall"
+)
+
+// The signals handled by [HandleSignals].
+var (
+	reopenSignal os.Signal = syscall.SIGHUP
+	debugSignal  os.Signal = syscall.SIGUSR1
+	resetSignal  os.Signal = syscall.SIGUSR2
+)
+
+// controlSignals are the signals handled by [HandleSignals].
+var controlSignals = []os.Signal{reopenSignal, debugSignal, resetSignal}
@@ -0,0 +1,65 @@
+//go:build unix
+
+package logger
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestHandleSignals(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	path := filepath.Join(t.TempDir(), "app.log")
+	log, err := NewFromConfig(Config{Output: path})
+	if err != nil {
+		t.Fatalf("NewFromConfig() error = %v", err)
+	}
+	HandleSignals(ctx, log)
+
+	signalAndWait := func(sig syscall.Signal, done func() bool) {
+		t.Helper()
+		if err := syscall.Kill(os.Getpid(), sig); err != nil {
+			t.Fatal(err)
+		}
+		for range 100 {
+			if done() {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("Signal %s was not handled", sig)
+	}
+
+	signalAndWait(syscall.SIGUSR1, func() bool { return log.Enabled(ctx, LevelDebug) })
+	log.Debug("debug")
+	signalAndWait(syscall.SIGUSR2, func() bool { return !log.Enabled(ctx, LevelDebug) })
+	log.Debug("hidden")
+
+	if err = os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	signalAndWait(syscall.SIGHUP, func() bool {
+		_, err := os.Stat(path)
+		return err == nil
+	})
+	log.Info("reopened")
+
+	rotated, _ := os.ReadFile(path + ".1")
+	for _, want := range []string{"Enabled the debug level", `"msg":"debug"`, "Restored the configured level"} {
+		if !strings.Contains(string(rotated), want) {
+			t.Errorf("Expected %q in the rotated file, got %s", want, rotated)
+		}
+	}
+	if strings.Contains(string(rotated), "hidden") {
+		t.Errorf("Expected no debug record after SIGUSR2, got %s", rotated)
+	}
+	if current, _ := os.ReadFile(path); !strings.Contains(string(current), `"msg":"reopened"`) {
+		t.Errorf("Expected the record in the reopened file, got %s", current)
+	}
+}
@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// silencedMu guards silenced.
+	silencedMu sync.RWMutex
+	// silenced is a map of silenced logger names to the number of active silencing windows.
+	silenced = map[string]int{}
+	// silencedCount is the number of silenced names, used to skip the lookup if nothing is silenced.
+	silencedCount atomic.Int64
+)
+
+// Silence mutes the named loggers with the given names until the returned restore function is called
+// or the context is done, whichever happens first.
+// This is useful to temporarily mute a noisy dependency, e.g. during a bulk import.
+//
+// The start and the end of the silencing window are logged with the logger found in the context.
+// Silencing windows may overlap, a name stays muted until all windows including it are restored.
+// The restore function is safe to call multiple times.
+func Silence(ctx context.Context, names ...string) (restore func()) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	log := FromContext(ctx)
+	log.NoticeContext(ctx, "Silencing loggers", "loggers", names)
+
+	silencedMu.Lock()
+	for _, name := range names {
+		if silenced[name] == 0 {
+			silencedCount.Add(1)
+		}
+		silenced[name]++
+	}
+	silencedMu.Unlock()
+
+	start := time.Now()
+	var once sync.Once
+	unsilence := func() {
+		once.Do(func() {
+			silencedMu.Lock()
+			for _, name := range names {
+				silenced[name]--
+				if silenced[name] == 0 {
+					delete(silenced, name)
+					silencedCount.Add(-1)
+				}
+			}
+			silencedMu.Unlock()
+			log.NoticeContext(context.WithoutCancel(ctx), "Restored silenced loggers", "loggers", names, "duration", time.Since(start))
+		})
+	}
+
+	stop := context.AfterFunc(ctx, unsilence)
+	return func() {
+		stop()
+		unsilence()
+	}
+}
+
+// isSilenced reports whether the logger is muted by [Silence].
+func (l *logger) isSilenced() bool {
+	if l.name == "" || silencedCount.Load() == 0 {
+		return false
+	}
+
+	silencedMu.RLock()
+	defer silencedMu.RUnlock()
+	return silenced[l.name] > 0
+}
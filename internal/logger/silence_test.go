@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+)
+
+func TestSilence(t *testing.T) {
+	tests := []struct {
+		name    string
+		logger  string
+		silence []string
+		want    bool
+	}{
+		{
+			name:    "silenced logger",
+			logger:  "noisy",
+			silence: []string{"noisy"},
+			want:    false,
+		},
+		{
+			name:    "other logger",
+			logger:  "quiet",
+			silence: []string{"noisy"},
+			want:    true,
+		},
+		{
+			name:    "unnamed logger",
+			logger:  "",
+			silence: []string{"noisy"},
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var handled atomic.Int64
+			h := test.MockHandler{
+				HandleFunc: func(_ context.Context, _ slog.Record) error {
+					handled.Add(1)
+					return nil
+				},
+			}
+			l := NewLogger(Options{Handler: h})
+			if tt.logger != "" {
+				l = NewNamedLogger(tt.logger, Options{Handler: h})
+			}
+			ctx := IntoContext(context.Background(), NewLogger(Options{Handler: test.MockHandler{}}))
+
+			restore := Silence(ctx, tt.silence...)
+			l.With("key", "value").Info("test")
+			l.Log(ctx, LevelError, "test")
+			if got := handled.Load() == 2; got != tt.want {
+				t.Errorf("Expected records to be handled: %v, got %d records", tt.want, handled.Load())
+			}
+
+			restore()
+			restore()
+			handled.Store(0)
+			l.Info("test")
+			if handled.Load() != 1 {
+				t.Errorf("Expected logger to be restored")
+			}
+		})
+	}
+}
+
+func TestSilence_ContextDone(t *testing.T) {
+	l := NewNamedLogger("noisy", Options{Handler: test.MockHandler{}})
+	ctx, cancel := context.WithCancel(IntoContext(context.Background(), NewLogger(Options{Handler: test.MockHandler{}})))
+
+	restored := make(chan struct{})
+	ctx = IntoContext(ctx, NewLogger(Options{Handler: test.MockHandler{
+		HandleFunc: func(_ context.Context, r slog.Record) error {
+			if r.Message == "Restored silenced loggers" {
+				close(restored)
+			}
+			return nil
+		},
+	}}))
+
+	_ = Silence(ctx, "noisy")
+	if l.Enabled(context.Background(), LevelInfo) {
+		t.Fatalf("Expected logger to be silenced")
+	}
+
+	cancel()
+	<-restored
+	if !l.Enabled(context.Background(), LevelInfo) {
+		t.Errorf("Expected logger to be restored after the context is done")
+	}
+}
+
+func TestSilence_Overlapping(t *testing.T) {
+	l := NewNamedLogger("noisy", Options{Handler: test.MockHandler{}})
+	ctx := IntoContext(context.Background(), NewLogger(Options{Handler: test.MockHandler{}}))
+
+	first := Silence(ctx, "noisy")
+	second := Silence(ctx, "noisy")
+	first()
+	if l.Enabled(context.Background(), LevelInfo) {
+		t.Errorf("Expected logger to stay silenced while a window is active")
+	}
+	second()
+	if !l.Enabled(context.Background(), LevelInfo) {
+		t.Errorf("Expected logger to be restored after all windows ended")
+	}
+}
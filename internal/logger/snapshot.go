@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// ErrInvalidInterval is returned by periodic tasks like [SnapshotEvery] if the interval is not positive.
+var ErrInvalidInterval = errors.New("interval must be positive")
+
+const (
+	// SnapshotKey is the key used for the name of a metrics snapshot.
+	SnapshotKey = "snapshot"
+	// MetricsKey is the key used for the group of metrics of a snapshot.
+	MetricsKey = "metrics"
+)
+
+// MetricsProvider provides the current values of application metrics, e.g. queue depths or cache hit rates.
+type MetricsProvider interface {
+	// Metrics returns the current values of the metrics.
+	Metrics(ctx context.Context) []slog.Attr
+}
+
+// MetricsProviderFunc is an adapter to allow the use of ordinary functions as [MetricsProvider].
+type MetricsProviderFunc func(ctx context.Context) []slog.Attr
+
+// Metrics calls f(ctx).
+func (f MetricsProviderFunc) Metrics(ctx context.Context) []slog.Attr {
+	return f(ctx)
+}
+
+// Snapshot logs a structured snapshot of the metrics provided by p with the logger found in the context.
+// The record is logged at [LevelInfo] with the name under [SnapshotKey] and the metrics grouped under [MetricsKey].
+// This is useful for environments without a metrics backend.
+func Snapshot(ctx context.Context, name string, p MetricsProvider) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	log := FromContext(ctx)
+	if !log.Enabled(ctx, LevelInfo) {
+		return
+	}
+	log.LogAttrs(ctx, LevelInfo, "Metrics snapshot",
+		slog.String(SnapshotKey, name),
+		slog.Group(MetricsKey, attrsToArgs(p.Metrics(ctx))...),
+	)
+}
+
+// SnapshotEvery logs a snapshot of the metrics provided by p every interval until the context is done.
+// It blocks until the context is done, so it should be run in a separate goroutine.
+// Returns [ErrInvalidInterval] if the interval is not positive.
+func SnapshotEvery(ctx context.Context, name string, p MetricsProvider, interval time.Duration) error {
+	if interval <= 0 {
+		return ErrInvalidInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			Snapshot(ctx, name, p)
+		}
+	}
+}
+
+// attrsToArgs converts the attributes to arguments for functions like [slog.Group].
+func attrsToArgs(attrs []slog.Attr) []any {
+	args := make([]any, len(attrs))
+	for i, a := range attrs {
+		args[i] = a
+	}
+	return args
+}
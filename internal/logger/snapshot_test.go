@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+)
+
+func TestSnapshot(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		metrics []slog.Attr
+	}{
+		{
+			name:    "logs metrics",
+			enabled: true,
+			metrics: []slog.Attr{slog.Int("queue_depth", 42), slog.Float64("cache_hit_rate", 0.9)},
+		},
+		{
+			name:    "level disabled",
+			enabled: false,
+			metrics: []slog.Attr{slog.Int("queue_depth", 42)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []slog.Record
+			var calls int
+			log := NewLogger(Options{Handler: test.MockHandler{
+				EnabledFunc: func(context.Context, slog.Level) bool { return tt.enabled },
+				HandleFunc: func(_ context.Context, r slog.Record) error {
+					got = append(got, r)
+					return nil
+				},
+			}})
+			p := MetricsProviderFunc(func(context.Context) []slog.Attr {
+				calls++
+				return tt.metrics
+			})
+
+			Snapshot(IntoContext(context.Background(), log), "app", p)
+			if !tt.enabled {
+				if len(got) != 0 || calls != 0 {
+					t.Errorf("Expected no record and no metrics collection, got %d records and %d calls", len(got), calls)
+				}
+				return
+			}
+
+			if len(got) != 1 {
+				t.Fatalf("Expected 1 record, got %d", len(got))
+			}
+			attrs := map[string]slog.Value{}
+			got[0].Attrs(func(a slog.Attr) bool {
+				attrs[a.Key] = a.Value
+				return true
+			})
+			if attrs[SnapshotKey].String() != "app" {
+				t.Errorf("Expected snapshot name %q, got %v", "app", attrs[SnapshotKey])
+			}
+			if len(attrs[MetricsKey].Group()) != len(tt.metrics) {
+				t.Errorf("Expected metrics %v, got %v", tt.metrics, attrs[MetricsKey])
+			}
+		})
+	}
+}
+
+func TestSnapshotEvery(t *testing.T) {
+	var count atomic.Int64
+	log := NewLogger(Options{Handler: test.MockHandler{
+		HandleFunc: func(context.Context, slog.Record) error {
+			count.Add(1)
+			return nil
+		},
+	}})
+	ctx, cancel := context.WithCancel(IntoContext(context.Background(), log))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := SnapshotEvery(ctx, "app", MetricsProviderFunc(func(context.Context) []slog.Attr { return nil }), time.Millisecond); err != nil {
+			t.Errorf("SnapshotEvery() error = %v", err)
+		}
+	}()
+
+	for count.Load() < 3 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+}
+
+func TestSnapshotEvery_InvalidInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if err := SnapshotEvery(context.Background(), "app", MetricsProviderFunc(nil), interval); !errors.Is(err, ErrInvalidInterval) {
+			t.Errorf("Expected %v for interval %v, got %v", ErrInvalidInterval, interval, err)
+		}
+	}
+}
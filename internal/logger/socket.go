@@ -0,0 +1,226 @@
+package logger
+
+import (
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultSocketBufferSize is the default [SocketOptions.BufferSize].
+	defaultSocketBufferSize = 1024
+	// defaultSocketReconnectWait is the default [SocketOptions.ReconnectWait].
+	defaultSocketReconnectWait = 2 * time.Second
+	// defaultSocketTimeout is the default [SocketOptions.Timeout].
+	defaultSocketTimeout = 5 * time.Second
+)
+
+// ErrSocketDropped is reported to [SocketOptions.OnError] if records were dropped because the buffer was full.
+var ErrSocketDropped = errors.New("socket buffer full, records dropped")
+
+// SocketOptions is the configuration for [NewSocketHandler].
+type SocketOptions struct {
+	// Address is the target of the records with its network as scheme,
+	// e.g. "tcp://logstash:5000", "udp://rsyslog:514" or "unix:///run/app/log.sock".
+	// The networks tcp, tcp4, tcp6, udp, udp4, udp6, unix and unixgram are supported.
+	Address string
+	// Level is the minimum log level.
+	Level Level
+	// TLSConfig is the TLS configuration for tcp targets. If nil, the connection is not encrypted.
+	TLSConfig *tls.Config
+	// Framing is the framing of the records, either "NEWLINE" for newline-delimited JSON
+	// or "LENGTH" for JSON prefixed with its length as 4-byte big-endian integer. Defaults to "NEWLINE".
+	Framing string
+	// BufferSize is the number of records buffered while the target is not reachable.
+	// If the buffer is full, the oldest records are dropped. Defaults to 1024.
+	BufferSize int
+	// ReconnectWait is the wait between attempts to reconnect to the target. Defaults to 2 seconds.
+	ReconnectWait time.Duration
+	// Timeout is the timeout of connecting, of writing a record and of flushing the buffer on Close. Defaults to 5 seconds.
+	Timeout time.Duration
+	// OnError is called with the errors of the connection, which cannot be returned to the caller.
+	OnError func(err error)
+}
+
+// newSocketOptions returns the provided SocketOptions merged with the default SocketOptions.
+func newSocketOptions(o SocketOptions) SocketOptions {
+	return o.merge(SocketOptions{
+		Level:         LevelInfo,
+		Framing:       "NEWLINE",
+		BufferSize:    defaultSocketBufferSize,
+		ReconnectWait: defaultSocketReconnectWait,
+		Timeout:       defaultSocketTimeout,
+	})
+}
+
+// merge merges the provided SocketOptions with the receiver SocketOptions.
+func (o *SocketOptions) merge(d SocketOptions) SocketOptions {
+	if o.Level != 0 {
+		d.Level = o.Level
+	}
+	for _, f := range []struct{ src, dst *string }{
+		{&o.Address, &d.Address},
+		{&o.Framing, &d.Framing},
+	} {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+	if o.TLSConfig != nil {
+		d.TLSConfig = o.TLSConfig
+	}
+	if o.BufferSize > 0 {
+		d.BufferSize = o.BufferSize
+	}
+	if o.ReconnectWait > 0 {
+		d.ReconnectWait = o.ReconnectWait
+	}
+	if o.Timeout > 0 {
+		d.Timeout = o.Timeout
+	}
+	if o.OnError != nil {
+		d.OnError = o.OnError
+	}
+	return d
+}
+
+var _ slog.Handler = (*SocketHandler)(nil)
+
+// SocketHandler is a [slog.Handler] writing records to a TCP, UDP or Unix socket.
+// It must be closed to write the buffered records.
+type SocketHandler struct {
+	slog.Handler
+	outbox *outbox
+}
+
+// NewSocketHandler returns a new [SocketHandler] writing records as JSON to [SocketOptions.Address],
+// e.g. for the ingestion by Logstash or rsyslog. The records are written by a background worker,
+// which reconnects if the connection is lost. While the target is not reachable,
+// up to [SocketOptions.BufferSize] records are buffered, dropping the oldest ones.
+//
+// It returns an error if the address or the framing is invalid.
+func NewSocketHandler(o SocketOptions) (*SocketHandler, error) {
+	opts := newSocketOptions(o)
+	ob, err := newSocketOutbox(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &SocketHandler{
+		Handler: slog.NewJSONHandler(ob, &slog.HandlerOptions{
+			AddSource:   true,
+			Level:       slog.Level(opts.Level),
+			ReplaceAttr: replaceAttr,
+		}),
+		outbox: ob,
+	}, nil
+}
+
+// newSocketOutbox returns a new [outbox] writing the records to the socket of the options.
+// It returns an error if the address or the framing is invalid.
+func newSocketOutbox(opts SocketOptions) (*outbox, error) {
+	network, address, ok := strings.Cut(opts.Address, "://")
+	if !ok || address == "" {
+		return nil, fmt.Errorf("invalid socket address %q, expected <network>://<address>", opts.Address)
+	}
+	switch network {
+	case "tcp", "tcp4", "tcp6":
+	case "udp", "udp4", "udp6", "unix", "unixgram":
+		if opts.TLSConfig != nil {
+			return nil, fmt.Errorf("tls is not supported for %s sockets", network)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported socket network %q", network)
+	}
+	framing := strings.ToUpper(opts.Framing)
+	if framing != "NEWLINE" && framing != "LENGTH" {
+		return nil, fmt.Errorf("unknown socket framing %q", opts.Framing)
+	}
+
+	return newOutbox(outboxOptions{
+		name:          opts.Address,
+		bufferSize:    opts.BufferSize,
+		reconnectWait: opts.ReconnectWait,
+		timeout:       opts.Timeout,
+		errDropped:    ErrSocketDropped,
+		onError:       opts.OnError,
+	}, func() (outboxConn, error) {
+		c, err := dialSocket(network, address, framing, opts)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	}), nil
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *SocketHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &SocketHandler{Handler: h.Handler.WithAttrs(attrs), outbox: h.outbox}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *SocketHandler) WithGroup(name string) slog.Handler {
+	return &SocketHandler{Handler: h.Handler.WithGroup(name), outbox: h.outbox}
+}
+
+// Close stops accepting records and waits until the buffered records are written or [SocketOptions.Timeout] elapsed.
+// It returns an error if records could not be written.
+// It closes the handlers derived by WithAttrs and WithGroup as well.
+func (h *SocketHandler) Close() error {
+	return h.outbox.close()
+}
+
+// socketConn is a connection to the target of a [SocketHandler].
+type socketConn struct {
+	conn    net.Conn
+	framing string
+	timeout time.Duration
+	buf     []byte
+}
+
+// dialSocket connects to the address of the network.
+func dialSocket(network, address, framing string, opts SocketOptions) (*socketConn, error) {
+	dialer := &net.Dialer{Timeout: opts.Timeout}
+	var conn net.Conn
+	var err error
+	if opts.TLSConfig != nil {
+		conn, err = tls.DialWithDialer(dialer, network, address, opts.TLSConfig)
+	} else {
+		conn, err = dialer.Dial(network, address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", opts.Address, err)
+	}
+	return &socketConn{conn: conn, framing: framing, timeout: opts.Timeout}, nil
+}
+
+// send writes the framed record. Failed records are retried on a new connection.
+func (c *socketConn) send(msg []byte) (retry bool, err error) {
+	c.buf = c.buf[:0]
+	switch c.framing {
+	case "LENGTH":
+		if uint64(len(msg)) > math.MaxUint32 {
+			return false, fmt.Errorf("record of %d bytes exceeds the length prefix", len(msg))
+		}
+		c.buf = binary.BigEndian.AppendUint32(c.buf, uint32(len(msg))) //nolint:gosec // checked above
+		c.buf = append(c.buf, msg...)
+	default:
+		c.buf = append(append(c.buf, msg...), '\n')
+	}
+
+	_ = c.conn.SetWriteDeadline(time.Now().Add(c.timeout))
+	if _, err := c.conn.Write(c.buf); err != nil {
+		return true, fmt.Errorf("failed to write to socket: %w", err)
+	}
+	return false, nil
+}
+
+// close closes the connection.
+func (c *socketConn) close() {
+	_ = c.conn.Close()
+}
@@ -0,0 +1,211 @@
+package logger
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// socketServer accepts connections on the listener and sends the received records to the returned channel,
+// split by the framing.
+func socketServer(t *testing.T, ln net.Listener, framing string) <-chan string {
+	t.Helper()
+	t.Cleanup(func() { _ = ln.Close() })
+	ch := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				for {
+					var rec string
+					if framing == "LENGTH" {
+						var n uint32
+						if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+							return
+						}
+						b := make([]byte, n)
+						if _, err := io.ReadFull(r, b); err != nil {
+							return
+						}
+						rec = string(b)
+					} else if rec, err = r.ReadString('\n'); err != nil {
+						return
+					}
+					ch <- rec
+				}
+			}()
+		}
+	}()
+	return ch
+}
+
+// receiveRecord returns the next received record or fails the test after a second.
+func receiveRecord(t *testing.T, records <-chan string) string {
+	t.Helper()
+	select {
+	case rec := <-records:
+		return rec
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for a record")
+		return ""
+	}
+}
+
+func TestSocketHandler(t *testing.T) {
+	tests := []struct {
+		name    string
+		network string
+		framing string
+	}{
+		{name: "tcp newline", network: "tcp", framing: "NEWLINE"},
+		{name: "tcp length", network: "tcp", framing: "LENGTH"},
+		{name: "unix", network: "unix"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := "127.0.0.1:0"
+			if tt.network == "unix" {
+				if runtime.GOOS == "windows" {
+					t.Skip("unix sockets are not supported")
+				}
+				addr = filepath.Join(t.TempDir(), "log.sock")
+			}
+			ln, err := net.Listen(tt.network, addr)
+			if err != nil {
+				t.Fatalf("Listen() error = %v", err)
+			}
+			records := socketServer(t, ln, tt.framing)
+
+			h, err := NewSocketHandler(SocketOptions{Address: tt.network + "://" + ln.Addr().String(), Framing: tt.framing})
+			if err != nil {
+				t.Fatalf("NewSocketHandler() error = %v", err)
+			}
+			log := NewLogger(Options{Handler: h}).With("service", "api")
+			log.Info("first")
+			log.Info("second")
+
+			for _, want := range []string{`"msg":"first","service":"api"}`, `"msg":"second","service":"api"}`} {
+				rec := receiveRecord(t, records)
+				if !strings.Contains(rec, want) {
+					t.Errorf("Expected %s, got %s", want, rec)
+				}
+				if tt.framing == "LENGTH" && strings.HasSuffix(rec, "\n") {
+					t.Errorf("Expected no newline with length framing, got %q", rec)
+				}
+			}
+			if err := h.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			if _, err := h.outbox.Write([]byte("{}")); !errors.Is(err, ErrHandlerClosed) {
+				t.Errorf("Expected ErrHandlerClosed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSocketHandler_UDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket() error = %v", err)
+	}
+	defer pc.Close()
+
+	h, err := NewSocketHandler(SocketOptions{Address: "udp://" + pc.LocalAddr().String()})
+	if err != nil {
+		t.Fatalf("NewSocketHandler() error = %v", err)
+	}
+	defer h.Close()
+	NewLogger(Options{Handler: h}).Info("datagram")
+
+	buf := make([]byte, 4096)
+	_ = pc.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := pc.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("ReadFrom() error = %v", err)
+	}
+	if !strings.Contains(string(buf[:n]), `"msg":"datagram"`) {
+		t.Errorf("Unexpected datagram %s", buf[:n])
+	}
+}
+
+func TestSocketHandler_Reconnect(t *testing.T) {
+	// Reserve a port, which is unreachable until the server is started.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close()
+
+	dropped := make(chan error, 10)
+	h, err := NewSocketHandler(SocketOptions{
+		Address:       "tcp://" + addr,
+		BufferSize:    2,
+		ReconnectWait: 10 * time.Millisecond,
+		OnError: func(err error) {
+			if errors.Is(err, ErrSocketDropped) {
+				dropped <- err
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewSocketHandler() error = %v", err)
+	}
+	defer h.Close()
+
+	log := NewLogger(Options{Handler: h})
+	for i := range 3 {
+		log.Info("record", "i", i)
+	}
+
+	ln, err = net.Listen("tcp", addr)
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	records := socketServer(t, ln, "NEWLINE")
+	for _, want := range []string{`"i":1`, `"i":2`} {
+		if rec := receiveRecord(t, records); !strings.Contains(rec, want) {
+			t.Errorf("Expected record with %s, got %s", want, rec)
+		}
+	}
+	select {
+	case err := <-dropped:
+		if !strings.HasSuffix(err.Error(), ": 1") {
+			t.Errorf("Expected 1 dropped record, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected the dropped records to be reported")
+	}
+}
+
+func TestNewSocketHandler_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opts SocketOptions
+	}{
+		{name: "no scheme", opts: SocketOptions{Address: "localhost:5000"}},
+		{name: "unsupported network", opts: SocketOptions{Address: "http://localhost:5000"}},
+		{name: "unknown framing", opts: SocketOptions{Address: "tcp://localhost:5000", Framing: "XML"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSocketHandler(tt.opts); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
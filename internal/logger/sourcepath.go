@@ -0,0 +1,93 @@
+package logger
+
+import (
+	"fmt"
+	"log/slog"
+	"path"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// SourcePathStyle is the form of the file paths of the sources of JSON records, see [Options.SourcePath].
+type SourcePathStyle string
+
+const (
+	// SourcePathFull writes the absolute paths of the files on the build host.
+	SourcePathFull SourcePathStyle = "full"
+	// SourcePathModule writes the paths of the files relative to the root of the main module,
+	// e.g. "internal/server/server.go", and the import paths of the packages of other modules,
+	// e.g. "github.com/org/lib/client/client.go".
+	SourcePathModule SourcePathStyle = "module"
+	// SourcePathPackage writes the name of the directory of the package and the file, e.g. "server/server.go".
+	SourcePathPackage SourcePathStyle = "package"
+)
+
+// mainModule returns the path of the main module of the program, empty if it is unknown.
+var mainModule = sync.OnceValue(func() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	return info.Main.Path
+})
+
+// functionPackage returns the path of the package of the fully qualified function name.
+func functionPackage(function string) string {
+	// The function is qualified by the package path, e.g. "github.com/org/repo/pkg.(*T).Method",
+	// so the package ends at the first dot after the last slash.
+	slash := strings.LastIndexByte(function, '/')
+	if dot := strings.IndexByte(function[slash+1:], '.'); dot >= 0 {
+		return function[:slash+1+dot]
+	}
+	return function
+}
+
+// trimSourcePath returns the file path of the source in the given style.
+func trimSourcePath(src *slog.Source, style SourcePathStyle) string {
+	switch style {
+	case SourcePathPackage:
+		return path.Join(filepath.Base(filepath.Dir(src.File)), filepath.Base(src.File))
+	case SourcePathModule:
+		pkg := functionPackage(src.Function)
+		if pkg == "" {
+			return src.File
+		}
+		if mod := mainModule(); mod != "" {
+			if pkg == mod {
+				return filepath.Base(src.File)
+			}
+			pkg = strings.TrimPrefix(pkg, mod+"/")
+		}
+		return path.Join(pkg, filepath.Base(src.File))
+	default:
+		return src.File
+	}
+}
+
+// sourceReplaceAttr returns a replace function that writes the source in the given style,
+// as "file:line" string if compact is set, before calling next.
+// It returns next if the source is written unchanged.
+func sourceReplaceAttr(style SourcePathStyle, compact bool, next func([]string, slog.Attr) slog.Attr) func([]string, slog.Attr) slog.Attr {
+	if (style == "" || style == SourcePathFull) && !compact {
+		return next
+	}
+	return func(groups []string, a slog.Attr) slog.Attr {
+		a = next(groups, a)
+		if len(groups) > 0 || a.Key != slog.SourceKey {
+			return a
+		}
+		src, ok := a.Value.Any().(*slog.Source)
+		if !ok || src == nil {
+			return a
+		}
+		file := trimSourcePath(src, style)
+		if compact {
+			a.Value = slog.StringValue(fmt.Sprintf("%s:%d", file, src.Line))
+			return a
+		}
+		a.Value = slog.AnyValue(&slog.Source{Function: src.Function, File: file, Line: src.Line})
+		return a
+	}
+}
@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTrimSourcePath(t *testing.T) {
+	src := &slog.Source{Function: "github.com/other/lib/client.(*Client).Do", File: "/home/build/go/pkg/mod/github.com/other/lib/client/client.go", Line: 42}
+	tests := []struct {
+		name  string
+		style SourcePathStyle
+		want  string
+	}{
+		{name: "default", want: src.File},
+		{name: "full", style: SourcePathFull, want: src.File},
+		{name: "package", style: SourcePathPackage, want: "client/client.go"},
+		{name: "module of dependency", style: SourcePathModule, want: "github.com/other/lib/client/client.go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := trimSourcePath(src, tt.style); got != tt.want {
+				t.Errorf("trimSourcePath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewLogger_SourcePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		compact bool
+		want    string
+	}{
+		{name: "package", opts: Options{SourcePath: "package"}, want: "logger/sourcepath_test.go"},
+		{name: "module", opts: Options{SourcePath: "MODULE"}, want: "internal/logger/sourcepath_test.go"},
+		{name: "compact", opts: Options{SourcePath: "package", CompactSource: true}, compact: true, want: "logger/sourcepath_test.go:"},
+		{name: "compact full", opts: Options{CompactSource: true}, compact: true, want: "/sourcepath_test.go:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "app.log")
+			t.Setenv("LOG_FORMAT", "JSON")
+			t.Setenv("LOG_OUTPUT", path)
+			NewLogger(tt.opts).Info("test")
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("ReadFile() error = %v", err)
+			}
+			var rec map[string]any
+			if err = json.Unmarshal(data, &rec); err != nil {
+				t.Fatalf("Failed to unmarshal record %q: %v", data, err)
+			}
+
+			var file string
+			if tt.compact {
+				file, _ = rec[slog.SourceKey].(string)
+			} else {
+				src, _ := rec[slog.SourceKey].(map[string]any)
+				file, _ = src["file"].(string)
+			}
+			if !strings.Contains(file, tt.want) {
+				t.Errorf("Expected source containing %q, got %v", tt.want, rec[slog.SourceKey])
+			}
+			if tt.opts.SourcePath != "" && strings.HasPrefix(file, "/") {
+				t.Errorf("Expected a relative source path, got %q", file)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"unicode/utf8"
+)
+
+const (
+	// SplitIDKey is the key used for the ID shared by all parts of a split record.
+	SplitIDKey = "split_id"
+	// PartKey is the key used for the 1-based index of a part of a split record.
+	PartKey = "part"
+	// TotalKey is the key used for the total number of parts of a split record.
+	TotalKey = "total"
+)
+
+// splitOverhead is the estimated size of the attributes added to every part of a split record.
+const splitOverhead = len(`,"split_id":"0123456789abcdef","part":1000,"total":1000`)
+
+var _ slog.Handler = (*splitHandler)(nil)
+
+// splitHandler is a [slog.Handler] that splits records exceeding a maximum size into linked continuation records.
+type splitHandler struct {
+	slog.Handler
+	// maxSize is the maximum size of a record in bytes.
+	maxSize int
+	// overhead is the estimated size of the attributes and groups added by WithAttrs and WithGroup
+	// and of the attributes added by the wrapped handlers.
+	overhead int
+}
+
+// NewSplitHandler returns a new [slog.Handler] that splits records exceeding the given size in bytes
+// into multiple records instead of passing them to the given handler as a whole.
+// This prevents sinks with a maximum payload size (e.g. CloudWatch or UDP) from truncating records or returning errors.
+//
+// The parts of a split record share the same [SplitIDKey] and carry their 1-based index as [PartKey]
+// and the total number of parts as [TotalKey].
+// The message is split across the first parts, followed by the attributes.
+// String attributes exceeding the size of a part are split into multiple attributes with the same key.
+//
+// The size of a record is estimated from its JSON encoding, including the source of the logging call.
+// Returns the given handler if maxSize is not positive.
+func NewSplitHandler(h slog.Handler, maxSize int) slog.Handler {
+	return newSplitHandler(h, maxSize, 0)
+}
+
+// newSplitHandler returns a new split handler reserving the given number of bytes in every part
+// for the attributes added by the wrapped handler, e.g. the record ID.
+func newSplitHandler(h slog.Handler, maxSize, reserved int) slog.Handler {
+	if maxSize <= 0 {
+		return h
+	}
+	return &splitHandler{Handler: h, maxSize: maxSize, overhead: reserved}
+}
+
+// Handle splits the record if it exceeds the maximum size and passes the parts to the wrapped handler.
+func (h *splitHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	if h.overhead+encodedSize(r) <= h.maxSize {
+		return h.Handler.Handle(ctx, r)
+	}
+
+	empty := slog.NewRecord(r.Time, r.Level, "", r.PC)
+	budget := h.maxSize - h.overhead - encodedSize(empty) - splitOverhead
+	if budget <= 0 {
+		return h.Handler.Handle(ctx, r)
+	}
+
+	parts := splitRecord(r, budget)
+	id := newSplitID()
+	var errs []error
+	for i, part := range parts {
+		part.AddAttrs(slog.String(SplitIDKey, id), slog.Int(PartKey, i+1), slog.Int(TotalKey, len(parts)))
+		errs = append(errs, h.Handler.Handle(ctx, part))
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *splitHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	r := slog.Record{}
+	r.AddAttrs(attrs...)
+	return &splitHandler{
+		Handler:  h.Handler.WithAttrs(attrs),
+		maxSize:  h.maxSize,
+		overhead: h.overhead + encodedSize(r) - encodedSize(slog.Record{}),
+	}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *splitHandler) WithGroup(name string) slog.Handler {
+	return &splitHandler{
+		Handler:  h.Handler.WithGroup(name),
+		maxSize:  h.maxSize,
+		overhead: h.overhead + len(`,"":{}`) + len(name),
+	}
+}
+
+// splitRecord splits the record into parts whose message and attributes fit into the given budget.
+func splitRecord(r slog.Record, budget int) []slog.Record { //nolint:gocritic // records are passed by value
+	newPart := func(msg string) slog.Record {
+		return slog.NewRecord(r.Time, r.Level, msg, r.PC)
+	}
+
+	emptyPart := encodedSize(newPart(""))
+	msgSize := func(msg string) int { return encodedSize(newPart(msg)) - emptyPart }
+
+	var parts []slog.Record
+	for _, chunk := range chunkEncoded(r.Message, budget, msgSize) {
+		parts = append(parts, newPart(chunk))
+	}
+	remaining := budget - msgSize(parts[len(parts)-1].Message)
+	empty := encodedSize(slog.Record{})
+
+	r.Attrs(func(a slog.Attr) bool {
+		for _, attr := range splitAttr(a, budget, empty) {
+			size := attrSize(attr, empty)
+			if size > remaining {
+				parts = append(parts, newPart(""))
+				remaining = budget
+			}
+			parts[len(parts)-1].AddAttrs(attr)
+			remaining -= size
+		}
+		return true
+	})
+	return parts
+}
+
+// splitAttr splits a string attribute exceeding the budget into multiple attributes with the same key.
+// Other attributes are returned as is.
+func splitAttr(a slog.Attr, budget, empty int) []slog.Attr {
+	a.Value = a.Value.Resolve()
+	size := attrSize(a, empty)
+	if size <= budget || a.Value.Kind() != slog.KindString {
+		return []slog.Attr{a}
+	}
+
+	keySize := attrSize(slog.String(a.Key, ""), empty)
+	if budget-keySize <= 0 {
+		return []slog.Attr{a}
+	}
+
+	chunks := chunkEncoded(a.Value.String(), budget, func(chunk string) int { return attrSize(slog.String(a.Key, chunk), empty) })
+	attrs := make([]slog.Attr, 0, len(chunks))
+	for _, chunk := range chunks {
+		attrs = append(attrs, slog.String(a.Key, chunk))
+	}
+	return attrs
+}
+
+// attrSize returns the estimated encoded size of the attribute.
+func attrSize(a slog.Attr, empty int) int {
+	r := slog.Record{}
+	r.AddAttrs(a)
+	return encodedSize(r) - empty
+}
+
+// encodedSize returns the size of the JSON encoding of the record.
+// The source is included if the record has a PC, since the handlers may add it.
+func encodedSize(r slog.Record) int { //nolint:gocritic // records are passed by value
+	var buf bytes.Buffer
+	_ = slog.NewJSONHandler(&buf, &slog.HandlerOptions{AddSource: r.PC != 0, ReplaceAttr: replaceAttr}).Handle(context.Background(), r)
+	return buf.Len()
+}
+
+// chunkEncoded splits the string into chunks whose size returned by size fits into the budget,
+// e.g. their encoded size including escaped characters, without splitting runes.
+// Chunks consisting of a single rune may exceed the budget. Always returns at least one chunk.
+func chunkEncoded(s string, budget int, size func(string) int) []string {
+	var chunks []string
+	for {
+		n := budget
+		chunk := firstChunk(s, n)
+		for sz := size(chunk); sz > budget && utf8.RuneCountInString(chunk) > 1; sz = size(chunk) {
+			// The chunk is shrunk in proportion to its excess, but at least by one byte.
+			n = min(n-1, len(chunk)*budget/sz)
+			chunk = firstChunk(chunk, max(n, 1))
+		}
+		chunks = append(chunks, chunk)
+		if s = s[len(chunk):]; s == "" {
+			return chunks
+		}
+	}
+}
+
+// chunkString splits the string into chunks of at most size bytes without splitting runes.
+// Always returns at least one chunk.
+func chunkString(s string, size int) []string {
+	chunks := []string{firstChunk(s, size)}
+	for s = s[len(chunks[0]):]; s != ""; s = s[len(chunks[len(chunks)-1]):] {
+		chunks = append(chunks, firstChunk(s, size))
+	}
+	return chunks
+}
+
+// firstChunk returns the longest prefix of the string of at most size bytes without splitting runes,
+// or the first rune if it is longer than size.
+func firstChunk(s string, size int) string {
+	if len(s) <= size {
+		return s
+	}
+	end := size
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	if end == 0 {
+		_, end = utf8.DecodeRuneInString(s)
+	}
+	return s[:end]
+}
+
+// newSplitID returns a new random ID for a split record.
+func newSplitID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,167 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSplitHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxSize   int
+		msg       string
+		attrs     []any
+		addSource bool
+		wantParts bool
+	}{
+		{
+			name:      "record within limit",
+			maxSize:   1024,
+			msg:       "short message",
+			attrs:     []any{"key", "value"},
+			wantParts: false,
+		},
+		{
+			name:      "oversized message",
+			maxSize:   512,
+			msg:       strings.Repeat("äbc", 200),
+			wantParts: true,
+		},
+		{
+			name:      "oversized attributes",
+			maxSize:   512,
+			msg:       "many attributes",
+			attrs:     []any{"a", strings.Repeat("a", 100), "b", strings.Repeat("b", 100), "c", strings.Repeat("c", 100), "d", 42},
+			wantParts: true,
+		},
+		{
+			name:      "oversized string attribute",
+			maxSize:   512,
+			msg:       "large attribute",
+			attrs:     []any{"payload", strings.Repeat("x", 1000)},
+			wantParts: true,
+		},
+		{
+			name:      "oversized escaped message with source",
+			maxSize:   512,
+			msg:       strings.Repeat("\"q\"\n<>", 200),
+			attrs:     []any{"payload", strings.Repeat("\t", 300)},
+			addSource: true,
+			wantParts: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			h := NewSplitHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{AddSource: tt.addSource}), tt.maxSize).WithAttrs([]slog.Attr{slog.String("name", "test")})
+			NewLogger(Options{Handler: h}).Info(tt.msg, tt.attrs...)
+
+			var msg strings.Builder
+			values := map[string]string{}
+			ids := map[any]bool{}
+			lines := 0
+			scanner := bufio.NewScanner(&buf)
+			scanner.Buffer(nil, 1<<20)
+			for scanner.Scan() {
+				lines++
+				if len(scanner.Bytes()) > tt.maxSize {
+					t.Errorf("Record exceeds maximum size: %d > %d", len(scanner.Bytes()), tt.maxSize)
+				}
+
+				var rec map[string]any
+				if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+					t.Fatalf("Failed to unmarshal record: %v", err)
+				}
+				msg.WriteString(rec[slog.MessageKey].(string))
+				for k, v := range rec {
+					if s, ok := v.(string); ok {
+						values[k] += s
+					}
+				}
+				if tt.wantParts {
+					ids[rec[SplitIDKey]] = true
+					if rec[PartKey] != float64(lines) {
+						t.Errorf("Expected part %d, got %v", lines, rec[PartKey])
+					}
+				}
+			}
+
+			if !tt.wantParts && lines != 1 {
+				t.Errorf("Expected a single record, got %d", lines)
+			}
+			if tt.wantParts && (lines < 2 || len(ids) != 1) {
+				t.Errorf("Expected multiple parts with a shared split ID, got %d parts with IDs %v", lines, ids)
+			}
+			if msg.String() != tt.msg {
+				t.Errorf("Expected reassembled message %q, got %q", tt.msg, msg.String())
+			}
+			for i := 0; i+1 < len(tt.attrs); i += 2 {
+				if want, ok := tt.attrs[i+1].(string); ok && values[tt.attrs[i].(string)] != want {
+					t.Errorf("Expected reassembled attribute %v, got %q", tt.attrs[i], values[tt.attrs[i].(string)])
+				}
+			}
+		})
+	}
+}
+
+func TestChunkEncoded(t *testing.T) {
+	// Quotes are escaped, so they take two bytes.
+	size := func(s string) int { return len(s) + strings.Count(s, `"`) }
+	tests := []struct {
+		name   string
+		s      string
+		budget int
+		want   []string
+	}{
+		{name: "within budget", s: "abc", budget: 3, want: []string{"abc"}},
+		{name: "plain", s: "abcdefg", budget: 3, want: []string{"abc", "def", "g"}},
+		{name: "escaped", s: `a"b"c`, budget: 3, want: []string{`a"`, `b"`, "c"}},
+		{name: "multi-byte runes", s: "äöü", budget: 5, want: []string{"äö", "ü"}},
+		{name: "large value", s: strings.Repeat("x", 100000), budget: 1400, want: chunkString(strings.Repeat("x", 100000), 1400)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := chunkEncoded(tt.s, tt.budget, size)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("chunkEncoded(%q, %d) = %q, want %q", tt.s, tt.budget, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChunkString(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		size int
+		want []string
+	}{
+		{name: "empty string", s: "", size: 3, want: []string{""}},
+		{name: "within size", s: "abc", size: 3, want: []string{"abc"}},
+		{name: "ascii", s: "abcdefg", size: 3, want: []string{"abc", "def", "g"}},
+		{name: "multi-byte runes", s: "äöü", size: 3, want: []string{"ä", "ö", "ü"}},
+		{name: "rune larger than size", s: "ä", size: 1, want: []string{"ä"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := chunkString(tt.s, tt.size)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("chunkString(%q, %d) = %q, want %q", tt.s, tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSplitHandler_Disabled(t *testing.T) {
+	h := slog.NewJSONHandler(&bytes.Buffer{}, nil)
+	if got := NewSplitHandler(h, 0); got != h {
+		t.Errorf("Expected the given handler to be returned, got %T", got)
+	}
+}
@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// StacktraceKey is the key used for stack traces.
+const StacktraceKey = "stack_trace"
+
+// maxStackDepth is the maximum number of frames captured for a stack trace.
+const maxStackDepth = 64
+
+// captureStack returns the program counters of the calling goroutine's stack,
+// skipping the given number of frames in addition to captureStack itself.
+func captureStack(skip int) []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip+2, pcs) //nolint:mnd // skip runtime.Callers and captureStack
+	return pcs[:n]
+}
+
+// formatStack formats the stack of the given program counters in the manner of [runtime/debug.Stack],
+// one function per line followed by its indented file and line.
+func formatStack(pcs []uintptr) string {
+	var b strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" {
+			b.WriteString(frame.Function)
+			b.WriteString("\n\t")
+			b.WriteString(frame.File)
+			b.WriteByte(':')
+			b.WriteString(strconv.Itoa(frame.Line))
+			b.WriteByte('\n')
+		}
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
+
+var _ slog.Handler = (*stacktraceHandler)(nil)
+
+// stacktraceHandler is a [slog.Handler] that adds a stack trace to records at or above a minimum level.
+type stacktraceHandler struct {
+	slog.Handler
+	level Level
+}
+
+// NewStacktraceHandler returns a new [slog.Handler] that adds the stack trace of the logging call under [StacktraceKey]
+// to every record at or above the given level, similar to zap's AddStacktrace.
+// The stack trace starts at the caller of the logging method, so the frames of the logger and the handlers are trimmed.
+func NewStacktraceHandler(h slog.Handler, level Level) slog.Handler {
+	return &stacktraceHandler{Handler: h, level: level}
+}
+
+// Handle adds the stack trace to the record if its level is at or above the minimum level and passes it to the wrapped handler.
+func (h *stacktraceHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	if Level(r.Level) >= h.level {
+		r.AddAttrs(slog.String(StacktraceKey, formatStack(trimStack(captureStack(1), r.PC))))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *stacktraceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &stacktraceHandler{Handler: h.Handler.WithAttrs(attrs), level: h.level}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *stacktraceHandler) WithGroup(name string) slog.Handler {
+	return &stacktraceHandler{Handler: h.Handler.WithGroup(name), level: h.level}
+}
+
+// trimStack returns the stack starting at the frame of the given program counter, usually the caller of the logging method.
+// Returns the whole stack if the program counter is not part of it, e.g. if the record was created manually.
+func trimStack(pcs []uintptr, pc uintptr) []uintptr {
+	if i := slices.Index(pcs, pc); pc != 0 && i >= 0 {
+		return pcs[i:]
+	}
+	return pcs
+}
@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+)
+
+func TestStacktraceHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		level     Level
+		log       func(l Provider)
+		wantStack bool
+	}{
+		{
+			name:      "below level",
+			level:     LevelError,
+			log:       func(l Provider) { l.Warn("test") },
+			wantStack: false,
+		},
+		{
+			name:      "at level",
+			level:     LevelError,
+			log:       func(l Provider) { l.Error("test") },
+			wantStack: true,
+		},
+		{
+			name:      "above level",
+			level:     LevelWarn,
+			log:       func(l Provider) { l.With("key", "value").ErrorContext(context.Background(), "test") },
+			wantStack: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stack string
+			l := NewLogger(Options{Handler: NewStacktraceHandler(test.MockHandler{
+				HandleFunc: func(_ context.Context, r slog.Record) error {
+					r.Attrs(func(a slog.Attr) bool {
+						if a.Key == StacktraceKey {
+							stack = a.Value.String()
+						}
+						return true
+					})
+					return nil
+				},
+			}, tt.level)})
+
+			tt.log(l)
+			if (stack != "") != tt.wantStack {
+				t.Fatalf("Expected stack trace = %v, got %q", tt.wantStack, stack)
+			}
+			if !tt.wantStack {
+				return
+			}
+			if !strings.HasPrefix(stack, "github.com/lvlcn-t/loggerhead/internal/logger.TestStacktraceHandler.func") {
+				t.Errorf("Expected the stack trace to start at the caller, got %q", stack)
+			}
+			for _, frame := range []string{"log/slog.", "(*logger).", "(*stacktraceHandler)."} {
+				if strings.Contains(stack, frame) {
+					t.Errorf("Expected the stack trace not to contain %q frames, got %q", frame, stack)
+				}
+			}
+		})
+	}
+}
+
+func TestTrimStack(t *testing.T) {
+	pcs := []uintptr{1, 2, 3, 4}
+
+	tests := []struct {
+		name string
+		pc   uintptr
+		want []uintptr
+	}{
+		{name: "caller found", pc: 3, want: []uintptr{3, 4}},
+		{name: "caller not found", pc: 5, want: pcs},
+		{name: "no caller", pc: 0, want: pcs},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := trimStack(pcs, tt.pc)
+			if len(got) != len(tt.want) || got[0] != tt.want[0] {
+				t.Errorf("trimStack() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
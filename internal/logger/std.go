@@ -0,0 +1,77 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"log/slog"
+	"sync"
+)
+
+// NewStdLogger returns a new [log.Logger] whose output is logged at the given level with the handler of the given logger,
+// one record per call. This allows legacy libraries accepting only a [log.Logger] (e.g. the ErrorLog of an http.Server)
+// to log through the structured pipeline.
+//
+// Example:
+//
+//	srv := &http.Server{ErrorLog: logger.NewStdLogger(log, logger.LevelError)}
+func NewStdLogger(l Provider, level Level) *log.Logger {
+	return slog.NewLogLogger(l.Handler(), slog.Level(level))
+}
+
+// lineWriter is an [io.WriteCloser] logging every line written to it as a record.
+type lineWriter struct {
+	log   Provider
+	level Level
+	mu    sync.Mutex
+	buf   []byte
+}
+
+// Writer returns a new [io.WriteCloser] that splits the written bytes on newlines
+// and logs every line as a record at the given level with the given logger.
+// Incomplete lines are buffered until their newline is written or the writer is closed.
+// This allows to capture the output of an exec.Cmd or of libraries that only write to an [io.Writer].
+//
+// Example:
+//
+//	w := logger.Writer(log.With("cmd", "git"), logger.LevelInfo)
+//	defer w.Close()
+//	cmd.Stdout = w
+func Writer(l Provider, level Level) io.WriteCloser {
+	return &lineWriter{log: l, level: level}
+}
+
+// Write logs every complete line of p and buffers the remainder.
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.logLine(w.buf[:i])
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Close logs the buffered incomplete line, if any.
+func (w *lineWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) > 0 {
+		w.logLine(w.buf)
+		w.buf = nil
+	}
+	return nil
+}
+
+// logLine logs the line without a trailing carriage return.
+func (w *lineWriter) logLine(line []byte) {
+	w.log.LogAttrs(context.Background(), w.level, string(bytes.TrimSuffix(line, []byte("\r"))))
+}
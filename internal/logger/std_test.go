@@ -0,0 +1,113 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+)
+
+// leveledLogger is the LeveledLogger interface of github.com/hashicorp/go-retryablehttp.
+type leveledLogger interface {
+	Error(msg string, keysAndValues ...any)
+	Info(msg string, keysAndValues ...any)
+	Debug(msg string, keysAndValues ...any)
+	Warn(msg string, keysAndValues ...any)
+}
+
+// The Provider is passed to libraries expecting a leveled logger directly, so it must keep satisfying their interface.
+var _ leveledLogger = Provider(nil)
+
+func TestNewStdLogger(t *testing.T) {
+	tests := []struct {
+		name    string
+		level   Level
+		minimum Level
+		wantLog bool
+	}{
+		{name: "logs at level", level: LevelError, minimum: LevelInfo, wantLog: true},
+		{name: "custom level", level: LevelNotice, minimum: LevelInfo, wantLog: true},
+		{name: "below minimum level", level: LevelDebug, minimum: LevelInfo, wantLog: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			log := NewLogger(Options{Handler: slog.NewJSONHandler(buf, &slog.HandlerOptions{
+				AddSource:   true,
+				Level:       slog.Level(tt.minimum),
+				ReplaceAttr: replaceAttr,
+			})}).With("component", "legacy")
+
+			NewStdLogger(log, tt.level).Printf("http: TLS handshake error from %s", "127.0.0.1")
+
+			if !tt.wantLog {
+				if buf.Len() != 0 {
+					t.Errorf("Expected no output, got %s", buf.String())
+				}
+				return
+			}
+			var rec map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+				t.Fatalf("Failed to unmarshal record %q: %v", buf.String(), err)
+			}
+			if rec[slog.MessageKey] != "http: TLS handshake error from 127.0.0.1" {
+				t.Errorf("Expected message without trailing newline, got %q", rec[slog.MessageKey])
+			}
+			if rec[slog.LevelKey] != tt.level.String() || rec["component"] != "legacy" {
+				t.Errorf("Expected record at %v with the logger's attributes, got %v", tt.level, rec)
+			}
+			if src, _ := rec[slog.SourceKey].(map[string]any); !strings.HasSuffix(src["file"].(string), "std_test.go") {
+				t.Errorf("Expected source to be the caller, got %v", src)
+			}
+		})
+	}
+}
+
+func TestWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []string
+		want   []string
+	}{
+		{name: "single line", writes: []string{"hello\n"}, want: []string{"hello"}},
+		{name: "multiple lines in one write", writes: []string{"a\nb\r\nc\n"}, want: []string{"a", "b", "c"}},
+		{name: "line split across writes", writes: []string{"hel", "lo\nwor", "ld\n"}, want: []string{"hello", "world"}},
+		{name: "incomplete line flushed on close", writes: []string{"a\nb"}, want: []string{"a", "b"}},
+		{name: "empty lines", writes: []string{"\n\n"}, want: []string{"", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			log := NewLogger(Options{Handler: test.MockHandler{
+				HandleFunc: func(_ context.Context, r slog.Record) error {
+					if r.Level != slog.Level(LevelWarn) {
+						t.Errorf("Expected level %v, got %v", LevelWarn, r.Level)
+					}
+					got = append(got, r.Message)
+					return nil
+				},
+			}})
+
+			w := Writer(log, LevelWarn)
+			for _, s := range tt.writes {
+				if n, err := w.Write([]byte(s)); err != nil || n != len(s) {
+					t.Fatalf("Write() = %d, %v", n, err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Expected lines %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync/atomic"
+)
+
+// swapTarget is the handler of a [SwapHandler], boxed so a swap can be detected by pointer comparison.
+type swapTarget struct {
+	handler slog.Handler
+}
+
+// swapDerived is the handler derived from a [swapTarget] by the attributes and groups of a [SwapHandler].
+type swapDerived struct {
+	target  *swapTarget
+	handler slog.Handler
+}
+
+// SetHandler replaces the handler writing the records of the logger and the loggers sharing it.
+func (l *logger) SetHandler(h slog.Handler) {
+	if l.swap != nil {
+		l.swap.Swap(h)
+	}
+}
+
+var _ slog.Handler = (*SwapHandler)(nil)
+
+// SwapHandler is a [slog.Handler] whose handler can be replaced at runtime.
+type SwapHandler struct {
+	// target is the current handler, shared by a SwapHandler and the handlers derived from it.
+	target *atomic.Pointer[swapTarget]
+	// derive are the WithAttrs and WithGroup calls applied to the current handler.
+	derive []func(h slog.Handler) slog.Handler
+	// derived caches the current handler with derive applied until it is swapped.
+	derived atomic.Pointer[swapDerived]
+}
+
+// NewSwapHandler returns a new [SwapHandler] passing the records to the given handler until it is swapped.
+//
+// The handlers derived from it by WithAttrs and WithGroup, e.g. by the loggers already stored in contexts,
+// follow the swaps, so the destination or format of all records can be switched, e.g. on SIGHUP or a configuration
+// reload, without recreating the loggers. The attributes and groups are applied to the new handler on its first use.
+func NewSwapHandler(h slog.Handler) *SwapHandler {
+	target := &atomic.Pointer[swapTarget]{}
+	target.Store(&swapTarget{handler: h})
+	return &SwapHandler{target: target}
+}
+
+// Swap replaces the handler of the receiver and all handlers derived from it by the given handler.
+// Records being handled concurrently may still be passed to the previous handler.
+func (h *SwapHandler) Swap(handler slog.Handler) {
+	h.target.Store(&swapTarget{handler: handler})
+}
+
+// Handler returns the current handler with the attributes and groups of the receiver applied.
+func (h *SwapHandler) Handler() slog.Handler {
+	target := h.target.Load()
+	if d := h.derived.Load(); d != nil && d.target == target {
+		return d.handler
+	}
+
+	handler := target.handler
+	for _, derive := range h.derive {
+		handler = derive(handler)
+	}
+	h.derived.Store(&swapDerived{target: target, handler: handler})
+	return handler
+}
+
+// Enabled reports whether the current handler handles records at the given level.
+func (h *SwapHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.Handler().Enabled(ctx, level)
+}
+
+// Handle passes the record to the current handler.
+func (h *SwapHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	return h.Handler().Handle(ctx, r)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *SwapHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler { return handler.WithAttrs(attrs) })
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *SwapHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.with(func(handler slog.Handler) slog.Handler { return handler.WithGroup(name) })
+}
+
+// with returns a new handler sharing the receiver's current handler with the given call appended.
+func (h *SwapHandler) with(derive func(h slog.Handler) slog.Handler) *SwapHandler {
+	return &SwapHandler{target: h.target, derive: append(slices.Clip(h.derive), derive)}
+}
@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSwapHandler(t *testing.T) {
+	var first, second strings.Builder
+	h := NewSwapHandler(slog.NewTextHandler(&first, nil))
+	log := slog.New(h).With("service", "api").WithGroup("req")
+
+	log.Info("before", "id", 1)
+	h.Swap(slog.NewJSONHandler(&second, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	log.Info("filtered", "id", 2)
+	log.Warn("after", "id", 3)
+
+	if got := first.String(); strings.Count(got, "\n") != 1 || !strings.Contains(got, "msg=before service=api req.id=1") {
+		t.Errorf("Expected the record before the swap, got %s", got)
+	}
+	if got := second.String(); strings.Count(got, "\n") != 1 || !strings.Contains(got, `"msg":"after","service":"api","req":{"id":3}`) {
+		t.Errorf("Expected the record after the swap in the new format, got %s", got)
+	}
+}
+
+func TestSwapHandler_Concurrent(t *testing.T) {
+	h := NewSwapHandler(slog.NewTextHandler(&strings.Builder{}, nil))
+	log := slog.New(h).With("service", "api")
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				log.Info("record")
+			}
+		}()
+	}
+	for range 10 {
+		h.Swap(slog.NewJSONHandler(&lockedBuilder{}, nil))
+	}
+	wg.Wait()
+}
+
+func TestLogger_SetHandler(t *testing.T) {
+	log := NewLogger(Options{Format: "JSON"})
+	ctx := IntoContext(context.Background(), log.With("service", "api"))
+
+	var buf strings.Builder
+	log.SetHandler(slog.NewTextHandler(&buf, nil))
+	FromContext(ctx).WithGroup("req").Info("reloaded", "id", 1)
+	if got := buf.String(); !strings.Contains(got, "msg=reloaded service=api req.id=1") {
+		t.Errorf("Expected the captured logger to use the new handler, got %s", got)
+	}
+
+	// Loggers with a custom handler can only be swapped if it is a SwapHandler.
+	var custom, swapped strings.Builder
+	fixed := NewLogger(Options{Handler: slog.NewTextHandler(&custom, nil)})
+	fixed.SetHandler(slog.NewTextHandler(&swapped, nil))
+	fixed.Info("kept")
+	swappable := FromSlog(slog.New(NewSwapHandler(slog.NewTextHandler(&custom, nil))))
+	swappable.SetHandler(slog.NewTextHandler(&swapped, nil))
+	swappable.Info("swapped")
+	if !strings.Contains(custom.String(), "msg=kept") || !strings.Contains(swapped.String(), "msg=swapped") || strings.Contains(swapped.String(), "kept") {
+		t.Errorf("Unexpected records %q and %q", custom.String(), swapped.String())
+	}
+}
+
+// lockedBuilder is a strings.Builder safe for concurrent writes.
+type lockedBuilder struct {
+	mu sync.Mutex
+	b  strings.Builder
+}
+
+func (b *lockedBuilder) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.b.Write(p)
+}
@@ -0,0 +1,348 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SyslogFacility is the facility of syslog messages as defined by RFC 5424.
+type SyslogFacility int
+
+// The facilities commonly used by applications.
+const (
+	SyslogUser   SyslogFacility = 1
+	SyslogDaemon SyslogFacility = 3
+	SyslogAuth   SyslogFacility = 4
+	SyslogLocal0 SyslogFacility = 16
+	SyslogLocal1 SyslogFacility = 17
+	SyslogLocal2 SyslogFacility = 18
+	SyslogLocal3 SyslogFacility = 19
+	SyslogLocal4 SyslogFacility = 20
+	SyslogLocal5 SyslogFacility = 21
+	SyslogLocal6 SyslogFacility = 22
+	SyslogLocal7 SyslogFacility = 23
+)
+
+// DefaultSyslogStructuredDataID is the default [SyslogOptions.StructuredDataID].
+// 32473 is the private enterprise number reserved for documentation by RFC 5612.
+const DefaultSyslogStructuredDataID = "attrs@32473"
+
+// syslogSockets are the paths of the local syslog sockets tried if no network is configured.
+var syslogSockets = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// ErrNoSyslog is returned by [NewSyslogHandler] if no network is configured and no local syslog socket was found.
+var ErrNoSyslog = errors.New("no local syslog socket found")
+
+// SyslogOptions is the optional configuration for [NewSyslogHandler].
+type SyslogOptions struct {
+	// Level is the minimum log level.
+	Level Level
+	// Network is the network of the syslog server: "udp", "tcp", "unix" or "unixgram".
+	// If empty, the local syslog daemon is used via its unix socket, e.g. /dev/log.
+	Network string
+	// Address is the address of the syslog server, e.g. "localhost:514" or the path of a unix socket.
+	Address string
+	// Facility is the facility of the messages. Defaults to [SyslogUser].
+	Facility SyslogFacility
+	// AppName is the name of the application. Defaults to the name of the executable.
+	AppName string
+	// Hostname is the name of the host. Defaults to [os.Hostname].
+	Hostname string
+	// StructuredDataID is the ID of the structured data element carrying the attributes of a record.
+	// Defaults to [DefaultSyslogStructuredDataID].
+	StructuredDataID string
+}
+
+// newSyslogOptions returns the provided SyslogOptions merged with the default SyslogOptions.
+func newSyslogOptions(o ...SyslogOptions) SyslogOptions {
+	hostname, _ := os.Hostname()
+	opts := SyslogOptions{
+		Level:            LevelInfo,
+		Facility:         SyslogUser,
+		AppName:          filepath.Base(os.Args[0]),
+		Hostname:         hostname,
+		StructuredDataID: DefaultSyslogStructuredDataID,
+	}
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided SyslogOptions with the receiver SyslogOptions.
+func (o *SyslogOptions) merge(d SyslogOptions) SyslogOptions {
+	if o.Level != 0 {
+		d.Level = o.Level
+	}
+	if o.Facility != 0 {
+		d.Facility = o.Facility
+	}
+	for _, f := range []struct{ src, dst *string }{
+		{&o.Network, &d.Network},
+		{&o.Address, &d.Address},
+		{&o.AppName, &d.AppName},
+		{&o.Hostname, &d.Hostname},
+		{&o.StructuredDataID, &d.StructuredDataID},
+	} {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+	return d
+}
+
+// syslogConn is the connection to the syslog server shared by a [SyslogHandler] and the handlers derived from it.
+type syslogConn struct {
+	mu      sync.Mutex
+	network string
+	address string
+	conn    net.Conn
+	closed  bool
+}
+
+var _ slog.Handler = (*SyslogHandler)(nil)
+
+// SyslogHandler is a [slog.Handler] writing records as RFC 5424 messages to a syslog server.
+type SyslogHandler struct {
+	opts SyslogOptions
+	conn *syslogConn
+	// prefix is the dotted path of the groups of the handler.
+	prefix string
+	// params are the structured data parameters of the attributes added by WithAttrs.
+	params []string
+}
+
+// NewSyslogHandler returns a new [SyslogHandler] writing records to the local or a remote syslog server
+// as RFC 5424 messages. The attributes are written as parameters of a structured data element,
+// with the keys of nested attributes joined by dots. Messages sent via TCP are framed by octet counting (RFC 6587).
+//
+// The levels are mapped to the syslog severities: [LevelTrace] and [LevelDebug] to debug, [LevelInfo] to informational,
+// [LevelNotice] to notice, [LevelWarn] to warning, [LevelError] to error, [LevelPanic] to critical
+// and [LevelFatal] to alert.
+//
+// It returns an error if the connection to the server cannot be established. Close the handler to close the connection.
+func NewSyslogHandler(o ...SyslogOptions) (*SyslogHandler, error) {
+	opts := newSyslogOptions(o...)
+	c := &syslogConn{network: opts.Network, address: opts.Address}
+	if err := c.dial(); err != nil {
+		return nil, err
+	}
+	return &SyslogHandler{opts: opts, conn: c}, nil
+}
+
+// Enabled reports whether the handler handles records at the given level.
+func (h *SyslogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= slog.Level(h.opts.Level)
+}
+
+// Handle writes the record as syslog message.
+func (h *SyslogHandler) Handle(_ context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	params := slices.Clip(h.params)
+	r.Attrs(func(a slog.Attr) bool {
+		params = appendSyslogParams(params, h.prefix, a)
+		return true
+	})
+	return h.conn.write(h.format(r, params))
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *SyslogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	params := slices.Clip(h.params)
+	for _, a := range attrs {
+		params = appendSyslogParams(params, h.prefix, a)
+	}
+	return &SyslogHandler{opts: h.opts, conn: h.conn, prefix: h.prefix, params: params}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *SyslogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &SyslogHandler{opts: h.opts, conn: h.conn, prefix: h.prefix + name + ".", params: h.params}
+}
+
+// Close closes the connection to the syslog server. Records handled afterwards return [ErrHandlerClosed].
+// It closes the connection of the handlers derived by WithAttrs and WithGroup as well.
+func (h *SyslogHandler) Close() error {
+	h.conn.mu.Lock()
+	defer h.conn.mu.Unlock()
+	h.conn.closed = true
+	if h.conn.conn == nil {
+		return nil
+	}
+	err := h.conn.conn.Close()
+	h.conn.conn = nil
+	return err
+}
+
+// format returns the RFC 5424 message of the record with the given structured data parameters.
+func (h *SyslogHandler) format(r slog.Record, params []string) []byte { //nolint:gocritic // records are passed by value
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "<%d>1 ", int(h.opts.Facility)*8+syslogSeverity(Level(r.Level))) //nolint:mnd // the priority is facility * 8 + severity
+	if r.Time.IsZero() {
+		b.WriteString("-")
+	} else {
+		b.WriteString(r.Time.Format("2006-01-02T15:04:05.000000Z07:00"))
+	}
+	b.WriteByte(' ')
+	b.WriteString(syslogHeaderField(h.opts.Hostname, 255)) //nolint:mnd // maximum length of the hostname
+	b.WriteByte(' ')
+	b.WriteString(syslogHeaderField(h.opts.AppName, 48)) //nolint:mnd // maximum length of the app name
+	b.WriteByte(' ')
+	b.WriteString(strconv.Itoa(os.Getpid()))
+	b.WriteString(" - ")
+	if len(params) == 0 {
+		b.WriteString("-")
+	} else {
+		b.WriteByte('[')
+		b.WriteString(h.opts.StructuredDataID)
+		for _, p := range params {
+			b.WriteByte(' ')
+			b.WriteString(p)
+		}
+		b.WriteByte(']')
+	}
+	if r.Message != "" {
+		b.WriteByte(' ')
+		b.WriteString(r.Message)
+	}
+	return b.Bytes()
+}
+
+// syslogSeverity returns the syslog severity of the level.
+func syslogSeverity(level Level) int {
+	switch {
+	case level >= LevelFatal:
+		return 1
+	case level >= LevelPanic:
+		return 2
+	case level >= LevelError:
+		return 3
+	case level >= LevelWarn:
+		return 4
+	case level >= LevelNotice:
+		return 5
+	case level >= LevelInfo:
+		return 6
+	default:
+		return 7
+	}
+}
+
+// syslogHeaderField returns the value as header field of at most maxLen printable ASCII characters
+// or the nil value "-" if it is empty.
+func syslogHeaderField(value string, maxLen int) string {
+	field := strings.Map(func(r rune) rune {
+		if r < '!' || r > '~' {
+			return '_'
+		}
+		return r
+	}, value)
+	if field == "" {
+		return "-"
+	}
+	return field[:min(len(field), maxLen)]
+}
+
+// appendSyslogParams appends the structured data parameters of the attribute to params.
+// The keys of group attributes are prefixed by the group name, groups with an empty key are inlined.
+func appendSyslogParams(params []string, prefix string, a slog.Attr) []string {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			params = appendSyslogParams(params, prefix, ga)
+		}
+		return params
+	}
+	if a.Key == "" {
+		return params
+	}
+
+	value := a.Value.String()
+	if err, ok := a.Value.Any().(error); ok {
+		value = err.Error()
+	}
+	return append(params, syslogParamName(prefix+a.Key)+`="`+syslogParamValue(value)+`"`)
+}
+
+// syslogParamName returns the name as structured data parameter name of at most 32 printable ASCII characters
+// without '=', ' ', ']' and '"'.
+func syslogParamName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < '!' || r > '~' || r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+		return r
+	}, name)
+	return name[:min(len(name), 32)] //nolint:mnd // maximum length of a parameter name
+}
+
+// syslogParamValue returns the value with '"', '\' and ']' escaped as required for structured data parameter values.
+func syslogParamValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
+
+// dial connects to the syslog server. Must be called with the lock held or before the connection is shared.
+func (c *syslogConn) dial() error {
+	if c.network != "" {
+		conn, err := net.Dial(c.network, c.address)
+		if err != nil {
+			return fmt.Errorf("failed to connect to syslog: %w", err)
+		}
+		c.conn = conn
+		return nil
+	}
+
+	sockets := syslogSockets
+	if c.address != "" {
+		sockets = []string{c.address}
+	}
+	for _, path := range sockets {
+		for _, network := range []string{"unixgram", "unix"} {
+			if conn, err := net.Dial(network, path); err == nil {
+				c.conn = conn
+				return nil
+			}
+		}
+	}
+	return ErrNoSyslog
+}
+
+// write writes the message to the syslog server. If the write fails, the connection is re-established once.
+func (c *syslogConn) write(msg []byte) error {
+	if c.network == "tcp" || c.network == "tcp4" || c.network == "tcp6" {
+		msg = append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return ErrHandlerClosed
+	}
+	if c.conn != nil {
+		if _, err := c.conn.Write(msg); err == nil {
+			return nil
+		}
+		_ = c.conn.Close()
+		c.conn = nil
+	}
+	if err := c.dial(); err != nil {
+		return err
+	}
+	_, err := c.conn.Write(msg)
+	return err
+}
@@ -0,0 +1,151 @@
+package logger
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSyslogHandler_UDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket() error = %v", err)
+	}
+	defer pc.Close()
+
+	h, err := NewSyslogHandler(SyslogOptions{
+		Network:  "udp",
+		Address:  pc.LocalAddr().String(),
+		Facility: SyslogLocal0,
+		AppName:  "my app",
+		Hostname: "host",
+	})
+	if err != nil {
+		t.Fatalf("NewSyslogHandler() error = %v", err)
+	}
+	defer h.Close()
+
+	log := NewLogger(Options{Handler: h}).With("service", "api").WithGroup("req")
+	log.Warn("slow request", "path", `/a"b]`, "id", 1)
+
+	buf := make([]byte, 1024)
+	_ = pc.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := pc.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("ReadFrom() error = %v", err)
+	}
+	got := string(buf[:n])
+
+	// local0 (16) * 8 + warning (4)
+	if !strings.HasPrefix(got, "<132>1 ") {
+		t.Errorf("Expected priority 132, got %q", got)
+	}
+	want := ` host my_app ` + strconv.Itoa(os.Getpid()) + ` - [attrs@32473 service="api" req.path="/a\"b\]" req.id="1"] slow request`
+	if !strings.HasSuffix(got, want) {
+		t.Errorf("Expected message ending with %q, got %q", want, got)
+	}
+
+	if err := h.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "closed", 0)); !errors.Is(err, ErrHandlerClosed) {
+		t.Errorf("Expected ErrHandlerClosed, got %v", err)
+	}
+}
+
+func TestSyslogHandler_TCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	defer ln.Close()
+
+	lines := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		length, _ := r.ReadString(' ')
+		n, _ := strconv.Atoi(strings.TrimSpace(length))
+		msg := make([]byte, n)
+		_, _ = r.Read(msg)
+		lines <- string(msg)
+	}()
+
+	h, err := NewSyslogHandler(SyslogOptions{Network: "tcp", Address: ln.Addr().String(), Hostname: "host", AppName: "app"})
+	if err != nil {
+		t.Fatalf("NewSyslogHandler() error = %v", err)
+	}
+	defer h.Close()
+	if err := h.Handle(context.Background(), slog.NewRecord(time.Time{}, slog.Level(LevelFatal), "bye", 0)); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	select {
+	case got := <-lines:
+		// user (1) * 8 + alert (1)
+		want := "<9>1 - host app " + strconv.Itoa(os.Getpid()) + " - - bye"
+		if got != want {
+			t.Errorf("Expected %q, got %q", want, got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a message")
+	}
+}
+
+func TestNewSyslogHandler_NoSocket(t *testing.T) {
+	_, err := NewSyslogHandler(SyslogOptions{Address: filepath.Join(t.TempDir(), "missing.sock")})
+	if !errors.Is(err, ErrNoSyslog) {
+		t.Errorf("Expected ErrNoSyslog, got %v", err)
+	}
+}
+
+func TestSyslogSeverity(t *testing.T) {
+	tests := []struct {
+		level Level
+		want  int
+	}{
+		{LevelTrace, 7},
+		{LevelDebug, 7},
+		{LevelInfo, 6},
+		{LevelNotice, 5},
+		{LevelWarn, 4},
+		{LevelError, 3},
+		{LevelPanic, 2},
+		{LevelFatal, 1},
+	}
+
+	for _, tt := range tests {
+		if got := syslogSeverity(tt.level); got != tt.want {
+			t.Errorf("syslogSeverity(%v) = %d, want %d", tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestSyslogParamName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "key", want: "key"},
+		{name: `a b=c]d"e`, want: "a_b_c_d_e"},
+		{name: strings.Repeat("k", 40), want: strings.Repeat("k", 32)},
+	}
+
+	for _, tt := range tests {
+		if got := syslogParamName(tt.name); got != tt.want {
+			t.Errorf("syslogParamName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
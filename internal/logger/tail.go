@@ -0,0 +1,239 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultTailMaxRecords is the default [TailSamplingOptions.MaxRecords].
+	defaultTailMaxRecords = 100
+	// defaultTailTTL is the default [TailSamplingOptions.TTL].
+	defaultTailTTL = time.Minute
+)
+
+// TailSamplingOptions is the optional configuration for [NewTailSamplingHandler].
+type TailSamplingOptions struct {
+	// Key is the key of the top-level attribute identifying the request of a record. Defaults to [RequestIDKey].
+	Key string
+	// Level is the name of the minimum level of the buffered records. Defaults to "DEBUG".
+	Level string
+	// TriggerLevel is the name of the level of the records emitting the buffered records of their request.
+	// Defaults to "ERROR".
+	TriggerLevel string
+	// MaxRecords is the maximum number of records buffered per request. If the buffer is full,
+	// the oldest records are dropped. Defaults to 100.
+	MaxRecords int
+	// TTL is the time after the last record of a request its buffer is discarded. Defaults to 1 minute.
+	TTL time.Duration
+}
+
+// newTailSamplingOptions returns the provided TailSamplingOptions merged with the default TailSamplingOptions.
+func newTailSamplingOptions(o ...TailSamplingOptions) TailSamplingOptions {
+	opts := TailSamplingOptions{
+		Key:          RequestIDKey,
+		Level:        "DEBUG",
+		TriggerLevel: "ERROR",
+		MaxRecords:   defaultTailMaxRecords,
+		TTL:          defaultTailTTL,
+	}
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided TailSamplingOptions with the receiver TailSamplingOptions.
+func (o *TailSamplingOptions) merge(d TailSamplingOptions) TailSamplingOptions {
+	for _, f := range []struct{ src, dst *string }{
+		{&o.Key, &d.Key},
+		{&o.Level, &d.Level},
+		{&o.TriggerLevel, &d.TriggerLevel},
+	} {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+	if o.MaxRecords > 0 {
+		d.MaxRecords = o.MaxRecords
+	}
+	if o.TTL > 0 {
+		d.TTL = o.TTL
+	}
+	return d
+}
+
+// tailBuffer are the buffered records of a request.
+type tailBuffer struct {
+	records []bufferedRecord
+	// triggered reports whether a trigger record was logged, after which the records are no longer buffered.
+	triggered bool
+	// seen is the time of the last record of the request.
+	seen time.Time
+}
+
+// tailSampler holds the buffers of the requests of a [tailSamplingHandler] and the handlers derived from it.
+type tailSampler struct {
+	opts    TailSamplingOptions
+	level   Level
+	trigger Level
+	mu      sync.Mutex
+	buffers map[string]*tailBuffer
+	// swept is the time the expired buffers were last discarded.
+	swept time.Time
+}
+
+var _ slog.Handler = (*tailSamplingHandler)(nil)
+
+// tailSamplingHandler is a [slog.Handler] buffering the records of a request until a trigger record is logged.
+type tailSamplingHandler struct {
+	slog.Handler
+	sampler *tailSampler
+	// id is the value of the key added by WithAttrs.
+	id string
+	// grouped reports whether the handler has groups, so the attributes of the records are not top-level.
+	grouped bool
+}
+
+// NewTailSamplingHandler returns a new [slog.Handler] that buffers the records of a request below
+// [TailSamplingOptions.TriggerLevel] down to [TailSamplingOptions.Level] instead of passing them to the given handler.
+// The request is identified by the top-level attribute [TailSamplingOptions.Key], e.g. added by [RequestID].
+//
+// Once a record at or above the trigger level is logged for a request, its buffered records are passed
+// to the given handler before it, so failures come with their full debug context without the cost of always logging
+// at debug level. The following records of the request are passed through directly.
+// The buffers of requests without a trigger record are discarded after [TailSamplingOptions.TTL].
+//
+// The buffered records are passed to the given handler regardless of its level, records without the key
+// only if it is enabled for them.
+func NewTailSamplingHandler(h slog.Handler, o ...TailSamplingOptions) slog.Handler {
+	opts := newTailSamplingOptions(o...)
+	return &tailSamplingHandler{
+		Handler: h,
+		sampler: &tailSampler{
+			opts:    opts,
+			level:   newLevel(opts.Level),
+			trigger: newLevel(opts.TriggerLevel),
+			buffers: map[string]*tailBuffer{},
+		},
+	}
+}
+
+// Enabled reports whether the wrapped handler handles records at the given level or the level is buffered.
+func (h *tailSamplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.Handler.Enabled(ctx, level) || Level(level) >= h.sampler.level
+}
+
+// Handle buffers the record of a request, emits the buffered records of the request if it is a trigger record
+// or passes it to the wrapped handler.
+func (h *tailSamplingHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	id := h.id
+	if id == "" && !h.grouped {
+		r.Attrs(func(a slog.Attr) bool {
+			if a.Key == h.sampler.opts.Key {
+				id = a.Value.Resolve().String()
+				return false
+			}
+			return true
+		})
+	}
+
+	level := Level(r.Level)
+	switch {
+	case id == "" || level < h.sampler.level:
+		if h.Handler.Enabled(ctx, r.Level) {
+			return h.Handler.Handle(ctx, r)
+		}
+		return nil
+	case level >= h.sampler.trigger:
+		var errs []error
+		for _, br := range h.sampler.flush(id) {
+			errs = append(errs, br.handler.Handle(br.ctx, br.record))
+		}
+		return errors.Join(append(errs, h.Handler.Handle(ctx, r))...)
+	case h.sampler.add(id, bufferedRecord{ctx: context.WithoutCancel(ctx), handler: h.Handler, record: r.Clone()}):
+		return nil
+	default:
+		return h.Handler.Handle(ctx, r)
+	}
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *tailSamplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	id := h.id
+	if !h.grouped {
+		for _, a := range attrs {
+			if a.Key == h.sampler.opts.Key {
+				id = a.Value.Resolve().String()
+			}
+		}
+	}
+	return &tailSamplingHandler{Handler: h.Handler.WithAttrs(attrs), sampler: h.sampler, id: id, grouped: h.grouped}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *tailSamplingHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &tailSamplingHandler{Handler: h.Handler.WithGroup(name), sampler: h.sampler, id: h.id, grouped: true}
+}
+
+// add buffers the record of the request, dropping its oldest record if the buffer is full.
+// Reports false if the request was triggered and the record must be handled directly.
+func (s *tailSampler) add(id string, br bufferedRecord) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.sweep(now)
+
+	b, ok := s.buffers[id]
+	if !ok {
+		b = &tailBuffer{}
+		s.buffers[id] = b
+	}
+	b.seen = now
+	if b.triggered {
+		return false
+	}
+	if len(b.records) >= s.opts.MaxRecords {
+		b.records[0] = bufferedRecord{}
+		b.records = b.records[1:]
+	}
+	b.records = append(b.records, br)
+	return true
+}
+
+// flush marks the request as triggered and returns its buffered records.
+func (s *tailSampler) flush(id string) []bufferedRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.sweep(now)
+
+	b, ok := s.buffers[id]
+	if !ok {
+		b = &tailBuffer{}
+		s.buffers[id] = b
+	}
+	records := b.records
+	b.records, b.triggered, b.seen = nil, true, now
+	return records
+}
+
+// sweep discards the buffers of the requests without records within the TTL, at most once per TTL.
+// Must be called with the lock held.
+func (s *tailSampler) sweep(now time.Time) {
+	if now.Sub(s.swept) < s.opts.TTL {
+		return
+	}
+	s.swept = now
+	for id, b := range s.buffers {
+		if now.Sub(b.seen) >= s.opts.TTL {
+			delete(s.buffers, id)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTailSamplingHandler(t *testing.T) {
+	var buf strings.Builder
+	h := NewTailSamplingHandler(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	log := NewLogger(Options{Handler: h})
+
+	ok := log.With(RequestIDKey, "ok")
+	failed := log.With(RequestIDKey, "failed")
+	ok.Debug("ok debug")
+	ok.Info("ok info")
+	failed.Debug("failed debug")
+	failed.WithGroup("db").Info("failed info", "table", "orders")
+	log.Debug("no request debug")
+	log.Info("no request info")
+	if got := buf.String(); strings.Count(got, "\n") != 1 || !strings.Contains(got, "no request info") {
+		t.Fatalf("Expected only the record without request, got %s", got)
+	}
+
+	buf.Reset()
+	failed.Error("failed")
+	failed.Debug("failed after")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	want := []string{"msg=\"failed debug\"", "msg=\"failed info\" request_id=failed db.table=orders", "msg=failed", "msg=\"failed after\""}
+	if len(lines) != len(want) {
+		t.Fatalf("Expected %d records, got %v", len(want), lines)
+	}
+	for i, w := range want {
+		if !strings.Contains(lines[i], w) {
+			t.Errorf("Expected record %d to contain %s, got %s", i, w, lines[i])
+		}
+	}
+	if strings.Contains(buf.String(), "ok") {
+		t.Errorf("Expected the records of the other request to stay buffered, got %s", buf.String())
+	}
+}
+
+func TestTailSamplingHandler_RecordKey(t *testing.T) {
+	var buf strings.Builder
+	h := NewTailSamplingHandler(slog.NewTextHandler(&buf, nil), TailSamplingOptions{Key: "trace", Level: "INFO", MaxRecords: 2})
+	log := slog.New(h)
+
+	log.Debug("below level", "trace", "a")
+	for _, msg := range []string{"first", "second", "third"} {
+		log.Info(msg, "trace", "a")
+	}
+	log.Warn("other", "trace", "b")
+	if buf.Len() > 0 {
+		t.Fatalf("Expected the records to be buffered, got %s", buf.String())
+	}
+
+	log.Error("boom", "trace", "a")
+	got := buf.String()
+	for _, want := range []string{"msg=second", "msg=third", "msg=boom"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %s, got %s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"below level", "msg=first", "msg=other"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("Expected no %s, got %s", unwanted, got)
+		}
+	}
+}
+
+func TestTailSamplingHandler_TTL(t *testing.T) {
+	var buf strings.Builder
+	h := NewTailSamplingHandler(slog.NewTextHandler(&buf, nil), TailSamplingOptions{TTL: 20 * time.Millisecond})
+	log := slog.New(h).With(RequestIDKey, "1")
+
+	log.Info("expired")
+	time.Sleep(50 * time.Millisecond)
+	log.InfoContext(context.Background(), "kept")
+	log.Error("boom")
+	if got := buf.String(); strings.Contains(got, "expired") || !strings.Contains(got, "kept") {
+		t.Errorf("Expected the expired buffer to be discarded, got %s", got)
+	}
+}
@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+// MessageTemplateKey is the key used for the un-interpolated template of a record logged with a message template.
+// Backends can group records by the template, which is the same for all records of a log statement.
+const MessageTemplateKey = "msg_template"
+
+// TraceT logs at [LevelTrace] with a message template, see [Provider.InfoT].
+func (l *logger) TraceT(ctx context.Context, template string, args ...any) {
+	l.logAttrs(ctx, LevelTrace, renderTemplate(template, args), templateArgs(template, args)...)
+}
+
+// DebugT logs at [LevelDebug] with a message template, see [Provider.InfoT].
+func (l *logger) DebugT(ctx context.Context, template string, args ...any) {
+	l.logAttrs(ctx, LevelDebug, renderTemplate(template, args), templateArgs(template, args)...)
+}
+
+// InfoT logs at [LevelInfo] with a message template.
+// The placeholders of the template, e.g. {user_id}, are replaced by the values of the attributes with the same key.
+// The template itself is added under [MessageTemplateKey].
+func (l *logger) InfoT(ctx context.Context, template string, args ...any) {
+	l.logAttrs(ctx, LevelInfo, renderTemplate(template, args), templateArgs(template, args)...)
+}
+
+// NoticeT logs at [LevelNotice] with a message template, see [Provider.InfoT].
+func (l *logger) NoticeT(ctx context.Context, template string, args ...any) {
+	l.logAttrs(ctx, LevelNotice, renderTemplate(template, args), templateArgs(template, args)...)
+}
+
+// WarnT logs at [LevelWarn] with a message template, see [Provider.InfoT].
+func (l *logger) WarnT(ctx context.Context, template string, args ...any) {
+	l.logAttrs(ctx, LevelWarn, renderTemplate(template, args), templateArgs(template, args)...)
+}
+
+// ErrorT logs at [LevelError] with a message template, see [Provider.InfoT].
+func (l *logger) ErrorT(ctx context.Context, template string, args ...any) {
+	l.logAttrs(ctx, LevelError, renderTemplate(template, args), templateArgs(template, args)...)
+}
+
+// templateArgs returns the arguments followed by the template under [MessageTemplateKey].
+func templateArgs(template string, args []any) []any {
+	return append(slices.Clip(args), MessageTemplateKey, template)
+}
+
+// renderTemplate replaces the placeholders of the template by the values of the top-level attributes with the same key.
+// Placeholders without a matching attribute are kept, "{{" and "}}" are written as literal braces.
+func renderTemplate(template string, args []any) string {
+	if !strings.ContainsAny(template, "{}") {
+		return template
+	}
+
+	values := make(map[string]slog.Value, len(args)/2)
+	for _, a := range argsToAttrs(args) {
+		values[a.Key] = a.Value
+	}
+
+	var b strings.Builder
+	b.Grow(len(template))
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		switch {
+		case (c == '{' || c == '}') && i+1 < len(template) && template[i+1] == c:
+			b.WriteByte(c)
+			i++
+		case c == '{':
+			end := strings.IndexAny(template[i+1:], "{}")
+			if end < 0 || template[i+1+end] != '}' {
+				b.WriteByte(c)
+				continue
+			}
+			name := template[i+1 : i+1+end]
+			v, ok := values[name]
+			if !ok {
+				b.WriteString(template[i : i+end+2])
+			} else {
+				b.WriteString(v.Resolve().String())
+			}
+			i += end + 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,124 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		args     []any
+		want     string
+	}{
+		{
+			name:     "no placeholders",
+			template: "user purchased",
+			args:     []any{"user_id", 42},
+			want:     "user purchased",
+		},
+		{
+			name:     "placeholders",
+			template: "user {user_id} purchased {sku}",
+			args:     []any{"user_id", 42, slog.String("sku", "A-1")},
+			want:     "user 42 purchased A-1",
+		},
+		{
+			name:     "missing attribute",
+			template: "user {user_id} purchased {sku}",
+			args:     []any{"user_id", 42},
+			want:     "user 42 purchased {sku}",
+		},
+		{
+			name:     "escaped braces",
+			template: "{{user_id}} is {user_id}}}",
+			args:     []any{"user_id", 42},
+			want:     "{user_id} is 42}",
+		},
+		{
+			name:     "unclosed placeholder",
+			template: "user {user_id purchased {sku}",
+			args:     []any{"sku", "A-1"},
+			want:     "user {user_id purchased A-1",
+		},
+		{
+			name:     "log valuer",
+			template: "count is {count}",
+			args:     []any{"count", counter{n: new(int)}},
+			want:     "count is 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderTemplate(tt.template, tt.args); got != tt.want {
+				t.Errorf("renderTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogger_MessageTemplates(t *testing.T) {
+	tests := []struct {
+		name      string
+		log       func(l Provider)
+		wantLevel string
+	}{
+		{name: "trace", log: func(l Provider) {
+			l.TraceT(context.Background(), "user {user_id} purchased {sku}", "user_id", 42, "sku", "A-1")
+		}, wantLevel: "TRACE"},
+		{name: "debug", log: func(l Provider) {
+			l.DebugT(context.Background(), "user {user_id} purchased {sku}", "user_id", 42, "sku", "A-1")
+		}, wantLevel: "DEBUG"},
+		{name: "info", log: func(l Provider) {
+			l.InfoT(context.Background(), "user {user_id} purchased {sku}", "user_id", 42, "sku", "A-1")
+		}, wantLevel: "INFO"},
+		{name: "notice", log: func(l Provider) {
+			l.NoticeT(context.Background(), "user {user_id} purchased {sku}", "user_id", 42, "sku", "A-1")
+		}, wantLevel: "NOTICE"},
+		{name: "warn", log: func(l Provider) {
+			l.WarnT(context.Background(), "user {user_id} purchased {sku}", "user_id", 42, "sku", "A-1")
+		}, wantLevel: "WARN"},
+		{name: "error", log: func(l Provider) {
+			l.ErrorT(context.Background(), "user {user_id} purchased {sku}", "user_id", 42, "sku", "A-1")
+		}, wantLevel: "ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			l := NewLogger(Options{Handler: slog.NewJSONHandler(buf, &slog.HandlerOptions{
+				AddSource:   true,
+				Level:       slog.Level(LevelTrace),
+				ReplaceAttr: replaceAttr,
+			})})
+			tt.log(l)
+
+			var got map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("Failed to parse record: %v", err)
+			}
+			want := map[string]any{
+				slog.LevelKey:      tt.wantLevel,
+				slog.MessageKey:    "user 42 purchased A-1",
+				MessageTemplateKey: "user {user_id} purchased {sku}",
+				"user_id":          float64(42),
+				"sku":              "A-1",
+			}
+			for k, v := range want {
+				if got[k] != v {
+					t.Errorf("Expected %s to be %v, got %v", k, v, got[k])
+				}
+			}
+			source, _ := got[slog.SourceKey].(map[string]any)
+			if file, _ := source["file"].(string); !strings.HasSuffix(file, "template_test.go") {
+				t.Errorf("Expected source to be the caller, got %v", source)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// TB is the subset of [testing.TB] used to write records through a test.
+// It is implemented by [testing.T], [testing.B] and [testing.F].
+type TB interface {
+	Helper()
+	Log(args ...any)
+	Error(args ...any)
+	Cleanup(f func())
+}
+
+// NewTestHandler returns a new [slog.Handler] writing every record of all levels as a line of text through [TB.Log],
+// so the output is attributed to the test and only shown if it fails or runs verbosely.
+// Records with level [LevelError] or above are written with [TB.Error] and fail the test.
+//
+// The handler is safe to use from parallel tests and goroutines. Records handled after the test finished
+// are dropped, since the testing package panics on output of completed tests.
+func NewTestHandler(tb TB) slog.Handler {
+	tb.Helper()
+	return newTestHandler(tb)
+}
+
+// newTestHandler returns a new [testHandler] writing the records of all levels through the test.
+func newTestHandler(tb TB) *testHandler {
+	tb.Helper()
+	state := &testState{}
+	tb.Cleanup(func() { state.done.Store(true) })
+
+	h := &testHandler{tb: tb, state: state}
+	h.handler = slog.NewTextHandler(&state.buf, &slog.HandlerOptions{
+		AddSource:   true,
+		Level:       slog.Level(LevelTrace),
+		ReplaceAttr: replaceTestAttr,
+	})
+	return h
+}
+
+// testState is the state shared by a test handler and the handlers derived from it.
+type testState struct {
+	// mu guards buf.
+	mu   sync.Mutex
+	buf  bytes.Buffer
+	done atomic.Bool
+}
+
+var _ slog.Handler = (*testHandler)(nil)
+
+// testHandler is a [slog.Handler] writing records through [TB].
+type testHandler struct {
+	tb      TB
+	state   *testState
+	handler slog.Handler
+}
+
+// Enabled reports whether the test is still running.
+func (h *testHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return !h.state.done.Load() && h.handler.Enabled(ctx, level)
+}
+
+// Handle formats the record and writes it through the test.
+func (h *testHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	h.state.mu.Lock()
+	h.state.buf.Reset()
+	err := h.handler.Handle(ctx, r)
+	line := strings.TrimSuffix(h.state.buf.String(), "\n")
+	h.state.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if h.state.done.Load() {
+		return nil
+	}
+	if r.Level >= slog.Level(LevelError) {
+		h.tb.Error(line)
+		return nil
+	}
+	h.tb.Log(line)
+	return nil
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *testHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &testHandler{tb: h.tb, state: h.state, handler: h.handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *testHandler) WithGroup(name string) slog.Handler {
+	return &testHandler{tb: h.tb, state: h.state, handler: h.handler.WithGroup(name)}
+}
+
+// replaceTestAttr drops the time, since the test output is ordered anyway,
+// and shortens the source to the file name and line. The source of records without a caller is dropped.
+func replaceTestAttr(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return a
+	}
+	switch a.Key {
+	case slog.TimeKey:
+		return slog.Attr{}
+	case slog.SourceKey:
+		if src, ok := a.Value.Any().(*slog.Source); ok {
+			if src.File == "" {
+				return slog.Attr{}
+			}
+			a.Value = slog.StringValue(filepath.Base(src.File) + ":" + strconv.Itoa(src.Line))
+		}
+		return a
+	}
+	return replaceAttr(groups, a)
+}
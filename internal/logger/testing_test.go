@@ -0,0 +1,132 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingTB is a [TB] recording the output instead of writing it.
+type recordingTB struct {
+	mu       sync.Mutex
+	logs     []string
+	errors   []string
+	cleanups []func()
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Log(args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logs = append(r.logs, fmt.Sprint(args...))
+}
+
+func (r *recordingTB) Error(args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, fmt.Sprint(args...))
+}
+
+func (r *recordingTB) Cleanup(f func()) {
+	r.cleanups = append(r.cleanups, f)
+}
+
+func TestNewTestHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		log        func(l Provider)
+		wantLogs   []string
+		wantErrors []string
+	}{
+		{
+			name: "info through log",
+			log: func(l Provider) {
+				l.With("key", "value").Info("test")
+			},
+			wantLogs: []string{"level=INFO", "msg=test", "key=value", "source=testing_test.go:"},
+		},
+		{
+			name: "trace is enabled",
+			log: func(l Provider) {
+				l.Trace("test")
+			},
+			wantLogs: []string{"level=TRACE", "msg=test"},
+		},
+		{
+			name: "error through error",
+			log: func(l Provider) {
+				l.WithGroup("db").Error("connection refused", "host", "localhost")
+			},
+			wantErrors: []string{"level=ERROR", "msg=\"connection refused\"", "db.host=localhost"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &recordingTB{}
+			tt.log(NewLogger(Options{Handler: NewTestHandler(tb)}))
+
+			assertOutput(t, "log", tb.logs, tt.wantLogs)
+			assertOutput(t, "error", tb.errors, tt.wantErrors)
+		})
+	}
+}
+
+func TestNewTestHandler_AfterCleanup(t *testing.T) {
+	tb := &recordingTB{}
+	l := NewLogger(Options{Handler: NewTestHandler(tb)})
+	for _, f := range tb.cleanups {
+		f()
+	}
+
+	l.Info("test")
+	l.Error("test")
+	if len(tb.logs) != 0 || len(tb.errors) != 0 {
+		t.Errorf("Expected no output after the test finished, got %v and %v", tb.logs, tb.errors)
+	}
+}
+
+func TestNewTestHandler_Parallel(t *testing.T) {
+	tb := &recordingTB{}
+	l := NewLogger(Options{Handler: NewTestHandler(tb)})
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.Info("test", "n", i)
+		}()
+	}
+	wg.Wait()
+
+	if len(tb.logs) != 10 {
+		t.Fatalf("Expected 10 lines, got %d", len(tb.logs))
+	}
+	for _, line := range tb.logs {
+		if strings.Count(line, "msg=test") != 1 {
+			t.Errorf("Expected a single record per line, got %q", line)
+		}
+	}
+}
+
+// assertOutput checks that there is exactly one line if want is set, containing all of want.
+func assertOutput(t *testing.T, kind string, lines, want []string) {
+	t.Helper()
+	if len(want) == 0 {
+		if len(lines) != 0 {
+			t.Errorf("Expected no %s output, got %v", kind, lines)
+		}
+		return
+	}
+	if len(lines) != 1 {
+		t.Fatalf("Expected 1 %s line, got %v", kind, lines)
+	}
+	for _, w := range want {
+		if !strings.Contains(lines[0], w) {
+			t.Errorf("Expected %s line %q to contain %q", kind, lines[0], w)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// isTextFormat reports whether the given format is the text format.
+func isTextFormat(format string) bool {
+	return strings.EqualFold(format, "TEXT")
+}
+
+var _ slog.Handler = (*inlineHandler)(nil)
+
+// inlineHandler is a [slog.Handler] that inlines the attributes of groups with an empty key,
+// as done by the handlers of the standard library.
+// The text handler drops such groups otherwise.
+type inlineHandler struct {
+	slog.Handler
+}
+
+// newInlineHandler returns a new [slog.Handler] that inlines groups with an empty key.
+func newInlineHandler(h slog.Handler) slog.Handler {
+	return &inlineHandler{Handler: h}
+}
+
+// Handle inlines the groups with an empty key and passes the record to the wrapped handler.
+func (h *inlineHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	inline := false
+	r.Attrs(func(a slog.Attr) bool {
+		inline = a.Key == "" && a.Value.Kind() == slog.KindGroup
+		return !inline
+	})
+	if !inline {
+		return h.Handler.Handle(ctx, r)
+	}
+
+	nr := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		nr.AddAttrs(inlineAttrs([]slog.Attr{a})...)
+		return true
+	})
+	return h.Handler.Handle(ctx, nr)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *inlineHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &inlineHandler{Handler: h.Handler.WithAttrs(inlineAttrs(attrs))}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *inlineHandler) WithGroup(name string) slog.Handler {
+	return &inlineHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// inlineAttrs returns the attributes with the attributes of groups with an empty key inlined.
+func inlineAttrs(attrs []slog.Attr) []slog.Attr {
+	inlined := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		if a.Key == "" && a.Value.Kind() == slog.KindGroup {
+			inlined = append(inlined, inlineAttrs(a.Value.Group())...)
+			continue
+		}
+		inlined = append(inlined, a)
+	}
+	return inlined
+}
+
+// TruncatedKey is the key used for the hint on omitted attributes, e.g. of text records truncated to the terminal width
+// or of the headers omitted by [Headers].
+const TruncatedKey = "…"
+
+// minTruncateWidth is the minimum terminal width below which records are not truncated.
+const minTruncateWidth = 40
+
+var _ slog.Handler = (*truncateHandler)(nil)
+
+// truncateHandler is a [slog.Handler] that drops the attributes of records exceeding the width of the terminal,
+// so interactive terminals stay readable. The dropped attributes are replaced by a hint under [TruncatedKey].
+type truncateHandler struct {
+	slog.Handler
+	// width is the width of the terminal in columns.
+	width int
+	// timeWidth is the estimated width of the times written with the layout of the handler.
+	timeWidth int
+	// prefix is the dotted path of the groups of the handler.
+	prefix string
+	// used is the estimated width of the attributes added by WithAttrs.
+	used int
+}
+
+// newTruncateHandler returns a new [slog.Handler] that truncates the attributes of records to the given width.
+// The times of the records are expected to be written with the given layout.
+// Returns the given handler if the width is too small to be meaningful, e.g. if no terminal was detected.
+func newTruncateHandler(h slog.Handler, width int, layout string) slog.Handler {
+	if width < minTruncateWidth {
+		return h
+	}
+	return &truncateHandler{Handler: h, width: width, timeWidth: layoutWidth(layout)}
+}
+
+// layoutWidth returns the estimated width of the times written with the layout.
+// The time of the estimate has the longest month and weekday names and two digits wherever possible.
+func layoutWidth(layout string) int {
+	return utf8.RuneCountInString(time.Date(2006, time.September, 27, 22, 59, 59, 999999999, time.UTC).Format(layout))
+}
+
+// Handle drops the attributes exceeding the width and passes the record to the wrapped handler.
+// The [RecordIDKey] is always kept, so the full record can be found in the JSON output.
+func (h *truncateHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	budget := h.width - h.used - textPrefixWidth(r, h.timeWidth)
+	if budget-attrsWidth(h.prefix, r) >= 0 {
+		return h.Handler.Handle(ctx, r)
+	}
+
+	out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	var id *slog.Attr
+	dropped := 0
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == RecordIDKey {
+			id = &a
+			budget -= attrWidth(h.prefix, a)
+		}
+		return true
+	})
+	// Space is reserved for the hint, so it fits as well.
+	budget -= len(TruncatedKey) + len(`="+00 attrs"`) + 1
+	full := false
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == RecordIDKey {
+			return true
+		}
+		w := attrWidth(h.prefix, a)
+		if full || w > budget {
+			full = true
+			dropped += countAttrs(a)
+			return true
+		}
+		budget -= w
+		out.AddAttrs(a)
+		return true
+	})
+	if id != nil {
+		out.AddAttrs(*id)
+	}
+	out.AddAttrs(slog.String(TruncatedKey, fmt.Sprintf("+%d attrs", dropped)))
+	return h.Handler.Handle(ctx, out)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *truncateHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	used := h.used
+	for _, a := range attrs {
+		used += attrWidth(h.prefix, a)
+	}
+	c := *h
+	c.Handler, c.used = h.Handler.WithAttrs(attrs), used
+	return &c
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *truncateHandler) WithGroup(name string) slog.Handler {
+	c := *h
+	c.Handler, c.prefix = h.Handler.WithGroup(name), h.prefix+name+"."
+	return &c
+}
+
+// textPrefixWidth returns the estimated width of the time, level, caller and message of a text record
+// whose time is written with the given width.
+func textPrefixWidth(r slog.Record, timeWidth int) int { //nolint:gocritic // records are passed by value
+	const levelWidth = 4
+	width := timeWidth + 1 + levelWidth + 1 + utf8.RuneCountInString(r.Message)
+	if r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		// The caller is written as <dir/file:line>.
+		caller := filepath.Join(filepath.Base(filepath.Dir(frame.File)), filepath.Base(frame.File))
+		width += len(caller) + len(strconv.Itoa(frame.Line)) + len("<:> ")
+	}
+	return width
+}
+
+// attrsWidth returns the estimated width of the attributes of the record.
+func attrsWidth(prefix string, r slog.Record) int { //nolint:gocritic // records are passed by value
+	width := 0
+	r.Attrs(func(a slog.Attr) bool {
+		width += attrWidth(prefix, a)
+		return true
+	})
+	return width
+}
+
+// attrWidth returns the estimated width of the attribute written as key=value with a leading space,
+// where the key is prefixed by the dotted path of its groups.
+func attrWidth(prefix string, a slog.Attr) int {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() != slog.KindGroup {
+		return 1 + utf8.RuneCountInString(prefix+a.Key) + 1 + utf8.RuneCountInString(a.Value.String())
+	}
+	if a.Key != "" {
+		prefix += a.Key + "."
+	}
+	width := 0
+	for _, ga := range a.Value.Group() {
+		width += attrWidth(prefix, ga)
+	}
+	return width
+}
+
+// countAttrs returns the number of attributes written for the attribute, i.e. the attributes of groups are counted.
+func countAttrs(a slog.Attr) int {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() != slog.KindGroup {
+		return 1
+	}
+	n := 0
+	for _, ga := range a.Value.Group() {
+		n += countAttrs(ga)
+	}
+	return n
+}
@@ -0,0 +1,85 @@
+//go:build !js && !wasip1
+
+package logger
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	clog "github.com/charmbracelet/log"
+)
+
+// newTextHandler returns the colored [slog.Handler] of the text format writing to stderr.
+// The times are written with the given layout, [time.Kitchen] if empty, in the given location, the local one if nil.
+// The output is colored if stderr is a terminal, unless NO_COLOR, FORCE_COLOR, CLICOLOR_FORCE or CLICOLOR say otherwise.
+// If stderr is a terminal, the attributes of records exceeding its width are truncated.
+func newTextHandler(level Level, layout string, loc *time.Location) slog.Handler {
+	return newTextWriterHandler(os.Stderr, level, layout, loc, true)
+}
+
+// newTextWriterHandler returns the [slog.Handler] of the text format writing to w, see [newTextHandler].
+// The output is only colored and truncated if w is a terminal. The caller is only reported if source is set.
+func newTextWriterHandler(w io.Writer, level Level, layout string, loc *time.Location, source bool) slog.Handler {
+	if layout == "" {
+		layout = time.Kitchen
+	}
+	var timeFunc clog.TimeFunction
+	if loc != nil {
+		timeFunc = func(t time.Time) time.Time { return t.In(loc) }
+	}
+	log := clog.NewWithOptions(w, clog.Options{
+		TimeFormat:      layout,
+		TimeFunction:    timeFunc,
+		Level:           clog.Level(level),
+		ReportTimestamp: true,
+		ReportCaller:    source,
+	})
+	log.SetStyles(newCustomStyles())
+	if profile, ok := colorProfile(w); ok {
+		log.SetColorProfile(profile)
+	}
+	if f, ok := w.(*os.File); ok {
+		return newTruncateHandler(log, textWidth(f), layout)
+	}
+	return log
+}
+
+// textWidth returns the width of the text output in columns or 0 if it is not truncated.
+// The LOG_TEXT_WIDTH environment variable takes precedence over the width of the terminal,
+// "0" disables the truncation.
+func textWidth(f *os.File) int {
+	if env, ok := os.LookupEnv("LOG_TEXT_WIDTH"); ok {
+		width, err := strconv.Atoi(strings.TrimSpace(env))
+		if err != nil {
+			return 0
+		}
+		return width
+	}
+	return terminalWidth(f)
+}
+
+// newCustomStyles returns the custom styles for the text logger.
+func newCustomStyles() *clog.Styles {
+	styles := clog.DefaultStyles()
+
+	const maxWidth = 4
+	levelsMu.RLock()
+	defer levelsMu.RUnlock()
+	for level, name := range levelNames {
+		style := lipgloss.NewStyle().
+			SetString(name).
+			Bold(true).
+			MaxWidth(maxWidth)
+		if color, ok := LevelColors[level]; ok {
+			style = style.Foreground(lipgloss.Color(color))
+		}
+		styles.Levels[clog.Level(int(level))] = style
+	}
+
+	return styles
+}
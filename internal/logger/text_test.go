@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+)
+
+func TestTruncateHandler(t *testing.T) {
+	long := strings.Repeat("x", 90)
+	tests := []struct {
+		name   string
+		width  int
+		layout string
+		attrs  []slog.Attr
+		want   []string
+	}{
+		{
+			name:  "fits",
+			width: 200,
+			attrs: []slog.Attr{slog.String("a", "1"), slog.String("b", "2")},
+			want:  []string{"a=1", "b=2"},
+		},
+		{
+			name:  "truncated",
+			width: 100,
+			attrs: []slog.Attr{slog.String("a", "1"), slog.String("b", long), slog.String("c", "3")},
+			want:  []string{"a=1", TruncatedKey + "=+2 attrs"},
+		},
+		{
+			name:  "groups are counted by their attributes",
+			width: 100,
+			attrs: []slog.Attr{slog.String("a", "1"), slog.Group("g", "b", long, "c", "3")},
+			want:  []string{"a=1", TruncatedKey + "=+2 attrs"},
+		},
+		{
+			name:  "record id is kept",
+			width: 100,
+			attrs: []slog.Attr{slog.String("a", long), slog.String(RecordIDKey, "id")},
+			want:  []string{RecordIDKey + "=id", TruncatedKey + "=+1 attrs"},
+		},
+		{
+			name:  "short time layout",
+			width: 100,
+			attrs: []slog.Attr{slog.String("a", "1"), slog.String("b", long[:60])},
+			want:  []string{"a=1", "b=" + long[:60]},
+		},
+		{
+			name:   "long time layout",
+			width:  100,
+			layout: time.RFC3339Nano,
+			attrs:  []slog.Attr{slog.String("a", "1"), slog.String("b", long[:60])},
+			want:   []string{"a=1", TruncatedKey + "=+1 attrs"},
+		},
+		{
+			name:  "too narrow",
+			width: 10,
+			attrs: []slog.Attr{slog.String("a", long), slog.String("b", long)},
+			want:  []string{"a=" + long, "b=" + long},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.layout == "" {
+				tt.layout = time.Kitchen
+			}
+			var got []string
+			h := newTruncateHandler(test.MockHandler{
+				HandleFunc: func(_ context.Context, r slog.Record) error {
+					r.Attrs(func(a slog.Attr) bool {
+						got = append(got, a.String())
+						return true
+					})
+					return nil
+				},
+			}, tt.width, tt.layout)
+
+			r := slog.NewRecord(time.Now(), slog.LevelInfo, "message", 0)
+			r.AddAttrs(tt.attrs...)
+			if err := h.Handle(context.Background(), r); err != nil {
+				t.Fatalf("Handle() error = %v", err)
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("attrs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateHandler_WithAttrs(t *testing.T) {
+	var got int
+	base := test.MockHandler{
+		HandleFunc: func(_ context.Context, r slog.Record) error {
+			got = r.NumAttrs()
+			return nil
+		},
+	}
+	h := newTruncateHandler(base, 80, time.Kitchen).WithAttrs([]slog.Attr{slog.String("a", strings.Repeat("x", 50))})
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "message", 0)
+	r.AddAttrs(slog.String("b", strings.Repeat("y", 20)))
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	// The attribute of the logger leaves no space for the attribute of the record, which is replaced by the hint.
+	if got != 1 {
+		t.Errorf("NumAttrs() = %d, want 1", got)
+	}
+}
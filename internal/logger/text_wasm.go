@@ -0,0 +1,22 @@
+//go:build js || wasip1
+
+package logger
+
+import (
+	"io"
+	"log/slog"
+	"time"
+)
+
+// newTextHandler returns the [slog.Handler] of the text format writing to the console.
+// There is no terminal to detect colors for on WASM platforms, so the output is not colored.
+// The time layout and location are not supported by the console handler and ignored.
+func newTextHandler(level Level, _ string, _ *time.Location) slog.Handler {
+	return NewConsoleHandler(level)
+}
+
+// newTextWriterHandler returns the [slog.Handler] of the text format writing to w.
+// The console handler only writes to the console, so the text handler of the standard library is used instead.
+func newTextWriterHandler(w io.Writer, level Level, _ string, _ *time.Location, source bool) slog.Handler {
+	return slog.NewTextHandler(w, &slog.HandlerOptions{AddSource: source, Level: slog.Level(level), ReplaceAttr: replaceAttr})
+}
@@ -0,0 +1,10 @@
+//go:build !unix && !windows
+
+package logger
+
+import "os"
+
+// terminalWidth returns 0, since terminals are not detected on this platform.
+func terminalWidth(_ *os.File) int {
+	return 0
+}
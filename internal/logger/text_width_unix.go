@@ -0,0 +1,18 @@
+//go:build unix
+
+package logger
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// terminalWidth returns the width of the terminal in columns or 0 if the file is not a terminal.
+func terminalWidth(f *os.File) int {
+	ws, err := unix.IoctlGetWinsize(int(f.Fd()), unix.TIOCGWINSZ) //nolint:gosec // file descriptors fit into an int
+	if err != nil {
+		return 0
+	}
+	return int(ws.Col)
+}
@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+)
+
+// timeSettings returns the layout and the location of the times of the records of the options.
+// The LOG_TIME_FORMAT and LOG_UTC environment variables take precedence, a false LOG_UTC selects the local time zone.
+func timeSettings(o Options) (layout string, loc *time.Location) {
+	layout, loc = o.TimeFormat, o.TimeZone
+	if env, ok := os.LookupEnv("LOG_TIME_FORMAT"); ok {
+		layout = env
+	}
+	if env, ok := os.LookupEnv("LOG_UTC"); ok {
+		loc = nil
+		if utc, err := strconv.ParseBool(env); err == nil && utc {
+			loc = time.UTC
+		}
+	}
+	return layout, loc
+}
+
+// timeReplaceAttr returns the replacement function for slog.HandlerOptions that formats the time of a record
+// with the given layout in the given location. An empty layout keeps the default format of the handler,
+// a nil location keeps the local time zone. Returns next if there is nothing to change.
+func timeReplaceAttr(layout string, loc *time.Location, next func([]string, slog.Attr) slog.Attr) func([]string, slog.Attr) slog.Attr {
+	if layout == "" && loc == nil {
+		return next
+	}
+	return func(groups []string, a slog.Attr) slog.Attr {
+		a = next(groups, a)
+		if len(groups) > 0 || a.Key != slog.TimeKey || a.Value.Kind() != slog.KindTime {
+			return a
+		}
+		t := a.Value.Time()
+		if loc != nil {
+			t = t.In(loc)
+		}
+		if layout != "" {
+			a.Value = slog.StringValue(t.Format(layout))
+			return a
+		}
+		a.Value = slog.TimeValue(t)
+		return a
+	}
+}
@@ -0,0 +1,106 @@
+package logger
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestTimeReplaceAttr(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+	tests := []struct {
+		name   string
+		layout string
+		loc    *time.Location
+		groups []string
+		attr   slog.Attr
+		want   string
+	}{
+		{
+			name:   "layout",
+			layout: time.DateTime,
+			attr:   slog.Time(slog.TimeKey, now),
+			want:   "2024-05-01 12:30:00",
+		},
+		{
+			name:   "layout and location",
+			layout: time.RFC3339,
+			loc:    time.UTC,
+			attr:   slog.Time(slog.TimeKey, now),
+			want:   "2024-05-01T10:30:00Z",
+		},
+		{
+			name: "location only",
+			loc:  time.UTC,
+			attr: slog.Time(slog.TimeKey, now),
+			want: "2024-05-01 10:30:00 +0000 UTC",
+		},
+		{
+			name:   "nested time is kept",
+			layout: time.DateTime,
+			groups: []string{"group"},
+			attr:   slog.Time(slog.TimeKey, now),
+			want:   "2024-05-01 12:30:00 +0200 CEST",
+		},
+		{
+			name:   "other keys are kept",
+			layout: time.DateTime,
+			attr:   slog.Time("started", now),
+			want:   "2024-05-01 12:30:00 +0200 CEST",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := timeReplaceAttr(tt.layout, tt.loc, replaceAttr)(tt.groups, tt.attr)
+			if got.Value.String() != tt.want {
+				t.Errorf("timeReplaceAttr() = %q, want %q", got.Value.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       Options
+		env        map[string]string
+		wantLayout string
+		wantLoc    *time.Location
+	}{
+		{
+			name:       "options",
+			opts:       Options{TimeFormat: time.RFC3339, TimeZone: time.UTC},
+			wantLayout: time.RFC3339,
+			wantLoc:    time.UTC,
+		},
+		{
+			name:       "env takes precedence",
+			opts:       Options{TimeFormat: time.RFC3339},
+			env:        map[string]string{"LOG_TIME_FORMAT": time.DateTime, "LOG_UTC": "true"},
+			wantLayout: time.DateTime,
+			wantLoc:    time.UTC,
+		},
+		{
+			name:    "false utc selects the local time zone",
+			opts:    Options{TimeZone: time.UTC},
+			env:     map[string]string{"LOG_UTC": "false"},
+			wantLoc: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			layout, loc := timeSettings(tt.opts)
+			if layout != tt.wantLayout {
+				t.Errorf("layout = %q, want %q", layout, tt.wantLayout)
+			}
+			if loc != tt.wantLoc {
+				t.Errorf("loc = %v, want %v", loc, tt.wantLoc)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrWriteTimeout is returned by a [TimeoutHandler] if a record was not handled in time.
+	ErrWriteTimeout = errors.New("handler write timed out")
+	// ErrHandlerBusy is returned by a [TimeoutHandler] for records dropped because all of its workers are busy.
+	ErrHandlerBusy = errors.New("handler busy, record dropped")
+)
+
+// maxTimeoutWorkers is the maximum number of records a [TimeoutHandler] passes to the wrapped handler concurrently.
+const maxTimeoutWorkers = 64
+
+var (
+	_ slog.Handler    = (*TimeoutHandler)(nil)
+	_ MetricsProvider = (*TimeoutHandler)(nil)
+)
+
+// TimeoutHandler is a [slog.Handler] that limits the time the wrapped handler may take to handle a record.
+type TimeoutHandler struct {
+	slog.Handler
+	timeout  time.Duration
+	fallback slog.Handler
+	state    *timeoutState
+}
+
+// timeoutState is the state shared by a [TimeoutHandler] and the handlers derived from it.
+type timeoutState struct {
+	// workers is the semaphore of the records being handled by the wrapped handler.
+	workers  chan struct{}
+	timeouts atomic.Uint64
+	dropped  atomic.Uint64
+}
+
+// NewTimeoutHandler returns a new [TimeoutHandler] that passes every record to the given handler with a context
+// whose deadline is the given timeout, so a hung sink (e.g. a stalled network connection) cannot block the caller indefinitely.
+//
+// If the handler does not return in time or returns a deadline error, [ErrWriteTimeout] is returned and the record
+// is passed to the fallback handler, if one is provided. Handlers ignoring the context keep running in the background
+// until they return, so such a record may be written by both handlers, like a record re-sent by a [FailoverHandler],
+// but it is never lost. At most 64 records are handled in the background at once. If all workers are busy,
+// the record is dropped with [ErrHandlerBusy] and passed to the fallback handler as well.
+// Records are passed to the given handler as is if the timeout is not positive.
+func NewTimeoutHandler(h slog.Handler, timeout time.Duration, fallback slog.Handler) *TimeoutHandler {
+	return &TimeoutHandler{
+		Handler:  h,
+		timeout:  timeout,
+		fallback: fallback,
+		state:    &timeoutState{workers: make(chan struct{}, maxTimeoutWorkers)},
+	}
+}
+
+// Metrics returns the number of records currently handled in the background,
+// of records that timed out and of records dropped because all workers were busy.
+func (h *TimeoutHandler) Metrics(_ context.Context) []slog.Attr {
+	return []slog.Attr{
+		slog.Int("running", len(h.state.workers)),
+		slog.Uint64("timeouts", h.state.timeouts.Load()),
+		slog.Uint64("dropped", h.state.dropped.Load()),
+	}
+}
+
+// Handle passes the record to the wrapped handler and returns [ErrWriteTimeout] if it does not return in time.
+// Records that timed out or were dropped are passed to the fallback handler.
+func (h *TimeoutHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	if h.timeout <= 0 {
+		return h.Handler.Handle(ctx, r)
+	}
+
+	select {
+	case h.state.workers <- struct{}{}:
+	default:
+		h.state.dropped.Add(1)
+		return h.fallBack(ctx, r, ErrHandlerBusy)
+	}
+
+	// The handler may keep running after Handle returned, so the context and the record are snapshotted.
+	wctx, cancel := context.WithTimeout(snapshotContext(ctx), h.timeout)
+	done := make(chan error, 1)
+	rec := snapshotRecord(r)
+	go func() {
+		defer func() { <-h.state.workers }()
+		defer cancel()
+		done <- h.Handler.Handle(wctx, rec)
+	}()
+
+	select {
+	case err := <-done:
+		if errors.Is(err, context.DeadlineExceeded) {
+			h.state.timeouts.Add(1)
+			return h.fallBack(ctx, r, errors.Join(ErrWriteTimeout, err))
+		}
+		return err
+	case <-wctx.Done():
+		h.state.timeouts.Add(1)
+		return h.fallBack(ctx, r, ErrWriteTimeout)
+	}
+}
+
+// fallBack passes the record to the fallback handler, if one is provided and enabled, and returns err joined with its error.
+func (h *TimeoutHandler) fallBack(ctx context.Context, r slog.Record, err error) error { //nolint:gocritic // records are passed by value
+	if h.fallback != nil && h.fallback.Enabled(ctx, r.Level) {
+		return errors.Join(err, h.fallback.Handle(ctx, r))
+	}
+	return err
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *TimeoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := &TimeoutHandler{Handler: h.Handler.WithAttrs(attrs), timeout: h.timeout, state: h.state}
+	if h.fallback != nil {
+		c.fallback = h.fallback.WithAttrs(attrs)
+	}
+	return c
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *TimeoutHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	c := &TimeoutHandler{Handler: h.Handler.WithGroup(name), timeout: h.timeout, state: h.state}
+	if h.fallback != nil {
+		c.fallback = h.fallback.WithGroup(name)
+	}
+	return c
+}
@@ -0,0 +1,174 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+)
+
+func TestTimeoutHandler(t *testing.T) {
+	errSink := errors.New("sink error")
+	release := make(chan struct{})
+	defer close(release)
+
+	tests := []struct {
+		name         string
+		handle       func(ctx context.Context, r slog.Record) error
+		fallback     bool
+		wantErr      error
+		wantFallback bool
+	}{
+		{
+			name:   "handled in time",
+			handle: func(context.Context, slog.Record) error { return nil },
+		},
+		{
+			name:    "error in time",
+			handle:  func(context.Context, slog.Record) error { return errSink },
+			wantErr: errSink,
+		},
+		{
+			name: "context aware sink times out",
+			handle: func(ctx context.Context, _ slog.Record) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			fallback:     true,
+			wantErr:      ErrWriteTimeout,
+			wantFallback: true,
+		},
+		{
+			name:         "sink returns deadline error",
+			handle:       func(context.Context, slog.Record) error { return context.DeadlineExceeded },
+			fallback:     true,
+			wantErr:      ErrWriteTimeout,
+			wantFallback: true,
+		},
+		{
+			name: "hung sink times out",
+			handle: func(context.Context, slog.Record) error {
+				<-release
+				return nil
+			},
+			fallback:     true,
+			wantErr:      ErrWriteTimeout,
+			wantFallback: true,
+		},
+		{
+			name: "timeout without fallback",
+			handle: func(context.Context, slog.Record) error {
+				<-release
+				return nil
+			},
+			wantErr: ErrWriteTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fallback slog.Handler
+			var fellBack string
+			if tt.fallback {
+				fallback = test.MockHandler{
+					HandleFunc: func(_ context.Context, r slog.Record) error {
+						fellBack = r.Message
+						return nil
+					},
+				}
+			}
+
+			h := NewTimeoutHandler(test.MockHandler{HandleFunc: tt.handle}, 10*time.Millisecond, fallback)
+			err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "test", 0))
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if (fellBack == "test") != tt.wantFallback {
+				t.Errorf("Expected fallback = %v, got record %q", tt.wantFallback, fellBack)
+			}
+		})
+	}
+}
+
+func TestTimeoutHandler_CanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	h := NewTimeoutHandler(test.MockHandler{
+		HandleFunc: func(ctx context.Context, _ slog.Record) error { return ctx.Err() },
+	}, time.Second, nil)
+	if err := h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "test", 0)); err != nil {
+		t.Errorf("Expected the caller's cancellation to be ignored, got %v", err)
+	}
+}
+
+func TestTimeoutHandler_Busy(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	var handled atomic.Int64
+	var fellBack []string
+	h := NewTimeoutHandler(test.MockHandler{
+		HandleFunc: func(context.Context, slog.Record) error {
+			handled.Add(1)
+			<-release
+			return nil
+		},
+	}, time.Millisecond, test.MockHandler{
+		HandleFunc: func(_ context.Context, r slog.Record) error {
+			fellBack = append(fellBack, r.Message)
+			return nil
+		},
+	})
+
+	for range maxTimeoutWorkers {
+		if err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "hung", 0)); !errors.Is(err, ErrWriteTimeout) {
+			t.Fatalf("Expected %v, got %v", ErrWriteTimeout, err)
+		}
+	}
+	if len(fellBack) != maxTimeoutWorkers {
+		t.Errorf("Expected the timed out records to be passed to the fallback handler, got %v", fellBack)
+	}
+	fellBack = nil
+
+	err := h.WithAttrs([]slog.Attr{slog.String("k", "v")}).Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "dropped", 0))
+	if !errors.Is(err, ErrHandlerBusy) {
+		t.Errorf("Expected %v, got %v", ErrHandlerBusy, err)
+	}
+	if len(fellBack) != 1 || fellBack[0] != "dropped" {
+		t.Errorf("Expected the dropped record to be passed to the fallback handler, got %v", fellBack)
+	}
+	if got := handled.Load(); got != maxTimeoutWorkers {
+		t.Errorf("Expected %d records to be handled, got %d", maxTimeoutWorkers, got)
+	}
+
+	metrics := map[string]slog.Value{}
+	for _, a := range h.Metrics(context.Background()) {
+		metrics[a.Key] = a.Value
+	}
+	if metrics["running"].Int64() != maxTimeoutWorkers || metrics["timeouts"].Uint64() != maxTimeoutWorkers || metrics["dropped"].Uint64() != 1 {
+		t.Errorf("Unexpected metrics %v", metrics)
+	}
+}
+
+func TestNewTimeoutHandler_NoTimeout(t *testing.T) {
+	var ctxs []context.Context
+	h := NewTimeoutHandler(test.MockHandler{
+		HandleFunc: func(ctx context.Context, _ slog.Record) error {
+			ctxs = append(ctxs, ctx)
+			return nil
+		},
+	}, 0, nil)
+
+	ctx := context.WithValue(context.Background(), ctxAttrsKey{}, "value")
+	if err := h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "test", 0)); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if len(ctxs) != 1 || ctxs[0] != ctx {
+		t.Errorf("Expected the record to be passed as is, got contexts %v", ctxs)
+	}
+}
@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// TraceIDKey is the key used for the trace ID of the span found in the context.
+	TraceIDKey = "trace_id"
+	// SpanIDKey is the key used for the span ID of the span found in the context.
+	SpanIDKey = "span_id"
+)
+
+var _ slog.Handler = (*traceHandler)(nil)
+
+// traceHandler is a [slog.Handler] that adds the trace and span ID of the span found in the context to every record.
+type traceHandler struct {
+	slog.Handler
+}
+
+// NewTraceHandler returns a new [slog.Handler] that adds the trace and span ID of the OpenTelemetry span
+// found in the context to every record before passing it to the given handler.
+//
+// In contrast to the OpenTelemetry support enabled by [Options.OpenTelemetry], it neither records
+// log events on the span nor adds the baggage of the context to the record.
+func NewTraceHandler(h slog.Handler) slog.Handler {
+	return &traceHandler{Handler: h}
+}
+
+// Handle adds the trace and span ID to the record and passes it to the wrapped handler.
+func (h *traceHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	if ctx != nil {
+		if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+			r.AddAttrs(slog.String(TraceIDKey, sc.TraceID().String()), slog.String(SpanIDKey, sc.SpanID().String()))
+		}
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *traceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &traceHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *traceHandler) WithGroup(name string) slog.Handler {
+	return &traceHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceHandler(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01, 0x02, 0x03},
+		SpanID:  trace.SpanID{0x04, 0x05, 0x06},
+	})
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		wantTrace string
+		wantSpan  string
+	}{
+		{
+			name: "context without span",
+			ctx:  context.Background(),
+		},
+		{
+			name:      "context with span",
+			ctx:       trace.ContextWithSpanContext(context.Background(), sc),
+			wantTrace: sc.TraceID().String(),
+			wantSpan:  sc.SpanID().String(),
+		},
+		{
+			name:      "context with remote span",
+			ctx:       trace.ContextWithRemoteSpanContext(context.Background(), sc),
+			wantTrace: sc.TraceID().String(),
+			wantSpan:  sc.SpanID().String(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string]string{}
+			h := NewTraceHandler(test.MockHandler{
+				HandleFunc: func(_ context.Context, r slog.Record) error {
+					r.Attrs(func(a slog.Attr) bool {
+						got[a.Key] = a.Value.String()
+						return true
+					})
+					return nil
+				},
+			})
+
+			NewLogger(Options{Handler: h}).InfoContext(tt.ctx, "test")
+			if got[TraceIDKey] != tt.wantTrace {
+				t.Errorf("Expected trace ID %q, got %q", tt.wantTrace, got[TraceIDKey])
+			}
+			if got[SpanIDKey] != tt.wantSpan {
+				t.Errorf("Expected span ID %q, got %q", tt.wantSpan, got[SpanIDKey])
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Transform actions supported by [TransformRule].
+const (
+	// TransformRename renames the attribute [TransformRule.Key] to [TransformRule.To].
+	TransformRename = "rename"
+	// TransformAdd adds the static attribute [TransformRule.Key] with [TransformRule.Value].
+	TransformAdd = "add"
+	// TransformDrop drops the attribute [TransformRule.Key].
+	TransformDrop = "drop"
+	// TransformLevel maps records at the level [TransformRule.From] to the level [TransformRule.To].
+	TransformLevel = "level"
+)
+
+// TransformRule is a declarative rule transforming records, e.g. loaded from a configuration file:
+//
+//	[
+//		{"action": "rename", "key": "msg_id", "to": "message_id"},
+//		{"action": "add", "key": "service", "value": "checkout"},
+//		{"action": "drop", "key": "path", "when": {"key": "path", "equals": "/healthz"}},
+//		{"action": "level", "from": "NOTICE", "to": "INFO"}
+//	]
+//
+// The rules are compiled to a [RecordTransformer] by [NewTransformer] or set by [Options.Transforms],
+// [Config.Transforms] or the LOG_TRANSFORMS environment variable. Like every [RecordTransformer],
+// they apply to the attributes of the record, not to those added by [Provider.With], and are applied in the given order.
+type TransformRule struct {
+	// Action is the action of the rule, one of [TransformRename], [TransformAdd], [TransformDrop] and [TransformLevel].
+	Action string `json:"action" yaml:"action"`
+	// Key is the key of the attribute to rename, add or drop.
+	Key string `json:"key,omitempty" yaml:"key,omitempty"`
+	// To is the new key of a renamed attribute or the name of the new level of a level mapping.
+	To string `json:"to,omitempty" yaml:"to,omitempty"`
+	// Value is the value of an added attribute.
+	Value any `json:"value,omitempty" yaml:"value,omitempty"`
+	// From is the name of the level to map.
+	From string `json:"from,omitempty" yaml:"from,omitempty"`
+	// When restricts the rule to matching records. The rule applies to all records if nil.
+	When *TransformCondition `json:"when,omitempty" yaml:"when,omitempty"`
+}
+
+// TransformCondition restricts a [TransformRule] to matching records.
+// All set fields must match.
+type TransformCondition struct {
+	// Level is the name of the level the record must have.
+	Level string `json:"level,omitempty" yaml:"level,omitempty"`
+	// Message is the message the record must have.
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	// Key is the key of a top-level attribute the record must have.
+	Key string `json:"key,omitempty" yaml:"key,omitempty"`
+	// Equals is the value the attribute [TransformCondition.Key] must have, compared by its string representation.
+	// Any value matches if nil.
+	Equals any `json:"equals,omitempty" yaml:"equals,omitempty"`
+}
+
+// transformRecord is the mutable state of a record being transformed.
+type transformRecord struct {
+	level slog.Level
+	msg   string
+	attrs []slog.Attr
+}
+
+// transform is a compiled [TransformRule].
+type transform struct {
+	when  func(r *transformRecord) bool
+	apply func(r *transformRecord)
+}
+
+// compileTransforms validates and compiles the rules.
+func compileTransforms(rules []TransformRule) ([]transform, error) {
+	transforms := make([]transform, 0, len(rules))
+	var errs []error
+	for i, rule := range rules {
+		t, err := compileTransform(rule)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rule %d: %w", i, err))
+			continue
+		}
+		transforms = append(transforms, t)
+	}
+	return transforms, errors.Join(errs...)
+}
+
+// compileTransform validates and compiles the rule.
+func compileTransform(rule TransformRule) (transform, error) {
+	when, err := compileCondition(rule.When)
+	if err != nil {
+		return transform{}, err
+	}
+
+	t := transform{when: when}
+	switch strings.ToLower(rule.Action) {
+	case TransformRename:
+		if rule.Key == "" || rule.To == "" {
+			return transform{}, errors.New("rename requires a key and a new key")
+		}
+		t.apply = func(r *transformRecord) {
+			for i := range r.attrs {
+				if r.attrs[i].Key == rule.Key {
+					r.attrs[i].Key = rule.To
+				}
+			}
+		}
+	case TransformAdd:
+		if rule.Key == "" {
+			return transform{}, errors.New("add requires a key")
+		}
+		t.apply = func(r *transformRecord) {
+			r.attrs = append(r.attrs, slog.Any(rule.Key, rule.Value))
+		}
+	case TransformDrop:
+		if rule.Key == "" {
+			return transform{}, errors.New("drop requires a key")
+		}
+		t.apply = func(r *transformRecord) {
+			r.attrs = slices.DeleteFunc(r.attrs, func(a slog.Attr) bool { return a.Key == rule.Key })
+		}
+	case TransformLevel:
+		from, to, err := parseLevels(rule.From, rule.To)
+		if err != nil {
+			return transform{}, err
+		}
+		t.apply = func(r *transformRecord) {
+			if r.level == from {
+				r.level = to
+			}
+		}
+	default:
+		return transform{}, fmt.Errorf("unknown action %q", rule.Action)
+	}
+	return t, nil
+}
+
+// compileCondition validates and compiles the condition.
+func compileCondition(c *TransformCondition) (func(r *transformRecord) bool, error) {
+	if c == nil {
+		return func(*transformRecord) bool { return true }, nil
+	}
+	if c.Equals != nil && c.Key == "" {
+		return nil, errors.New("condition with a value requires a key")
+	}
+
+	var level *slog.Level
+	if c.Level != "" {
+		l, err := parseLevel(c.Level)
+		if err != nil {
+			return nil, err
+		}
+		level = &l
+	}
+	equals := fmt.Sprint(c.Equals)
+
+	return func(r *transformRecord) bool {
+		if level != nil && r.level != *level {
+			return false
+		}
+		if c.Message != "" && r.msg != c.Message {
+			return false
+		}
+		if c.Key == "" {
+			return true
+		}
+		return slices.ContainsFunc(r.attrs, func(a slog.Attr) bool {
+			return a.Key == c.Key && (c.Equals == nil || a.Value.String() == equals)
+		})
+	}, nil
+}
+
+// parseLevels parses the names of the levels of a level mapping.
+func parseLevels(from, to string) (slog.Level, slog.Level, error) {
+	f, err := parseLevel(from)
+	if err != nil {
+		return 0, 0, err
+	}
+	t, err := parseLevel(to)
+	if err != nil {
+		return 0, 0, err
+	}
+	return f, t, nil
+}
+
+// parseLevel parses the name of a level.
+// In contrast to newLevel, unknown names are rejected instead of falling back to [LevelInfo].
+func parseLevel(name string) (slog.Level, error) {
+	level := newLevel(name)
+	if level == LevelInfo && !strings.EqualFold(name, "INFO") {
+		return 0, fmt.Errorf("unknown level %q", name)
+	}
+	return slog.Level(level), nil
+}
+
+// NewTransformer returns a new [RecordTransformer] transforming records by the given rules, see [Chain].
+// Returns an error if a rule is invalid.
+//
+// Records are only transformed if the handler is enabled for their level, so a level mapping
+// applies to records at enabled levels. Records mapped to a disabled level are dropped.
+func NewTransformer(rules []TransformRule) (RecordTransformer, error) {
+	transforms, err := compileTransforms(rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transform rules: %w", err)
+	}
+	return func(r slog.Record) slog.Record {
+		attrs := make([]slog.Attr, 0, r.NumAttrs())
+		r.Attrs(func(a slog.Attr) bool {
+			attrs = append(attrs, a)
+			return true
+		})
+
+		tr := &transformRecord{level: r.Level, msg: r.Message, attrs: attrs}
+		for _, t := range transforms {
+			if t.when(tr) {
+				t.apply(tr)
+			}
+		}
+		out := slog.NewRecord(r.Time, tr.level, tr.msg, r.PC)
+		out.AddAttrs(tr.attrs...)
+		return out
+	}, nil
+}
+
+// transformRules returns the transform rules of the options.
+// The LOG_TRANSFORMS environment variable takes precedence as JSON array of [TransformRule]s.
+func transformRules(o Options) ([]TransformRule, error) {
+	env, ok := os.LookupEnv("LOG_TRANSFORMS")
+	if !ok || strings.TrimSpace(env) == "" {
+		return o.Transforms, nil
+	}
+	var rules []TransformRule
+	if err := decodeStrictJSON([]byte(env), &rules); err != nil {
+		return nil, fmt.Errorf("LOG_TRANSFORMS: %w", err)
+	}
+	return rules, nil
+}
+
+// optionsTransformer returns the [RecordTransformer] of the transform rules of the options, nil if there are none.
+func optionsTransformer(o Options) (RecordTransformer, error) {
+	rules, err := transformRules(o)
+	if err != nil || len(rules) == 0 {
+		return nil, err
+	}
+	return NewTransformer(rules)
+}
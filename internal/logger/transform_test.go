@@ -0,0 +1,186 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewTransformer(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules string
+		log   func(l Provider)
+		want  map[string]any
+	}{
+		{
+			name:  "rename attribute",
+			rules: `[{"action": "rename", "key": "msg_id", "to": "message_id"}]`,
+			log:   func(l Provider) { l.Info("test", "msg_id", 2) },
+			want:  map[string]any{"message_id": float64(2), "msg_id": nil},
+		},
+		{
+			name:  "add static attribute",
+			rules: `[{"action": "add", "key": "service", "value": "checkout"}]`,
+			log:   func(l Provider) { l.Info("test", "key", "value") },
+			want:  map[string]any{"service": "checkout", "key": "value"},
+		},
+		{
+			name:  "drop attribute when condition matches",
+			rules: `[{"action": "drop", "key": "path", "when": {"key": "path", "equals": "/healthz"}}]`,
+			log:   func(l Provider) { l.Info("test", "path", "/healthz", "status", 200) },
+			want:  map[string]any{"status": float64(200), "path": nil},
+		},
+		{
+			name:  "keep attribute when condition does not match",
+			rules: `[{"action": "drop", "key": "path", "when": {"key": "path", "equals": "/healthz"}}]`,
+			log:   func(l Provider) { l.Info("test", "path", "/users") },
+			want:  map[string]any{"path": "/users"},
+		},
+		{
+			name:  "map level",
+			rules: `[{"action": "level", "from": "NOTICE", "to": "WARN"}]`,
+			log:   func(l Provider) { l.Notice("test") },
+			want:  map[string]any{"level": "WARN"},
+		},
+		{
+			name:  "map to disabled level",
+			rules: `[{"action": "level", "from": "INFO", "to": "DEBUG"}]`,
+			log:   func(l Provider) { l.Info("test"); l.Warn("kept") },
+			want:  map[string]any{"level": "WARN", "msg": "kept"},
+		},
+		{
+			name:  "condition on level and message",
+			rules: `[{"action": "add", "key": "alert", "value": true, "when": {"level": "ERROR", "message": "test"}}]`,
+			log:   func(l Provider) { l.Error("test") },
+			want:  map[string]any{"alert": true},
+		},
+		{
+			name:  "rules in order",
+			rules: `[{"action": "rename", "key": "a", "to": "b"}, {"action": "drop", "key": "b"}]`,
+			log:   func(l Provider) { l.Info("test", "a", 1) },
+			want:  map[string]any{"a": nil, "b": nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rules []TransformRule
+			if err := json.Unmarshal([]byte(tt.rules), &rules); err != nil {
+				t.Fatalf("Failed to decode rules: %v", err)
+			}
+
+			var buf bytes.Buffer
+			transformer, err := NewTransformer(rules)
+			if err != nil {
+				t.Fatalf("NewTransformer() error = %v", err)
+			}
+			h := Chain(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: replaceAttr}), transformer)
+			tt.log(NewLogger(Options{Handler: h}))
+
+			var record map[string]any
+			if err = json.Unmarshal(buf.Bytes(), &record); err != nil {
+				t.Fatalf("Failed to decode record %q: %v", buf.String(), err)
+			}
+			for key, want := range tt.want {
+				got, ok := record[key]
+				if want == nil {
+					if ok {
+						t.Errorf("Expected %q to be dropped, got %v", key, got)
+					}
+					continue
+				}
+				gotJSON, _ := json.Marshal(got)
+				wantJSON, _ := json.Marshal(want)
+				if string(gotJSON) != string(wantJSON) {
+					t.Errorf("Expected %q = %s, got %s", key, wantJSON, gotJSON)
+				}
+			}
+		})
+	}
+}
+
+func TestNewTransformer_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    TransformRule
+		wantErr string
+	}{
+		{name: "unknown action", rule: TransformRule{Action: "explode"}, wantErr: "unknown action"},
+		{name: "rename without new key", rule: TransformRule{Action: TransformRename, Key: "a"}, wantErr: "rename requires"},
+		{name: "add without key", rule: TransformRule{Action: TransformAdd}, wantErr: "add requires"},
+		{name: "drop without key", rule: TransformRule{Action: TransformDrop}, wantErr: "drop requires"},
+		{name: "unknown level", rule: TransformRule{Action: TransformLevel, From: "LOUD", To: "INFO"}, wantErr: "unknown level"},
+		{
+			name:    "condition value without key",
+			rule:    TransformRule{Action: TransformDrop, Key: "a", When: &TransformCondition{Equals: 1}},
+			wantErr: "requires a key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTransformer([]TransformRule{tt.rule})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNewLogger_Transforms(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		opts    Options
+		want    []string
+		notWant string
+	}{
+		{
+			name:    "options",
+			opts:    Options{Transforms: []TransformRule{{Action: TransformDrop, Key: "path"}}},
+			want:    []string{`"status":200`},
+			notWant: `"path"`,
+		},
+		{
+			name: "environment takes precedence",
+			env:  `[{"action": "rename", "key": "path", "to": "route"}]`,
+			opts: Options{Transforms: []TransformRule{{Action: TransformDrop, Key: "path"}}},
+			want: []string{`"route":"/healthz"`},
+		},
+		{
+			name: "invalid rules",
+			opts: Options{Transforms: []TransformRule{{Action: "explode"}}},
+			want: []string{"Invalid transform rules", `"path":"/healthz"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.env != "" {
+				t.Setenv("LOG_TRANSFORMS", tt.env)
+			}
+			path := filepath.Join(t.TempDir(), "app.log")
+			tt.opts.Output, tt.opts.Format = path, "JSON"
+			NewLogger(tt.opts).Info("test", "path", "/healthz", "status", 200)
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read the log file: %v", err)
+			}
+			out := string(data)
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("Expected %s in the output, got %s", want, out)
+				}
+			}
+			if tt.notWant != "" && strings.Contains(out, tt.notWant) {
+				t.Errorf("Expected no %s in the output, got %s", tt.notWant, out)
+			}
+		})
+	}
+}
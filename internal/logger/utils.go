@@ -2,14 +2,13 @@ package logger
 
 import (
 	"context"
+	"io"
 	"log/slog"
 	"net/http"
 	"os"
 	"strings"
 	"time"
 
-	"github.com/charmbracelet/lipgloss"
-	clog "github.com/charmbracelet/log"
 	otel "github.com/remychantenay/slog-otel"
 )
 
@@ -24,8 +23,15 @@ import (
 //	log := logger.NewLogger(opts)
 //	log.Info("Hello, world!")
 func NewLogger(o ...Options) Provider {
+	opts := newOptions(o...)
+	h, swap, level := newHandler(o...)
 	return &logger{
-		Logger: slog.New(newHandler(o...)),
+		Logger:      slog.New(withControl(h, swap, level)),
+		swap:        swap,
+		level:       level,
+		fatal:       newFatalConfig(opts),
+		panicErrors: opts.PanicErrors,
+		caller:      callerConfig{skip: max(opts.CallerSkip, 0)},
 	}
 }
 
@@ -37,9 +43,18 @@ func NewLogger(o ...Options) Provider {
 //	opts := logger.Options{Level: "DEBUG", Format: "TEXT"}
 //	log := logger.NewNamedLogger("myServiceLogger", opts)
 func NewNamedLogger(name string, o ...Options) Provider {
-	return &logger{
-		Logger: slog.New(newHandler(o...)).With("name", name),
+	opts := newOptions(o...)
+	h, swap, level := newHandler(o...)
+	l := &logger{
+		Logger:      slog.New(withControl(h, swap, level)),
+		swap:        swap,
+		level:       level,
+		name:        name,
+		fatal:       newFatalConfig(opts),
+		panicErrors: opts.PanicErrors,
+		caller:      callerConfig{skip: max(opts.CallerSkip, 0)},
 	}
+	return l.With(NameKey, name)
 }
 
 // NewContextWithLogger creates a new context based on the provided parent context.
@@ -61,7 +76,8 @@ func IntoContext(ctx context.Context, log Provider) context.Context {
 }
 
 // FromContext extracts the slog.Logger from the provided context.
-// If the context does not have a logger, it returns a new logger with the default configuration.
+// If the context does not have a logger, it returns the default logger, see [Default].
+// The default logger is built once, so the outputs it opens (e.g. the file of LOG_OUTPUT) are shared by all calls.
 // This function is useful for retrieving loggers from context in different parts of an application.
 func FromContext(ctx context.Context) Provider {
 	if ctx != nil {
@@ -69,7 +85,7 @@ func FromContext(ctx context.Context) Provider {
 			return logger
 		}
 	}
-	return NewLogger()
+	return Default()
 }
 
 // Middleware takes the logger from the context and adds it to the request context
@@ -83,22 +99,62 @@ func Middleware(ctx context.Context) func(http.Handler) http.Handler {
 	}
 }
 
-// ToSlog returns the underlying [slog.Logger].
+// ToSlog returns the underlying [slog.Logger] carrying the logger's attributes and groups.
 func (l *logger) ToSlog() *slog.Logger {
 	if l.Logger == nil {
-		return slog.New(newHandler())
+		h, _, _ := newHandler()
+		return slog.New(h)
 	}
 
 	return l.Logger
 }
 
 // FromSlog returns a new Logger instance based on the provided [slog.Logger].
+// The logger uses the handler of the [slog.Logger], so a logger round-tripped via [Provider.Handler] or [Provider.ToSlog]
+// keeps its pipeline, and [Provider.SetHandler], [Provider.SetLevel] and [EnableLevelFor] still control it.
+// The Fatal and Panic behavior configured by the [Options] is not part of the handler and is not kept.
 func FromSlog(l *slog.Logger) Provider {
 	if l == nil {
 		return NewLogger()
 	}
 
-	return &logger{l}
+	if c, ok := l.Handler().(*controlHandler); ok {
+		return &logger{Logger: l, swap: c.swap, level: c.level}
+	}
+	swap, _ := l.Handler().(*SwapHandler)
+	return &logger{Logger: l, swap: swap}
+}
+
+var _ slog.Handler = (*controlHandler)(nil)
+
+// controlHandler is the outermost handler of the loggers built from the [Options] or a [Config].
+// It carries the [SwapHandler] and the levelControl of the logger, so [FromSlog] recovers them
+// from the handler returned by [Provider.Handler] and [Provider.ToSlog].
+type controlHandler struct {
+	slog.Handler
+	swap  *SwapHandler
+	level *levelControl
+}
+
+// withControl returns the handler wrapped to carry the swap handler and the level control, if there are any.
+func withControl(h slog.Handler, swap *SwapHandler, level *levelControl) slog.Handler {
+	if swap == nil && level == nil {
+		return h
+	}
+	return &controlHandler{Handler: h, swap: swap, level: level}
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *controlHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &controlHandler{Handler: h.Handler.WithAttrs(attrs), swap: h.swap, level: h.level}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *controlHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &controlHandler{Handler: h.Handler.WithGroup(name), swap: h.swap, level: h.level}
 }
 
 // newHandler returns a new slog.Handler based on the provided options.
@@ -106,59 +162,199 @@ func FromSlog(l *slog.Logger) Provider {
 // It returns the handler based on several conditions:
 //  1. If a handler is provided, it returns the handler.
 //  2. If OpenTelemetry support is enabled, it returns a new OtelHandler.
-//  3. Otherwise, it returns a new BaseHandler.
-func newHandler(o ...Options) slog.Handler {
+//  3. If trace context support is enabled, it returns a new TraceHandler.
+//  4. Otherwise, it returns a new BaseHandler.
+//
+// The BaseHandler is wrapped to add the attributes stored in the context by [AppendCtx].
+//
+// It also returns the [SwapHandler] replaced by [Provider.SetHandler], which wraps the BaseHandler
+// or is the provided handler itself. It is nil if the provided handler is not a [SwapHandler].
+// The level of the BaseHandler is controlled by the returned levelControl, which is nil for a provided handler.
+func newHandler(o ...Options) (slog.Handler, *SwapHandler, *levelControl) {
 	opts := newOptions(o...)
 	if opts.Handler != nil {
-		return opts.Handler
+		swap, _ := opts.Handler.(*SwapHandler)
+		return opts.Handler, swap, nil
+	}
+
+	level, control := newLevel(opts.Level), &levelControl{}
+	base := opts
+	base.Level = lowestLevel(level).String()
+	h := NewMetricsHandler(newBaseHandler(base), opts.Metrics, metricsHandlerName(opts.Output))
+	swap := NewSwapHandler(newLevelHandler(h, level, control))
+	pipeline := newPipeline(swap, opts)
+	if policy := redactionPolicy(opts); !policy.isKnown() {
+		r := slog.NewRecord(time.Now(), slog.LevelWarn, "Unknown redaction policy, redacting with the standard policy", 0)
+		r.AddAttrs(slog.String("policy", string(policy)))
+		_ = pipeline.Handle(context.Background(), r)
+	}
+	if _, err := optionsTransformer(opts); err != nil {
+		r := slog.NewRecord(time.Now(), slog.LevelWarn, "Invalid transform rules, records are not transformed", 0)
+		r.AddAttrs(slog.Any("error", err))
+		_ = pipeline.Handle(context.Background(), r)
+	}
+	return pipeline, swap, control
+}
+
+// NewPipeline returns the handler pipeline built for the given options with the handler writing the records
+// replaced by the handler returned by sink for the configured minimum level. [Options.Handler] is ignored.
+//
+// It allows tests to assert the behavior of the wrappers, e.g. redaction or splitting, for a configuration.
+func NewPipeline(sink func(level Level) slog.Handler, o ...Options) slog.Handler {
+	opts := newOptions(o...)
+	return newPipeline(sink(newLevel(opts.Level)), opts)
+}
+
+// newPipeline wraps the given handler writing the records with the handlers enabled by the options.
+func newPipeline(base slog.Handler, opts Options) slog.Handler {
+	var gen IDGenerator
+	if opts.Deterministic {
+		base = newDeterministicHandler(base)
+		gen = &sequenceGenerator{}
+	} else if opts.OmitSource {
+		base = newOmitSourceHandler(base)
+	}
+	// The metadata is stripped right before the record is written, so all wrappers can read it.
+	handler := NewMetaStripHandler(base)
+	if isTextFormat(opts.Format) {
+		handler = newInlineHandler(handler)
+	}
+	if opts.RecordIDs {
+		// The IDs are added after splitting, so every part of a split record has its own ID.
+		handler = NewRecordIDHandler(handler, gen)
+	}
+	reserved := 0
+	if opts.RecordIDs {
+		reserved = recordIDOverhead
+	}
+	handler = newSplitHandler(handler, opts.MaxRecordSize, reserved)
+	if opts.Limits != nil {
+		// The records are truncated before splitting, so only the records within the limits are split.
+		handler = NewLimitHandler(handler, *opts.Limits)
 	}
+	if opts.StacktraceLevel != "" {
+		// The stack trace is added before splitting, so it is captured once per record.
+		handler = NewStacktraceHandler(handler, newLevel(opts.StacktraceLevel))
+	}
+	// The records are redacted after the attributes of the context are added.
+	handler = NewRedactionHandler(handler, redactionPolicy(opts), RedactionOptions{
+		Paths:            redactionPaths(opts),
+		Keys:             redactionKeys(opts),
+		Patterns:         redactionPatternNames(opts),
+		HashKeys:         hashKeys(opts),
+		HashSecret:       hashSecret(opts),
+		AllowKeys:        allowKeys(opts),
+		AllowPlaceholder: opts.AllowPlaceholder,
+	})
+	if opts.AutoName {
+		handler = newAutoNameHandler(handler)
+	}
+	if opts.Sampling != nil {
+		// The records are sampled before the other wrappers, so the dropped ones cost as little as possible.
+		sampling := *opts.Sampling
+		if sampling.Metrics == nil {
+			sampling.Metrics = opts.Metrics
+		}
+		handler = NewSamplingHandler(handler, sampling)
+	}
+	if transformer, err := optionsTransformer(opts); err == nil && transformer != nil {
+		// The records are transformed after the attributes of the context are added and before they are sampled.
+		handler = Chain(handler, transformer)
+	}
+	handler = NewContextHandler(handler)
 	if opts.OpenTelemetry {
-		return otel.NewOtelHandler()(newBaseHandler(opts))
+		return otel.NewOtelHandler()(handler)
 	}
-	return newBaseHandler(opts)
+	if opts.TraceContext {
+		return NewTraceHandler(handler)
+	}
+	return handler
 }
 
 // newBaseHandler returns a new slog.Handler based on the environment variables.
+// If the output cannot be opened, the records are written to stderr and the error is logged as warning.
 func newBaseHandler(o Options) slog.Handler {
-	if strings.EqualFold(o.Format, "TEXT") {
-		log := clog.NewWithOptions(os.Stderr, clog.Options{
-			TimeFormat:      time.Kitchen,
-			Level:           clog.Level(newLevel(o.Level)),
-			ReportTimestamp: true,
-			ReportCaller:    true,
-		})
-		log.SetStyles(newCustomStyles())
-		return log
+	w, err := newOutput(o.Output)
+	if err != nil {
+		w = os.Stderr
 	}
-
-	return slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
-		AddSource:   true,
-		Level:       slog.Level(newLevel(o.Level)),
-		ReplaceAttr: replaceAttr,
-	})
+	h := newWriterHandler(o, w)
+	if err != nil {
+		r := slog.NewRecord(time.Now(), slog.LevelWarn, "Failed to open the log output, writing to stderr", 0)
+		r.AddAttrs(slog.String("output", o.Output), slog.Any("error", err))
+		_ = h.Handle(context.Background(), r)
+	}
+	return h
 }
 
-// newCustomStyles returns the custom styles for the text logger.
-func newCustomStyles() *clog.Styles {
-	styles := clog.DefaultStyles()
+// newWriterHandler returns the handler of the format of the options writing to w.
+func newWriterHandler(o Options, w io.Writer) slog.Handler {
+	layout, loc := timeSettings(o)
+	if isTextFormat(o.Format) {
+		if w == os.Stderr {
+			return newTextHandler(newLevel(o.Level), layout, loc)
+		}
+		return newTextWriterHandler(w, newLevel(o.Level), layout, loc, true)
+	}
 
-	const maxWidth = 4
-	for level, color := range LevelColors {
-		styles.Levels[clog.Level(int(level))] = lipgloss.NewStyle().
-			SetString(level.String()).
-			Bold(true).
-			MaxWidth(maxWidth).
-			Foreground(lipgloss.Color(color))
+	if isDevFormat(o.Format) {
+		return NewDevHandler(DevOptions{Level: newLevel(o.Level), Writer: w, TimeFormat: layout, TimeZone: loc})
 	}
 
-	return styles
+	if isDockerFormat(o.Format) {
+		opts := DockerOptions{Level: newLevel(o.Level), KeyNames: keyNames(o)}
+		if standardStream(o.Output) == nil {
+			// The records are split into stdout and stderr unless another output is selected.
+			opts.Stdout, opts.Stderr = w, w
+		}
+		return NewDockerHandler(opts)
+	}
+
+	if isDatadogFormat(o.Format) {
+		return NewDatadogHandler(DatadogOptions{Level: newLevel(o.Level), Writer: w})
+	}
+
+	var journalErr error
+	if isJournaldFormat(o.Format) {
+		// The handler is never closed, the socket is closed when the process exits.
+		h, err := NewJournaldHandler(JournaldOptions{Level: newLevel(o.Level)})
+		if err == nil {
+			return h
+		}
+		journalErr = err
+	}
+
+	source := sourceReplaceAttr(SourcePathStyle(strings.ToLower(o.SourcePath)), o.CompactSource, replaceAttr)
+	replace := renameReplaceAttr(keyNames(o), timeReplaceAttr(layout, loc, source))
+	if o.CompactKeys {
+		replace = compactReplaceAttr(replace)
+		if o.KeyDictionary {
+			w = newDictionaryWriter(w)
+		}
+	}
+	var handler slog.Handler = slog.NewJSONHandler(w, &slog.HandlerOptions{
+		AddSource:   true,
+		Level:       slog.Level(newLevel(o.Level)),
+		ReplaceAttr: replace,
+	})
+	if o.LevelHints {
+		handler = newLevelHintHandler(handler)
+	}
+	if journalErr != nil {
+		r := slog.NewRecord(time.Now(), slog.LevelWarn, "Failed to open the journal, writing JSON records instead", 0)
+		r.AddAttrs(slog.Any("error", journalErr))
+		_ = handler.Handle(context.Background(), r)
+	}
+	return handler
 }
 
 // replaceAttr is the replacement function for slog.HandlerOptions.
 func replaceAttr(_ []string, a slog.Attr) slog.Attr {
 	if a.Key == slog.LevelKey {
-		lev := Level(a.Value.Any().(slog.Level))
-		a.Value = slog.StringValue(lev.String())
+		// Attributes of the user may use the same key.
+		if lev, ok := a.Value.Any().(slog.Level); ok {
+			a.Value = slog.StringValue(Level(lev).String())
+		}
 	}
 	return a
 }
@@ -0,0 +1,80 @@
+//go:build !js && !wasip1
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	clog "github.com/charmbracelet/log"
+)
+
+func TestNewBaseHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    string
+		level     string
+		wantLevel int
+	}{
+		{
+			name:      "Default handler",
+			format:    "",
+			level:     "",
+			wantLevel: int(slog.LevelInfo),
+		},
+		{
+			name:      "Text handler with custom log level",
+			format:    "TEXT",
+			level:     "DEBUG",
+			wantLevel: int(clog.DebugLevel),
+		},
+		{
+			name:      "JSON handler with custom log level",
+			format:    "JSON",
+			level:     "WARN",
+			wantLevel: int(slog.LevelWarn),
+		},
+		{
+			name:      "Invalid log level",
+			format:    "TEXT",
+			level:     "UNKNOWN",
+			wantLevel: int(clog.InfoLevel),
+		},
+		{
+			name:      "Docker handler with custom log level",
+			format:    "DOCKER",
+			level:     "ERROR",
+			wantLevel: int(slog.LevelError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LOG_FORMAT", tt.format)
+			t.Setenv("LOG_LEVEL", tt.level)
+			opts := newDefaultOptions()
+			handler := newBaseHandler(opts)
+
+			switch tt.format {
+			case "TEXT":
+				if _, ok := handler.(*clog.Logger); !ok {
+					t.Errorf("Expected handler to be of type *log.Logger")
+				}
+			case "DOCKER":
+				if _, ok := handler.(*streamHandler); !ok {
+					t.Errorf("Expected handler to be of type *streamHandler")
+				}
+			default:
+				if _, ok := handler.(*slog.JSONHandler); !ok {
+					t.Errorf("Expected handler to be of type *slog.JSONHandler")
+				}
+			}
+
+			ok := handler.Enabled(context.Background(), slog.Level(tt.wantLevel))
+			if !ok {
+				t.Errorf("Expected log level: %v", tt.wantLevel)
+			}
+		})
+	}
+}
@@ -1,15 +1,18 @@
 package logger
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
-	clog "github.com/charmbracelet/log"
 	otel "github.com/remychantenay/slog-otel"
 )
 
@@ -98,8 +101,12 @@ func TestNewLogger(t *testing.T) {
 
 			if len(tt.opts) > 0 {
 				if tt.opts[0].OpenTelemetry {
-					if _, ok := log.Handler().(*otel.OtelHandler); !ok {
-						t.Errorf("Want %T, got %T", &otel.OtelHandler{}, log.Handler())
+					h := log.Handler()
+					if c, ok := h.(*controlHandler); ok {
+						h = c.Handler
+					}
+					if _, ok := h.(*otel.OtelHandler); !ok {
+						t.Errorf("Want %T, got %T", &otel.OtelHandler{}, h)
 					}
 					return
 				}
@@ -124,7 +131,7 @@ func TestNewContextWithLogger(t *testing.T) {
 		},
 		{
 			name:      "With already set logger in context",
-			parentCtx: context.WithValue(context.Background(), logger{}, NewLogger()),
+			parentCtx: context.WithValue(context.Background(), ctxKey{}, NewLogger()),
 		},
 	}
 
@@ -178,6 +185,13 @@ func TestFromContext(t *testing.T) {
 	}
 }
 
+func TestFromContext_Default(t *testing.T) {
+	t.Cleanup(func() { SetDefault(nil) })
+	if FromContext(context.Background()) != FromContext(nil) { //nolint:staticcheck // nil context is handled
+		t.Error("Expected the same default logger for contexts without logger")
+	}
+}
+
 func TestFromSlog(t *testing.T) {
 	tests := []struct {
 		name string
@@ -221,7 +235,7 @@ func TestLogger_ToSlog(t *testing.T) {
 		},
 		{
 			name: "Nil logger",
-			l:    &logger{nil},
+			l:    &logger{Logger: nil},
 		},
 	}
 
@@ -237,6 +251,25 @@ func TestLogger_ToSlog(t *testing.T) {
 	}
 }
 
+func TestLogger_ToSlog_Attrs(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := NewLogger(Options{Handler: slog.NewJSONHandler(buf, nil)}).
+		With("a", 1).
+		WithGroup("group").
+		WithAttrs(slog.Int("b", 2))
+
+	l.ToSlog().Info("test", "c", 3)
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Failed to unmarshal record %q: %v", buf.String(), err)
+	}
+	group, _ := got["group"].(map[string]any)
+	if got["a"] != float64(1) || group["b"] != float64(2) || group["c"] != float64(3) {
+		t.Errorf("Expected the attributes and groups of the logger, got %v", got)
+	}
+}
+
 func TestMiddleware(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -273,60 +306,65 @@ func TestMiddleware(t *testing.T) {
 	}
 }
 
-func TestNewBaseHandler(t *testing.T) {
-	tests := []struct {
-		name      string
-		format    string
-		level     string
-		wantLevel int
-	}{
-		{
-			name:      "Default handler",
-			format:    "",
-			level:     "",
-			wantLevel: int(slog.LevelInfo),
-		},
-		{
-			name:      "Text handler with custom log level",
-			format:    "TEXT",
-			level:     "DEBUG",
-			wantLevel: int(clog.DebugLevel),
-		},
-		{
-			name:      "JSON handler with custom log level",
-			format:    "JSON",
-			level:     "WARN",
-			wantLevel: int(slog.LevelWarn),
-		},
-		{
-			name:      "Invalid log level",
-			format:    "TEXT",
-			level:     "UNKNOWN",
-			wantLevel: int(clog.InfoLevel),
-		},
+func TestLogger_Handler_RoundTrip(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
 	}
+	stderr := os.Stderr
+	os.Stderr = w
+	l := NewLogger(Options{Format: "JSON", Redaction: "standard", RecordIDs: true}).With("key", "value")
+	os.Stderr = stderr
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Setenv("LOG_FORMAT", tt.format)
-			t.Setenv("LOG_LEVEL", tt.level)
-			opts := newDefaultOptions()
-			handler := newBaseHandler(opts)
-
-			if tt.format == "TEXT" {
-				if _, ok := handler.(*clog.Logger); !ok {
-					t.Errorf("Expected handler to be of type *log.Logger")
-				}
-			} else {
-				if _, ok := handler.(*slog.JSONHandler); !ok {
-					t.Errorf("Expected handler to be of type *slog.JSONHandler")
-				}
-			}
+	FromSlog(slog.New(l.Handler())).Info("test", "password", "hunter2")
+	slog.New(l.Handler()).Info("test", "password", "hunter2")
+	_ = w.Close()
 
-			ok := handler.Enabled(context.Background(), slog.Level(tt.wantLevel))
-			if !ok {
-				t.Errorf("Expected log level: %v", tt.wantLevel)
-			}
-		})
+	dec := json.NewDecoder(r)
+	for range 2 {
+		var got map[string]any
+		if err = dec.Decode(&got); err != nil {
+			t.Fatalf("Failed to parse record: %v", err)
+		}
+		if got["password"] != RedactedValue || got["key"] != "value" || got[RecordIDKey] == nil {
+			t.Errorf("Expected the pipeline and attributes to be kept, got %v", got)
+		}
+	}
+
+	rebuilt := FromSlog(slog.New(l.Handler()))
+	rebuilt.SetLevel(LevelError)
+	if l.Enabled(context.Background(), LevelWarn) {
+		t.Errorf("Expected SetLevel on the rebuilt logger to apply to the original logger")
+	}
+	rebuilt.ResetLevel()
+	restore, err := EnableLevelFor(IntoContext(context.Background(), rebuilt), LevelDebug, time.Minute)
+	if err != nil {
+		t.Fatalf("EnableLevelFor() error = %v", err)
+	}
+	if !l.Enabled(context.Background(), LevelDebug) {
+		t.Errorf("Expected EnableLevelFor on the rebuilt logger to lower the level of the original logger")
+	}
+	restore()
+
+	var buf bytes.Buffer
+	rebuilt.SetHandler(slog.NewJSONHandler(&buf, nil))
+	slog.New(rebuilt.Handler()).Info("swapped", "password", "hunter2")
+	if !strings.Contains(buf.String(), `"msg":"swapped"`) || !strings.Contains(buf.String(), `"password":"`+RedactedValue+`"`) {
+		t.Errorf("Expected SetHandler on the rebuilt logger to swap the sink and keep the pipeline, got %s", buf.String())
+	}
+}
+
+func TestNewWriterHandler_JournaldFallback(t *testing.T) {
+	if _, err := os.Stat(DefaultJournalSocket); err == nil {
+		t.Skip("journal is available")
+	}
+
+	var buf bytes.Buffer
+	h := newWriterHandler(Options{Format: "JOURNALD", Level: "INFO"}, &buf)
+	if _, ok := h.(*JournaldHandler); ok {
+		t.Fatal("Expected the JSON handler without a journal")
+	}
+	if !strings.Contains(buf.String(), `"msg":"Failed to open the journal, writing JSON records instead"`) {
+		t.Errorf("Expected a warning on the fallback, got %s", buf.String())
 	}
 }
@@ -0,0 +1,135 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Escalation is a step of a [Watchdog], logging at Level once the watched operation runs longer than After.
+type Escalation struct {
+	// After is the duration since the start of the operation after which the step is logged.
+	After time.Duration
+	// Level is the level the step is logged at.
+	Level Level
+}
+
+// WatchOptions is the optional configuration for [Watch].
+type WatchOptions struct {
+	// Escalations are the steps logged while the operation is not done.
+	// Defaults to [LevelWarn] after the threshold and [LevelError] after twice the threshold.
+	Escalations []Escalation
+}
+
+// newWatchOptions returns the provided WatchOptions merged with the default WatchOptions for the given threshold.
+func newWatchOptions(threshold time.Duration, o ...WatchOptions) WatchOptions {
+	opts := WatchOptions{
+		Escalations: []Escalation{
+			{After: threshold, Level: LevelWarn},
+			{After: 2 * threshold, Level: LevelError}, //nolint:mnd // escalate to error after twice the threshold
+		},
+	}
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided WatchOptions with the receiver WatchOptions.
+func (o *WatchOptions) merge(d WatchOptions) WatchOptions {
+	if len(o.Escalations) > 0 {
+		d.Escalations = o.Escalations
+	}
+	return d
+}
+
+// Watchdog watches an operation started by [Watch] and escalates the level of its records while it is not done.
+type Watchdog struct {
+	ctx    context.Context
+	log    Provider
+	start  time.Time
+	mu     sync.Mutex
+	timers []*time.Timer
+	// stopAfterCtx unregisters the stop of the escalation once the context is done.
+	stopAfterCtx func() bool
+	done         bool
+}
+
+// Watch logs the start of the named operation at [LevelInfo] with the logger found in the context
+// and escalates to [LevelWarn] and [LevelError] if it is not marked done by [Watchdog.Done] within
+// the threshold and twice the threshold. This catches silently stuck operations.
+// The escalation steps can be configured with [WatchOptions]. Escalation stops once the context is done.
+//
+// Example:
+//
+//	w := logger.Watch(ctx, "db migration", 5*time.Minute)
+//	defer w.Done()
+func Watch(ctx context.Context, name string, threshold time.Duration, o ...WatchOptions) *Watchdog {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	opts := newWatchOptions(threshold, o...)
+	w := &Watchdog{
+		ctx:   ctx,
+		log:   FromContext(ctx).With(OperationKey, name),
+		start: time.Now(),
+	}
+	w.log.LogAttrs(ctx, LevelInfo, "Operation started")
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, e := range opts.Escalations {
+		w.timers = append(w.timers, time.AfterFunc(e.After, func() { w.escalate(e.Level) }))
+	}
+	w.stopAfterCtx = context.AfterFunc(ctx, w.stop)
+	return w
+}
+
+// Done marks the operation as done and logs its completion at [LevelInfo] with its duration.
+// It releases the escalation timers and the watch of the context, so it must be called even if the context outlives the operation.
+// Further calls are ignored.
+func (w *Watchdog) Done(args ...any) {
+	w.mu.Lock()
+	if w.done {
+		w.mu.Unlock()
+		return
+	}
+	w.done = true
+	w.stopTimers()
+	w.stopAfterCtx()
+	w.mu.Unlock()
+
+	w.log.LogAttrs(w.ctx, LevelInfo, "Operation finished", append(argsToAttrs(args), w.duration())...)
+}
+
+// escalate logs that the operation is still running at the given level.
+func (w *Watchdog) escalate(level Level) {
+	w.mu.Lock()
+	done := w.done
+	w.mu.Unlock()
+	if done {
+		return
+	}
+	w.log.LogAttrs(w.ctx, level, "Operation still running", w.duration())
+}
+
+// stop stops the escalation without marking the operation as done.
+func (w *Watchdog) stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stopTimers()
+}
+
+// stopTimers stops all escalation timers. The caller must hold w.mu.
+func (w *Watchdog) stopTimers() {
+	for _, t := range w.timers {
+		t.Stop()
+	}
+}
+
+// duration returns the duration since the start of the operation.
+func (w *Watchdog) duration() slog.Attr {
+	return slog.Duration(OperationDurationKey, time.Since(w.start))
+}
@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger/test"
+)
+
+func TestWatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []WatchOptions
+		wait       time.Duration
+		cancel     bool
+		wantLevels []Level
+	}{
+		{
+			name:       "done in time",
+			wantLevels: []Level{LevelInfo, LevelInfo},
+		},
+		{
+			name:       "escalates to warn",
+			wait:       30 * time.Millisecond,
+			wantLevels: []Level{LevelInfo, LevelWarn, LevelInfo},
+		},
+		{
+			name:       "escalates to error",
+			wait:       60 * time.Millisecond,
+			wantLevels: []Level{LevelInfo, LevelWarn, LevelError, LevelInfo},
+		},
+		{
+			name:       "custom escalations",
+			opts:       []WatchOptions{{Escalations: []Escalation{{After: 5 * time.Millisecond, Level: LevelNotice}}}},
+			wait:       30 * time.Millisecond,
+			wantLevels: []Level{LevelInfo, LevelNotice, LevelInfo},
+		},
+		{
+			name:       "context done stops escalation",
+			wait:       30 * time.Millisecond,
+			cancel:     true,
+			wantLevels: []Level{LevelInfo, LevelInfo},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var levels []Level
+			log := NewLogger(Options{Handler: test.MockHandler{
+				HandleFunc: func(_ context.Context, r slog.Record) error {
+					mu.Lock()
+					defer mu.Unlock()
+					levels = append(levels, Level(r.Level))
+					return nil
+				},
+			}})
+			ctx, cancel := context.WithCancel(IntoContext(context.Background(), log))
+			defer cancel()
+
+			w := Watch(ctx, "migration", 20*time.Millisecond, tt.opts...)
+			if tt.cancel {
+				cancel()
+			}
+			time.Sleep(tt.wait)
+			w.Done()
+			w.Done()
+
+			mu.Lock()
+			defer mu.Unlock()
+			if !slices.Equal(levels, tt.wantLevels) {
+				t.Errorf("Expected levels %v, got %v", tt.wantLevels, levels)
+			}
+		})
+	}
+}
+
+func TestWatchdog_DoneReleasesContext(t *testing.T) {
+	w := Watch(IntoContext(context.Background(), NewLogger(Options{Handler: test.MockHandler{}})), "op", time.Minute)
+	w.Done()
+
+	if w.stopAfterCtx() {
+		t.Errorf("Expected the watch of the context to be stopped by Done")
+	}
+}
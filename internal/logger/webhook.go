@@ -0,0 +1,353 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+const (
+	// defaultWebhookBatchSize is the default [WebhookOptions.BatchSize].
+	defaultWebhookBatchSize = 1
+	// defaultWebhookBatchWait is the default [WebhookOptions.BatchWait].
+	defaultWebhookBatchWait = time.Second
+	// defaultWebhookMaxRetries is the default [WebhookOptions.MaxRetries].
+	defaultWebhookMaxRetries = 5
+	// defaultWebhookMinBackoff is the default [WebhookOptions.MinBackoff].
+	defaultWebhookMinBackoff = 500 * time.Millisecond
+	// defaultWebhookMaxBackoff is the default [WebhookOptions.MaxBackoff].
+	defaultWebhookMaxBackoff = 30 * time.Second
+	// defaultWebhookTimeout is the timeout of the default [WebhookOptions.Client].
+	defaultWebhookTimeout = 10 * time.Second
+	// maxWebhookErrorLength is the maximum length of the response body included in the error of a failed request.
+	maxWebhookErrorLength = 1024
+)
+
+// WebhookOptions is the configuration for [NewWebhookHandler].
+type WebhookOptions struct {
+	// URL is the URL the records are posted to.
+	URL string
+	// Level is the minimum log level.
+	Level Level
+	// Headers are the headers of the requests, e.g. for authentication. The Content-Type defaults to application/json.
+	Headers map[string]string
+	// Template is the [text/template] of the request body. Without a template, the body is the record as JSON object
+	// or, if [WebhookOptions.BatchSize] is greater than 1, the records as JSON array.
+	//
+	// The template is executed with the record as map, e.g. {{.msg}} or {{.level}}, or,
+	// if [WebhookOptions.BatchSize] is greater than 1, with the slice of the records.
+	// The "json" function encodes a value as JSON, e.g. {"text": {{json .msg}}}.
+	Template string
+	// BatchSize is the maximum number of records posted at once. Defaults to 1, which posts every record on its own.
+	BatchSize int
+	// BatchWait is the maximum time records are buffered before they are posted. Defaults to 1 second.
+	BatchWait time.Duration
+	// MaxRetries is the number of retries of a failed request. Defaults to 5.
+	MaxRetries int
+	// MinBackoff is the wait before the first retry, which is doubled with every retry. Defaults to 500 milliseconds.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum wait between retries. Defaults to 30 seconds.
+	MaxBackoff time.Duration
+	// Client is the HTTP client used to post the records. Defaults to a client with a timeout of 10 seconds.
+	Client *http.Client
+	// OnError is called with the errors of requests that failed after all retries, which cannot be returned to the caller.
+	OnError func(err error)
+}
+
+// newWebhookOptions returns the provided WebhookOptions merged with the default WebhookOptions.
+func newWebhookOptions(o WebhookOptions) WebhookOptions {
+	return o.merge(WebhookOptions{
+		Level:      LevelInfo,
+		BatchSize:  defaultWebhookBatchSize,
+		BatchWait:  defaultWebhookBatchWait,
+		MaxRetries: defaultWebhookMaxRetries,
+		MinBackoff: defaultWebhookMinBackoff,
+		MaxBackoff: defaultWebhookMaxBackoff,
+		Client:     &http.Client{Timeout: defaultWebhookTimeout},
+	})
+}
+
+// merge merges the provided WebhookOptions with the receiver WebhookOptions.
+func (o *WebhookOptions) merge(d WebhookOptions) WebhookOptions {
+	if o.Level != 0 {
+		d.Level = o.Level
+	}
+	if o.Headers != nil {
+		d.Headers = o.Headers
+	}
+	for _, f := range []struct{ src, dst *string }{
+		{&o.URL, &d.URL},
+		{&o.Template, &d.Template},
+	} {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+	if o.BatchSize > 0 {
+		d.BatchSize = o.BatchSize
+	}
+	if o.BatchWait > 0 {
+		d.BatchWait = o.BatchWait
+	}
+	if o.MaxRetries > 0 {
+		d.MaxRetries = o.MaxRetries
+	}
+	if o.MinBackoff > 0 {
+		d.MinBackoff = o.MinBackoff
+	}
+	if o.MaxBackoff > 0 {
+		d.MaxBackoff = o.MaxBackoff
+	}
+	if o.Client != nil {
+		d.Client = o.Client
+	}
+	if o.OnError != nil {
+		d.OnError = o.OnError
+	}
+	return d
+}
+
+// webhookFuncs are the functions available in a [WebhookOptions.Template].
+var webhookFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// webhookBatch is the batch and worker shared by a [WebhookHandler] and the handlers derived from it.
+// It is the writer of the JSON handler encoding the records, which writes every record with a single call.
+type webhookBatch struct {
+	opts     WebhookOptions
+	template *template.Template
+	mu       sync.Mutex
+	records  []json.RawMessage
+	closed   bool
+	full     chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+	errMu    sync.Mutex
+	// err is the error of the last request that failed after all retries.
+	err error
+}
+
+var _ slog.Handler = (*WebhookHandler)(nil)
+
+// WebhookHandler is a [slog.Handler] posting records to a webhook.
+// It must be closed to post the buffered records.
+type WebhookHandler struct {
+	slog.Handler
+	batch *webhookBatch
+}
+
+// NewWebhookHandler returns a new [WebhookHandler] posting the records to [WebhookOptions.URL].
+// The records are posted by a background worker, one by one or in batches of [WebhookOptions.BatchSize] records,
+// with the body rendered by [WebhookOptions.Template], e.g. in the payload shape of Slack, Teams or PagerDuty.
+// Failed requests are retried with exponential backoff if the webhook is unreachable,
+// rate limits the requests or returns a server error.
+//
+// It returns an error if the template cannot be parsed.
+func NewWebhookHandler(o WebhookOptions) (*WebhookHandler, error) {
+	opts := newWebhookOptions(o)
+	var tmpl *template.Template
+	if opts.Template != "" {
+		var err error
+		if tmpl, err = template.New("webhook").Funcs(webhookFuncs).Parse(opts.Template); err != nil {
+			return nil, fmt.Errorf("failed to parse webhook template: %w", err)
+		}
+	}
+
+	b := &webhookBatch{
+		opts:     opts,
+		template: tmpl,
+		full:     make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go b.run()
+	return &WebhookHandler{
+		Handler: slog.NewJSONHandler(b, &slog.HandlerOptions{
+			AddSource:   true,
+			Level:       slog.Level(opts.Level),
+			ReplaceAttr: replaceAttr,
+		}),
+		batch: b,
+	}, nil
+}
+
+// Handle buffers the record until it is posted.
+// It returns [ErrHandlerClosed] once the handler is closed.
+func (h *WebhookHandler) Handle(ctx context.Context, r slog.Record) error { //nolint:gocritic // slog.Handler interface
+	h.batch.mu.Lock()
+	if h.batch.closed {
+		h.batch.mu.Unlock()
+		return ErrHandlerClosed
+	}
+	err := h.Handler.Handle(ctx, r)
+	full := len(h.batch.records) >= h.batch.opts.BatchSize
+	h.batch.mu.Unlock()
+
+	if full {
+		select {
+		case h.batch.full <- struct{}{}:
+		default:
+		}
+	}
+	return err
+}
+
+// WithAttrs returns a new handler whose attributes consist of the receiver's attributes followed by attrs.
+func (h *WebhookHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &WebhookHandler{Handler: h.Handler.WithAttrs(attrs), batch: h.batch}
+}
+
+// WithGroup returns a new handler with the given group appended to the receiver's groups.
+func (h *WebhookHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &WebhookHandler{Handler: h.Handler.WithGroup(name), batch: h.batch}
+}
+
+// Close stops accepting records, posts the buffered records and returns the error of the last failed request.
+// It closes the handlers derived by WithAttrs and WithGroup as well.
+func (h *WebhookHandler) Close() error {
+	h.batch.mu.Lock()
+	if !h.batch.closed {
+		h.batch.closed = true
+		close(h.batch.stop)
+	}
+	h.batch.mu.Unlock()
+
+	<-h.batch.done
+	h.batch.errMu.Lock()
+	defer h.batch.errMu.Unlock()
+	return h.batch.err
+}
+
+// Write buffers the encoded record.
+// Must be called with the lock held, which the JSON handler does since it is only called by [WebhookHandler.Handle].
+func (b *webhookBatch) Write(p []byte) (int, error) {
+	b.records = append(b.records, bytes.Clone(bytes.TrimSuffix(p, []byte("\n"))))
+	return len(p), nil
+}
+
+// run posts the buffered records once the batch is full or the batch wait elapsed until the handler is closed.
+func (b *webhookBatch) run() {
+	defer close(b.done)
+	ticker := time.NewTicker(b.opts.BatchWait)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-b.full:
+		case <-b.stop:
+			b.flush()
+			return
+		}
+		b.flush()
+	}
+}
+
+// flush posts the buffered records in batches and reports the failed requests.
+func (b *webhookBatch) flush() {
+	b.mu.Lock()
+	records := b.records
+	b.records = nil
+	b.mu.Unlock()
+
+	for len(records) > 0 {
+		n := min(len(records), b.opts.BatchSize)
+		err := b.post(records[:n])
+		records = records[n:]
+		if err == nil {
+			continue
+		}
+		b.errMu.Lock()
+		b.err = err
+		b.errMu.Unlock()
+		if b.opts.OnError != nil {
+			b.opts.OnError(err)
+		}
+	}
+}
+
+// post renders the body of the records and posts it, retrying with exponential backoff if the request may succeed later.
+func (b *webhookBatch) post(records []json.RawMessage) error {
+	body, err := b.render(records)
+	if err != nil {
+		return err
+	}
+
+	backoff := b.opts.MinBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := b.send(body)
+		if err == nil || !retry || attempt >= b.opts.MaxRetries {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff = min(backoff*2, b.opts.MaxBackoff) //nolint:mnd // the backoff is doubled with every retry
+	}
+}
+
+// render returns the request body of the records.
+func (b *webhookBatch) render(records []json.RawMessage) ([]byte, error) {
+	if b.template == nil {
+		if b.opts.BatchSize == 1 {
+			return records[0], nil
+		}
+		return json.Marshal(records)
+	}
+
+	data := make([]map[string]any, len(records))
+	for i, rec := range records {
+		if err := json.Unmarshal(rec, &data[i]); err != nil {
+			return nil, fmt.Errorf("failed to decode webhook record: %w", err)
+		}
+	}
+	var buf bytes.Buffer
+	var err error
+	if b.opts.BatchSize == 1 {
+		err = b.template.Execute(&buf, data[0])
+	} else {
+		err = b.template.Execute(&buf, data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to render webhook template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// send posts the body once and reports whether a failed request should be retried.
+func (b *webhookBatch) send(body []byte) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, b.opts.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range b.opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := b.opts.Client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to post to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 { //nolint:mnd // 2xx status codes
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return false, nil
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookErrorLength))
+	err = fmt.Errorf("failed to post to webhook: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError, err
+}
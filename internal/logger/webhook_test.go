@@ -0,0 +1,158 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// webhookServer is a fake webhook recording the request bodies.
+type webhookServer struct {
+	mu      sync.Mutex
+	headers []http.Header
+	bodies  []string
+	// fail is the number of requests answered with the status before succeeding.
+	fail   int
+	status int
+}
+
+func (s *webhookServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	body, _ := io.ReadAll(r.Body)
+	s.headers = append(s.headers, r.Header)
+	s.bodies = append(s.bodies, string(body))
+	if s.fail > 0 {
+		s.fail--
+		http.Error(w, "try again", s.status)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestWebhookHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      WebhookOptions
+		wantCalls int
+		want      []string
+	}{
+		{
+			name:      "single records",
+			wantCalls: 2,
+			want:      []string{`"msg":"first","service":"api"}`, `"msg":"second","service":"api"}`},
+		},
+		{
+			name:      "batched records",
+			opts:      WebhookOptions{BatchSize: 10, BatchWait: time.Hour},
+			wantCalls: 1,
+			want:      []string{`[{"time":`, `"msg":"second","service":"api"}]`},
+		},
+		{
+			name:      "template",
+			opts:      WebhookOptions{Template: `{"text": {{json (printf "%s: %s" .level .msg)}}}`},
+			wantCalls: 2,
+			want:      []string{`{"text": "INFO: first"}`, `{"text": "WARN: second"}`},
+		},
+		{
+			name:      "batched template",
+			opts:      WebhookOptions{BatchSize: 10, Template: `{{range .}}{{.msg}};{{end}}`},
+			wantCalls: 1,
+			want:      []string{`first;second;`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &webhookServer{}
+			ts := httptest.NewServer(srv)
+			defer ts.Close()
+
+			tt.opts.URL = ts.URL
+			tt.opts.Headers = map[string]string{"Authorization": "Token secret"}
+			h, err := NewWebhookHandler(tt.opts)
+			if err != nil {
+				t.Fatalf("NewWebhookHandler() error = %v", err)
+			}
+			log := NewLogger(Options{Handler: h}).With("service", "api")
+			log.Debug("ignored")
+			log.Info("first")
+			log.Warn("second")
+			if err := h.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			if len(srv.bodies) != tt.wantCalls {
+				t.Fatalf("Expected %d requests, got %d: %v", tt.wantCalls, len(srv.bodies), srv.bodies)
+			}
+			all := strings.Join(srv.bodies, "\n")
+			for _, want := range tt.want {
+				if !strings.Contains(all, want) {
+					t.Errorf("Expected %s in the requests, got %s", want, all)
+				}
+			}
+			if got := srv.headers[0].Get("Authorization"); got != "Token secret" {
+				t.Errorf("Expected the Authorization header, got %q", got)
+			}
+			if err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "late", 0)); !errors.Is(err, ErrHandlerClosed) {
+				t.Errorf("Expected ErrHandlerClosed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_Retry(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		fail      int
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "server error", status: http.StatusBadGateway, fail: 2, wantCalls: 3},
+		{name: "rate limited", status: http.StatusTooManyRequests, fail: 1, wantCalls: 2},
+		{name: "client error", status: http.StatusBadRequest, fail: 1, wantCalls: 1, wantErr: true},
+		{name: "retries exhausted", status: http.StatusServiceUnavailable, fail: 10, wantCalls: 3, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &webhookServer{fail: tt.fail, status: tt.status}
+			ts := httptest.NewServer(srv)
+			defer ts.Close()
+
+			var reported error
+			h, err := NewWebhookHandler(WebhookOptions{
+				URL:        ts.URL,
+				MaxRetries: 2,
+				MinBackoff: time.Millisecond,
+				OnError:    func(err error) { reported = err },
+			})
+			if err != nil {
+				t.Fatalf("NewWebhookHandler() error = %v", err)
+			}
+			NewLogger(Options{Handler: h}).Info("hello")
+			err = h.Close()
+
+			if len(srv.bodies) != tt.wantCalls {
+				t.Errorf("Expected %d requests, got %d", tt.wantCalls, len(srv.bodies))
+			}
+			if (err != nil) != tt.wantErr || (reported != nil) != tt.wantErr {
+				t.Errorf("Close() error = %v, reported = %v, wantErr %v", err, reported, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewWebhookHandler_InvalidTemplate(t *testing.T) {
+	if _, err := NewWebhookHandler(WebhookOptions{URL: "http://localhost", Template: "{{.msg"}); err == nil {
+		t.Error("Expected an error for the invalid template")
+	}
+}
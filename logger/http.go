@@ -0,0 +1,119 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/lvlcn-t/loggerhead/internal/logger"
+)
+
+// Middleware takes the logger from the context and adds it to the request context.
+func Middleware(ctx context.Context) func(http.Handler) http.Handler {
+	return logger.Middleware(ctx)
+}
+
+const (
+	// RequestIDHeader is the default header used by [RequestID] to read and write the request ID.
+	RequestIDHeader = logger.RequestIDHeader
+	// RequestIDKey is the key used for the request ID.
+	RequestIDKey = logger.RequestIDKey
+)
+
+// RequestID returns a middleware that reads the request ID from the given header or generates a new UUID if it is missing.
+// The request ID is set as header of the response, stored in the request context and added to the attributes
+// of the logger in the request context, so all downstream logs carry the ID.
+// If the header is empty, [RequestIDHeader] is used.
+//
+// Example:
+//
+//	http.Handle("/", logger.Middleware(ctx)(logger.RequestID("")(handler)))
+func RequestID(header string) func(http.Handler) http.Handler {
+	return logger.RequestID(header)
+}
+
+// RequestIDFromContext returns the request ID stored in the context by the [RequestID] middleware.
+// Returns an empty string if the context does not carry a request ID.
+func RequestIDFromContext(ctx context.Context) string {
+	return logger.RequestIDFromContext(ctx)
+}
+
+// AccessLogOptions is the optional configuration for the [AccessLog] middleware.
+// Empty fields are replaced by their defaults.
+type AccessLogOptions = logger.AccessLogOptions
+
+// AccessLog returns a middleware that logs every request with the logger found in the request context.
+// The record contains the method, path, status code, response size, duration, remote address and user agent.
+// The level is chosen by the status class: 5xx are logged at [LevelError], 4xx at [LevelWarn] and all others at [LevelInfo].
+// With [AccessLogOptions.Debug], the request headers are added as well, with the sensitive ones masked.
+//
+// Use it after [Middleware] and [RequestID] to log with the request's logger.
+//
+// Example:
+//
+//	http.Handle("/", logger.Middleware(ctx)(logger.RequestID("")(logger.AccessLog()(handler))))
+func AccessLog(o ...AccessLogOptions) func(http.Handler) http.Handler {
+	return logger.AccessLog(o...)
+}
+
+// HeadersKey is the key used for the group of HTTP headers returned by [Headers].
+const HeadersKey = logger.HeadersKey
+
+// Headers returns a group attribute with the given HTTP headers under [HeadersKey], sorted by name.
+// The values of sensitive headers (Authorization, Proxy-Authorization, Cookie and Set-Cookie) are replaced by [RedactedValue]
+// and the values of headers with multiple values are joined by commas.
+//
+// The size of the group is capped: values longer than 256 bytes are truncated with an ellipsis
+// and only the first 64 headers are added, followed by the number of omitted headers under [TruncatedKey].
+//
+// Example:
+//
+//	log.Debug("Webhook received", logger.Headers(r.Header))
+func Headers(h http.Header) slog.Attr {
+	return logger.Headers(h)
+}
+
+// Recover returns a middleware that recovers from panics in downstream handlers.
+// The panic is logged at [LevelPanic] with the logger found in the request context,
+// including a stack trace under [StacktraceKey] and the request's method, path and remote address.
+// If the response was not written yet, it responds with [http.StatusInternalServerError].
+//
+// Example:
+//
+//	http.Handle("/", logger.Middleware(ctx)(logger.Recover()(handler)))
+func Recover() func(http.Handler) http.Handler {
+	return logger.Recover()
+}
+
+// NewTransport returns a new [http.RoundTripper] that logs every outbound request with the logger found in the request context.
+// The record contains the method, URL, status code, duration and the error if the request failed.
+// The query and the fragment of the URL are omitted and its password is redacted, since they often carry tokens or personal data.
+// Failed requests are logged at [LevelError], otherwise the level is chosen by the status class like in [AccessLog].
+// If base is nil, [http.DefaultTransport] is used.
+//
+// Example:
+//
+//	client := &http.Client{Transport: logger.NewTransport(nil)}
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	return logger.NewTransport(base)
+}
+
+// RequestScopeOptions is the optional configuration for the [RequestScope] middleware.
+// Empty fields are replaced by their defaults.
+type RequestScopeOptions = logger.RequestScopeOptions
+
+// RequestScope returns a middleware that buffers all records logged with the logger of the request context
+// and flushes them together with a summary record once the request context is done.
+// The flush is registered with [context.AfterFunc], so the records and the summary are emitted even if the client
+// disconnects and the request is abandoned while the handler is still running.
+// Records logged after the flush are passed through directly.
+//
+// The summary contains the method, path, duration and number of buffered records, the status code if the request finished
+// and whether the request was aborted. Its level is chosen by the status class like in [AccessLog].
+//
+// Example:
+//
+//	http.Handle("/", logger.Middleware(ctx)(logger.RequestID("")(logger.RequestScope()(handler))))
+func RequestScope(o ...RequestScopeOptions) func(http.Handler) http.Handler {
+	return logger.RequestScope(o...)
+}
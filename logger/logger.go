@@ -2,8 +2,11 @@ package logger
 
 import (
 	"context"
+	"io"
+	"log"
 	"log/slog"
-	"net/http"
+	"regexp"
+	"time"
 
 	"github.com/lvlcn-t/loggerhead/internal/logger"
 )
@@ -16,11 +19,27 @@ type Logger = Provider // TODO: Remove in v0.4.0
 
 // Provider is the interface for the logger.
 // Its build on top of slog.Logger and extends it with additional logging methods.
+// It satisfies leveled logger interfaces like the LeveledLogger of go-retryablehttp,
+// so it can be passed to such libraries without an adapter.
 type Provider = logger.Provider
 
 // Options is the optional configuration for the logger.
 type Options = logger.Options
 
+// SourcePathStyle is the form of the file paths of the sources of JSON records, see [Options.SourcePath].
+type SourcePathStyle = logger.SourcePathStyle
+
+const (
+	// SourcePathFull writes the absolute paths of the files on the build host.
+	SourcePathFull = logger.SourcePathFull
+	// SourcePathModule writes the paths of the files relative to the root of the main module,
+	// e.g. "internal/server/server.go", and the import paths of the packages of other modules,
+	// e.g. "github.com/org/lib/client/client.go".
+	SourcePathModule = logger.SourcePathModule
+	// SourcePathPackage writes the name of the directory of the package and the file, e.g. "server/server.go".
+	SourcePathPackage = logger.SourcePathPackage
+)
+
 // Level is a custom type for log levels.
 type Level = logger.Level
 
@@ -43,6 +62,25 @@ const (
 	LevelFatal = logger.LevelFatal
 )
 
+const (
+	// ColorKey is the key used for the color hint of a record's level if [Options.LevelHints] is enabled.
+	ColorKey = logger.ColorKey
+	// SeverityKey is the key used for the numeric severity of a record's level if [Options.LevelHints] is enabled.
+	SeverityKey = logger.SeverityKey
+	// TraceIDKey is the key used for the trace ID of the span found in the context if [Options.TraceContext] is enabled.
+	TraceIDKey = logger.TraceIDKey
+	// SpanIDKey is the key used for the span ID of the span found in the context if [Options.TraceContext] is enabled.
+	SpanIDKey = logger.SpanIDKey
+	// SplitIDKey is the key used for the ID shared by all parts of a split record.
+	SplitIDKey = logger.SplitIDKey
+	// PartKey is the key used for the 1-based index of a part of a split record.
+	PartKey = logger.PartKey
+	// TotalKey is the key used for the total number of parts of a split record.
+	TotalKey = logger.TotalKey
+	// StacktraceKey is the key used for stack traces.
+	StacktraceKey = logger.StacktraceKey
+)
+
 // NewLogger creates a new Logger instance with optional configurations.
 // The logger can be customized by passing an Options struct which allows for
 // setting the log level, format, OpenTelemetry support, and a custom handler.
@@ -68,6 +106,125 @@ func NewNamedLogger(name string, o ...logger.Options) logger.Provider {
 	return logger.NewNamedLogger(name, o...)
 }
 
+// NewDevelopment returns a new logger for local development, writing colored text records
+// with their source at [LevelDebug]. The given options take precedence over the profile,
+// and the environment variables like LOG_LEVEL over both, see [NewLogger].
+//
+// Example:
+//
+//	log := logger.NewDevelopment()
+func NewDevelopment(o ...logger.Options) logger.Provider {
+	return logger.NewDevelopment(o...)
+}
+
+// NewProduction returns a new logger for production, writing JSON records at [LevelInfo].
+// Repeated records are sampled with the default [SamplingOptions] and sensitive attributes are redacted
+// with the [RedactStandard] policy. The given options take precedence over the profile,
+// and the environment variables like LOG_LEVEL over both, see [NewLogger].
+//
+// Example:
+//
+//	log := logger.NewProduction(logger.Options{RecordIDs: true})
+func NewProduction(o ...logger.Options) logger.Provider {
+	return logger.NewProduction(o...)
+}
+
+// NewTesting returns a new logger for tests, capturing the records of all levels through the test
+// like [NewTestHandler]. The output is deterministic, i.e. it omits the time and the source
+// and numbers the record IDs sequentially, see [Options.Deterministic].
+// Unlike a logger with the [NewTestHandler], the wrappers enabled by the given options, e.g. redaction, are applied.
+// [Options.Handler] is ignored.
+//
+// Example:
+//
+//	func TestServer(t *testing.T) {
+//		srv := NewServer(logger.NewTesting(t))
+//		// ...
+//	}
+func NewTesting(tb TB, o ...logger.Options) logger.Provider {
+	tb.Helper()
+	return logger.NewTesting(tb, o...)
+}
+
+// NewFromAppEnv returns a new logger with the profile of the environment in the APP_ENV environment variable:
+// [NewDevelopment] for "dev", "development" or "local" and [NewProduction] for "prod" or "production".
+// For "test" or "testing", the records are written to stderr with the deterministic output of [NewTesting].
+// Otherwise, it returns [NewLogger] with the given options.
+//
+// Example:
+//
+//	log := logger.NewFromAppEnv()
+func NewFromAppEnv(o ...logger.Options) logger.Provider {
+	return logger.NewFromAppEnv(o...)
+}
+
+// ErrInvalidConfig is returned by [NewFromConfig] if the [Config] is invalid.
+var ErrInvalidConfig = logger.ErrInvalidConfig
+
+// Config is the configuration of a logger built by [NewFromConfig], e.g. decoded from the configuration
+// of the application. In contrast to the [Options], it is not overridden by environment variables like LOG_LEVEL.
+type Config = logger.Config
+
+// SinkConfig is the configuration of an output of a [Config].
+type SinkConfig = logger.SinkConfig
+
+// NewFromConfig returns a new logger built from the configuration,
+// so applications can drive the logger from their own configuration systems instead of environment variables.
+// Returns an error wrapping [ErrInvalidConfig] if the configuration is invalid or an output cannot be opened.
+//
+// The files of the outputs stay open for the lifetime of the program.
+// The outputs can be replaced at runtime with [Provider.SetHandler].
+//
+// Example:
+//
+//	log, err := logger.NewFromConfig(logger.Config{
+//		Level:    "DEBUG",
+//		Sampling: &logger.SamplingOptions{Initial: 10, Thereafter: 100},
+//		Outputs: []logger.SinkConfig{
+//			{Format: "TEXT"},
+//			{Output: "/var/log/app/errors.log", Level: "ERROR"},
+//		},
+//	})
+func NewFromConfig(cfg Config) (logger.Provider, error) {
+	return logger.NewFromConfig(cfg)
+}
+
+// LoadConfig reads the [Config] from the file at the path and validates it.
+// The format of the file is selected by its extension: ".yaml" or ".yml" for YAML, ".json" for JSON
+// and ".toml" for TOML.
+// Unknown keys are rejected, so typos do not go unnoticed.
+//
+// Durations like the sampling tick are written as strings like "1s" or as integer nanoseconds.
+//
+// Example:
+//
+//	cfg, err := logger.LoadConfig("/etc/app/logging.yaml")
+//	if err != nil {
+//		return err
+//	}
+//	log, err := logger.NewFromConfig(cfg)
+func LoadConfig(path string) (Config, error) {
+	return logger.LoadConfig(path)
+}
+
+// WatchConfig returns a new logger built from the [Config] in the file at the path, see [LoadConfig],
+// and reloads it every interval (5 seconds if zero) until the context is done.
+//
+// If the file changed, the outputs with their levels and formats, the time settings and the sampling
+// are replaced atomically, so the loggers already derived from the returned one follow the change.
+// The other settings, e.g. the redaction, only take effect on restart. If the changed file is invalid,
+// the error is logged and the previous configuration is kept. The files of replaced outputs are closed.
+//
+// Example:
+//
+//	log, err := logger.WatchConfig(ctx, "/etc/app/logging.yaml", 0)
+//	if err != nil {
+//		return err
+//	}
+func WatchConfig(ctx context.Context, path string, interval time.Duration) (logger.Provider, error) {
+	return logger.WatchConfig(ctx, path, interval)
+}
+
 // NewContextWithLogger creates a new context based on the provided parent context.
 // It embeds a logger into this new context, which is a child of the logger from the parent context.
 // The child logger inherits settings from the parent.
@@ -85,18 +242,1598 @@ func IntoContext(ctx context.Context, log logger.Provider) context.Context {
 }
 
 // FromContext extracts the [logger.Provider] from the provided context.
-// If the context does not have a logger, it returns a new logger with the default configuration.
+// If the context does not have a logger, it returns the default logger, see [Default].
+// The default logger is built once, so the outputs it opens (e.g. the file of LOG_OUTPUT) are shared by all calls.
 // This function is useful for retrieving loggers from context in different parts of an application.
 func FromContext(ctx context.Context) logger.Provider {
 	return logger.FromContext(ctx)
 }
 
-// Middleware takes the logger from the context and adds it to the request context.
-func Middleware(ctx context.Context) func(http.Handler) http.Handler {
-	return logger.Middleware(ctx)
-}
-
 // FromSlog returns a new [Logger] instance from the provided [slog.Logger].
+// The logger uses the handler of the [slog.Logger], so a logger round-tripped via [Provider.Handler] or [Provider.ToSlog]
+// keeps its pipeline, and [Provider.SetHandler], [Provider.SetLevel] and [EnableLevelFor] still control it.
+// The Fatal and Panic behavior configured by the [Options] is not part of the handler and is not kept.
 func FromSlog(l *slog.Logger) logger.Provider {
 	return logger.FromSlog(l)
 }
+
+// RegisterLevel registers a custom log level with the given name, e.g. AUDIT or SECURITY.
+// Registered levels are rendered by their name in both the JSON and the text format
+// and can be used as the minimum log level, e.g. via the LOG_LEVEL environment variable.
+// It is safe for concurrent use with logging.
+//
+// Levels must be registered before the logger is created to be rendered by the text format.
+//
+// Example:
+//
+//	const LevelAudit = logger.Level(10)
+//	logger.RegisterLevel("AUDIT", LevelAudit)
+//	log := logger.NewLogger()
+//	log.Log(ctx, LevelAudit, "User deleted", "user", "jane.doe")
+func RegisterLevel(name string, level Level) {
+	logger.RegisterLevel(name, level)
+}
+
+// AppendCtx returns a copy of the context with the given attributes appended to the attributes already stored in it.
+// The attributes are added to every record logged with the returned context,
+// so per-request fields don't need to be passed to every log call.
+//
+// Example:
+//
+//	ctx = logger.AppendCtx(ctx, slog.String("request_id", id))
+//	log.InfoContext(ctx, "Handling request") // includes request_id
+func AppendCtx(ctx context.Context, attrs ...slog.Attr) context.Context {
+	return logger.AppendCtx(ctx, attrs...)
+}
+
+// AttrsFromContext returns the attributes stored in the context by [AppendCtx].
+func AttrsFromContext(ctx context.Context) []slog.Attr {
+	return logger.AttrsFromContext(ctx)
+}
+
+// NewContextHandler returns a new [slog.Handler] that adds the attributes stored in the context by [AppendCtx]
+// to every record before passing it to the given handler.
+func NewContextHandler(h slog.Handler) slog.Handler {
+	return logger.NewContextHandler(h)
+}
+
+// Silence mutes the named loggers with the given names until the returned restore function is called
+// or the context is done, whichever happens first.
+// The start and the end of the silencing window are logged with the logger found in the context.
+//
+// Example:
+//
+//	restore := logger.Silence(ctx, "importer")
+//	defer restore()
+func Silence(ctx context.Context, names ...string) (restore func()) {
+	return logger.Silence(ctx, names...)
+}
+
+// SetLevelFor sets the minimum level of the named loggers with the given name and the names below it,
+// e.g. "db" applies to "db" and "db.migrations", so a noisy subsystem can be silenced or boosted
+// without affecting the rest. The level of the most specific name wins and takes precedence
+// over the level of the logger, including the level set by [Provider.SetLevel].
+// The windows of [EnableLevelFor] still lower it while they are active.
+//
+// It applies to the loggers created by [NewNamedLogger] or given a name with the [NameKey] attribute,
+// if their handlers are built from the [Options] or a [Config].
+//
+// Example:
+//
+//	logger.SetLevelFor("db", logger.LevelError)
+//	logger.SetLevelFor("db.migrations", logger.LevelDebug)
+func SetLevelFor(name string, level Level) {
+	logger.SetLevelFor(name, level)
+}
+
+// ResetLevelFor removes the level of the name set by [SetLevelFor].
+// The named loggers fall back to the level of the closest name above it or their own level.
+func ResetLevelFor(name string) {
+	logger.ResetLevelFor(name)
+}
+
+// Helper marks the calling function as a logging helper, like [testing.T.Helper].
+// The records logged by a helper, or by functions called by a helper, report the caller of the helper
+// as source instead of the helper itself, so wrapper functions report the real call site.
+// It may be called from multiple goroutines and its cost is small after the first call of a function.
+//
+// Example:
+//
+//	func logFailure(log logger.Provider, err error) {
+//		logger.Helper()
+//		log.Error("Request failed", "error", err)
+//	}
+func Helper() {
+	logger.Helper()
+}
+
+// ErrNoContextLogger is returned by [EnableLevelFor] if the context does not have a logger.
+var ErrNoContextLogger = logger.ErrNoContextLogger
+
+// EnableLevelFor lowers the minimum level of the logger found in the context to the given level
+// for the duration, e.g. to debug a production issue for 10 minutes without a restart.
+// The level is restored once the duration has elapsed, the returned restore function is called
+// or the context is done, whichever happens first.
+// Returns [ErrNoContextLogger] if the context does not have a logger, see [IntoContext],
+// so the level of the [Default] logger is not changed by accident.
+//
+// The start and the end of the window are logged with the logger at [LevelNotice].
+// A window only lowers the minimum level: while it is active, the minimum level is the lowest
+// of the window levels and the level that applies otherwise, i.e. the level set by [SetLevelFor]
+// for the logger's name, the level set by [Provider.SetLevel] or the configured level, so windows may overlap.
+// The restore function is safe to call multiple times.
+// Like [Provider.SetLevel], it has no effect on handlers passed to [Options.Handler] or [Provider.SetHandler].
+//
+// Example:
+//
+//	restore, err := logger.EnableLevelFor(ctx, logger.LevelDebug, 10*time.Minute)
+//	if err != nil {
+//		return err
+//	}
+//	defer restore()
+func EnableLevelFor(ctx context.Context, level Level, d time.Duration) (restore func(), err error) {
+	return logger.EnableLevelFor(ctx, level, d)
+}
+
+// NewTraceHandler returns a new [slog.Handler] that adds the trace and span ID of the OpenTelemetry span
+// found in the context to every record before passing it to the given handler.
+func NewTraceHandler(h slog.Handler) slog.Handler {
+	return logger.NewTraceHandler(h)
+}
+
+const (
+	// ErrorKey is the key used for the error group by [Err].
+	ErrorKey = logger.ErrorKey
+	// ErrorMessageKey is the key used for the error message within the error group.
+	ErrorMessageKey = logger.ErrorMessageKey
+	// ErrorTypeKey is the key used for the type name of the error within the error group.
+	ErrorTypeKey = logger.ErrorTypeKey
+	// ErrorChainKey is the key used for the chain of wrapped errors within the error group.
+	ErrorChainKey = logger.ErrorChainKey
+)
+
+// Wrap returns an error wrapping err that carries the given attributes.
+// The arguments are handled in the manner of [Provider.With].
+// When the error is logged with [Err], the attributes of all wrapped errors are added to the record,
+// which preserves context gathered deep in the call stack.
+//
+// Returns nil if err is nil.
+//
+// Example:
+//
+//	if err := db.Ping(); err != nil {
+//		return logger.Wrap(err, "host", host)
+//	}
+func Wrap(err error, args ...any) error {
+	return logger.Wrap(err, args...)
+}
+
+// WithStack returns an error wrapping err that carries the stack trace of the caller.
+// When the error is logged with [Err], the stack trace is added to the error group under [StacktraceKey].
+//
+// Returns nil if err is nil.
+func WithStack(err error) error {
+	return logger.WithStack(err)
+}
+
+// Err returns an attribute for the given error.
+// The attribute consists of a group under [ErrorKey] followed by the attributes
+// attached to the error and its wrapped errors by [Wrap].
+// The group contains the error message, the type name of the error, the message and type name of
+// every wrapped error in the unwrap chain (if any) and the stack trace captured by [WithStack] (if any).
+//
+// Example:
+//
+//	log.Error("Failed to connect", logger.Err(err))
+func Err(err error) slog.Attr {
+	return logger.Err(err)
+}
+
+// NewSplitHandler returns a new [slog.Handler] that splits records exceeding the given size in bytes
+// into multiple records instead of passing them to the given handler as a whole.
+// This prevents sinks with a maximum payload size (e.g. CloudWatch or UDP) from truncating records or returning errors.
+//
+// The parts of a split record share the same [SplitIDKey] and carry their 1-based index as [PartKey]
+// and the total number of parts as [TotalKey].
+// The size of a record is estimated from its JSON encoding.
+func NewSplitHandler(h slog.Handler, maxSize int) slog.Handler {
+	return logger.NewSplitHandler(h, maxSize)
+}
+
+// TruncatedRecordKey is the key used to flag the records truncated by [NewLimitHandler].
+const TruncatedRecordKey = logger.TruncatedRecordKey
+
+// TruncationMarker is appended to the strings truncated by [NewLimitHandler].
+const TruncationMarker = logger.TruncationMarker
+
+// LimitOptions is the configuration for [NewLimitHandler]. Zero values disable the limits.
+type LimitOptions = logger.LimitOptions
+
+// NewLimitHandler returns a new [slog.Handler] that truncates records exceeding the given limits before
+// passing them to the given handler, which protects downstream ingestion from accidental huge payloads,
+// e.g. a whole response body logged as attribute. Truncated records carry [TruncatedRecordKey] set to true.
+//
+// In contrast to [NewSplitHandler], the data exceeding the limits is lost.
+// Returns the given handler if no limit is set.
+//
+// Example:
+//
+//	h := logger.NewLimitHandler(slog.NewJSONHandler(os.Stderr, nil), logger.LimitOptions{
+//		MaxStringLength: 1024,
+//		MaxAttrs:        64,
+//		MaxRecordSize:   64 << 10,
+//	})
+func NewLimitHandler(h slog.Handler, o LimitOptions) slog.Handler {
+	return logger.NewLimitHandler(h, o)
+}
+
+// ElapsedKey is the key used by [Elapsed].
+const ElapsedKey = logger.ElapsedKey
+
+// Elapsed returns a group attribute with the duration since start measured with the monotonic clock
+// and with the wall clock.
+// A difference between both durations indicates that the wall clock was adjusted (e.g. by NTP) during the measured period,
+// which helps to correlate logs across machines with skewed clocks.
+//
+// Example:
+//
+//	start := time.Now()
+//	// ...
+//	log.Info("Finished", logger.Elapsed(start))
+func Elapsed(start time.Time) slog.Attr {
+	return logger.Elapsed(start)
+}
+
+// ClockOffsetSampler periodically samples the offset of the local clock to an NTP server.
+// The latest sampled offset is added to every record by the handler returned by [NewClockOffsetHandler].
+type ClockOffsetSampler = logger.ClockOffsetSampler
+
+// ClockOffsetKey is the key used for the clock offset sampled by a [ClockOffsetSampler].
+const ClockOffsetKey = logger.ClockOffsetKey
+
+// DefaultNTPServer is the NTP server queried by a [ClockOffsetSampler] if no server is provided.
+const DefaultNTPServer = logger.DefaultNTPServer
+
+// NewClockOffsetSampler returns a new [ClockOffsetSampler] querying the given NTP server ("host:port") every interval.
+// If the server is empty, [DefaultNTPServer] is used.
+//
+// Example:
+//
+//	sampler := logger.NewClockOffsetSampler("", time.Minute)
+//	go sampler.Start(ctx)
+//	log := logger.NewLogger(logger.Options{Handler: logger.NewClockOffsetHandler(h, sampler)})
+func NewClockOffsetSampler(server string, interval time.Duration) *ClockOffsetSampler {
+	return logger.NewClockOffsetSampler(server, interval)
+}
+
+// NewClockOffsetHandler returns a new [slog.Handler] that adds the latest clock offset sampled by s
+// under [ClockOffsetKey] to every record before passing it to the given handler.
+// The attribute is omitted until the first offset was sampled.
+func NewClockOffsetHandler(h slog.Handler, s *ClockOffsetSampler) slog.Handler {
+	return logger.NewClockOffsetHandler(h, s)
+}
+
+// ErrInvalidInterval is returned by periodic tasks like [SnapshotEvery] if the interval is not positive.
+var ErrInvalidInterval = logger.ErrInvalidInterval
+
+const (
+	// SnapshotKey is the key used for the name of a metrics snapshot.
+	SnapshotKey = logger.SnapshotKey
+	// MetricsKey is the key used for the group of metrics of a snapshot.
+	MetricsKey = logger.MetricsKey
+)
+
+// MetricsProvider provides the current values of application metrics, e.g. queue depths or cache hit rates.
+type MetricsProvider = logger.MetricsProvider
+
+// MetricsProviderFunc is an adapter to allow the use of ordinary functions as [MetricsProvider].
+type MetricsProviderFunc = logger.MetricsProviderFunc
+
+// Snapshot logs a structured snapshot of the metrics provided by p with the logger found in the context.
+// The record is logged at [LevelInfo] with the name under [SnapshotKey] and the metrics grouped under [MetricsKey].
+// This is useful for environments without a metrics backend.
+//
+// Example:
+//
+//	logger.Snapshot(ctx, "worker", logger.MetricsProviderFunc(func(ctx context.Context) []slog.Attr {
+//		return []slog.Attr{slog.Int("queue_depth", queue.Len())}
+//	}))
+func Snapshot(ctx context.Context, name string, p MetricsProvider) {
+	logger.Snapshot(ctx, name, p)
+}
+
+// SnapshotEvery logs a snapshot of the metrics provided by p every interval until the context is done.
+// It blocks until the context is done, so it should be run in a separate goroutine.
+// Returns [ErrInvalidInterval] if the interval is not positive.
+func SnapshotEvery(ctx context.Context, name string, p MetricsProvider, interval time.Duration) error {
+	return logger.SnapshotEvery(ctx, name, p, interval)
+}
+
+const (
+	// DropReasonSampler is the reason of the records dropped by [NewSamplingHandler].
+	DropReasonSampler = logger.DropReasonSampler
+	// DropReasonAsyncQueue is the reason of the records dropped by an [AsyncHandler] after it was closed.
+	DropReasonAsyncQueue = logger.DropReasonAsyncQueue
+)
+
+// PipelineMetrics collects counters of the logging pipeline: the records emitted per handler and level,
+// the records dropped per reason, e.g. by the sampler or a closed async queue, and the errors per handler.
+// The counters are exposed in the Prometheus text format by ServeHTTP and WriteTo,
+// so dashboards can alert on error log surges or log loss, and as attributes by Metrics, e.g. for [SnapshotEvery].
+type PipelineMetrics = logger.PipelineMetrics
+
+// NewPipelineMetrics returns a new [PipelineMetrics] without any counts.
+//
+// Example:
+//
+//	metrics := logger.NewPipelineMetrics()
+//	log := logger.NewLogger(logger.Options{Metrics: metrics, Sampling: &logger.SamplingOptions{}})
+//	http.Handle("/metrics/logging", metrics)
+func NewPipelineMetrics() *PipelineMetrics {
+	return logger.NewPipelineMetrics()
+}
+
+// NewMetricsHandler returns a new [slog.Handler] that counts the records handled by the given handler
+// per level and the errors it returns in the metrics under the given handler name, e.g. the name of the output.
+// Returns the given handler if the metrics are nil.
+//
+// Example:
+//
+//	h := logger.NewMetricsHandler(logger.NewLokiHandler(opts), metrics, "loki")
+//	log := logger.NewLogger(logger.Options{Handler: h})
+func NewMetricsHandler(h slog.Handler, m *PipelineMetrics, name string) slog.Handler {
+	return logger.NewMetricsHandler(h, m, name)
+}
+
+// RecordIDKey is the key used for the unique ID of a record.
+const RecordIDKey = logger.RecordIDKey
+
+// TruncatedKey is the key used for the hint on omitted attributes, e.g. of text records truncated to the terminal width
+// or of the headers omitted by [Headers].
+const TruncatedKey = logger.TruncatedKey
+
+// IDGenerator generates unique IDs for records.
+// Implementations must be safe for concurrent use.
+type IDGenerator = logger.IDGenerator
+
+// ULIDGenerator generates monotonic ULIDs (https://github.com/ulid/spec).
+// IDs generated within the same millisecond are strictly increasing, so they are sortable by creation order.
+type ULIDGenerator = logger.ULIDGenerator
+
+// NewULIDGenerator returns a new [ULIDGenerator].
+func NewULIDGenerator() *ULIDGenerator {
+	return logger.NewULIDGenerator()
+}
+
+// NewULID returns a new monotonic ULID.
+func NewULID() string {
+	return logger.NewULID()
+}
+
+// NewRecordIDHandler returns a new [slog.Handler] that adds a unique ID generated by gen to every record under [RecordIDKey].
+// Downstream consumers of at-least-once sinks can use the ID to deduplicate records.
+// Records already carrying a [RecordIDKey] attribute keep their ID, e.g. to wait for their delivery with [Ack].
+// If gen is nil, monotonic ULIDs are generated.
+func NewRecordIDHandler(h slog.Handler, gen IDGenerator) slog.Handler {
+	return logger.NewRecordIDHandler(h, gen)
+}
+
+// Envelope is the format in which records are shipped to at-least-once sinks.
+// The ID is always at the top level, so consumers can deduplicate records without knowing their schema.
+type Envelope = logger.Envelope
+
+// NewEnvelope returns the [Envelope] of the record.
+// The ID is taken from the record's [RecordIDKey] attribute if present, otherwise a new ID is generated by gen.
+// If gen is nil, monotonic ULIDs are generated.
+func NewEnvelope(r slog.Record, gen IDGenerator) Envelope { //nolint:gocritic // records are passed by value
+	return logger.NewEnvelope(r, gen)
+}
+
+// Ack returns a channel receiving the result of the delivery of the record with the given ID, see [RecordIDKey]:
+// nil once a sink acknowledged its durable delivery, the sink's error if the delivery failed
+// or the error of the context if it is done first. The channel is closed after the result is sent.
+//
+// This allows applications with audit requirements to confirm that critical records were delivered before proceeding.
+// It requires a sink supporting acknowledgments, which reports the delivery of every record by calling [Acknowledge] with its ID:
+// a [FluentHandler] with [FluentOptions.RequireAck], a [LokiHandler] once Loki accepted the batch
+// and a [NATSHandler] with [NATSOptions.JetStream] once the stream stored the record.
+// Custom sinks call [Acknowledge] themselves.
+// Records logged with a [RecordIDKey] attribute keep their ID, so the ID to wait for can be chosen upfront:
+//
+//	id := logger.NewULID()
+//	log.Info("Payment captured", logger.RecordIDKey, id)
+//	if err := <-logger.Ack(ctx, id); err != nil {
+//		return err
+//	}
+func Ack(ctx context.Context, recordID string) <-chan error {
+	return logger.Ack(ctx, recordID)
+}
+
+// Acknowledge reports the delivery of the record with the given ID to the callers of [Ack] waiting for it.
+// A nil error reports a durable delivery, any other error a failed one.
+// It is called by sinks supporting acknowledgments, e.g. once the broker confirmed the record.
+func Acknowledge(recordID string, err error) {
+	logger.Acknowledge(recordID, err)
+}
+
+const (
+	// AuditActorKey is the key of the mandatory attribute identifying who performed the audited action.
+	AuditActorKey = logger.AuditActorKey
+	// AuditActionKey is the key of the mandatory attribute naming the audited action, e.g. "delete".
+	AuditActionKey = logger.AuditActionKey
+	// AuditResourceKey is the key of the mandatory attribute identifying the resource the action was performed on.
+	AuditResourceKey = logger.AuditResourceKey
+	// AuditOutcomeKey is the key of the mandatory attribute describing the outcome of the action, e.g. "success".
+	AuditOutcomeKey = logger.AuditOutcomeKey
+	// AuditHashKey is the key used for the hash of a chained audit record, see [AuditOptions.Chain].
+	AuditHashKey = logger.AuditHashKey
+	// AuditPrevHashKey is the key used for the hash of the previous chained audit record, see [AuditOptions.Chain].
+	AuditPrevHashKey = logger.AuditPrevHashKey
+)
+
+var (
+	// ErrMissingAuditField is returned by [AuditLogger.Audit] for events without a mandatory attribute.
+	ErrMissingAuditField = logger.ErrMissingAuditField
+	// ErrAuditChainBroken is returned by [VerifyAuditChain] for records whose hashes do not match.
+	ErrAuditChainBroken = logger.ErrAuditChainBroken
+)
+
+// AuditOptions is the optional configuration for [NewAuditLogger].
+type AuditOptions = logger.AuditOptions
+
+// AuditLogger writes audit events to its own handlers, separate from the application logs.
+// Unlike a [Provider], it never samples, filters or drops events: every event with all mandatory attributes
+// is passed to every handler regardless of its level, and the errors of the handlers are returned to the caller.
+// Handlers dropping records themselves, e.g. a [NewAsyncHandler] with a full queue, should not be used.
+type AuditLogger = logger.AuditLogger
+
+// NewAuditLogger returns a new [AuditLogger] writing to the handlers of the given options.
+//
+// Example:
+//
+//	audit := logger.NewAuditLogger(logger.AuditOptions{Handlers: []slog.Handler{h}, Chain: true})
+//	err := audit.Audit(ctx, "User deleted",
+//		slog.String(logger.AuditActorKey, "admin"),
+//		slog.String(logger.AuditActionKey, "delete"),
+//		slog.String(logger.AuditResourceKey, "user/jane.doe"),
+//		slog.String(logger.AuditOutcomeKey, "success"),
+//	)
+func NewAuditLogger(o ...AuditOptions) *AuditLogger {
+	return logger.NewAuditLogger(o...)
+}
+
+// VerifyAuditChain verifies the chained audit records read as JSON lines from r, e.g. from the file of a
+// JSON handler of an [AuditLogger] with [AuditOptions.Chain]. The times must be written in RFC 3339 with
+// nanoseconds, like [slog.JSONHandler] does. prevHash is the hash preceding the first record,
+// empty for a new chain. It returns the hash of the last record, to verify the next part of a rotated chain,
+// or an error wrapping [ErrAuditChainBroken] for the first record that was modified, inserted or removed.
+//
+// Example:
+//
+//	f, _ := os.Open("audit.log")
+//	if _, err := logger.VerifyAuditChain(f, ""); err != nil {
+//		return err
+//	}
+func VerifyAuditChain(r io.Reader, prevHash string) (string, error) {
+	return logger.VerifyAuditChain(r, prevHash)
+}
+
+// RecordPredicate reports whether a record matches, e.g. to filter records with [NewFilterHandler].
+type RecordPredicate = logger.RecordPredicate
+
+// MatchLevel returns a [RecordPredicate] matching the records at the given level or above.
+func MatchLevel(level Level) RecordPredicate {
+	return logger.MatchLevel(level)
+}
+
+// MatchAttr returns a [RecordPredicate] matching the records with the top-level attribute key
+// whose value equals the given value, compared by its string representation. Any value matches if nil.
+//
+// Only the attributes of the record are matched, not those added by [Provider.With].
+func MatchAttr(key string, value any) RecordPredicate {
+	return logger.MatchAttr(key, value)
+}
+
+// MatchMessageRegexp returns a [RecordPredicate] matching the records whose message matches the regular expression.
+func MatchMessageRegexp(re *regexp.Regexp) RecordPredicate {
+	return logger.MatchMessageRegexp(re)
+}
+
+// Not returns a [RecordPredicate] matching the records the given predicate does not match.
+func Not(p RecordPredicate) RecordPredicate {
+	return logger.Not(p)
+}
+
+// NewFilterHandler returns a new [slog.Handler] that passes only the records matching the given predicate
+// to the given handler and drops the others.
+//
+// Example:
+//
+//	// Suppress the noisy health check access logs.
+//	h := logger.NewFilterHandler(slog.NewJSONHandler(os.Stdout, nil), logger.Not(logger.MatchAttr("path", "/healthz")))
+//	log := logger.NewLogger(logger.Options{Handler: h})
+func NewFilterHandler(h slog.Handler, keep RecordPredicate) slog.Handler {
+	return logger.NewFilterHandler(h, keep)
+}
+
+// RecordTransformer transforms a record, e.g. rewrites its message, adds or removes attributes or remaps its level.
+// A transformer removing attributes must return a new record created with [slog.NewRecord].
+type RecordTransformer = logger.RecordTransformer
+
+// Chain returns a new [slog.Handler] that passes every record through the given transformers in order
+// before passing it to the given handler. In contrast to [slog.HandlerOptions.ReplaceAttr], the transformers see the whole record,
+// so they can rewrite its message and level or add attributes depending on the others.
+// Chains can be nested, the transformers of the outer chain run first.
+// Declarative rules, e.g. loaded from a configuration file, are compiled to a transformer by [NewTransformer].
+//
+// The transformers only see the attributes of the record, not those added by [Provider.With].
+// Records remapped to a level the given handler is not enabled for are dropped,
+// while records at a level it is not enabled for never reach the transformers.
+//
+// Example:
+//
+//	prefix := func(r slog.Record) slog.Record {
+//		r.Message = "[checkout] " + r.Message
+//		return r
+//	}
+//	log := logger.NewLogger(logger.Options{Handler: logger.Chain(slog.NewJSONHandler(os.Stdout, nil), prefix)})
+func Chain(h slog.Handler, transformers ...RecordTransformer) slog.Handler {
+	return logger.Chain(h, transformers...)
+}
+
+// SwapHandler is a [slog.Handler] whose handler can be replaced at runtime.
+type SwapHandler = logger.SwapHandler
+
+// NewSwapHandler returns a new [SwapHandler] passing the records to the given handler until it is swapped.
+//
+// The handlers derived from it by WithAttrs and WithGroup, e.g. by the loggers already stored in contexts,
+// follow the swaps, so the destination or format of all records can be switched, e.g. on SIGHUP or a configuration
+// reload, without recreating the loggers. The attributes and groups are applied to the new handler on its first use.
+//
+// The loggers created without [Options.Handler] already swap their handler with [Provider.SetHandler].
+//
+// Example:
+//
+//	h := logger.NewSwapHandler(slog.NewJSONHandler(os.Stdout, nil))
+//	log := logger.NewLogger(logger.Options{Handler: h})
+//	// On reload:
+//	h.Swap(slog.NewTextHandler(os.Stdout, nil))
+func NewSwapHandler(h slog.Handler) *SwapHandler {
+	return logger.NewSwapHandler(h)
+}
+
+// SamplingOptions is the optional configuration for [NewSamplingHandler].
+type SamplingOptions = logger.SamplingOptions
+
+// NewSamplingHandler returns a new [slog.Handler] that limits the records with the same level and message
+// passed to the given handler, which caps the cost of logging in hot paths while keeping a representative sample.
+// Within every [SamplingOptions.Tick], the first [SamplingOptions.Initial] records are passed on
+// and then every [SamplingOptions.Thereafter]th record, the others are dropped.
+//
+// Example:
+//
+//	h := logger.NewSamplingHandler(slog.NewJSONHandler(os.Stdout, nil), logger.SamplingOptions{Initial: 10, Thereafter: 100})
+//	log := logger.NewLogger(logger.Options{Handler: h})
+func NewSamplingHandler(h slog.Handler, o ...SamplingOptions) slog.Handler {
+	return logger.NewSamplingHandler(h, o...)
+}
+
+// FailoverOptions is the optional configuration for [NewFailoverHandler].
+type FailoverOptions = logger.FailoverOptions
+
+// FailoverHandler is a [slog.Handler] passing records to the first working handler of a chain.
+type FailoverHandler = logger.FailoverHandler
+
+// NewFailoverHandler returns a new [FailoverHandler] that passes the records to the first of the given handlers,
+// e.g. a remote collector, and transparently falls back to the next ones, e.g. a local file and stderr,
+// if it returns an error or does not return within [FailoverOptions.Timeout].
+//
+// The records are passed to the first working handler until it fails as well. Every [FailoverOptions.ProbeInterval],
+// a record is tried with the preceding handlers again, so the chain recovers once the primary handler works again.
+// Handle returns an error only if all handlers failed.
+//
+// Example:
+//
+//	h := logger.NewFailoverHandler([]slog.Handler{remote, file, slog.NewJSONHandler(os.Stderr, nil)},
+//		logger.FailoverOptions{Timeout: time.Second})
+//	log := logger.NewLogger(logger.Options{Handler: h})
+func NewFailoverHandler(handlers []slog.Handler, o ...FailoverOptions) *FailoverHandler {
+	return logger.NewFailoverHandler(handlers, o...)
+}
+
+// ErrCircuitOpen is returned by the handler returned by [NewCircuitBreakerHandler] for records dropped
+// because the circuit is open.
+var ErrCircuitOpen = logger.ErrCircuitOpen
+
+// The states of a [CircuitBreakerHandler].
+const (
+	// CircuitClosed is the state in which the records are passed to the wrapped handler.
+	CircuitClosed = logger.CircuitClosed
+	// CircuitOpen is the state in which the records are dropped or buffered.
+	CircuitOpen = logger.CircuitOpen
+	// CircuitHalfOpen is the state in which a single record is passed to the wrapped handler to probe it.
+	CircuitHalfOpen = logger.CircuitHalfOpen
+)
+
+// CircuitBreakerOptions is the optional configuration for [NewCircuitBreakerHandler].
+type CircuitBreakerOptions = logger.CircuitBreakerOptions
+
+// CircuitBreakerHandler is a [slog.Handler] that stops passing records to a failing handler.
+type CircuitBreakerHandler = logger.CircuitBreakerHandler
+
+// NewCircuitBreakerHandler returns a new [CircuitBreakerHandler] that stops passing records to the given handler,
+// e.g. one sending them to a remote sink, after [CircuitBreakerOptions.Threshold] consecutive errors.
+//
+// While the circuit is open, the records are buffered up to [CircuitBreakerOptions.BufferSize]
+// or dropped with [ErrCircuitOpen], so they can fall back to another handler, e.g. with [NewFailoverHandler].
+// After [CircuitBreakerOptions.RetryInterval], a single record probes the wrapped handler: if it succeeds,
+// the buffered records are passed to it before the probing record and the circuit closes, otherwise it opens again.
+//
+// The state and counters of the circuit are exposed with Metrics, e.g. to log them with [SnapshotEvery].
+//
+// Example:
+//
+//	cb := logger.NewCircuitBreakerHandler(remote, logger.CircuitBreakerOptions{Threshold: 3, BufferSize: 1000})
+//	go logger.SnapshotEvery(ctx, "log-sink", cb, time.Minute)
+//	log := logger.NewLogger(logger.Options{Handler: cb})
+func NewCircuitBreakerHandler(h slog.Handler, o ...CircuitBreakerOptions) *CircuitBreakerHandler {
+	return logger.NewCircuitBreakerHandler(h, o...)
+}
+
+// RetryPolicy is the configuration for [NewRetryHandler].
+type RetryPolicy = logger.RetryPolicy
+
+// NewRetryHandler returns a new [slog.Handler] that retries passing a record to the given handler,
+// e.g. one sending it to a network sink, if it returns a transient error as reported by [RetryPolicy.Retryable].
+//
+// The record is passed at most [RetryPolicy.MaxAttempts] times. The wait between the attempts starts
+// at [RetryPolicy.MinBackoff] and doubles up to [RetryPolicy.MaxBackoff], with a random jitter of up to half of it
+// so the retries of many loggers do not synchronize. Handle blocks while waiting and stops if the context is done.
+// If all attempts failed, the error of the last one is returned.
+//
+// Example:
+//
+//	h := logger.NewRetryHandler(remote, logger.RetryPolicy{MaxAttempts: 5, MaxBackoff: 10 * time.Second})
+//	log := logger.NewLogger(logger.Options{Handler: logger.NewAsyncHandler(h)})
+func NewRetryHandler(h slog.Handler, policy RetryPolicy) slog.Handler {
+	return logger.NewRetryHandler(h, policy)
+}
+
+var (
+	// ErrWriteTimeout is returned by a [TimeoutHandler] if a record was not handled in time.
+	ErrWriteTimeout = logger.ErrWriteTimeout
+	// ErrHandlerBusy is returned by a [TimeoutHandler] for records dropped because all of its workers are busy.
+	ErrHandlerBusy = logger.ErrHandlerBusy
+)
+
+// TimeoutHandler is a [slog.Handler] that limits the time the wrapped handler may take to handle a record.
+type TimeoutHandler = logger.TimeoutHandler
+
+// NewTimeoutHandler returns a new [TimeoutHandler] that passes every record to the given handler with a context
+// whose deadline is the given timeout, so a hung sink (e.g. a stalled network connection) cannot block the caller indefinitely.
+//
+// If the handler does not return in time or returns a deadline error, [ErrWriteTimeout] is returned and the record
+// is passed to the fallback handler, if one is provided. Handlers ignoring the context keep running in the background
+// until they return, so such a record may be written by both handlers, like a record re-sent by a [FailoverHandler],
+// but it is never lost. At most 64 records are handled in the background at once. If all workers are busy,
+// the record is dropped with [ErrHandlerBusy] and passed to the fallback handler as well.
+// Records are passed to the given handler as is if the timeout is not positive.
+//
+// The numbers of timed out and dropped records are exposed with Metrics, e.g. to log them with [SnapshotEvery].
+func NewTimeoutHandler(h slog.Handler, timeout time.Duration, fallback slog.Handler) *TimeoutHandler {
+	return logger.NewTimeoutHandler(h, timeout, fallback)
+}
+
+// Experimental reports whether the experimental feature with the given name is enabled.
+// Experimental handlers and sinks check it at runtime, so they can be opted into per environment without separate builds,
+// e.g. by [NewExperimentalHandler].
+//
+// The features are enabled by [EnableExperimental] or by the LOG_EXPERIMENTAL environment variable,
+// a comma-separated list of feature names. Like LOG_LEVEL and LOG_FORMAT, the environment variable takes precedence if set.
+func Experimental(name string) bool {
+	return logger.Experimental(name)
+}
+
+// EnableExperimental enables the experimental features with the given names.
+func EnableExperimental(names ...string) {
+	logger.EnableExperimental(names...)
+}
+
+// DisableExperimental disables the experimental features with the given names.
+func DisableExperimental(names ...string) {
+	logger.DisableExperimental(names...)
+}
+
+// NewExperimentalHandler returns a new [slog.Handler] that passes every record to the experimental handler
+// while the feature with the given name is enabled, see [Experimental], and to the stable handler otherwise.
+// The feature is checked for every record, so enabling or disabling it takes effect immediately.
+//
+// Example:
+//
+//	// LOG_EXPERIMENTAL=zstd-sink opts into the new sink.
+//	h := logger.NewExperimentalHandler("zstd-sink", newZstdSink(file), slog.NewJSONHandler(file, nil))
+//	log := logger.NewLogger(logger.Options{Handler: h})
+func NewExperimentalHandler(name string, experimental, stable slog.Handler) slog.Handler {
+	return logger.NewExperimentalHandler(name, experimental, stable)
+}
+
+// NewStacktraceHandler returns a new [slog.Handler] that adds the stack trace of the logging call under [StacktraceKey]
+// to every record at or above the given level, similar to zap's AddStacktrace.
+// The stack trace starts at the caller of the logging method, so the frames of the logger and the handlers are trimmed.
+func NewStacktraceHandler(h slog.Handler, level Level) slog.Handler {
+	return logger.NewStacktraceHandler(h, level)
+}
+
+const (
+	// DockerTagKey is the key used for the tag of a record in the Docker format.
+	DockerTagKey = logger.DockerTagKey
+	// DockerStreamKey is the key used for the stream a record was written to in the Docker format.
+	// It differs from the "source" key of the logging call, which the Docker format includes as well.
+	DockerStreamKey = logger.DockerStreamKey
+)
+
+// DockerOptions is the optional configuration for [NewDockerHandler].
+type DockerOptions = logger.DockerOptions
+
+// NewDockerHandler returns a new [slog.Handler] for containers using Docker's fluentd, gelf or awslogs logging drivers.
+// Records are written as JSON, records at or above [LevelError] to stderr and all others to stdout,
+// so the stream reported by the logging driver reflects the severity.
+// Every record carries the tag under [DockerTagKey] and the stream under [DockerStreamKey],
+// which preserves the metadata when the driver forwards the JSON payload without a sidecar.
+//
+// The handler is used for the "DOCKER" format.
+func NewDockerHandler(o ...DockerOptions) slog.Handler {
+	return logger.NewDockerHandler(o...)
+}
+
+// Transform actions supported by [TransformRule].
+const (
+	// TransformRename renames the attribute [TransformRule.Key] to [TransformRule.To].
+	TransformRename = logger.TransformRename
+	// TransformAdd adds the static attribute [TransformRule.Key] with [TransformRule.Value].
+	TransformAdd = logger.TransformAdd
+	// TransformDrop drops the attribute [TransformRule.Key].
+	TransformDrop = logger.TransformDrop
+	// TransformLevel maps records at the level [TransformRule.From] to the level [TransformRule.To].
+	TransformLevel = logger.TransformLevel
+)
+
+// TransformRule is a declarative rule transforming records, e.g. loaded from a configuration file:
+//
+//	[
+//		{"action": "rename", "key": "msg_id", "to": "message_id"},
+//		{"action": "add", "key": "service", "value": "checkout"},
+//		{"action": "drop", "key": "path", "when": {"key": "path", "equals": "/healthz"}},
+//		{"action": "level", "from": "NOTICE", "to": "INFO"}
+//	]
+//
+// The rules are compiled to a [RecordTransformer] by [NewTransformer] or set by [Options.Transforms],
+// [Config.Transforms] or the LOG_TRANSFORMS environment variable. Like every [RecordTransformer],
+// they apply to the attributes of the record, not to those added by [Provider.With], and are applied in the given order.
+type TransformRule = logger.TransformRule
+
+// TransformCondition restricts a [TransformRule] to matching records.
+// All set fields must match.
+type TransformCondition = logger.TransformCondition
+
+// NewTransformer returns a new [RecordTransformer] transforming records by the given rules, see [Chain].
+// Returns an error if a rule is invalid.
+//
+// Records are only transformed if the handler is enabled for their level, so a level mapping
+// applies to records at enabled levels. Records mapped to a disabled level are dropped.
+//
+// Example:
+//
+//	t, err := logger.NewTransformer([]logger.TransformRule{{Action: logger.TransformDrop, Key: "path"}})
+//	if err != nil {
+//		return err
+//	}
+//	h := logger.Chain(slog.NewJSONHandler(os.Stdout, nil), t)
+func NewTransformer(rules []TransformRule) (RecordTransformer, error) {
+	return logger.NewTransformer(rules)
+}
+
+// PanicError is the value the logger panics with if [Options.PanicErrors] is set.
+// It carries the message and the attributes of the panic call.
+type PanicError = logger.PanicError
+
+// RecoverAndLog recovers from a panic and logs it at [LevelPanic] with the logger found in the context,
+// including a stack trace under [StacktraceKey].
+// The attributes of a [*PanicError] are added to the record, so panics raised by the logger
+// with [Options.PanicErrors] keep their structured context.
+// It must be deferred directly:
+//
+//	defer logger.RecoverAndLog(ctx)
+//
+// It is a variable rather than a wrapper function because recover only stops a panic if called by the deferred function itself.
+var RecoverAndLog = logger.RecoverAndLog
+
+const (
+	// OperationKey is the key used for the name of an [Operation].
+	OperationKey = logger.OperationKey
+	// OperationIDKey is the key used for the ID shared by all records of an [Operation].
+	OperationIDKey = logger.OperationIDKey
+	// OperationDurationKey is the key used for the duration of an [Operation].
+	OperationDurationKey = logger.OperationDurationKey
+)
+
+// Operation is a long-running operation started by [Provider.Begin].
+// All records of an operation carry its name under [OperationKey] and its ID under [OperationIDKey].
+// An operation must be ended by either [Operation.Success] or [Operation.Fail], further calls are ignored.
+type Operation = logger.Operation
+
+// DictionaryKey is the key of the dictionary record mapping the short keys of the compact mode
+// back to the standard keys, see [Options.CompactKeys].
+const DictionaryKey = logger.DictionaryKey
+
+// DefaultOptions is the optional configuration for [SetDefault].
+type DefaultOptions = logger.DefaultOptions
+
+// SetDefault makes the given logger the default logger used by the package-level logging functions like [Info].
+// A nil logger resets the default logger.
+func SetDefault(l Provider, o ...DefaultOptions) {
+	logger.SetDefault(l, o...)
+}
+
+// Default returns the default logger used by the package-level logging functions.
+// If no logger was set by [SetDefault], a logger with the default configuration is created on first use.
+func Default() Provider {
+	return logger.Default()
+}
+
+// The package-level logging functions are variables rather than wrapper functions,
+// so the caller is reported as source of the records.
+var (
+	// Debug logs at [LevelDebug] with the default logger.
+	Debug = logger.Debug
+	// Info logs at [LevelInfo] with the default logger.
+	Info = logger.Info
+	// Warn logs at [LevelWarn] with the default logger.
+	Warn = logger.Warn
+	// Error logs at [LevelError] with the default logger.
+	Error = logger.Error
+	// Fatal logs at [LevelFatal] with the default logger, calls its [Options.OnFatal] hooks
+	// and then exits with its [Options.ExitCode] (default 1).
+	Fatal = logger.Fatal
+)
+
+// WritableFile is a file opened by a [WritableFS].
+type WritableFile = logger.WritableFile
+
+// WritableFS is a writable file system used by a [FileSink].
+// It allows to use the rotation logic without a real file system, e.g. in tests or on platforms
+// like WASM or read-only roots with an in-memory file system.
+type WritableFS = logger.WritableFS
+
+// OSFS is the [WritableFS] of the operating system.
+type OSFS = logger.OSFS
+
+// FileSinkOptions is the configuration for a [FileSink].
+type FileSinkOptions = logger.FileSinkOptions
+
+// FileSink is an [io.WriteCloser] writing to a log file that is rotated once it exceeds a maximum size.
+// It is safe for concurrent use.
+type FileSink = logger.FileSink
+
+// NewFileSink opens the log file of the given options for appending, creating it if necessary.
+//
+// Example:
+//
+//	sink, err := logger.NewFileSink(logger.FileSinkOptions{Path: "app.log", MaxSize: 10 << 20, MaxBackups: 3})
+//	if err != nil {
+//		return err
+//	}
+//	defer sink.Close()
+//	log := logger.NewLogger(logger.Options{Handler: slog.NewJSONHandler(sink, nil)})
+func NewFileSink(o FileSinkOptions) (*FileSink, error) {
+	return logger.NewFileSink(o)
+}
+
+// ReopenFiles reopens the log files of all open [FileSink]s, see [FileSink.Reopen].
+// It is called on SIGHUP by [HandleSignals].
+func ReopenFiles() error {
+	return logger.ReopenFiles()
+}
+
+// HandleSignals lets operators control the logger with signals until the context is done:
+//   - SIGHUP reopens the log files of all [FileSink]s, see [ReopenFiles], so logrotate can move them away.
+//   - SIGUSR1 enables the debug records of the logger, see [Provider.SetLevel].
+//   - SIGUSR2 restores the configured levels of the logger, see [Provider.ResetLevel].
+//
+// The actions are logged by the logger. The signals are only handled on Unix-like systems.
+// Once the context is done, they get their default behavior back.
+//
+// Example:
+//
+//	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+//	defer cancel()
+//	logger.HandleSignals(ctx, log)
+func HandleSignals(ctx context.Context, log logger.Provider) {
+	logger.HandleSignals(ctx, log)
+}
+
+// NewConsoleHandler returns a new [slog.Handler] writing text records to the console,
+// records at or above [LevelError] to the error stream and all others to the standard stream.
+// On js/wasm the streams are the browser's console.error and console.log, so logs show up in the developer tools
+// with the matching severity. On all other platforms they are stderr and stdout.
+//
+// The handler is used for the "TEXT" format on WASM platforms, where no terminal is available.
+func NewConsoleHandler(level Level) slog.Handler {
+	return logger.NewConsoleHandler(level)
+}
+
+// Escalation is a step of a [Watchdog], logging at Level once the watched operation runs longer than After.
+type Escalation = logger.Escalation
+
+// WatchOptions is the optional configuration for [Watch].
+type WatchOptions = logger.WatchOptions
+
+// Watchdog watches an operation started by [Watch] and escalates the level of its records while it is not done.
+type Watchdog = logger.Watchdog
+
+// Watch logs the start of the named operation at [LevelInfo] with the logger found in the context
+// and escalates to [LevelWarn] and [LevelError] if it is not marked done by [Watchdog.Done] within
+// the threshold and twice the threshold. This catches silently stuck operations.
+// The escalation steps can be configured with [WatchOptions]. Escalation stops once the context is done.
+//
+// Example:
+//
+//	w := logger.Watch(ctx, "db migration", 5*time.Minute)
+//	defer w.Done()
+func Watch(ctx context.Context, name string, threshold time.Duration, o ...WatchOptions) *Watchdog {
+	return logger.Watch(ctx, name, threshold, o...)
+}
+
+// NewStdLogger returns a new [log.Logger] whose output is logged at the given level with the handler of the given logger,
+// one record per call. This allows legacy libraries accepting only a [log.Logger] (e.g. the ErrorLog of an http.Server)
+// to log through the structured pipeline.
+//
+// Example:
+//
+//	srv := &http.Server{ErrorLog: logger.NewStdLogger(log, logger.LevelError)}
+func NewStdLogger(l Provider, level Level) *log.Logger {
+	return logger.NewStdLogger(l, level)
+}
+
+// CardinalityOptions is the configuration for [NewCardinalityHandler].
+type CardinalityOptions = logger.CardinalityOptions
+
+// CardinalityHandler is a [slog.Handler] tracking the approximate number of distinct values per attribute key.
+type CardinalityHandler = logger.CardinalityHandler
+
+// NewCardinalityHandler returns a new [CardinalityHandler] that tracks the approximate number of distinct values
+// of the top-level attribute keys of every record with a HyperLogLog sketch.
+// Once a key exceeds [CardinalityOptions.Threshold], a warning is logged at [LevelWarn] once
+// and, if [CardinalityOptions.Hash] is set, its values are replaced by hash buckets.
+// This protects index-based log stores from cardinality explosions, e.g. caused by logging user IDs as keys.
+//
+// At most [CardinalityOptions.MaxKeys] keys are tracked, so the memory stays bounded if the keys themselves explode.
+// Once the maximum is reached, a warning is logged at [LevelWarn] once and the values of further keys
+// are passed through untracked. The number of untracked values is reported by [CardinalityHandler.Metrics].
+func NewCardinalityHandler(h slog.Handler, o ...CardinalityOptions) *CardinalityHandler {
+	return logger.NewCardinalityHandler(h, o...)
+}
+
+// Writer returns a new [io.WriteCloser] that splits the written bytes on newlines
+// and logs every line as a record at the given level with the given logger.
+// Incomplete lines are buffered until their newline is written or the writer is closed.
+// This allows to capture the output of an exec.Cmd or of libraries that only write to an [io.Writer].
+//
+// Example:
+//
+//	w := logger.Writer(log.With("cmd", "git"), logger.LevelInfo)
+//	defer w.Close()
+//	cmd.Stdout = w
+func Writer(l Provider, level Level) io.WriteCloser {
+	return logger.Writer(l, level)
+}
+
+// RedactedValue is the value replacing redacted data.
+const RedactedValue = logger.RedactedValue
+
+// RedactionPolicy is a preset of keys and value patterns to redact from records.
+type RedactionPolicy = logger.RedactionPolicy
+
+const (
+	// RedactOff disables redaction.
+	RedactOff = logger.RedactOff
+	// RedactStandard redacts credentials: the values of keys like password, secret, token, authorization or cookie,
+	// and bearer tokens, JWTs and AWS access keys anywhere in messages and string values.
+	RedactStandard = logger.RedactStandard
+	// RedactStrict additionally redacts personal data: the values of keys like email, phone or iban,
+	// and email addresses, card numbers, IBANs and IPv4 addresses anywhere in messages and string values.
+	RedactStrict = logger.RedactStrict
+)
+
+// RedactionOptions is the optional configuration for [NewRedactionHandler].
+type RedactionOptions = logger.RedactionOptions
+
+// NewRedactionHandler returns a new [slog.Handler] that replaces sensitive data by [RedactedValue]
+// according to the given policy before passing records to the given handler.
+// The values of sensitive keys are redacted including nested groups,
+// the value patterns are redacted in the message and in all string values.
+// With [RedactionOptions.Paths], the values of the attributes at the given paths are redacted as well,
+// which targets nested groups precisely instead of matching keys everywhere.
+// With [RedactionOptions.Keys], the values of the attributes with the given keys are redacted at any depth,
+// so it can be installed around any handler as a deny-list of keys, e.g. with [RedactOff] as policy.
+// With [RedactionOptions.Patterns], the built-in or registered patterns with the given names are redacted
+// in the message and in all string values as well, e.g. "iban" in addition to [RedactStandard].
+// With [RedactionOptions.HashKeys], the values of the attributes with the given keys are replaced by their keyed hash,
+// unless they are redacted.
+// With [RedactionOptions.AllowKeys], only the attributes with the given keys are emitted.
+//
+// Example:
+//
+//	h := logger.NewRedactionHandler(handler, logger.RedactOff, logger.RedactionOptions{Keys: []string{"password", "api_key"}})
+func NewRedactionHandler(h slog.Handler, policy RedactionPolicy, o ...RedactionOptions) slog.Handler {
+	return logger.NewRedactionHandler(h, policy, o...)
+}
+
+// RegisterRedactionPattern registers the pattern under the name, so it can be selected by [RedactionOptions.Patterns],
+// [Options.RedactPatterns] or LOG_REDACT_PATTERNS, e.g. for internal customer or order IDs.
+// Names are case-insensitive. A pattern registered under the name of another pattern replaces it,
+// including the built-in patterns "bearer", "jwt", "aws_key", "email", "card", "ipv4" and "iban".
+// Handlers already created keep the patterns they were created with.
+//
+// Example:
+//
+//	logger.RegisterRedactionPattern("customer_id", regexp.MustCompile(`\bCUST-\d{8}\b`))
+func RegisterRedactionPattern(name string, pattern *regexp.Regexp) {
+	logger.RegisterRedactionPattern(name, pattern)
+}
+
+// LazyOptions is the optional configuration for [NewLazyHandler].
+type LazyOptions = logger.LazyOptions
+
+// NewLazyHandler returns a new [slog.Handler] that defers the construction of an expensive handler,
+// e.g. one dialing a remote sink or opening a file, until the first record is handled.
+// This speeds up the start of programs that rarely log to the sink.
+// If the construction fails, the error is returned for every record until the construction is retried
+// with the next record after [LazyOptions.MinBackoff], doubling the wait up to [LazyOptions.MaxBackoff]
+// for every further failure, so a sink that is temporarily unavailable is used once it recovers.
+// With [LazyOptions.Prewarm], the handler is constructed in the background right away.
+func NewLazyHandler(build func() (slog.Handler, error), o ...LazyOptions) slog.Handler {
+	return logger.NewLazyHandler(build, o...)
+}
+
+// ErrHandlerClosed is returned by the handlers returned by [NewAsyncHandler], [NewSyslogHandler],
+// [NewJournaldHandler], [NewGELFHandler], [NewFluentHandler], [NewNATSHandler], [NewLokiHandler],
+// [NewWebhookHandler] and [NewSocketHandler] for records handled after they were closed.
+var ErrHandlerClosed = logger.ErrHandlerClosed
+
+// AsyncOptions is the optional configuration for [NewAsyncHandler].
+type AsyncOptions = logger.AsyncOptions
+
+// AsyncHandler is a [slog.Handler] passing records to the wrapped handler in a background worker,
+// so slow sinks do not block the caller. It must be closed to flush the buffered records.
+type AsyncHandler = logger.AsyncHandler
+
+// NewAsyncHandler returns a new [AsyncHandler] passing records to the given handler in a background worker.
+//
+// Since the context of a record may be cancelled by the time the worker handles it, the worker receives
+// a context carrying all values of the record's context that is never cancelled, see [context.WithoutCancel].
+// The attributes stored by [AppendCtx] and the values of the record's attributes are resolved eagerly.
+func NewAsyncHandler(h slog.Handler, o ...AsyncOptions) *AsyncHandler {
+	return logger.NewAsyncHandler(h, o...)
+}
+
+// MetaKey is the key of the group carrying the metadata of a record, see [AddMeta].
+const MetaKey = logger.MetaKey
+
+// AddMeta adds the given attributes to the metadata of the record.
+// The metadata is a side channel for wrapper handlers to pass decisions like routing or sampling downstream.
+// It is carried in a group under [MetaKey] and stripped before the record is written by the built-in handlers,
+// so it never shows up in the output. Custom terminal handlers can be wrapped with [NewMetaStripHandler].
+func AddMeta(r *slog.Record, attrs ...slog.Attr) {
+	logger.AddMeta(r, attrs...)
+}
+
+// Meta returns the metadata of the record added by [AddMeta], in the order it was added.
+func Meta(r slog.Record) []slog.Attr { //nolint:gocritic // records are passed by value
+	return logger.Meta(r)
+}
+
+// MetaValue returns the value of the metadata of the record with the given key.
+// If the key was added multiple times, the last value is returned.
+func MetaValue(r slog.Record, key string) (slog.Value, bool) { //nolint:gocritic // records are passed by value
+	return logger.MetaValue(r, key)
+}
+
+// NewMetaStripHandler returns a new [slog.Handler] that removes the metadata added by [AddMeta]
+// from every record before passing it to the given handler.
+func NewMetaStripHandler(h slog.Handler) slog.Handler {
+	return logger.NewMetaStripHandler(h)
+}
+
+// TB is the subset of [testing.TB] used to write records through a test.
+// It is implemented by [testing.T], [testing.B] and [testing.F].
+type TB = logger.TB
+
+// NewTestHandler returns a new [slog.Handler] writing every record of all levels as a line of text through [TB.Log],
+// so the output is attributed to the test and only shown if it fails or runs verbosely.
+// Records with level [LevelError] or above are written with [TB.Error] and fail the test.
+//
+// The handler is safe to use from parallel tests and goroutines. Records handled after the test finished
+// are dropped, since the testing package panics on output of completed tests.
+// The logtest package provides a logger writing through the test with NewTestLogger.
+func NewTestHandler(tb TB) slog.Handler {
+	tb.Helper()
+	return logger.NewTestHandler(tb)
+}
+
+// NewConfigPipeline returns the handler pipeline built for the configuration with the outputs replaced
+// by the handler returned by sink for the lowest level of the outputs. The sampling of the configuration is applied.
+// Returns an error wrapping [ErrInvalidConfig] if the configuration is invalid.
+//
+// It allows tests to assert the behavior of the wrappers, e.g. redaction or limits, for a configuration file.
+func NewConfigPipeline(cfg Config, sink func(level Level) slog.Handler) (slog.Handler, error) {
+	return logger.NewConfigPipeline(cfg, sink)
+}
+
+// NewPipeline returns the handler pipeline built for the given options with the handler writing the records
+// replaced by the handler returned by sink for the configured minimum level. [Options.Handler] is ignored.
+//
+// It allows tests to assert the behavior of the wrappers, e.g. redaction or splitting, for a configuration.
+func NewPipeline(sink func(level Level) slog.Handler, o ...Options) slog.Handler {
+	return logger.NewPipeline(sink, o...)
+}
+
+// PIDKey is the key used for the process ID of a record.
+const PIDKey = logger.PIDKey
+
+// NormalizeJSON normalizes JSON output of the logger, one record per line, for comparison with golden files.
+// It removes the fields that differ between runs: the time, the source, the process ID, the record ID and the split ID,
+// as well as the fields with the given keys. The remaining fields of every record are written with sorted keys.
+// Lines that are not JSON objects are kept as they are.
+func NormalizeJSON(data []byte, keys ...string) ([]byte, error) {
+	return logger.NormalizeJSON(data, keys...)
+}
+
+// MessageTemplateKey is the key used for the un-interpolated template of a record logged with a message template.
+// Backends can group records by the template, which is the same for all records of a log statement.
+const MessageTemplateKey = logger.MessageTemplateKey
+
+// NameKey is the key used for the name of a logger, see [NewNamedLogger] and [Options.AutoName].
+const NameKey = logger.NameKey
+
+const (
+	// DatadogStatusKey is the key used for the level of a record in the Datadog format.
+	DatadogStatusKey = logger.DatadogStatusKey
+	// DatadogMessageKey is the key used for the message of a record in the Datadog format.
+	DatadogMessageKey = logger.DatadogMessageKey
+	// DatadogTraceKey is the key of the group carrying the trace and span ID of a record in the Datadog format.
+	DatadogTraceKey = logger.DatadogTraceKey
+	// DatadogErrorKindKey is the key used for the type name of the error within the error group in the Datadog format.
+	DatadogErrorKindKey = logger.DatadogErrorKindKey
+	// DatadogErrorStackKey is the key used for the stack trace within the error group in the Datadog format.
+	DatadogErrorStackKey = logger.DatadogErrorStackKey
+)
+
+// DatadogOptions is the optional configuration for [NewDatadogHandler].
+type DatadogOptions = logger.DatadogOptions
+
+// NewDatadogHandler returns a new [slog.Handler] writing JSON records with the fields mapped to Datadog's standard attributes:
+//   - The level is written as status under [DatadogStatusKey], e.g. "info" or "critical" for [LevelPanic].
+//   - The message is written under [DatadogMessageKey].
+//   - The trace and span ID added by [NewTraceHandler] are written as decimal IDs to the group under [DatadogTraceKey],
+//     i.e. dd.trace_id and dd.span_id, so Datadog correlates the logs with the traces.
+//   - The error group of [Err] is written as error.message, error.kind and error.stack.
+//     A stack trace added by [NewStacktraceHandler] is written as error.stack as well.
+//
+// The handler is used for the "DATADOG" format.
+func NewDatadogHandler(o ...DatadogOptions) slog.Handler {
+	return logger.NewDatadogHandler(o...)
+}
+
+// DevOptions is the optional configuration for [NewDevHandler].
+type DevOptions = logger.DevOptions
+
+// NewDevHandler returns a new [slog.Handler] writing colorized multi-line records for local development,
+// similar to zap's development configuration: a header line with the time, the aligned level, the message
+// and the caller, followed by the attributes as an indented tree with aligned keys.
+// Errors are highlighted and multi-line values like stack traces are written as indented blocks.
+//
+// The handler is used for the "DEV" format, which is the default format if stderr is a terminal.
+// The output is not meant to be parsed, use the JSON format for that.
+func NewDevHandler(o ...DevOptions) slog.Handler {
+	return logger.NewDevHandler(o...)
+}
+
+// SyslogFacility is the facility of syslog messages as defined by RFC 5424.
+type SyslogFacility = logger.SyslogFacility
+
+// The facilities commonly used by applications.
+const (
+	SyslogUser   = logger.SyslogUser
+	SyslogDaemon = logger.SyslogDaemon
+	SyslogAuth   = logger.SyslogAuth
+	SyslogLocal0 = logger.SyslogLocal0
+	SyslogLocal1 = logger.SyslogLocal1
+	SyslogLocal2 = logger.SyslogLocal2
+	SyslogLocal3 = logger.SyslogLocal3
+	SyslogLocal4 = logger.SyslogLocal4
+	SyslogLocal5 = logger.SyslogLocal5
+	SyslogLocal6 = logger.SyslogLocal6
+	SyslogLocal7 = logger.SyslogLocal7
+)
+
+// DefaultSyslogStructuredDataID is the default [SyslogOptions.StructuredDataID].
+// 32473 is the private enterprise number reserved for documentation by RFC 5612.
+const DefaultSyslogStructuredDataID = logger.DefaultSyslogStructuredDataID
+
+// ErrNoSyslog is returned by [NewSyslogHandler] if no network is configured and no local syslog socket was found.
+var ErrNoSyslog = logger.ErrNoSyslog
+
+// SyslogOptions is the optional configuration for [NewSyslogHandler].
+type SyslogOptions = logger.SyslogOptions
+
+// SyslogHandler is a [slog.Handler] writing records as RFC 5424 messages to a syslog server.
+type SyslogHandler = logger.SyslogHandler
+
+// NewSyslogHandler returns a new [SyslogHandler] writing records to the local or a remote syslog server
+// as RFC 5424 messages. The attributes are written as parameters of a structured data element,
+// with the keys of nested attributes joined by dots. Messages sent via TCP are framed by octet counting (RFC 6587).
+//
+// The levels are mapped to the syslog severities: [LevelTrace] and [LevelDebug] to debug, [LevelInfo] to informational,
+// [LevelNotice] to notice, [LevelWarn] to warning, [LevelError] to error, [LevelPanic] to critical
+// and [LevelFatal] to alert.
+//
+// It returns an error if the connection to the server cannot be established. Close the handler to close the connection.
+//
+// Example:
+//
+//	h, err := logger.NewSyslogHandler(logger.SyslogOptions{Network: "udp", Address: "syslog:514", Facility: logger.SyslogLocal0})
+//	if err != nil {
+//		return err
+//	}
+//	defer h.Close()
+//	log := logger.NewLogger(logger.Options{Handler: h})
+func NewSyslogHandler(o ...SyslogOptions) (*SyslogHandler, error) {
+	return logger.NewSyslogHandler(o...)
+}
+
+// DefaultJournalSocket is the default [JournaldOptions.Socket].
+const DefaultJournalSocket = logger.DefaultJournalSocket
+
+// ErrJournaldUnsupported is returned by [NewJournaldHandler] on platforms other than Linux.
+var ErrJournaldUnsupported = logger.ErrJournaldUnsupported
+
+// JournaldOptions is the optional configuration for [NewJournaldHandler].
+type JournaldOptions = logger.JournaldOptions
+
+// JournaldHandler is a [slog.Handler] writing records to the systemd journal via its native protocol.
+type JournaldHandler = logger.JournaldHandler
+
+// NewJournaldHandler returns a new [JournaldHandler] writing records to the systemd journal via its native protocol.
+// Every record is written as entry with the following fields:
+//   - MESSAGE is the message of the record.
+//   - PRIORITY is the syslog severity of the level, mapped like by [NewSyslogHandler].
+//   - CODE_FILE, CODE_LINE and CODE_FUNC are the source of the record.
+//   - SYSLOG_IDENTIFIER is the [JournaldOptions.Identifier].
+//
+// The attributes are written as fields with upper-case names, e.g. "user_id" as USER_ID. The names of nested attributes
+// are joined by underscores and characters not allowed in field names are replaced by underscores.
+//
+// It is only supported on Linux and returns [ErrJournaldUnsupported] on other platforms.
+// It returns an error if the socket of the journal cannot be opened. Close the handler to close the socket.
+// The handler is used for the "JOURNALD" format.
+//
+// Example:
+//
+//	h, err := logger.NewJournaldHandler(logger.JournaldOptions{Identifier: "myservice"})
+//	if err != nil {
+//		return err
+//	}
+//	defer h.Close()
+//	log := logger.NewLogger(logger.Options{Handler: h})
+func NewJournaldHandler(o ...JournaldOptions) (*JournaldHandler, error) {
+	return logger.NewJournaldHandler(o...)
+}
+
+// ErrGELFMessageTooLarge is returned by a [GELFHandler] if a record exceeds the maximum number of chunks of a UDP message.
+var ErrGELFMessageTooLarge = logger.ErrGELFMessageTooLarge
+
+// GELFOptions is the configuration for [NewGELFHandler].
+type GELFOptions = logger.GELFOptions
+
+// GELFHandler is a [slog.Handler] writing records as GELF 1.1 messages to Graylog.
+type GELFHandler = logger.GELFHandler
+
+// NewGELFHandler returns a new [GELFHandler] writing records as GELF 1.1 messages to a GELF input of Graylog.
+// The message is written as short_message, the level as syslog severity like by [NewSyslogHandler]
+// and the source as _file, _line and _function. The attributes are written as additional fields prefixed by an underscore,
+// with the keys of nested attributes joined by underscores. Numbers are written as numbers, all other values as strings.
+//
+// Messages sent via UDP are optionally compressed and split into chunks if they exceed [GELFOptions.ChunkSize].
+// Messages sent via TCP, optionally encrypted with TLS, are terminated by a null byte.
+//
+// It returns an error if the connection cannot be established. Close the handler to close the connection.
+//
+// Example:
+//
+//	h, err := logger.NewGELFHandler(logger.GELFOptions{Address: "graylog:12201", Compress: true})
+//	if err != nil {
+//		return err
+//	}
+//	defer h.Close()
+//	log := logger.NewLogger(logger.Options{Handler: h})
+func NewGELFHandler(o GELFOptions) (*GELFHandler, error) {
+	return logger.NewGELFHandler(o)
+}
+
+// DefaultFluentAddress is the default [FluentOptions.Address], the default forward input of Fluentd and Fluent Bit.
+const DefaultFluentAddress = logger.DefaultFluentAddress
+
+// ErrFluentAck is returned by a [FluentHandler] if the acknowledgment of a message does not match its chunk ID.
+var ErrFluentAck = logger.ErrFluentAck
+
+// FluentOptions is the optional configuration for [NewFluentHandler].
+type FluentOptions = logger.FluentOptions
+
+// FluentHandler is a [slog.Handler] writing records as events to Fluentd or Fluent Bit via the forward protocol.
+type FluentHandler = logger.FluentHandler
+
+// NewFluentHandler returns a new [FluentHandler] writing records as events to the forward input of Fluentd
+// or Fluent Bit. Every record is sent as MessagePack encoded message with the [FluentOptions.Tag],
+// the time of the record and a map with the level, the message, the source and the attributes, with groups as nested maps.
+//
+// If [FluentOptions.RequireAck] is set, every message carries a chunk ID and Handle waits until the server acknowledged it,
+// which requires require_ack_response to be enabled for the forward output of an aggregator forwarding the events further.
+//
+// It returns an error if the connection cannot be established. Close the handler to close the connection.
+//
+// Example:
+//
+//	h, err := logger.NewFluentHandler(logger.FluentOptions{Address: "fluentd:24224", Tag: "app.api", RequireAck: true})
+//	if err != nil {
+//		return err
+//	}
+//	defer h.Close()
+//	log := logger.NewLogger(logger.Options{Handler: h})
+func NewFluentHandler(o ...FluentOptions) (*FluentHandler, error) {
+	return logger.NewFluentHandler(o...)
+}
+
+// DefaultNATSURL is the default [NATSOptions.URL].
+const DefaultNATSURL = logger.DefaultNATSURL
+
+var (
+	// ErrNATSDropped is reported to [NATSOptions.OnError] if records were dropped because the buffer was full.
+	ErrNATSDropped = logger.ErrNATSDropped
+	// ErrNATSPublish is reported to [NATSOptions.OnError] if JetStream rejected a record.
+	ErrNATSPublish = logger.ErrNATSPublish
+)
+
+// NATSOptions is the optional configuration for [NewNATSHandler].
+type NATSOptions = logger.NATSOptions
+
+// NATSHandler is a [slog.Handler] publishing records to a NATS subject or JetStream stream.
+// It must be closed to publish the buffered records.
+type NATSHandler = logger.NATSHandler
+
+// NewNATSHandler returns a new [NATSHandler] publishing records as JSON messages to a subject of a NATS server.
+// The records are published by a background worker, which reconnects if the connection is lost.
+// While the server is not reachable, up to [NATSOptions.BufferSize] records are buffered, dropping the oldest ones.
+//
+// If [NATSOptions.JetStream] is set, every record is published with a reply subject and the worker waits for the
+// acknowledgment of the stream, retrying the record after reconnecting if it is not acknowledged in time.
+//
+// Example:
+//
+//	h := logger.NewNATSHandler(logger.NATSOptions{URL: "nats://nats:4222", Subject: "logs.api", JetStream: true})
+//	defer h.Close()
+//	log := logger.NewLogger(logger.Options{Handler: h})
+func NewNATSHandler(o ...NATSOptions) *NATSHandler {
+	return logger.NewNATSHandler(o...)
+}
+
+// ErrLokiDropped is returned by a [LokiHandler] and reported to [LokiOptions.OnError]
+// if records were dropped because the buffer was full.
+var ErrLokiDropped = logger.ErrLokiDropped
+
+// LokiOptions is the configuration for [NewLokiHandler].
+type LokiOptions = logger.LokiOptions
+
+// LokiHandler is a [slog.Handler] pushing records in batches to Grafana Loki.
+// It must be closed to push the buffered records.
+type LokiHandler = logger.LokiHandler
+
+// NewLokiHandler returns a new [LokiHandler] pushing records as JSON lines to the /loki/api/v1/push endpoint of Loki.
+// The records are buffered and pushed once [LokiOptions.BatchSize] records are buffered
+// or [LokiOptions.BatchWait] elapsed. Failed pushes are retried with exponential backoff
+// if Loki is unreachable, rate limits the requests or returns a server error.
+//
+// Every stream has the [LokiOptions.Labels] and the values of the attributes at [LokiOptions.LabelKeys] as labels.
+// The promoted attributes are still written to the lines.
+//
+// Example:
+//
+//	h := logger.NewLokiHandler(logger.LokiOptions{
+//		URL:       "http://loki:3100",
+//		Labels:    map[string]string{"job": "api"},
+//		LabelKeys: []string{"env"},
+//	})
+//	defer h.Close()
+//	log := logger.NewLogger(logger.Options{Handler: h})
+func NewLokiHandler(o LokiOptions) *LokiHandler {
+	return logger.NewLokiHandler(o)
+}
+
+// TailSamplingOptions is the optional configuration for [NewTailSamplingHandler].
+type TailSamplingOptions = logger.TailSamplingOptions
+
+// NewTailSamplingHandler returns a new [slog.Handler] that buffers the records of a request below
+// [TailSamplingOptions.TriggerLevel] down to [TailSamplingOptions.Level] instead of passing them to the given handler.
+// The request is identified by the top-level attribute [TailSamplingOptions.Key], e.g. added by [RequestID].
+//
+// Once a record at or above the trigger level is logged for a request, its buffered records are passed
+// to the given handler before it, so failures come with their full debug context without the cost of always logging
+// at debug level. The following records of the request are passed through directly.
+// The buffers of requests without a trigger record are discarded after [TailSamplingOptions.TTL].
+//
+// The buffered records are passed to the given handler regardless of its level, records without the key
+// only if it is enabled for them.
+//
+// Example:
+//
+//	h := logger.NewTailSamplingHandler(slog.NewJSONHandler(os.Stdout, nil))
+//	log := logger.NewLogger(logger.Options{Handler: h}).With(logger.RequestIDKey, id)
+//	log.Debug("loading order") // buffered
+//	log.Error("failed to load order") // emits the debug record before the error
+func NewTailSamplingHandler(h slog.Handler, o ...TailSamplingOptions) slog.Handler {
+	return logger.NewTailSamplingHandler(h, o...)
+}
+
+// RingBufferOptions is the optional configuration for [NewRingBufferHandler].
+type RingBufferOptions = logger.RingBufferOptions
+
+// RingBufferHandler is a [slog.Handler] keeping the last records in memory for post-mortem debugging.
+type RingBufferHandler = logger.RingBufferHandler
+
+// NewRingBufferHandler returns a new [RingBufferHandler] that passes the records to the given handler
+// and keeps the last [RingBufferOptions.Size] records as JSON lines, including the records below the level
+// of the given handler down to [RingBufferOptions.Level].
+//
+// A record at or above [RingBufferOptions.DumpLevel], e.g. of Panic or Fatal, dumps and clears the buffer,
+// giving the context of the crash. The buffer can also be dumped on demand with [RingBufferHandler.Dump].
+//
+// Example:
+//
+//	h := logger.NewRingBufferHandler(slog.NewJSONHandler(os.Stdout, nil), logger.RingBufferOptions{Size: 500})
+//	log := logger.NewLogger(logger.Options{Handler: h})
+//	log.Debug("kept in memory only")
+//	log.Fatal("crash") // dumps the last 500 records to stderr
+func NewRingBufferHandler(h slog.Handler, o ...RingBufferOptions) *RingBufferHandler {
+	return logger.NewRingBufferHandler(h, o...)
+}
+
+// ErrSocketDropped is reported to [SocketOptions.OnError] if records were dropped because the buffer was full.
+var ErrSocketDropped = logger.ErrSocketDropped
+
+// SocketOptions is the configuration for [NewSocketHandler].
+type SocketOptions = logger.SocketOptions
+
+// SocketHandler is a [slog.Handler] writing records to a TCP, UDP or Unix socket.
+// It must be closed to write the buffered records.
+type SocketHandler = logger.SocketHandler
+
+// NewSocketHandler returns a new [SocketHandler] writing records as JSON to [SocketOptions.Address],
+// e.g. for the ingestion by Logstash or rsyslog. The records are written by a background worker,
+// which reconnects if the connection is lost. While the target is not reachable,
+// up to [SocketOptions.BufferSize] records are buffered, dropping the oldest ones.
+//
+// It returns an error if the address or the framing is invalid.
+//
+// Example:
+//
+//	h, err := logger.NewSocketHandler(logger.SocketOptions{Address: "tcp://logstash:5000"})
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer h.Close()
+//	log := logger.NewLogger(logger.Options{Handler: h})
+func NewSocketHandler(o SocketOptions) (*SocketHandler, error) {
+	return logger.NewSocketHandler(o)
+}
+
+// WebhookOptions is the configuration for [NewWebhookHandler].
+type WebhookOptions = logger.WebhookOptions
+
+// WebhookHandler is a [slog.Handler] posting records to a webhook.
+// It must be closed to post the buffered records.
+type WebhookHandler = logger.WebhookHandler
+
+// NewWebhookHandler returns a new [WebhookHandler] posting the records to [WebhookOptions.URL].
+// The records are posted by a background worker, one by one or in batches of [WebhookOptions.BatchSize] records,
+// with the body rendered by [WebhookOptions.Template], e.g. in the payload shape of Slack, Teams or PagerDuty.
+// Failed requests are retried with exponential backoff if the webhook is unreachable,
+// rate limits the requests or returns a server error.
+//
+// It returns an error if the template cannot be parsed.
+//
+// Example:
+//
+//	h, err := logger.NewWebhookHandler(logger.WebhookOptions{
+//		URL:      "https://hooks.slack.com/services/T000/B000/XXXX",
+//		Level:    logger.LevelError,
+//		Template: `{"text": {{json (printf "%s: %s" .level .msg)}}}`,
+//	})
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer h.Close()
+//	log := logger.NewLogger(logger.Options{Handler: h})
+func NewWebhookHandler(o WebhookOptions) (*WebhookHandler, error) {
+	return logger.NewWebhookHandler(o)
+}
+
+// NotifierOptions is the configuration for [NewNotifierHandler].
+type NotifierOptions = logger.NotifierOptions
+
+// NewNotifierHandler returns a new [slog.Handler] that passes all records to the given handler
+// and sends a notification to a Slack or Microsoft Teams webhook for the records at or above [NotifierOptions.Level],
+// which defaults to PANIC and FATAL records. The notification has the service, the host, the message,
+// the attributes and the [NotifierOptions.Link].
+//
+// At most one notification is sent per [NotifierOptions.Interval] to prevent alert storms.
+// The notifications are sent synchronously, so they are delivered before a FATAL record exits the program.
+// Errors are passed to [NotifierOptions.OnError].
+//
+// It returns an error if the URL is empty, the format is unknown or the link template cannot be parsed.
+//
+// Example:
+//
+//	h, err := logger.NewNotifierHandler(slog.NewJSONHandler(os.Stdout, nil), logger.NotifierOptions{
+//		URL:     os.Getenv("SLACK_WEBHOOK_URL"),
+//		Service: "api",
+//		Link:    "https://grafana.example.com/explore?service={{urlquery .Service}}",
+//	})
+//	if err != nil {
+//		panic(err)
+//	}
+//	log := logger.NewLogger(logger.Options{Handler: h})
+func NewNotifierHandler(h slog.Handler, o NotifierOptions) (slog.Handler, error) {
+	return logger.NewNotifierHandler(h, o)
+}
+
+// ErrInvalidSentryDSN is returned by [NewSentryHandler] if the DSN is empty or malformed.
+var ErrInvalidSentryDSN = logger.ErrInvalidSentryDSN
+
+// SentryOptions is the optional configuration for [NewSentryHandler].
+type SentryOptions = logger.SentryOptions
+
+// NewSentryHandler returns a new [slog.Handler] that passes all records to the given handler
+// and sends the records at or above [SentryOptions.Level] as events to Sentry:
+//   - The message and the level are the message and the level of the event, [LevelPanic] and [LevelFatal] map to fatal.
+//   - The attributes are added as extra data, groups as nested objects.
+//   - The first error attribute, e.g. added by [Err], is added as exception with the stack trace captured by [WithStack]
+//     or, if there is none, the stack trace added by [NewStacktraceHandler].
+//   - The records below the level logged with the same context are added as breadcrumbs, see [WithBreadcrumbs].
+//
+// The events are sent synchronously, errors are passed to [SentryOptions.OnError].
+// It returns [ErrInvalidSentryDSN] if the DSN is empty or malformed.
+//
+// Example:
+//
+//	h, err := logger.NewSentryHandler(slog.NewJSONHandler(os.Stdout, nil), logger.SentryOptions{DSN: os.Getenv("SENTRY_DSN")})
+//	if err != nil {
+//		panic(err)
+//	}
+//	log := logger.NewLogger(logger.Options{Handler: h})
+func NewSentryHandler(h slog.Handler, o ...SentryOptions) (slog.Handler, error) {
+	return logger.NewSentryHandler(h, o...)
+}
+
+// WithBreadcrumbs returns a copy of the context carrying a new breadcrumb trail.
+// The records below [SentryOptions.Level] logged with the returned context are recorded as breadcrumbs
+// by a handler returned by [NewSentryHandler] and sent with the next event of the same context,
+// e.g. to see what happened during a request before it failed.
+//
+// Example:
+//
+//	ctx := logger.WithBreadcrumbs(r.Context())
+//	log.InfoContext(ctx, "loading order", "id", id)
+//	log.ErrorContext(ctx, "failed to load order", logger.Err(err))
+func WithBreadcrumbs(ctx context.Context) context.Context {
+	return logger.WithBreadcrumbs(ctx)
+}
@@ -0,0 +1,218 @@
+package loggrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lvlcn-t/loggerhead/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+// AttemptKey is the key used for the number of attempts of an outbound RPC.
+const AttemptKey = "attempt"
+
+// attemptsKey is the key used to store the attempt counter of an outbound RPC in the context.
+type attemptsKey struct{}
+
+// attemptKey is the key used to store the current attempt in the attempt context.
+type attemptKey struct{}
+
+// attempt is an attempt of an outbound RPC.
+type attempt struct {
+	method string
+	number int64
+}
+
+// UnaryClientInterceptor returns a unary client interceptor that logs every outbound call with the logger
+// found in the outgoing context, including its method, status code and duration.
+// The level is chosen by the status code, see [CodeLevel].
+//
+// Combined with [ClientStatsHandler], the number of attempts is added to the record
+// and every retry is logged, since retries are performed by gRPC below the interceptors.
+//
+// Example:
+//
+//	conn, err := grpc.NewClient(target,
+//		grpc.WithChainUnaryInterceptor(loggrpc.UnaryClientInterceptor()),
+//		grpc.WithStatsHandler(loggrpc.ClientStatsHandler()),
+//	)
+func UnaryClientInterceptor(o ...Options) grpc.UnaryClientInterceptor {
+	opts := newOptions(o...)
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		attempts := &atomic.Int64{}
+		ctx = context.WithValue(ctx, attemptsKey{}, attempts)
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, callOpts...)
+
+		attrs := clientCallAttrs(method, err, time.Since(start), attempts)
+		if opts.LogPayloads {
+			attrs = append(attrs, slog.Any(RequestKey, req), slog.Any(ResponseKey, reply))
+		}
+		logger.FromContext(ctx).LogAttrs(ctx, CodeLevel(status.Code(err)), "gRPC client call", attrs...)
+		return err
+	}
+}
+
+// StreamClientInterceptor returns a stream client interceptor that logs every outbound stream with the logger
+// found in the outgoing context, including its method, status code and duration once the stream is finished.
+// The level is chosen by the status code, see [CodeLevel].
+//
+// Combined with [ClientStatsHandler], the number of attempts is added to the record and every retry is logged.
+//
+// Example:
+//
+//	conn, err := grpc.NewClient(target,
+//		grpc.WithChainStreamInterceptor(loggrpc.StreamClientInterceptor()),
+//		grpc.WithStatsHandler(loggrpc.ClientStatsHandler()),
+//	)
+func StreamClientInterceptor(o ...Options) grpc.StreamClientInterceptor {
+	opts := newOptions(o...)
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		attempts := &atomic.Int64{}
+		ctx = context.WithValue(ctx, attemptsKey{}, attempts)
+		log := logger.FromContext(ctx)
+		start := time.Now()
+
+		cs, err := streamer(ctx, desc, cc, method, callOpts...)
+		if err != nil {
+			log.LogAttrs(ctx, CodeLevel(status.Code(err)), "gRPC client stream", clientCallAttrs(method, err, time.Since(start), attempts)...)
+			return nil, err
+		}
+		return &clientStream{
+			ClientStream: cs,
+			ctx:          ctx,
+			log:          log,
+			method:       method,
+			payloads:     opts.LogPayloads,
+			start:        start,
+			attempts:     attempts,
+		}, nil
+	}
+}
+
+// clientCallAttrs returns the attributes logged for every outbound call.
+// The number of attempts is only added if it was counted by the [ClientStatsHandler].
+func clientCallAttrs(method string, err error, d time.Duration, attempts *atomic.Int64) []slog.Attr {
+	attrs := callAttrs(method, err, d)
+	if n := attempts.Load(); n > 0 {
+		attrs = append(attrs, slog.Int64(AttemptKey, n))
+	}
+	return attrs
+}
+
+var _ grpc.ClientStream = (*clientStream)(nil)
+
+// clientStream is a [grpc.ClientStream] that logs the stream once it is finished and optionally logs the streamed messages.
+type clientStream struct {
+	grpc.ClientStream
+	ctx      context.Context //nolint:containedctx // the outgoing context is required to log the finished stream
+	log      logger.Provider
+	method   string
+	payloads bool
+	start    time.Time
+	attempts *atomic.Int64
+	once     sync.Once
+}
+
+// SendMsg sends the message and logs it if payload logging is enabled.
+func (s *clientStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil && s.payloads {
+		s.log.LogAttrs(s.ctx, logger.LevelDebug, "gRPC stream message sent", slog.String(MethodKey, s.method), slog.Any(RequestKey, m))
+	}
+	return err
+}
+
+// RecvMsg receives a message and logs it if payload logging is enabled.
+// The stream is logged once it is finished, i.e. if [io.EOF] or an error is received.
+func (s *clientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == nil:
+		if s.payloads {
+			s.log.LogAttrs(s.ctx, logger.LevelDebug, "gRPC stream message received", slog.String(MethodKey, s.method), slog.Any(ResponseKey, m))
+		}
+	case errors.Is(err, io.EOF):
+		s.finish(nil)
+	default:
+		s.finish(err)
+	}
+	return err
+}
+
+// finish logs the finished stream once.
+func (s *clientStream) finish(err error) {
+	s.once.Do(func() {
+		s.log.LogAttrs(s.ctx, CodeLevel(status.Code(err)), "gRPC client stream", clientCallAttrs(s.method, err, time.Since(s.start), s.attempts)...)
+	})
+}
+
+var _ stats.Handler = (*clientStatsHandler)(nil)
+
+// clientStatsHandler is a [stats.Handler] that logs the attempts of outbound RPCs.
+type clientStatsHandler struct{}
+
+// ClientStatsHandler returns a [stats.Handler] that logs every attempt of an outbound RPC with the logger
+// found in the outgoing context. Retries are logged at [logger.LevelWarn], failed attempts at [logger.LevelDebug].
+// It counts the attempts for [UnaryClientInterceptor] and [StreamClientInterceptor],
+// which cannot observe retries since they are performed by gRPC below the interceptors.
+//
+// Example:
+//
+//	conn, err := grpc.NewClient(target, grpc.WithStatsHandler(loggrpc.ClientStatsHandler()))
+func ClientStatsHandler() stats.Handler {
+	return &clientStatsHandler{}
+}
+
+// TagRPC counts the attempt and stores its number in the attempt context.
+func (h *clientStatsHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	a := attempt{method: info.FullMethodName}
+	if attempts, ok := ctx.Value(attemptsKey{}).(*atomic.Int64); ok {
+		a.number = attempts.Add(1)
+	}
+	return context.WithValue(ctx, attemptKey{}, a)
+}
+
+// HandleRPC logs retries and failed attempts.
+func (h *clientStatsHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	if !s.IsClient() {
+		return
+	}
+
+	a, _ := ctx.Value(attemptKey{}).(attempt)
+	switch s := s.(type) {
+	case *stats.Begin:
+		if a.number > 1 {
+			logger.FromContext(ctx).LogAttrs(ctx, logger.LevelWarn, "Retrying gRPC call",
+				slog.String(MethodKey, a.method),
+				slog.Int64(AttemptKey, a.number),
+				slog.Bool("transparent", s.IsTransparentRetryAttempt),
+			)
+		}
+	case *stats.End:
+		if s.Error != nil {
+			logger.FromContext(ctx).LogAttrs(ctx, logger.LevelDebug, "gRPC call attempt failed",
+				slog.String(MethodKey, a.method),
+				slog.Int64(AttemptKey, a.number),
+				slog.String(CodeKey, status.Code(s.Error).String()),
+				slog.Duration(DurationKey, s.EndTime.Sub(s.BeginTime)),
+				logger.Err(s.Error),
+			)
+		}
+	}
+}
+
+// TagConn returns the context unchanged.
+func (h *clientStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn does nothing, connection stats are not logged.
+func (h *clientStatsHandler) HandleConn(context.Context, stats.ConnStats) {}
@@ -0,0 +1,159 @@
+package loggrpc
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// retryServiceConfig retries unavailable health checks up to three times.
+const retryServiceConfig = `{
+	"methodConfig": [{
+		"name": [{"service": "grpc.health.v1.Health"}],
+		"retryPolicy": {
+			"maxAttempts": 3,
+			"initialBackoff": "0.001s",
+			"maxBackoff": "0.01s",
+			"backoffMultiplier": 2,
+			"retryableStatusCodes": ["UNAVAILABLE"]
+		}
+	}]
+}`
+
+// flakyHealthServer is a health server failing the first calls with [codes.Unavailable].
+type flakyHealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	failures atomic.Int64
+}
+
+func (s *flakyHealthServer) Check(context.Context, *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if s.failures.Add(-1) >= 0 {
+		return nil, status.Error(codes.Unavailable, "try again")
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+func (s *flakyHealthServer) Watch(_ *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	if s.failures.Add(-1) >= 0 {
+		return status.Error(codes.Unavailable, "try again")
+	}
+	return stream.Send(&grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING})
+}
+
+// newTestClient starts a health server failing the given number of calls and returns a client connected to it.
+func newTestClient(t *testing.T, failures int64) grpc_health_v1.HealthClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20) //nolint:mnd // 1 MiB buffer
+	srv := grpc.NewServer()
+	health := &flakyHealthServer{}
+	health.failures.Store(failures)
+	grpc_health_v1.RegisterHealthServer(srv, health)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultServiceConfig(retryServiceConfig),
+		grpc.WithChainUnaryInterceptor(UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(StreamClientInterceptor()),
+		grpc.WithStatsHandler(ClientStatsHandler()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return grpc_health_v1.NewHealthClient(conn)
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int64
+		wantCode     codes.Code
+		wantLevel    string
+		wantAttempts float64
+		wantRetries  int
+	}{
+		{name: "success", failures: 0, wantCode: codes.OK, wantLevel: "INFO", wantAttempts: 1},
+		{name: "retried", failures: 2, wantCode: codes.OK, wantLevel: "INFO", wantAttempts: 3, wantRetries: 2},
+		{name: "exhausted", failures: 5, wantCode: codes.Unavailable, wantLevel: "ERROR", wantAttempts: 3, wantRetries: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, tt.failures)
+			ctx, buf := newTestContext()
+
+			_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("Expected code %v, got %v", tt.wantCode, err)
+			}
+
+			var call map[string]any
+			retries := 0
+			for _, r := range decodeRecords(t, buf) {
+				switch r["msg"] {
+				case "gRPC client call":
+					call = r
+				case "Retrying gRPC call":
+					retries++
+				}
+			}
+			if call == nil {
+				t.Fatal("Expected the call to be logged")
+			}
+			if call["level"] != tt.wantLevel {
+				t.Errorf("Expected level %q, got %v", tt.wantLevel, call["level"])
+			}
+			if call[MethodKey] != grpc_health_v1.Health_Check_FullMethodName {
+				t.Errorf("Expected method %q, got %v", grpc_health_v1.Health_Check_FullMethodName, call[MethodKey])
+			}
+			if call[AttemptKey] != tt.wantAttempts {
+				t.Errorf("Expected %v attempts, got %v", tt.wantAttempts, call[AttemptKey])
+			}
+			if retries != tt.wantRetries {
+				t.Errorf("Expected %d retries to be logged, got %d", tt.wantRetries, retries)
+			}
+		})
+	}
+}
+
+func TestStreamClientInterceptor(t *testing.T) {
+	client := newTestClient(t, 0)
+	ctx, buf := newTestContext()
+
+	stream, err := client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Failed to start stream: %v", err)
+	}
+	for {
+		if _, err = stream.Recv(); err != nil {
+			break
+		}
+	}
+
+	var logged []map[string]any
+	for _, r := range decodeRecords(t, buf) {
+		if r["msg"] == "gRPC client stream" {
+			logged = append(logged, r)
+		}
+	}
+	if len(logged) != 1 {
+		t.Fatalf("Expected the stream to be logged once, got %d records", len(logged))
+	}
+	if logged[0][CodeKey] != codes.OK.String() {
+		t.Errorf("Expected code %q, got %v", codes.OK, logged[0][CodeKey])
+	}
+	if logged[0][MethodKey] != grpc_health_v1.Health_Watch_FullMethodName {
+		t.Errorf("Expected method %q, got %v", grpc_health_v1.Health_Watch_FullMethodName, logged[0][MethodKey])
+	}
+}
@@ -0,0 +1,154 @@
+// Package loggrpc provides gRPC server interceptors that integrate the loggerhead logger into gRPC services.
+package loggrpc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/lvlcn-t/loggerhead/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// MethodKey is the key used for the full RPC method name.
+	MethodKey = "method"
+	// CodeKey is the key used for the gRPC status code.
+	CodeKey = "code"
+	// DurationKey is the key used for the duration of the RPC.
+	DurationKey = "duration"
+	// RequestKey is the key used for the request payload.
+	RequestKey = "request"
+	// ResponseKey is the key used for the response payload.
+	ResponseKey = "response"
+)
+
+// Options is the optional configuration for the interceptors.
+type Options struct {
+	// LogPayloads is a flag to log the request and response payloads.
+	// Unary payloads are added to the call record, stream messages are logged at [logger.LevelDebug].
+	// Payloads may contain sensitive data, so this is disabled by default.
+	LogPayloads bool
+}
+
+// newOptions returns the provided Options or the default Options if none are provided.
+func newOptions(o ...Options) Options {
+	if len(o) > 0 {
+		return o[0]
+	}
+	return Options{}
+}
+
+// UnaryServerInterceptor returns a unary server interceptor that adds the logger from the given context to the RPC context
+// and logs every call with its method, status code and duration.
+// The level is chosen by the status code, see [CodeLevel].
+//
+// Example:
+//
+//	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(loggrpc.UnaryServerInterceptor(ctx)))
+func UnaryServerInterceptor(ctx context.Context, o ...Options) grpc.UnaryServerInterceptor {
+	log := logger.FromContext(ctx)
+	opts := newOptions(o...)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx = logger.IntoContext(ctx, log)
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		attrs := callAttrs(info.FullMethod, err, time.Since(start))
+		if opts.LogPayloads {
+			attrs = append(attrs, slog.Any(RequestKey, req), slog.Any(ResponseKey, resp))
+		}
+		log.LogAttrs(ctx, CodeLevel(status.Code(err)), "gRPC call", attrs...)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a stream server interceptor that adds the logger from the given context to the stream context
+// and logs every call with its method, status code and duration once the stream is finished.
+// The level is chosen by the status code, see [CodeLevel].
+//
+// Example:
+//
+//	srv := grpc.NewServer(grpc.ChainStreamInterceptor(loggrpc.StreamServerInterceptor(ctx)))
+func StreamServerInterceptor(ctx context.Context, o ...Options) grpc.StreamServerInterceptor {
+	log := logger.FromContext(ctx)
+	opts := newOptions(o...)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		stream := &serverStream{
+			ServerStream: ss,
+			ctx:          logger.IntoContext(ss.Context(), log),
+			log:          log,
+			method:       info.FullMethod,
+			payloads:     opts.LogPayloads,
+		}
+		start := time.Now()
+		err := handler(srv, stream)
+
+		log.LogAttrs(stream.ctx, CodeLevel(status.Code(err)), "gRPC stream", callAttrs(info.FullMethod, err, time.Since(start))...)
+		return err
+	}
+}
+
+// callAttrs returns the attributes logged for every call.
+func callAttrs(method string, err error, d time.Duration) []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String(MethodKey, method),
+		slog.String(CodeKey, status.Code(err).String()),
+		slog.Duration(DurationKey, d),
+	}
+	if err != nil {
+		attrs = append(attrs, logger.Err(err))
+	}
+	return attrs
+}
+
+// CodeLevel returns the log level for the given gRPC status code.
+// Server-side failures are logged at [logger.LevelError], client-side failures at [logger.LevelWarn]
+// and successful calls at [logger.LevelInfo].
+func CodeLevel(code codes.Code) logger.Level {
+	switch code {
+	case codes.OK:
+		return logger.LevelInfo
+	case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.PermissionDenied,
+		codes.Unauthenticated, codes.ResourceExhausted, codes.FailedPrecondition, codes.Aborted, codes.OutOfRange:
+		return logger.LevelWarn
+	default:
+		return logger.LevelError
+	}
+}
+
+var _ grpc.ServerStream = (*serverStream)(nil)
+
+// serverStream is a [grpc.ServerStream] that carries the logger in its context and optionally logs the streamed messages.
+type serverStream struct {
+	grpc.ServerStream
+	ctx      context.Context //nolint:containedctx // the stream context is replaced
+	log      logger.Provider
+	method   string
+	payloads bool
+}
+
+// Context returns the stream context including the logger.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg sends the message and logs it if payload logging is enabled.
+func (s *serverStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil && s.payloads {
+		s.log.LogAttrs(s.ctx, logger.LevelDebug, "gRPC stream message sent", slog.String(MethodKey, s.method), slog.Any(ResponseKey, m))
+	}
+	return err
+}
+
+// RecvMsg receives a message and logs it if payload logging is enabled.
+func (s *serverStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.payloads {
+		s.log.LogAttrs(s.ctx, logger.LevelDebug, "gRPC stream message received", slog.String(MethodKey, s.method), slog.Any(RequestKey, m))
+	}
+	return err
+}
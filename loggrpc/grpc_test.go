@@ -0,0 +1,183 @@
+package loggrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/lvlcn-t/loggerhead/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newTestContext returns a context carrying a JSON logger writing to the returned buffer.
+func newTestContext() (context.Context, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	log := logger.NewLogger(logger.Options{
+		Handler: slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	})
+	return logger.IntoContext(context.Background(), log), buf
+}
+
+// decodeRecords decodes the JSON records written to the buffer.
+func decodeRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var r map[string]any
+		if err := dec.Decode(&r); err != nil {
+			t.Fatalf("Failed to decode record: %v", err)
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      Options
+		err       error
+		wantLevel string
+		wantCode  string
+		wantPay   bool
+	}{
+		{name: "ok", wantLevel: "INFO", wantCode: "OK"},
+		{name: "client error", err: status.Error(codes.NotFound, "missing"), wantLevel: "WARN", wantCode: "NotFound"},
+		{name: "server error", err: errors.New("boom"), wantLevel: "ERROR", wantCode: "Unknown"},
+		{name: "payloads", opts: Options{LogPayloads: true}, wantLevel: "INFO", wantCode: "OK", wantPay: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, buf := newTestContext()
+			interceptor := UnaryServerInterceptor(ctx, tt.opts)
+
+			handler := func(context.Context, any) (any, error) {
+				return "pong", tt.err
+			}
+			_, err := interceptor(context.Background(), "ping", &grpc.UnaryServerInfo{FullMethod: "/test.Service/Ping"}, handler)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Expected error %v, got %v", tt.err, err)
+			}
+
+			records := decodeRecords(t, buf)
+			if len(records) != 1 {
+				t.Fatalf("Expected 1 record, got %d", len(records))
+			}
+			r := records[0]
+			if r["level"] != tt.wantLevel {
+				t.Errorf("Expected level %q, got %v", tt.wantLevel, r["level"])
+			}
+			if r[MethodKey] != "/test.Service/Ping" {
+				t.Errorf("Expected method %q, got %v", "/test.Service/Ping", r[MethodKey])
+			}
+			if r[CodeKey] != tt.wantCode {
+				t.Errorf("Expected code %q, got %v", tt.wantCode, r[CodeKey])
+			}
+			if _, ok := r[DurationKey]; !ok {
+				t.Error("Expected duration to be logged")
+			}
+			if _, ok := r[RequestKey]; ok != tt.wantPay {
+				t.Errorf("Expected request payload logged = %v, got %v", tt.wantPay, ok)
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptor_InjectsLogger(t *testing.T) {
+	ctx, _ := newTestContext()
+	want := logger.FromContext(ctx)
+
+	var got logger.Provider
+	_, _ = UnaryServerInterceptor(ctx)(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ any) (any, error) {
+		got = logger.FromContext(ctx)
+		return nil, nil
+	})
+	if got != want {
+		t.Errorf("Expected the logger of the interceptor context in the RPC context")
+	}
+}
+
+// mockServerStream is a [grpc.ServerStream] echoing the sent messages.
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context //nolint:containedctx // test stream
+}
+
+func (m *mockServerStream) Context() context.Context { return m.ctx }
+func (m *mockServerStream) SendMsg(any) error        { return nil }
+func (m *mockServerStream) RecvMsg(any) error        { return nil }
+
+func TestStreamServerInterceptor(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        Options
+		err         error
+		wantLevel   string
+		wantRecords int
+	}{
+		{name: "ok", wantLevel: "INFO", wantRecords: 1},
+		{name: "server error", err: status.Error(codes.Internal, "boom"), wantLevel: "ERROR", wantRecords: 1},
+		{name: "payloads", opts: Options{LogPayloads: true}, wantLevel: "INFO", wantRecords: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, buf := newTestContext()
+			want := logger.FromContext(ctx)
+			interceptor := StreamServerInterceptor(ctx, tt.opts)
+
+			handler := func(_ any, ss grpc.ServerStream) error {
+				if logger.FromContext(ss.Context()) != want {
+					t.Error("Expected the logger to be stored in the stream context")
+				}
+				_ = ss.RecvMsg("ping")
+				_ = ss.SendMsg("pong")
+				return tt.err
+			}
+			ss := &mockServerStream{ctx: context.Background()}
+			if err := interceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: "/test.Service/Stream"}, handler); !errors.Is(err, tt.err) {
+				t.Errorf("Expected error %v, got %v", tt.err, err)
+			}
+
+			records := decodeRecords(t, buf)
+			if len(records) != tt.wantRecords {
+				t.Fatalf("Expected %d records, got %d", tt.wantRecords, len(records))
+			}
+			last := records[len(records)-1]
+			if last["level"] != tt.wantLevel {
+				t.Errorf("Expected level %q, got %v", tt.wantLevel, last["level"])
+			}
+			if last[MethodKey] != "/test.Service/Stream" {
+				t.Errorf("Expected method %q, got %v", "/test.Service/Stream", last[MethodKey])
+			}
+		})
+	}
+}
+
+func TestCodeLevel(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		want logger.Level
+	}{
+		{code: codes.OK, want: logger.LevelInfo},
+		{code: codes.Canceled, want: logger.LevelWarn},
+		{code: codes.PermissionDenied, want: logger.LevelWarn},
+		{code: codes.Unknown, want: logger.LevelError},
+		{code: codes.Unavailable, want: logger.LevelError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			if got := CodeLevel(tt.code); got != tt.want {
+				t.Errorf("CodeLevel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+// Package loghclog provides an [hclog.Logger] backed by the loggerhead logger,
+// so the logs of HashiCorp libraries like Vault, Consul or raft flow through the same handlers.
+package loghclog
+
+import (
+	"context"
+	"io"
+	"log"
+	"log/slog"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/lvlcn-t/loggerhead/logger"
+)
+
+// NameKey is the key used for the name of a logger set by [hclog.Logger.Named].
+const NameKey = "logger"
+
+// NewLogger returns a new [hclog.Logger] logging with the given logger.
+// The levels are mapped by [Level]. Until [hclog.Logger.SetLevel] is called, the level of the given logger is used.
+//
+// Example:
+//
+//	cfg := raft.DefaultConfig()
+//	cfg.Logger = loghclog.NewLogger(logger.NewLogger())
+func NewLogger(l logger.Provider) hclog.Logger {
+	return &adapter{log: l, level: &atomic.Int32{}}
+}
+
+// Level returns the level of the given hclog level.
+// [hclog.Off] and [hclog.NoLevel] have no equivalent and are returned as [logger.LevelInfo].
+func Level(level hclog.Level) logger.Level {
+	switch level { //nolint:exhaustive // Off and NoLevel have no equivalent
+	case hclog.Trace:
+		return logger.LevelTrace
+	case hclog.Debug:
+		return logger.LevelDebug
+	case hclog.Warn:
+		return logger.LevelWarn
+	case hclog.Error:
+		return logger.LevelError
+	default:
+		return logger.LevelInfo
+	}
+}
+
+var _ hclog.Logger = (*adapter)(nil)
+
+// adapter is an [hclog.Logger] logging with a [logger.Provider].
+type adapter struct {
+	log  logger.Provider
+	name string
+	args []any
+	// level is the [hclog.Level] set by SetLevel, shared with the sub-loggers.
+	// [hclog.NoLevel] defers to the level of the provider.
+	level *atomic.Int32
+}
+
+// Log logs a record at the given level.
+func (a *adapter) Log(level hclog.Level, msg string, args ...any) {
+	a.handle(level, msg, args)
+}
+
+// Trace logs a record at [logger.LevelTrace].
+func (a *adapter) Trace(msg string, args ...any) {
+	a.handle(hclog.Trace, msg, args)
+}
+
+// Debug logs a record at [logger.LevelDebug].
+func (a *adapter) Debug(msg string, args ...any) {
+	a.handle(hclog.Debug, msg, args)
+}
+
+// Info logs a record at [logger.LevelInfo].
+func (a *adapter) Info(msg string, args ...any) {
+	a.handle(hclog.Info, msg, args)
+}
+
+// Warn logs a record at [logger.LevelWarn].
+func (a *adapter) Warn(msg string, args ...any) {
+	a.handle(hclog.Warn, msg, args)
+}
+
+// Error logs a record at [logger.LevelError].
+func (a *adapter) Error(msg string, args ...any) {
+	a.handle(hclog.Error, msg, args)
+}
+
+// IsTrace reports whether records at [hclog.Trace] are logged.
+func (a *adapter) IsTrace() bool { return a.enabled(hclog.Trace) }
+
+// IsDebug reports whether records at [hclog.Debug] are logged.
+func (a *adapter) IsDebug() bool { return a.enabled(hclog.Debug) }
+
+// IsInfo reports whether records at [hclog.Info] are logged.
+func (a *adapter) IsInfo() bool { return a.enabled(hclog.Info) }
+
+// IsWarn reports whether records at [hclog.Warn] are logged.
+func (a *adapter) IsWarn() bool { return a.enabled(hclog.Warn) }
+
+// IsError reports whether records at [hclog.Error] are logged.
+func (a *adapter) IsError() bool { return a.enabled(hclog.Error) }
+
+// ImpliedArgs returns the arguments added by With.
+func (a *adapter) ImpliedArgs() []any {
+	return a.args
+}
+
+// With returns a sub-logger with the given key-value pairs added to every record.
+func (a *adapter) With(args ...any) hclog.Logger {
+	return &adapter{
+		log:   a.log.With(args...),
+		name:  a.name,
+		args:  append(a.args[:len(a.args):len(a.args)], args...),
+		level: a.level,
+	}
+}
+
+// Name returns the name of the logger.
+func (a *adapter) Name() string {
+	return a.name
+}
+
+// Named returns a sub-logger with the given name appended to the logger's name, separated by a dot.
+func (a *adapter) Named(name string) hclog.Logger {
+	if a.name != "" {
+		name = a.name + "." + name
+	}
+	return a.ResetNamed(name)
+}
+
+// ResetNamed returns a sub-logger with the given name.
+func (a *adapter) ResetNamed(name string) hclog.Logger {
+	return &adapter{log: a.log, name: name, args: a.args, level: a.level}
+}
+
+// SetLevel sets the minimum level of the logger and its sub-loggers.
+// [hclog.NoLevel] defers to the level of the underlying logger again.
+func (a *adapter) SetLevel(level hclog.Level) {
+	a.level.Store(int32(level))
+}
+
+// GetLevel returns the level set by SetLevel or the lowest level enabled by the underlying logger.
+func (a *adapter) GetLevel() hclog.Level {
+	if level := hclog.Level(a.level.Load()); level != hclog.NoLevel {
+		return level
+	}
+	for _, level := range []hclog.Level{hclog.Trace, hclog.Debug, hclog.Info, hclog.Warn, hclog.Error} {
+		if a.enabled(level) {
+			return level
+		}
+	}
+	return hclog.Off
+}
+
+// StandardLogger returns a [log.Logger] logging every call at [hclog.StandardLoggerOptions.ForceLevel],
+// or at [logger.LevelInfo] if it is not set. Inferring levels from the output is not supported.
+func (a *adapter) StandardLogger(opts *hclog.StandardLoggerOptions) *log.Logger {
+	level := logger.LevelInfo
+	if opts != nil && opts.ForceLevel != hclog.NoLevel {
+		level = Level(opts.ForceLevel)
+	}
+	l := a.log
+	if a.name != "" {
+		l = l.With(NameKey, a.name)
+	}
+	return logger.NewStdLogger(l, level)
+}
+
+// StandardWriter returns the [io.Writer] of the logger returned by StandardLogger.
+func (a *adapter) StandardWriter(opts *hclog.StandardLoggerOptions) io.Writer {
+	return a.StandardLogger(opts).Writer()
+}
+
+// enabled reports whether records at the given level are logged.
+func (a *adapter) enabled(level hclog.Level) bool {
+	if minLevel := hclog.Level(a.level.Load()); minLevel != hclog.NoLevel && level < minLevel {
+		return false
+	}
+	return level != hclog.Off && a.log.Enabled(context.Background(), Level(level))
+}
+
+// handle logs the record with the caller of the adapter method as source.
+func (a *adapter) handle(level hclog.Level, msg string, args []any) {
+	if !a.enabled(level) {
+		return
+	}
+
+	// skip is the number of stack frames to skip to find the caller.
+	// We need to skip calling runtime.Callers, this function and the adapter method.
+	const skip = 3
+	var pcs [1]uintptr
+	_ = runtime.Callers(skip, pcs[:])
+	r := slog.NewRecord(time.Now(), slog.Level(Level(level)), msg, pcs[0])
+	if a.name != "" {
+		r.AddAttrs(slog.String(NameKey, a.name))
+	}
+	r.Add(args...)
+	_ = a.log.Handler().Handle(context.Background(), r)
+}
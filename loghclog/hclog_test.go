@@ -0,0 +1,131 @@
+package loghclog
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/lvlcn-t/loggerhead/logger"
+)
+
+func TestNewLogger(t *testing.T) {
+	tests := []struct {
+		name      string
+		log       func(l hclog.Logger)
+		wantLevel logger.Level
+		wantAttrs map[string]any
+		wantNone  bool
+	}{
+		{
+			name:      "info",
+			log:       func(l hclog.Logger) { l.Info("test", "key", "value") },
+			wantLevel: logger.LevelInfo,
+			wantAttrs: map[string]any{"key": "value"},
+		},
+		{
+			name:      "trace",
+			log:       func(l hclog.Logger) { l.Trace("test") },
+			wantLevel: logger.LevelTrace,
+		},
+		{
+			name:      "log at level",
+			log:       func(l hclog.Logger) { l.Log(hclog.Warn, "test") },
+			wantLevel: logger.LevelWarn,
+		},
+		{
+			name:      "named sub-loggers",
+			log:       func(l hclog.Logger) { l.Named("raft").With("peer", "a").Named("snapshot").Error("test") },
+			wantLevel: logger.LevelError,
+			wantAttrs: map[string]any{NameKey: "raft.snapshot", "peer": "a"},
+		},
+		{
+			name:      "reset name",
+			log:       func(l hclog.Logger) { l.Named("raft").ResetNamed("vault").Info("test") },
+			wantLevel: logger.LevelInfo,
+			wantAttrs: map[string]any{NameKey: "vault"},
+		},
+		{
+			name: "level set by SetLevel",
+			log: func(l hclog.Logger) {
+				l.SetLevel(hclog.Warn)
+				l.Named("sub").Info("test")
+			},
+			wantNone: true,
+		},
+		{
+			name:     "off",
+			log:      func(l hclog.Logger) { l.SetLevel(hclog.Off); l.Error("test") },
+			wantNone: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			l := NewLogger(logger.NewLogger(logger.Options{Handler: slog.NewJSONHandler(buf, &slog.HandlerOptions{
+				AddSource: true,
+				Level:     slog.Level(logger.LevelTrace),
+			})}))
+
+			tt.log(l)
+			if tt.wantNone {
+				if buf.Len() != 0 {
+					t.Errorf("Expected no output, got %s", buf.String())
+				}
+				return
+			}
+
+			var rec map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+				t.Fatalf("Failed to unmarshal record %q: %v", buf.String(), err)
+			}
+			if rec[slog.LevelKey] != slog.Level(tt.wantLevel).String() {
+				t.Errorf("Expected level %v, got %v", slog.Level(tt.wantLevel), rec[slog.LevelKey])
+			}
+			for k, v := range tt.wantAttrs {
+				if rec[k] != v {
+					t.Errorf("Expected %s=%v, got %v", k, v, rec[k])
+				}
+			}
+			if src, _ := rec[slog.SourceKey].(map[string]any); !strings.HasSuffix(src["file"].(string), "hclog_test.go") {
+				t.Errorf("Expected source to be the caller, got %v", src)
+			}
+		})
+	}
+}
+
+func TestAdapter_Level(t *testing.T) {
+	l := NewLogger(logger.NewLogger(logger.Options{Handler: slog.NewJSONHandler(&bytes.Buffer{}, nil)}))
+	if got := l.GetLevel(); got != hclog.Info {
+		t.Errorf("Expected the level of the underlying logger, got %v", got)
+	}
+	if l.IsDebug() || !l.IsInfo() {
+		t.Errorf("Expected info but not debug to be enabled")
+	}
+
+	l.SetLevel(hclog.Error)
+	if got := l.Named("sub").GetLevel(); got != hclog.Error {
+		t.Errorf("Expected sub-loggers to share the level, got %v", got)
+	}
+	if l.IsWarn() {
+		t.Errorf("Expected warn to be disabled")
+	}
+}
+
+func TestAdapter_StandardLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := NewLogger(logger.NewLogger(logger.Options{Handler: slog.NewJSONHandler(buf, nil)})).Named("http")
+
+	l.StandardLogger(&hclog.StandardLoggerOptions{ForceLevel: hclog.Error}).Print("failed")
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("Failed to unmarshal record %q: %v", buf.String(), err)
+	}
+	if rec[slog.LevelKey] != slog.LevelError.String() || rec[NameKey] != "http" || rec[slog.MessageKey] != "failed" {
+		t.Errorf("Expected error record of the named logger, got %v", rec)
+	}
+}
@@ -0,0 +1,30 @@
+module github.com/lvlcn-t/loggerhead/loglogr
+
+go 1.23
+
+toolchain go1.23.3
+
+replace github.com/lvlcn-t/loggerhead => ../
+
+require (
+	github.com/go-logr/logr v1.4.2
+	github.com/lvlcn-t/loggerhead v0.3.1
+)
+
+require (
+	github.com/aymanbagabas/go-osc52/v2 v2.0.1 // indirect
+	github.com/charmbracelet/lipgloss v1.0.0 // indirect
+	github.com/charmbracelet/log v0.4.1-0.20240719134918-958009cd3692 // indirect
+	github.com/charmbracelet/x/ansi v0.5.2 // indirect
+	github.com/go-logfmt/logfmt v0.6.0 // indirect
+	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/mattn/go-runewidth v0.0.16 // indirect
+	github.com/muesli/termenv v0.15.2 // indirect
+	github.com/remychantenay/slog-otel v1.3.2 // indirect
+	github.com/rivo/uniseg v0.4.7 // indirect
+	go.opentelemetry.io/otel v1.32.0 // indirect
+	go.opentelemetry.io/otel/trace v1.32.0 // indirect
+	golang.org/x/exp v0.0.0-20241108190413-2d47ceb2692f // indirect
+	golang.org/x/sys v0.28.0 // indirect
+)
@@ -0,0 +1,119 @@
+// Package loglogr provides a [logr.LogSink] backed by the loggerhead logger,
+// so Kubernetes operators and controllers built on controller-runtime can use loggerhead as their logging backend.
+package loglogr
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/lvlcn-t/loggerhead/logger"
+)
+
+// NameKey is the key used for the name of a logger set by [logr.Logger.WithName].
+const NameKey = "logger"
+
+// NewLogger returns a new [logr.Logger] logging with the given logger.
+//
+// Example:
+//
+//	ctrl.SetLogger(loglogr.NewLogger(logger.NewLogger()))
+func NewLogger(l logger.Provider) logr.Logger {
+	return logr.New(NewLogSink(l))
+}
+
+// NewLogSink returns a new [logr.LogSink] logging with the given logger.
+// The verbosity levels are mapped by [VLevel].
+func NewLogSink(l logger.Provider) logr.LogSink {
+	return &sink{log: l}
+}
+
+// VLevel returns the level of the given logr verbosity:
+// V(0) is logged at [logger.LevelInfo], V(1) at [logger.LevelDebug] and V(2) and above at [logger.LevelTrace].
+func VLevel(v int) logger.Level {
+	switch {
+	case v <= 0:
+		return logger.LevelInfo
+	case v == 1:
+		return logger.LevelDebug
+	default:
+		return logger.LevelTrace
+	}
+}
+
+var (
+	_ logr.LogSink          = (*sink)(nil)
+	_ logr.CallDepthLogSink = (*sink)(nil)
+)
+
+// sink is a [logr.LogSink] logging with a [logger.Provider].
+type sink struct {
+	log   logger.Provider
+	name  string
+	depth int
+}
+
+// Init receives the call depth of the logr functions.
+func (s *sink) Init(info logr.RuntimeInfo) {
+	s.depth = info.CallDepth
+}
+
+// Enabled reports whether records at the given verbosity are logged.
+func (s *sink) Enabled(level int) bool {
+	return s.log.Enabled(context.Background(), VLevel(level))
+}
+
+// Info logs a record at the level of the given verbosity.
+func (s *sink) Info(level int, msg string, keysAndValues ...any) {
+	s.handle(VLevel(level), msg, keysAndValues)
+}
+
+// Error logs a record with the error at [logger.LevelError].
+func (s *sink) Error(err error, msg string, keysAndValues ...any) {
+	if err != nil {
+		keysAndValues = append([]any{logger.Err(err)}, keysAndValues...)
+	}
+	s.handle(logger.LevelError, msg, keysAndValues)
+}
+
+// WithValues returns a new sink with the given key-value pairs added.
+func (s *sink) WithValues(keysAndValues ...any) logr.LogSink {
+	return &sink{log: s.log.With(keysAndValues...), name: s.name, depth: s.depth}
+}
+
+// WithName returns a new sink with the given name appended to the sink's name, separated by a slash.
+func (s *sink) WithName(name string) logr.LogSink {
+	names := []string{name}
+	if s.name != "" {
+		names = []string{s.name, name}
+	}
+	return &sink{log: s.log, name: strings.Join(names, "/"), depth: s.depth}
+}
+
+// WithCallDepth returns a new sink skipping the given number of additional stack frames to find the caller.
+func (s *sink) WithCallDepth(depth int) logr.LogSink {
+	return &sink{log: s.log, name: s.name, depth: s.depth + depth}
+}
+
+// handle logs the record with the caller of the logr function as source.
+func (s *sink) handle(level logger.Level, msg string, keysAndValues []any) {
+	ctx := context.Background()
+	if !s.log.Enabled(ctx, level) {
+		return
+	}
+
+	// skip is the number of stack frames to skip to find the caller.
+	// We need to skip calling runtime.Callers, this function, the sink method and the logr functions.
+	skip := 3 + s.depth
+	var pcs [1]uintptr
+	_ = runtime.Callers(skip, pcs[:])
+	r := slog.NewRecord(time.Now(), slog.Level(level), msg, pcs[0])
+	if s.name != "" {
+		r.AddAttrs(slog.String(NameKey, s.name))
+	}
+	r.Add(keysAndValues...)
+	_ = s.log.Handler().Handle(ctx, r)
+}
@@ -0,0 +1,113 @@
+package loglogr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/lvlcn-t/loggerhead/logger"
+)
+
+func TestNewLogger(t *testing.T) {
+	tests := []struct {
+		name      string
+		log       func(l logr.Logger)
+		wantLevel logger.Level
+		wantAttrs map[string]any
+		wantNone  bool
+	}{
+		{
+			name:      "info",
+			log:       func(l logr.Logger) { l.Info("test", "key", "value") },
+			wantLevel: logger.LevelInfo,
+			wantAttrs: map[string]any{"key": "value"},
+		},
+		{
+			name:      "verbosity 1 is debug",
+			log:       func(l logr.Logger) { l.V(1).Info("test") },
+			wantLevel: logger.LevelDebug,
+		},
+		{
+			name:      "verbosity 3 is trace",
+			log:       func(l logr.Logger) { l.V(3).Info("test") },
+			wantLevel: logger.LevelTrace,
+		},
+		{
+			name:      "error",
+			log:       func(l logr.Logger) { l.Error(errors.New("boom"), "test") },
+			wantLevel: logger.LevelError,
+		},
+		{
+			name: "names and values",
+			log: func(l logr.Logger) {
+				l.WithName("controller").WithValues("kind", "Pod").WithName("reconciler").Info("test")
+			},
+			wantLevel: logger.LevelInfo,
+			wantAttrs: map[string]any{NameKey: "controller/reconciler", "kind": "Pod"},
+		},
+		{
+			name:     "disabled verbosity",
+			log:      func(l logr.Logger) { l.V(10).Info("test") },
+			wantNone: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			level := slog.Level(logger.LevelTrace)
+			if tt.wantNone {
+				level = slog.Level(logger.LevelDebug)
+			}
+			l := NewLogger(logger.NewLogger(logger.Options{Handler: slog.NewJSONHandler(buf, &slog.HandlerOptions{
+				AddSource: true,
+				Level:     level,
+			})}))
+
+			tt.log(l)
+			if tt.wantNone {
+				if buf.Len() != 0 {
+					t.Errorf("Expected no output, got %s", buf.String())
+				}
+				return
+			}
+
+			var rec map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+				t.Fatalf("Failed to unmarshal record %q: %v", buf.String(), err)
+			}
+			if rec[slog.LevelKey] != slog.Level(tt.wantLevel).String() {
+				t.Errorf("Expected level %v, got %v", slog.Level(tt.wantLevel), rec[slog.LevelKey])
+			}
+			for k, v := range tt.wantAttrs {
+				if rec[k] != v {
+					t.Errorf("Expected %s=%v, got %v", k, v, rec[k])
+				}
+			}
+			if src, _ := rec[slog.SourceKey].(map[string]any); !strings.HasSuffix(src["file"].(string), "logr_test.go") {
+				t.Errorf("Expected source to be the caller, got %v", src)
+			}
+		})
+	}
+}
+
+func TestNewLogger_CallDepth(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := NewLogger(logger.NewLogger(logger.Options{Handler: slog.NewJSONHandler(buf, &slog.HandlerOptions{AddSource: true})}))
+
+	helper := func(l logr.Logger) { l.WithCallDepth(1).Info("test") }
+	helper(l)
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("Failed to unmarshal record %q: %v", buf.String(), err)
+	}
+	src, _ := rec[slog.SourceKey].(map[string]any)
+	if fn, _ := src["function"].(string); !strings.HasSuffix(fn, "TestNewLogger_CallDepth") {
+		t.Errorf("Expected source to be the caller of the helper, got %v", src)
+	}
+}
@@ -0,0 +1,25 @@
+// Package logpflag adds the logging flags of the loggerhead [logger.Config] to a [pflag.FlagSet],
+// so CLIs built on pflag or cobra get the same flags as those built on the standard flag package.
+package logpflag
+
+import (
+	"flag"
+
+	"github.com/lvlcn-t/loggerhead/logger"
+	"github.com/spf13/pflag"
+)
+
+// RegisterFlags adds the --log-level, --log-format and --log-output flags setting the configuration
+// to the flag set, see [logger.Config.RegisterFlags].
+//
+// Example:
+//
+//	var cfg logger.Config
+//	logpflag.RegisterFlags(cmd.PersistentFlags(), &cfg)
+//	// after parsing
+//	log, err := logger.NewFromConfig(cfg)
+func RegisterFlags(fs *pflag.FlagSet, cfg *logger.Config) {
+	flags := flag.NewFlagSet("log", flag.ContinueOnError)
+	cfg.RegisterFlags(flags)
+	fs.AddGoFlagSet(flags)
+}
@@ -0,0 +1,54 @@
+package logpflag
+
+import (
+	"io"
+	"testing"
+
+	"github.com/lvlcn-t/loggerhead/logger"
+	"github.com/spf13/pflag"
+)
+
+func TestRegisterFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    logger.Config
+		wantErr bool
+	}{
+		{
+			name: "flags set",
+			args: []string{"--log-level=DEBUG", "--log-format", "TEXT", "--log-output=stdout"},
+			want: logger.Config{Level: "DEBUG", Format: "TEXT", Output: "stdout"},
+		},
+		{
+			name: "defaults kept",
+			args: []string{"--log-level=WARN"},
+			want: logger.Config{Level: "WARN", Format: "JSON"},
+		},
+		{
+			name:    "unknown level",
+			args:    []string{"--log-level=LOUD"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			cfg := logger.Config{Format: "JSON"}
+			RegisterFlags(fs, &cfg)
+
+			err := fs.Parse(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if cfg.Level != tt.want.Level || cfg.Format != tt.want.Format || cfg.Output != tt.want.Output {
+				t.Errorf("Expected config %+v, got %+v", tt.want, cfg)
+			}
+		})
+	}
+}
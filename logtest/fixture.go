@@ -0,0 +1,55 @@
+package logtest
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/lvlcn-t/loggerhead/logger"
+)
+
+// Fixture is a pipeline built from a configuration with the sink replaced by a [Recorder].
+type Fixture struct {
+	// Logger logs through the pipeline of the configuration.
+	Logger logger.Provider
+	// Recorder records what the pipeline would have written, i.e. after filtering, redaction and limits.
+	Recorder *Recorder
+	// Exits are the exit codes passed by Fatal, which does not exit the program.
+	Exits []int
+}
+
+// LoadPipelineFixture builds the pipeline configured by the file at the given path with the outputs
+// replaced by a [Recorder], so tests can assert the filtering, redaction and limits of real configurations.
+// The file is read by [logger.LoadConfig], so its format is selected by the extension and unknown keys
+// or invalid settings fail the test. The recorder records the lowest level of the outputs, e.g.:
+//
+//	level: info
+//	environment: production
+//	limits:
+//	  maxStringLength: 256
+//
+// Example:
+//
+//	f := logtest.LoadPipelineFixture(t, "testdata/pipeline.yaml")
+//	f.Logger.Info("login", "password", "hunter2")
+//	got := f.Recorder.Records()
+func LoadPipelineFixture(tb testing.TB, path string) *Fixture {
+	tb.Helper()
+	cfg, err := logger.LoadConfig(path)
+	if err != nil {
+		tb.Fatalf("Failed to load pipeline fixture: %v", err)
+	}
+
+	f := &Fixture{}
+	h, err := logger.NewConfigPipeline(cfg, func(level logger.Level) slog.Handler {
+		f.Recorder = NewRecorder(Options{Level: slog.Level(level)})
+		return f.Recorder
+	})
+	if err != nil {
+		tb.Fatalf("Failed to build pipeline fixture: %v", err)
+	}
+	f.Logger = logger.NewLogger(logger.Options{
+		Handler: h,
+		Exit:    func(code int) { f.Exits = append(f.Exits, code) },
+	})
+	return f
+}
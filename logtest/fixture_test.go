@@ -0,0 +1,85 @@
+package logtest
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lvlcn-t/loggerhead/logger"
+)
+
+// attrValue returns the string value of the top-level attribute of the record with the given key.
+func attrValue(r slog.Record, key string) (string, bool) { //nolint:gocritic // records are passed by value
+	var (
+		value string
+		found bool
+	)
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == key {
+			value, found = a.Value.String(), true
+			return false
+		}
+		return true
+	})
+	return value, found
+}
+
+func TestLoadPipelineFixture(t *testing.T) {
+	f := LoadPipelineFixture(t, filepath.Join("testdata", "pipeline.yaml"))
+
+	f.Logger.Debug("filtered")
+	f.Logger.Info("login", "password", "hunter2")
+	f.Logger.Warn("long", "body", strings.Repeat("a", 1000))
+	f.Logger.Fatal("fatal")
+
+	records := f.Recorder.Records()
+	var messages []string
+	for _, r := range records {
+		messages = append(messages, r.Message)
+		if _, ok := attrValue(r, logger.RecordIDKey); !ok {
+			t.Errorf("Expected record %q to have an ID", r.Message)
+		}
+	}
+	if slices.Contains(messages, "filtered") {
+		t.Error("Expected debug record to be filtered")
+	}
+	if got, _ := attrValue(records[0], "password"); got != logger.RedactedValue {
+		t.Errorf("Expected password to be redacted, got %q", got)
+	}
+	if got, _ := attrValue(records[1], "body"); len(got) >= 1000 || !strings.HasSuffix(got, logger.TruncationMarker) {
+		t.Errorf("Expected long attribute to be truncated, got %d bytes", len(got))
+	}
+	if !slices.Equal(f.Exits, []int{1}) {
+		t.Errorf("Expected exit code 1, got %v", f.Exits)
+	}
+}
+
+func TestLoadPipelineFixture_UnknownKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pipeline.yaml")
+	if err := os.WriteFile(path, []byte("levle: info\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tb := &fatalTB{TB: t}
+	func() {
+		defer func() { _ = recover() }()
+		LoadPipelineFixture(tb, path)
+	}()
+	if !tb.failed {
+		t.Error("Expected unknown key to fail the test")
+	}
+}
+
+// fatalTB is a [testing.TB] recording Fatalf instead of failing the test.
+type fatalTB struct {
+	testing.TB
+	failed bool
+}
+
+func (tb *fatalTB) Fatalf(string, ...any) {
+	tb.failed = true
+	panic("fatal")
+}
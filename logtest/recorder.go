@@ -0,0 +1,216 @@
+// Package logtest provides helpers for testing logging behavior.
+//
+// A [Recorder] is a [slog.Handler] keeping the handled records in memory, so applications can test
+// their logging behavior without parsing the output, and [LoadPipelineFixture] builds the pipeline
+// of a configuration with the sink replaced by a [Recorder]. [NewTestLogger] writes the records through the test instead.
+//
+// Example:
+//
+//	log, rec := logtest.NewLogger()
+//	connect(log)
+//	rec.AssertLogged(t, logger.LevelError, "connection refused")
+package logtest
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lvlcn-t/loggerhead/logger"
+)
+
+// Options is the optional configuration for [NewRecorder].
+type Options struct {
+	// Level is the minimum level of the recorded records. Defaults to [logger.LevelTrace].
+	Level slog.Leveler
+}
+
+// newOptions returns the provided Options merged with the default Options.
+func newOptions(o ...Options) Options {
+	opts := Options{Level: slog.Level(logger.LevelTrace)}
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided Options with the receiver Options.
+func (o *Options) merge(d Options) Options {
+	if o.Level != nil {
+		d.Level = o.Level
+	}
+	return d
+}
+
+// recorderState is the state shared by a recorder and the recorders derived from it.
+type recorderState struct {
+	// mu guards records.
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+// groupOrAttrs is either a group or attributes added to a recorder.
+type groupOrAttrs struct {
+	group string
+	attrs []slog.Attr
+}
+
+var _ slog.Handler = (*Recorder)(nil)
+
+// Recorder is a [slog.Handler] keeping the handled records in memory.
+// The attributes and groups added to the recorder are added to the records, so they look like the output of a handler.
+// It is safe for concurrent use.
+type Recorder struct {
+	opts  Options
+	state *recorderState
+	goas  []groupOrAttrs
+}
+
+// NewRecorder returns a new [Recorder].
+func NewRecorder(o ...Options) *Recorder {
+	return &Recorder{opts: newOptions(o...), state: &recorderState{}}
+}
+
+// NewLogger returns a new logger writing to a new [Recorder].
+func NewLogger(o ...Options) (logger.Provider, *Recorder) {
+	rec := NewRecorder(o...)
+	return logger.NewLogger(logger.Options{Handler: rec}), rec
+}
+
+// Enabled reports whether the level is at least the minimum level of the recorder.
+func (r *Recorder) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= r.opts.Level.Level()
+}
+
+// Handle records the record with the attributes and groups of the recorder.
+func (r *Recorder) Handle(_ context.Context, rec slog.Record) error { //nolint:gocritic // slog.Handler interface
+	attrs := make([]slog.Attr, 0, rec.NumAttrs())
+	rec.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	for i := len(r.goas) - 1; i >= 0; i-- {
+		if r.goas[i].group != "" {
+			if len(attrs) > 0 {
+				attrs = []slog.Attr{{Key: r.goas[i].group, Value: slog.GroupValue(attrs...)}}
+			}
+			continue
+		}
+		attrs = append(slices.Clone(r.goas[i].attrs), attrs...)
+	}
+
+	out := slog.NewRecord(rec.Time, rec.Level, rec.Message, rec.PC)
+	out.AddAttrs(attrs...)
+
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+	r.state.records = append(r.state.records, out)
+	return nil
+}
+
+// WithAttrs returns a new recorder sharing the records whose attributes consist of the receiver's attributes followed by attrs.
+func (r *Recorder) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return r
+	}
+	return r.with(groupOrAttrs{attrs: attrs})
+}
+
+// WithGroup returns a new recorder sharing the records with the given group appended to the receiver's groups.
+func (r *Recorder) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return r
+	}
+	return r.with(groupOrAttrs{group: name})
+}
+
+// with returns a new recorder sharing the records with the given group or attributes appended.
+func (r *Recorder) with(goa groupOrAttrs) *Recorder {
+	return &Recorder{opts: r.opts, state: r.state, goas: append(slices.Clone(r.goas), goa)}
+}
+
+// Records returns a copy of the recorded records in the order they were handled.
+func (r *Recorder) Records() []slog.Record {
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+	records := make([]slog.Record, len(r.state.records))
+	for i, rec := range r.state.records {
+		records[i] = rec.Clone()
+	}
+	return records
+}
+
+// Entry is a recorded record with its attributes flattened into a map.
+type Entry struct {
+	// Time is the time of the record.
+	Time time.Time
+	// Level is the level of the record.
+	Level logger.Level
+	// Message is the message of the record.
+	Message string
+	// Attrs are the resolved values of the attributes of the record.
+	// The keys of attributes in groups are joined by dots, e.g. "request.method".
+	Attrs map[string]any
+}
+
+// Entries returns the recorded records as entries in the order they were handled.
+func (r *Recorder) Entries() []Entry {
+	records := r.Records()
+	entries := make([]Entry, 0, len(records))
+	for _, rec := range records {
+		e := Entry{Time: rec.Time, Level: logger.Level(rec.Level), Message: rec.Message, Attrs: map[string]any{}}
+		rec.Attrs(func(a slog.Attr) bool {
+			flatten(e.Attrs, "", a)
+			return true
+		})
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// flatten adds the resolved value of the attribute to the map with the keys of groups joined by dots.
+func flatten(m map[string]any, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() != slog.KindGroup {
+		if a.Key != "" {
+			m[prefix+a.Key] = a.Value.Any()
+		}
+		return
+	}
+	if a.Key != "" {
+		prefix += a.Key + "."
+	}
+	for _, ga := range a.Value.Group() {
+		flatten(m, prefix, ga)
+	}
+}
+
+// Reset discards the recorded records, including those of the recorders sharing them.
+func (r *Recorder) Reset() {
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+	r.state.records = nil
+}
+
+// AssertLogged fails the test if no record with the given level and a message containing msg was recorded.
+// It returns the first matching entry.
+func (r *Recorder) AssertLogged(tb testing.TB, level logger.Level, msg string) Entry {
+	tb.Helper()
+	entries := r.Entries()
+	for _, e := range entries {
+		if e.Level == level && strings.Contains(e.Message, msg) {
+			return e
+		}
+	}
+
+	logged := make([]string, 0, len(entries))
+	for _, e := range entries {
+		logged = append(logged, e.Level.String()+" "+e.Message)
+	}
+	tb.Errorf("Expected a %s record containing %q, got %q", level, msg, logged)
+	return Entry{}
+}
@@ -0,0 +1,119 @@
+package logtest
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/lvlcn-t/loggerhead/logger"
+)
+
+func TestRecorder_Entries(t *testing.T) {
+	tests := []struct {
+		name      string
+		log       func(l logger.Provider)
+		wantMsg   string
+		wantAttrs map[string]any
+	}{
+		{
+			name:      "attributes",
+			log:       func(l logger.Provider) { l.Info("test", "key", "value", "n", 1) },
+			wantMsg:   "test",
+			wantAttrs: map[string]any{"key": "value", "n": int64(1)},
+		},
+		{
+			name: "logger attributes and groups",
+			log: func(l logger.Provider) {
+				l.With("service", "api").WithGroup("request").Info("test", "method", "GET", slog.Group("user", "id", "42"))
+			},
+			wantMsg:   "test",
+			wantAttrs: map[string]any{"service": "api", "request.method": "GET", "request.user.id": "42"},
+		},
+		{
+			name:      "empty group is dropped",
+			log:       func(l logger.Provider) { l.WithGroup("request").Info("test") },
+			wantMsg:   "test",
+			wantAttrs: map[string]any{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, rec := NewLogger()
+			tt.log(l)
+
+			entries := rec.Entries()
+			if len(entries) != 1 {
+				t.Fatalf("Expected 1 entry, got %d", len(entries))
+			}
+			if entries[0].Message != tt.wantMsg {
+				t.Errorf("Expected message %q, got %q", tt.wantMsg, entries[0].Message)
+			}
+			if fmt.Sprint(entries[0].Attrs) != fmt.Sprint(tt.wantAttrs) {
+				t.Errorf("Expected attributes %v, got %v", tt.wantAttrs, entries[0].Attrs)
+			}
+		})
+	}
+}
+
+func TestRecorder_Level(t *testing.T) {
+	l, rec := NewLogger(Options{Level: slog.LevelWarn})
+	l.Info("test")
+	l.Warn("test")
+
+	if entries := rec.Entries(); len(entries) != 1 || entries[0].Level != logger.LevelWarn {
+		t.Errorf("Expected only the warning to be recorded, got %v", entries)
+	}
+}
+
+func TestRecorder_Reset(t *testing.T) {
+	l, rec := NewLogger()
+	l.With("key", "value").Info("test")
+	rec.Reset()
+
+	if entries := rec.Entries(); len(entries) != 0 {
+		t.Errorf("Expected no entries after reset, got %v", entries)
+	}
+}
+
+// errorTB is a [testing.TB] recording Errorf instead of failing the test.
+type errorTB struct {
+	testing.TB
+	failed bool
+}
+
+func (tb *errorTB) Helper() {}
+
+func (tb *errorTB) Errorf(string, ...any) {
+	tb.failed = true
+}
+
+func TestRecorder_AssertLogged(t *testing.T) {
+	tests := []struct {
+		name       string
+		level      logger.Level
+		msg        string
+		wantFailed bool
+	}{
+		{name: "matching", level: logger.LevelError, msg: "connection refused"},
+		{name: "wrong level", level: logger.LevelWarn, msg: "connection refused", wantFailed: true},
+		{name: "wrong message", level: logger.LevelError, msg: "timeout", wantFailed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, rec := NewLogger()
+			l.ErrorContext(context.Background(), "dial tcp: connection refused", "host", "localhost")
+
+			tb := &errorTB{TB: t}
+			e := rec.AssertLogged(tb, tt.level, tt.msg)
+			if tb.failed != tt.wantFailed {
+				t.Errorf("Expected failed to be %v, got %v", tt.wantFailed, tb.failed)
+			}
+			if !tt.wantFailed && e.Attrs["host"] != "localhost" {
+				t.Errorf("Expected matching entry to be returned, got %v", e)
+			}
+		})
+	}
+}
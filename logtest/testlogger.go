@@ -0,0 +1,25 @@
+package logtest
+
+import (
+	"testing"
+
+	"github.com/lvlcn-t/loggerhead/logger"
+)
+
+// NewTestLogger returns a new logger writing every record as a line of text through [testing.TB.Log],
+// so the output is attributed to the test and only shown if it fails or runs verbosely.
+// Records with level [logger.LevelError] or above are written with [testing.TB.Error] and fail the test.
+//
+// The logger is safe to use from parallel tests and goroutines. Records logged after the test finished
+// are dropped, since the testing package panics on output of completed tests.
+//
+// Example:
+//
+//	func TestServer(t *testing.T) {
+//		srv := NewServer(logtest.NewTestLogger(t))
+//		// ...
+//	}
+func NewTestLogger(tb testing.TB) logger.Provider {
+	tb.Helper()
+	return logger.NewLogger(logger.Options{Handler: logger.NewTestHandler(tb)})
+}
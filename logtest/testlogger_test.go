@@ -0,0 +1,41 @@
+package logtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// recordingTB is a [testing.TB] recording the output instead of writing it.
+type recordingTB struct {
+	testing.TB
+	logs     []string
+	errors   []string
+	cleanups []func()
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Log(args ...any) { r.logs = append(r.logs, fmt.Sprint(args...)) }
+
+func (r *recordingTB) Error(args ...any) { r.errors = append(r.errors, fmt.Sprint(args...)) }
+
+func (r *recordingTB) Cleanup(f func()) { r.cleanups = append(r.cleanups, f) }
+
+func TestNewTestLogger(t *testing.T) {
+	tb := &recordingTB{}
+	log := NewTestLogger(tb)
+	log.Trace("first", "key", "value")
+	log.Error("second")
+	for _, f := range tb.cleanups {
+		f()
+	}
+	log.Info("third")
+
+	if len(tb.logs) != 1 || !strings.Contains(tb.logs[0], "msg=first") || !strings.Contains(tb.logs[0], "source=testlogger_test.go:") {
+		t.Errorf("Expected the trace record with its source through Log, got %v", tb.logs)
+	}
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "msg=second") {
+		t.Errorf("Expected the error record through Error, got %v", tb.errors)
+	}
+}
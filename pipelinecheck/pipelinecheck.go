@@ -0,0 +1,169 @@
+// Package pipelinecheck validates [slog.Handler] pipelines programmatically.
+// It runs conformance, throughput and allocation checks against a constructed handler and returns a structured [Report],
+// so custom configurations can be validated in CI without writing benchmarks.
+//
+// Example:
+//
+//	var buf bytes.Buffer
+//	h := logger.NewLogger(logger.Options{Handler: slog.NewJSONHandler(&buf, nil)}).ToSlog().Handler()
+//	report := pipelinecheck.Run(h, pipelinecheck.Options{Results: pipelinecheck.JSONResults(&buf)})
+//	if !report.Passed() {
+//		log.Fatal(report.Violations)
+//	}
+package pipelinecheck
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"runtime"
+	"testing/slogtest"
+	"time"
+)
+
+// defaultRecords is the default number of records handled by the throughput and allocation checks.
+const defaultRecords = 10000
+
+// Options is the optional configuration for [Run].
+type Options struct {
+	// Results returns the records written by the handler, parsed into maps.
+	// The conformance check is skipped if Results is nil.
+	// See [testing/slogtest.TestHandler] for the expected format.
+	Results func() []map[string]any
+	// Records is the number of records handled by the throughput and allocation checks. Defaults to 10000.
+	Records int
+}
+
+// newOptions returns the provided Options merged with the default Options.
+func newOptions(o ...Options) Options {
+	opts := Options{Records: defaultRecords}
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided Options with the receiver Options.
+func (o *Options) merge(d Options) Options {
+	if o.Results != nil {
+		d.Results = o.Results
+	}
+	if o.Records > 0 {
+		d.Records = o.Records
+	}
+	return d
+}
+
+// Report is the result of the checks run by [Run].
+type Report struct {
+	// ConformanceChecked reports whether the conformance check was run.
+	ConformanceChecked bool `json:"conformanceChecked"`
+	// Violations are the violations of the [slog.Handler] contract found by the conformance check.
+	Violations []string `json:"violations,omitempty"`
+	// Records is the number of records handled by the throughput and allocation checks.
+	Records int `json:"records"`
+	// Errors is the number of records for which the handler returned an error.
+	Errors int `json:"errors"`
+	// Duration is the total time spent handling the records.
+	Duration time.Duration `json:"duration"`
+	// Throughput is the number of records handled per second.
+	Throughput float64 `json:"throughput"`
+	// AllocsPerRecord is the average number of heap allocations per record.
+	AllocsPerRecord float64 `json:"allocsPerRecord"`
+	// BytesPerRecord is the average number of heap-allocated bytes per record.
+	BytesPerRecord float64 `json:"bytesPerRecord"`
+}
+
+// Passed reports whether the handler conforms to the [slog.Handler] contract and handled all records without errors.
+func (r *Report) Passed() bool {
+	return len(r.Violations) == 0 && r.Errors == 0
+}
+
+// Run runs the conformance, throughput and allocation checks against the handler and returns the report.
+// The conformance check is run first if [Options.Results] is set, followed by the throughput and allocation checks,
+// which handle a representative record [Options.Records] times.
+//
+// The handler must be safe for concurrent use and must not drop records at the info level.
+func Run(h slog.Handler, o ...Options) Report {
+	opts := newOptions(o...)
+	report := Report{Records: opts.Records}
+
+	if opts.Results != nil {
+		report.ConformanceChecked = true
+		report.Violations = conformance(h, opts.Results)
+	}
+
+	r := newRecord()
+	ctx := context.Background()
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for range opts.Records {
+		if err := h.Handle(ctx, r.Clone()); err != nil {
+			report.Errors++
+		}
+	}
+	report.Duration = time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	n := float64(opts.Records)
+	report.Throughput = n / report.Duration.Seconds()
+	report.AllocsPerRecord = float64(after.Mallocs-before.Mallocs) / n
+	report.BytesPerRecord = float64(after.TotalAlloc-before.TotalAlloc) / n
+	return report
+}
+
+// conformance runs the [testing/slogtest] conformance tests against the handler and returns the violations.
+func conformance(h slog.Handler, results func() []map[string]any) []string {
+	err := slogtest.TestHandler(h, results)
+	if err == nil {
+		return nil
+	}
+
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) {
+		return []string{err.Error()}
+	}
+	violations := make([]string, 0, len(joined.Unwrap()))
+	for _, e := range joined.Unwrap() {
+		violations = append(violations, e.Error())
+	}
+	return violations
+}
+
+// newRecord returns the representative record handled by the throughput and allocation checks.
+func newRecord() slog.Record {
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "pipeline check", 0)
+	r.AddAttrs(
+		slog.String("string", "value"),
+		slog.Int("int", 42),
+		slog.Float64("float", 3.14),
+		slog.Bool("bool", true),
+		slog.Duration("duration", time.Second),
+		slog.Group("group", slog.String("nested", "value")),
+	)
+	return r
+}
+
+// JSONResults returns a results function for [Options.Results] that parses the buffer as JSON lines,
+// e.g. the output of a [slog.JSONHandler].
+// Lines that cannot be parsed are skipped, which lets the conformance check report them as missing records.
+func JSONResults(buf *bytes.Buffer) func() []map[string]any {
+	return func() []map[string]any {
+		var records []map[string]any
+		s := bufio.NewScanner(bytes.NewReader(buf.Bytes()))
+		s.Buffer(nil, bufio.MaxScanTokenSize*16) //nolint:mnd // allow large records
+		for s.Scan() {
+			var m map[string]any
+			if err := json.Unmarshal(s.Bytes(), &m); err != nil {
+				continue
+			}
+			records = append(records, m)
+		}
+		return records
+	}
+}
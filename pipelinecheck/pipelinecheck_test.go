@@ -0,0 +1,112 @@
+package pipelinecheck
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/lvlcn-t/loggerhead/logger"
+)
+
+// dropGroupsHandler is a [slog.Handler] violating the contract by ignoring groups.
+type dropGroupsHandler struct {
+	slog.Handler
+}
+
+func (h *dropGroupsHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &dropGroupsHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *dropGroupsHandler) WithGroup(string) slog.Handler {
+	return h
+}
+
+// errorHandler is a [slog.Handler] failing every record.
+type errorHandler struct {
+	slog.Handler
+}
+
+func (h *errorHandler) Handle(context.Context, slog.Record) error { //nolint:gocritic // slog.Handler interface
+	return io.ErrShortWrite
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name           string
+		handler        func(buf *bytes.Buffer) slog.Handler
+		checkConform   bool
+		wantViolations bool
+		wantErrors     bool
+	}{
+		{
+			name:         "json handler",
+			handler:      func(buf *bytes.Buffer) slog.Handler { return slog.NewJSONHandler(buf, nil) },
+			checkConform: true,
+		},
+		{
+			name: "loggerhead pipeline",
+			handler: func(buf *bytes.Buffer) slog.Handler {
+				return logger.NewLogger(logger.Options{Handler: slog.NewJSONHandler(buf, nil)}).ToSlog().Handler()
+			},
+			checkConform: true,
+		},
+		{
+			name: "violating handler",
+			handler: func(buf *bytes.Buffer) slog.Handler {
+				return &dropGroupsHandler{Handler: slog.NewJSONHandler(buf, nil)}
+			},
+			checkConform:   true,
+			wantViolations: true,
+		},
+		{
+			name: "failing handler",
+			handler: func(buf *bytes.Buffer) slog.Handler {
+				return &errorHandler{Handler: slog.NewJSONHandler(buf, nil)}
+			},
+			wantErrors: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			opts := Options{Records: 100}
+			if tt.checkConform {
+				opts.Results = JSONResults(buf)
+			}
+
+			report := Run(tt.handler(buf), opts)
+			if report.ConformanceChecked != tt.checkConform {
+				t.Errorf("Expected conformance checked = %v, got %v", tt.checkConform, report.ConformanceChecked)
+			}
+			if (len(report.Violations) > 0) != tt.wantViolations {
+				t.Errorf("Expected violations = %v, got %v", tt.wantViolations, report.Violations)
+			}
+			if (report.Errors > 0) != tt.wantErrors {
+				t.Errorf("Expected errors = %v, got %d", tt.wantErrors, report.Errors)
+			}
+			if report.Passed() != (!tt.wantViolations && !tt.wantErrors) {
+				t.Errorf("Unexpected Passed() = %v", report.Passed())
+			}
+			if report.Records != 100 {
+				t.Errorf("Expected 100 records, got %d", report.Records)
+			}
+			if report.Throughput <= 0 {
+				t.Errorf("Expected throughput to be measured, got %+v", report)
+			}
+		})
+	}
+}
+
+func TestJSONResults(t *testing.T) {
+	buf := bytes.NewBufferString("{\"msg\":\"a\"}\nnot json\n{\"msg\":\"b\"}\n")
+	records := JSONResults(buf)()
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	if records[0]["msg"] != "a" || records[1]["msg"] != "b" {
+		t.Errorf("Unexpected records %v", records)
+	}
+}
@@ -0,0 +1,88 @@
+package sinktest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+)
+
+// defaultContainerMaxWait is the default time to wait for a container to be ready.
+const defaultContainerMaxWait = 2 * time.Minute
+
+// ContainerOptions is the configuration of a container started by [StartContainer].
+type ContainerOptions struct {
+	// Repository and Tag are the image of the container, e.g. "grafana/loki" and "3.4.2".
+	Repository string
+	Tag        string
+	// Env are the environment variables of the container in the form "KEY=value".
+	Env []string
+	// Cmd overrides the command of the image. Optional.
+	Cmd []string
+	// Port is the exposed port of the backend, e.g. "3100/tcp".
+	Port string
+	// Ready reports whether the backend at the address is ready to receive records.
+	// It is retried until it returns nil or MaxWait elapsed. Optional.
+	Ready func(ctx context.Context, addr string) error
+	// MaxWait is the time to wait for the container to be ready. Defaults to 2 minutes.
+	MaxWait time.Duration
+}
+
+// Container is an ephemeral container started by [StartContainer].
+type Container struct {
+	// Addr is the host and port the exposed port of the container is reachable at.
+	Addr string
+}
+
+// StartContainer starts an ephemeral container with dockertest and waits until it is ready.
+// The container is removed when the test finishes.
+// The test is skipped if Docker is not available, so integration tests do not fail on machines without it.
+//
+// Example:
+//
+//	c := sinktest.StartContainer(t, sinktest.ContainerOptions{Repository: "grafana/loki", Tag: "3.4.2", Port: "3100/tcp"})
+//	h := logger.NewLokiHandler(logger.LokiOptions{URL: "http://" + c.Addr})
+func StartContainer(tb testing.TB, o ContainerOptions) *Container {
+	tb.Helper()
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		tb.Skipf("Docker is not available: %v", err)
+	}
+	if err = pool.Client.Ping(); err != nil {
+		tb.Skipf("Docker is not available: %v", err)
+	}
+
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository:   o.Repository,
+		Tag:          o.Tag,
+		Env:          o.Env,
+		Cmd:          o.Cmd,
+		ExposedPorts: []string{o.Port},
+	}, func(hc *docker.HostConfig) {
+		hc.AutoRemove = true
+		hc.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		tb.Fatalf("Failed to start container %s:%s: %v", o.Repository, o.Tag, err)
+	}
+	tb.Cleanup(func() {
+		if err := pool.Purge(resource); err != nil {
+			tb.Errorf("Failed to remove container %s:%s: %v", o.Repository, o.Tag, err)
+		}
+	})
+
+	c := &Container{Addr: resource.GetHostPort(o.Port)}
+	if o.Ready == nil {
+		return c
+	}
+	pool.MaxWait = o.MaxWait
+	if pool.MaxWait <= 0 {
+		pool.MaxWait = defaultContainerMaxWait
+	}
+	if err = pool.Retry(func() error { return o.Ready(context.Background(), c.Addr) }); err != nil {
+		tb.Fatalf("Container %s:%s is not ready: %v", o.Repository, o.Tag, err)
+	}
+	return c
+}
@@ -0,0 +1,97 @@
+package sinktest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// lokiRepository and lokiTag are the image of the Loki container started by [StartLoki].
+const (
+	lokiRepository = "grafana/loki"
+	lokiTag        = "3.4.2"
+)
+
+// StartLoki starts an ephemeral Grafana Loki container, see [StartContainer], and returns its base URL
+// once it is ready to receive records, e.g. for [logger.LokiOptions.URL].
+func StartLoki(tb testing.TB) string {
+	tb.Helper()
+	c := StartContainer(tb, ContainerOptions{
+		Repository: lokiRepository,
+		Tag:        lokiTag,
+		Port:       "3100/tcp",
+		Ready: func(ctx context.Context, addr string) error {
+			return getOK(ctx, "http://"+addr+"/ready")
+		},
+	})
+	return "http://" + c.Addr
+}
+
+// LokiFetch returns a [Sink.Fetch] function querying the records matching the LogQL stream selector,
+// e.g. `{job="sinktest"}`, from the Loki at the base URL. The lines must be JSON records.
+func LokiFetch(baseURL, query string) func(ctx context.Context) ([]map[string]any, error) {
+	start := time.Now().Add(-time.Hour)
+	return func(ctx context.Context) ([]map[string]any, error) {
+		params := url.Values{
+			"query": {query},
+			"start": {strconv.FormatInt(start.UnixNano(), 10)},
+			"limit": {"5000"},
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/loki/api/v1/query_range?"+params.Encode(), http.NoBody)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("query failed with status %s", resp.Status)
+		}
+
+		var body struct {
+			Data struct {
+				Result []struct {
+					Values [][2]string `json:"values"`
+				} `json:"result"`
+			} `json:"data"`
+		}
+		if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		var records []map[string]any
+		for _, stream := range body.Data.Result {
+			for _, v := range stream.Values {
+				var r map[string]any
+				if err = json.Unmarshal([]byte(v[1]), &r); err != nil {
+					return nil, err
+				}
+				records = append(records, r)
+			}
+		}
+		return records, nil
+	}
+}
+
+// getOK requests the URL and returns an error unless the response status is 200 OK.
+func getOK(ctx context.Context, u string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %s", u, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+//go:build integration
+
+package sinktest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lvlcn-t/loggerhead/logger"
+)
+
+func TestRun_LokiHandler(t *testing.T) {
+	baseURL := StartLoki(t)
+	Run(t, func(testing.TB) Sink {
+		h := logger.NewLokiHandler(logger.LokiOptions{
+			URL:       baseURL,
+			Level:     logger.LevelDebug,
+			Labels:    map[string]string{"job": "sinktest"},
+			BatchWait: 100 * time.Millisecond,
+		})
+		return Sink{
+			Handler: h,
+			Close:   h.Close,
+			Fetch:   LokiFetch(baseURL, `{job="sinktest"}`),
+		}
+	}, Options{Timeout: time.Minute})
+}
+
+func TestLokiFetch_Unreachable(t *testing.T) {
+	if _, err := LokiFetch("http://127.0.0.1:1", `{job="sinktest"}`)(context.Background()); err == nil {
+		t.Error("Expected an error for an unreachable Loki")
+	}
+}
@@ -0,0 +1,186 @@
+// Package sinktest provides an end-to-end test harness for sinks, i.e. handlers delivering records to a backend.
+// It logs records through the sink and asserts that the backend received all of them with their attributes intact,
+// so sink implementations and applications configuring them can reuse the same delivery checks.
+//
+// The backend is reached through the [Sink.Fetch] function of the test, which can query a local file
+// or an ephemeral container started by [StartContainer], e.g. Grafana Loki started by [StartLoki] and queried by [LokiFetch].
+// The container tests of the sinks of this repository are built with the "integration" tag.
+//
+// Example:
+//
+//	func TestFileSink(t *testing.T) {
+//		sinktest.Run(t, func(tb testing.TB) sinktest.Sink {
+//			path := filepath.Join(tb.TempDir(), "app.log")
+//			w, _ := logger.NewFileSink(logger.FileSinkOptions{Path: path})
+//			return sinktest.Sink{
+//				Handler: slog.NewJSONHandler(w, nil),
+//				Close:   w.Close,
+//				Fetch:   func(context.Context) ([]map[string]any, error) { return sinktest.ReadJSONLines(path) },
+//			}
+//		})
+//	}
+package sinktest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// RunIDKey is the key of the attribute identifying the records logged by a run of [Run],
+// so records of other runs delivered to the same backend are ignored.
+const RunIDKey = "sinktest_run"
+
+// levels are the levels of the logged records, in turn.
+var levels = []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn, slog.LevelError}
+
+// Sink is a sink under test.
+type Sink struct {
+	// Handler delivers the records to the backend.
+	Handler slog.Handler
+	// Fetch returns the records received by the backend, parsed into maps with groups as nested maps.
+	// It is polled until all records arrived or the timeout is reached.
+	Fetch func(ctx context.Context) ([]map[string]any, error)
+	// Close flushes and closes the sink after the records were logged. Optional.
+	Close func() error
+}
+
+// Options is the optional configuration for [Run].
+type Options struct {
+	// Records is the number of records logged. Defaults to 10.
+	Records int
+	// Timeout is the time to wait for the records to arrive at the backend. Defaults to 30 seconds.
+	Timeout time.Duration
+	// PollInterval is the interval in which the backend is polled. Defaults to 100 milliseconds.
+	PollInterval time.Duration
+}
+
+// newOptions returns the provided Options merged with the default Options.
+func newOptions(o ...Options) Options {
+	opts := Options{Records: 10, Timeout: 30 * time.Second, PollInterval: 100 * time.Millisecond}
+	if len(o) > 0 {
+		return o[0].merge(opts)
+	}
+	return opts
+}
+
+// merge merges the provided Options with the receiver Options.
+func (o *Options) merge(d Options) Options {
+	if o.Records > 0 {
+		d.Records = o.Records
+	}
+	if o.Timeout > 0 {
+		d.Timeout = o.Timeout
+	}
+	if o.PollInterval > 0 {
+		d.PollInterval = o.PollInterval
+	}
+	return d
+}
+
+// Run logs records at different levels, with attributes and groups, through the sink returned by newSink
+// and fails the test unless the backend received all of them with the expected fields.
+func Run(tb testing.TB, newSink func(tb testing.TB) Sink, o ...Options) {
+	tb.Helper()
+	opts := newOptions(o...)
+	sink := newSink(tb)
+	runID := strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	log := slog.New(sink.Handler).With(RunIDKey, runID)
+	for i := range opts.Records {
+		log.WithGroup("request").Log(context.Background(), levels[i%len(levels)], "record "+strconv.Itoa(i), "seq", i, "path", "/orders")
+	}
+	if sink.Close != nil {
+		if err := sink.Close(); err != nil {
+			tb.Fatalf("Failed to close sink: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+	defer cancel()
+	received, err := poll(ctx, sink, runID, opts)
+	if err != nil {
+		tb.Fatalf("Records were not delivered: %v", err)
+	}
+
+	for i := range opts.Records {
+		r, ok := received["record "+strconv.Itoa(i)]
+		if !ok {
+			continue
+		}
+		request, _ := r["request"].(map[string]any)
+		if fmt.Sprint(request["seq"]) != strconv.Itoa(i) || request["path"] != "/orders" {
+			tb.Errorf("Expected record %d to carry the request group, got %v", i, r)
+		}
+		if want := levels[i%len(levels)].String(); r[slog.LevelKey] != want {
+			tb.Errorf("Expected record %d to have level %s, got %v", i, want, r[slog.LevelKey])
+		}
+	}
+}
+
+// poll fetches the records of the run from the backend until all records with enabled levels arrived.
+// The records are returned by message.
+func poll(ctx context.Context, sink Sink, runID string, opts Options) (map[string]map[string]any, error) {
+	want := 0
+	for i := range opts.Records {
+		if sink.Handler.Enabled(ctx, levels[i%len(levels)]) {
+			want++
+		}
+	}
+
+	ticker := time.NewTicker(opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		records, err := sink.Fetch(ctx)
+		received := map[string]map[string]any{}
+		for _, r := range records {
+			if r[RunIDKey] == runID {
+				msg, _ := r[slog.MessageKey].(string)
+				received[msg] = r
+			}
+		}
+		if err == nil && len(received) >= want {
+			return received, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if err == nil {
+				err = fmt.Errorf("received %d of %d records", len(received), want)
+			}
+			return nil, fmt.Errorf("%w: %w", ctx.Err(), err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReadJSONLines reads a file of JSON records, one per line, e.g. written by a [slog.JSONHandler].
+// It returns no records if the file does not exist yet.
+func ReadJSONLines(path string) ([]map[string]any, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is provided by the test
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var records []map[string]any
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(nil, len(data)+1)
+	for sc.Scan() {
+		var r map[string]any
+		if err = json.Unmarshal(sc.Bytes(), &r); err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, sc.Err()
+}
@@ -0,0 +1,77 @@
+package sinktest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lvlcn-t/loggerhead/logger"
+)
+
+func TestRun_FileSink(t *testing.T) {
+	Run(t, func(tb testing.TB) Sink {
+		path := filepath.Join(tb.TempDir(), "app.log")
+		w, err := logger.NewFileSink(logger.FileSinkOptions{Path: path})
+		if err != nil {
+			tb.Fatal(err)
+		}
+		return Sink{
+			Handler: slog.NewJSONHandler(w, nil),
+			Close:   w.Close,
+			Fetch:   func(context.Context) ([]map[string]any, error) { return ReadJSONLines(path) },
+		}
+	})
+}
+
+// fatalTB is a [testing.TB] recording Fatalf instead of failing the test.
+type fatalTB struct {
+	testing.TB
+	failed bool
+}
+
+func (tb *fatalTB) Fatalf(string, ...any) {
+	tb.failed = true
+	panic("fatal")
+}
+
+func TestRun_Lost(t *testing.T) {
+	tb := &fatalTB{TB: t}
+	func() {
+		defer func() { _ = recover() }()
+		Run(tb, func(testing.TB) Sink {
+			return Sink{
+				Handler: slog.NewJSONHandler(io.Discard, nil),
+				Fetch:   func(context.Context) ([]map[string]any, error) { return nil, nil },
+			}
+		}, Options{Timeout: 20 * time.Millisecond, PollInterval: 5 * time.Millisecond})
+	}()
+	if !tb.failed {
+		t.Error("Expected lost records to fail the test")
+	}
+}
+
+func TestLokiFetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/loki/api/v1/query_range" || r.URL.Query().Get("query") != `{job="sinktest"}` {
+			http.Error(w, "unexpected query", http.StatusBadRequest)
+			return
+		}
+		_, _ = io.WriteString(w, `{"status": "success", "data": {"resultType": "streams", "result": [
+			{"stream": {"job": "sinktest"}, "values": [["1", "{\"msg\": \"first\"}"], ["2", "{\"msg\": \"second\"}"]]}
+		]}}`)
+	}))
+	defer srv.Close()
+
+	records, err := LokiFetch(srv.URL, `{job="sinktest"}`)(context.Background())
+	if err != nil {
+		t.Fatalf("LokiFetch() error = %v", err)
+	}
+	if len(records) != 2 || records[0]["msg"] != "first" || records[1]["msg"] != "second" {
+		t.Errorf("Expected the two records of the stream, got %v", records)
+	}
+}